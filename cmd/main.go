@@ -4,6 +4,11 @@ import (
 	"github.com/dblaq/buzzycash/http"
 	"github.com/dblaq/buzzycash/docs"
 	"github.com/dblaq/buzzycash/internal/config"
+	"github.com/dblaq/buzzycash/internal/core/admin"
+	"github.com/dblaq/buzzycash/internal/core/outbox"
+	"github.com/dblaq/buzzycash/internal/core/payments"
+	"github.com/dblaq/buzzycash/internal/core/payouts"
+	"github.com/dblaq/buzzycash/internal/core/velocity"
 	"github.com/dblaq/buzzycash/server"
 	swaggerFiles "github.com/swaggo/files"
 	ginSwagger "github.com/swaggo/gin-swagger"
@@ -14,6 +19,8 @@ func main() {
 	config.InitDB()
 	defer config.CloseDB()
 
+	admin.BootstrapSuperAdmin(config.DB)
+
 	r := server.NewServer()
 
 	// Swagger setup
@@ -25,5 +32,13 @@ func main() {
 	server.HealthCheck(r)
 	http.RegisterRoutes(r, config.DB)
 
+	// Background workers
+	payments.StartWebhookWorker(config.DB)
+	payments.StartDepositRequeryJob(config.DB)
+	payments.StartWithdrawalRequeryJob(config.DB)
+	outbox.StartDispatcher(config.DB)
+	velocity.StartPruner(config.DB)
+	payouts.StartClaimReconciler(config.DB)
+
 	server.StartServer(r)
 }
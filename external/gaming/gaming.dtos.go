@@ -64,6 +64,7 @@ type DebitWalletRequest struct {
 	UserID    string  `json:"user_id"`
 	Amount    float64 `json:"amount"`
 	CompanyID string  `json:"company_id"`
+	Reference string  `json:"reference,omitempty"` // idempotency key
 }
 
 // PaymentRequest represents the request payload for payment
@@ -71,6 +72,7 @@ type CreditWalletRequest struct {
 	UserID string  `json:"user_id"`
 	Amount   float64 `json:"amount"`
 	CompanyID string  `json:"company_id"`
+	Reference string  `json:"reference,omitempty"` // idempotency key
 }
 
 // VirtualGameRequest represents the request payload for virtual games
@@ -86,4 +88,5 @@ type BuyTicketResponse struct {
 
 type PaymentResponse struct {
    Message string  `json:"message"`
+   Raw     string  `json:"-"` // undecoded response body
 }
\ No newline at end of file
@@ -3,6 +3,7 @@ package gaming
 import (
 	"bytes"
 	"encoding/json"
+	"errors"
 	"fmt"
 	"io"
 	"net/http"
@@ -21,6 +22,21 @@ var (
 	gmAuthOnce   sync.Once
 )
 
+// ErrRejected is returned when the gaming API turned a request down without
+// acting on it. Any other error leaves the outcome unknown.
+var ErrRejected = errors.New("request rejected by gaming API")
+
+// ErrNotSent is returned when a request failed before it left this process,
+// so the gaming API cannot have acted on it.
+var ErrNotSent = errors.New("request not sent to gaming API")
+
+// rejected reports whether an HTTP status means the gaming API refused the
+// request. Timeouts, throttling and server errors may have been acted on.
+func rejected(status int) bool {
+	return status >= 400 && status < 500 &&
+		status != http.StatusRequestTimeout && status != http.StatusTooManyRequests
+}
+
 
 func GmAuthInstance() *GamingAuthService {
 	gmAuthOnce.Do(func() {
@@ -1113,13 +1129,24 @@ func (gs *GMService) GetGames() (map[string]interface{}, error) {
 
 // DebitUserWallet debits amount from user's wallet
 func (gs *GMService) DebitUserWallet(username string, amount float64) (map[string]interface{}, error) {
+	return gs.debitUserWallet(username, amount, "")
+}
+
+// DebitUserWalletIdempotent debits amount from user's wallet, sending
+// idempotencyKey the same way as CreditUserWalletIdempotent and with the
+// same caveats. A refusal, such as insufficient funds, wraps ErrRejected.
+func (gs *GMService) DebitUserWalletIdempotent(username string, amount float64, idempotencyKey string) (map[string]interface{}, error) {
+	return gs.debitUserWallet(username, amount, idempotencyKey)
+}
+
+func (gs *GMService) debitUserWallet(username string, amount float64, idempotencyKey string) (map[string]interface{}, error) {
 	log.Println("Starting DebitUserWallet")
 
 	// Get access token
 	token, err := gs.auth.GetToken()
 	if err != nil  {
 		log.Println("Failed to get access token: ", err)
-		return nil, err
+		return nil, fmt.Errorf("%w: %v", ErrNotSent, err)
 	}
 
 	// Prepare request payload
@@ -1127,11 +1154,12 @@ func (gs *GMService) DebitUserWallet(username string, amount float64) (map[strin
 		UserID:    username,
 		Amount:    amount,
 		CompanyID: config.AppConfig.BuzzyCashCompanyID,
+		Reference: idempotencyKey,
 	}
 	body, err := json.Marshal(reqData)
 	if err != nil {
 		log.Printf("ERROR: Failed to marshal DebitUserWallet request: %v\n", err)
-		return nil, fmt.Errorf("failed to marshal request: %w", err)
+		return nil, fmt.Errorf("%w: failed to marshal request: %v", ErrNotSent, err)
 	}
 	log.Printf("DEBUG: Marshaled DebitUserWallet request body: %s\n", string(body))
 
@@ -1140,10 +1168,13 @@ func (gs *GMService) DebitUserWallet(username string, amount float64) (map[strin
 	httpReq, err := http.NewRequest("POST", url, bytes.NewBuffer(body))
 	if err != nil {
 		log.Printf("ERROR: Failed to create HTTP request for DebitUserWallet: %v\n", err)
-		return nil, fmt.Errorf("failed to create request: %w", err)
+		return nil, fmt.Errorf("%w: failed to create request: %v", ErrNotSent, err)
 	}
 	httpReq.Header.Set("Content-Type", "application/json")
 	httpReq.Header.Set("Authorization", "Bearer "+token.Accesstoken)
+	if idempotencyKey != "" {
+		httpReq.Header.Set("Idempotency-Key", idempotencyKey)
+	}
 	log.Println("Headers set successfully for DebitUserWallet")
 
 	// Send request
@@ -1166,6 +1197,9 @@ func (gs *GMService) DebitUserWallet(username string, amount float64) (map[strin
 	if resp.StatusCode >= 300 {
 		errorMessage := fmt.Sprintf("Gaming API returned error status %d: %s", resp.StatusCode, string(b))
 		log.Printf("ERROR: %s\n", errorMessage)
+		if rejected(resp.StatusCode) {
+			return nil, fmt.Errorf("%w: gaming API error %d", ErrRejected, resp.StatusCode)
+		}
 		return nil, fmt.Errorf("gaming API error %d", resp.StatusCode)
 	}
 
@@ -1182,13 +1216,30 @@ func (gs *GMService) DebitUserWallet(username string, amount float64) (map[strin
 
 // CreditUserWallet credits amount to user's wallet
 func (gs *GMService) CreditUserWallet(username string, amount float64) (*PaymentResponse, error) {
+	return gs.creditUserWallet(username, amount, "")
+}
+
+// CreditUserWalletIdempotent credits amount to user's wallet, sending
+// idempotencyKey as the Idempotency-Key header and the request reference.
+//
+// The gaming API does not document that it deduplicates on either, and it
+// offers no lookup of a credit by key, so callers must not rely on the key
+// to make a retry safe. Only a refusal (ErrRejected) or a request that was
+// never sent (ErrNotSent) is known not to have credited the wallet; any
+// other error leaves the outcome unknown, and the outbox parks such credits
+// for manual review instead of retrying them.
+func (gs *GMService) CreditUserWalletIdempotent(username string, amount float64, idempotencyKey string) (*PaymentResponse, error) {
+	return gs.creditUserWallet(username, amount, idempotencyKey)
+}
+
+func (gs *GMService) creditUserWallet(username string, amount float64, idempotencyKey string) (*PaymentResponse, error) {
 	log.Println("Starting CreditUserWallet")
 
 	// Get access token
 	token, err := gs.auth.GetToken()
 	if err != nil {
 		log.Println("Failed to get access token: ", err)
-		return nil, err
+		return nil, fmt.Errorf("%w: %v", ErrNotSent, err)
 	}
 
 	// Prepare request payload
@@ -1196,11 +1247,12 @@ func (gs *GMService) CreditUserWallet(username string, amount float64) (*Payment
 		UserID:    username,
 		Amount:    amount,
 		CompanyID: config.AppConfig.BuzzyCashCompanyID,
+		Reference: idempotencyKey,
 	}
 	body, err := json.Marshal(reqData)
 	if err != nil {
 		log.Printf("ERROR: Failed to marshal CreditUserWallet request: %v\n", err)
-		return nil, fmt.Errorf("failed to marshal request: %w", err)
+		return nil, fmt.Errorf("%w: failed to marshal request: %v", ErrNotSent, err)
 	}
 	log.Printf("DEBUG: Marshaled CreditUserWallet request body: %s\n", string(body))
 
@@ -1209,10 +1261,13 @@ func (gs *GMService) CreditUserWallet(username string, amount float64) (*Payment
 	httpReq, err := http.NewRequest("POST", url, bytes.NewBuffer(body))
 	if err != nil {
 		log.Printf("ERROR: Failed to create HTTP request for CreditUserWallet: %v\n", err)
-		return nil, fmt.Errorf("failed to create request: %w", err)
+		return nil, fmt.Errorf("%w: failed to create request: %v", ErrNotSent, err)
 	}
 	httpReq.Header.Set("Content-Type", "application/json")
 	httpReq.Header.Set("Authorization", "Bearer "+token.Accesstoken)
+	if idempotencyKey != "" {
+		httpReq.Header.Set("Idempotency-Key", idempotencyKey)
+	}
 	log.Println("Headers set successfully for CreditUserWallet")
 
 	// Send request
@@ -1236,6 +1291,9 @@ func (gs *GMService) CreditUserWallet(username string, amount float64) (*Payment
 	if resp.StatusCode >= 300 {
 		errorMessage := fmt.Sprintf("Gaming API returned error status %d: %s", resp.StatusCode, string(rawBody))
 		log.Printf("ERROR: %s\n", errorMessage)
+		if rejected(resp.StatusCode) {
+			return nil, fmt.Errorf("%w: gaming API error %d", ErrRejected, resp.StatusCode)
+		}
 		return nil, fmt.Errorf("gaming API error %d", resp.StatusCode)
 	}
 
@@ -1245,6 +1303,7 @@ func (gs *GMService) CreditUserWallet(username string, amount float64) (*Payment
 		log.Println("Failed to decode credit wallet response: ", err)
 		return nil, fmt.Errorf("failed to decode response: %w", err)
 	}
+	result.Raw = string(rawBody)
 	log.Println("Decoded credit wallet response:", result)
 
 	return &result, nil
@@ -1369,7 +1428,7 @@ func (gs *GMService) ListUserPayout(username string) (map[string]interface{}, er
 	return result, nil
 }
 
-// PayoutByAdmin processes payout by admin
+// PayoutByAdmin processes payout by admin. A refusal wraps ErrRejected.
 func (gs *GMService) PayoutByAdmin(payoutID string) (map[string]interface{}, error) {
 	log.Println("Starting PayoutByAdmin")
 
@@ -1419,6 +1478,9 @@ func (gs *GMService) PayoutByAdmin(payoutID string) (map[string]interface{}, err
 	if resp.StatusCode >= 300 {
 		errorMessage := fmt.Sprintf("Gaming API returned error status %d: %s", resp.StatusCode, string(b))
 		log.Printf("ERROR: %s\n", errorMessage)
+		if rejected(resp.StatusCode) {
+			return nil, fmt.Errorf("%w: gaming API error %d", ErrRejected, resp.StatusCode)
+		}
 		return nil, fmt.Errorf("gaming API error %d", resp.StatusCode)
 	}
 
@@ -58,5 +58,59 @@ type FWVerifyResp struct {
 	} `json:"data"`
 }
 
+type FWTransaction struct {
+	ID        int64   `json:"id"`
+	TxRef     string  `json:"tx_ref"`
+	FlwRef    string  `json:"flw_ref"`
+	Amount    float64 `json:"amount"`
+	Currency  string  `json:"currency"`
+	Status    string  `json:"status"`
+	CreatedAt string  `json:"created_at"`
+}
 
+// Reference is the reference the transaction is recorded under: its txRef,
+// or its flwRef for a transfer into a virtual account, as the webhook does.
+func (t FWTransaction) Reference() string {
+	return flutterwaveReference(t.TxRef, t.FlwRef)
+}
 
+type FWTransactionListResp struct {
+	Status  string          `json:"status"`
+	Message string          `json:"message"`
+	Data    []FWTransaction `json:"data"`
+	Meta    struct {
+		PageInfo struct {
+			Total       int `json:"total"`
+			CurrentPage int `json:"current_page"`
+			TotalPages  int `json:"total_pages"`
+		} `json:"page_info"`
+	} `json:"meta"`
+}
+
+// FWVirtualAccountRequest opens a static account number when IsPermanent is
+// set. Transfers into it are reported with TxRef as their txRef.
+type FWVirtualAccountRequest struct {
+	Email       string `json:"email"`
+	TxRef       string `json:"tx_ref"`
+	IsPermanent bool   `json:"is_permanent"`
+	BVN         string `json:"bvn,omitempty"`
+	PhoneNumber string `json:"phonenumber,omitempty"`
+	FirstName   string `json:"firstname,omitempty"`
+	LastName    string `json:"lastname,omitempty"`
+	Narration   string `json:"narration,omitempty"`
+	Currency    string `json:"currency,omitempty"`
+}
+
+type FWVirtualAccountResp struct {
+	Status  string `json:"status"`
+	Message string `json:"message"`
+	Data    struct {
+		ResponseCode    string `json:"response_code"`
+		ResponseMessage string `json:"response_message"`
+		FlwRef          string `json:"flw_ref"`
+		OrderRef        string `json:"order_ref"`
+		AccountNumber   string `json:"account_number"`
+		BankName        string `json:"bank_name"`
+		Note            string `json:"note"`
+	} `json:"data"`
+}
@@ -9,6 +9,7 @@ import (
 	"fmt"
 	"io"
 	"net/http"
+	neturl "net/url"
 	"time"
 	"github.com/dblaq/buzzycash/internal/config"
 )
@@ -89,6 +90,134 @@ func (s *PaymentService)CreateCheckout(req FWPaymentRequest) (string, error) {
 }
 
 
+// CreateVirtualAccount opens a dedicated account number for bank transfer
+// funding.
+func (s *PaymentService) CreateVirtualAccount(req FWVirtualAccountRequest) (*FWVirtualAccountResp, error) {
+	body, err := json.Marshal(req)
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal virtual account request: %w", err)
+	}
+
+	url := config.AppConfig.FlutterwaveApiBase + "virtual-account-numbers"
+	httpReq, err := http.NewRequest(http.MethodPost, url, bytes.NewReader(body))
+	if err != nil {
+		return nil, fmt.Errorf("failed to create HTTP request: %w", err)
+	}
+	httpReq.Header.Set("Authorization", "Bearer "+config.AppConfig.FlutterwaveSecretKey)
+	httpReq.Header.Set("Content-Type", "application/json")
+
+	resp, err := s.client.Do(httpReq)
+	if err != nil {
+		return nil, fmt.Errorf("flutterwave HTTP request failed: %w", err)
+	}
+	defer resp.Body.Close()
+
+	b, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read response body: %w", err)
+	}
+
+	if resp.StatusCode >= 300 {
+		log.Printf("ERROR: Flutterwave virtual account creation for tx_ref=%s returned %d: %s\n", req.TxRef, resp.StatusCode, string(b))
+		return nil, fmt.Errorf("flutterwave error %d", resp.StatusCode)
+	}
+
+	var fr FWVirtualAccountResp
+	if err := json.Unmarshal(b, &fr); err != nil {
+		return nil, fmt.Errorf("failed to unmarshal flutterwave response: %w", err)
+	}
+	if fr.Status != "success" || fr.Data.AccountNumber == "" {
+		return nil, fmt.Errorf("flutterwave virtual account creation failed: status='%s', message='%s'", fr.Status, fr.Message)
+	}
+	return &fr, nil
+}
+
+// VerifyByReference asks Flutterwave for the current state of a charge by
+// our tx_ref. ErrTransactionNotFound means Flutterwave has no record of it.
+func (s *PaymentService) VerifyByReference(txRef string) (*FWVerifyResp, error) {
+	url := config.AppConfig.FlutterwaveApiBase + "transactions/verify_by_reference?tx_ref=" + neturl.QueryEscape(txRef)
+
+	httpReq, err := http.NewRequest(http.MethodGet, url, nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create HTTP request: %w", err)
+	}
+	httpReq.Header.Set("Authorization", "Bearer "+config.AppConfig.FlutterwaveSecretKey)
+	httpReq.Header.Set("Content-Type", "application/json")
+
+	resp, err := s.client.Do(httpReq)
+	if err != nil {
+		return nil, fmt.Errorf("flutterwave HTTP request failed: %w", err)
+	}
+	defer resp.Body.Close()
+
+	b, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read response body: %w", err)
+	}
+
+	if resp.StatusCode == http.StatusNotFound {
+		return nil, ErrTransactionNotFound
+	}
+	if resp.StatusCode >= 300 {
+		log.Printf("ERROR: Flutterwave verify for tx_ref=%s returned %d: %s\n", txRef, resp.StatusCode, string(b))
+		return nil, fmt.Errorf("flutterwave error %d", resp.StatusCode)
+	}
+
+	var fr FWVerifyResp
+	if err := json.Unmarshal(b, &fr); err != nil {
+		return nil, fmt.Errorf("failed to unmarshal flutterwave response: %w", err)
+	}
+	if fr.Status != "success" {
+		return nil, fmt.Errorf("flutterwave verify failed: status='%s', message='%s'", fr.Status, fr.Message)
+	}
+
+	return &fr, nil
+}
+
+// ListTransactions returns every Flutterwave transaction created between
+// from and to (inclusive dates), following pagination to the end.
+func (s *PaymentService) ListTransactions(from, to time.Time) ([]FWTransaction, error) {
+	var all []FWTransaction
+	for page := 1; ; page++ {
+		url := fmt.Sprintf("%stransactions?from=%s&to=%s&page=%d",
+			config.AppConfig.FlutterwaveApiBase, from.Format("2006-01-02"), to.Format("2006-01-02"), page)
+
+		httpReq, err := http.NewRequest(http.MethodGet, url, nil)
+		if err != nil {
+			return nil, fmt.Errorf("failed to create HTTP request: %w", err)
+		}
+		httpReq.Header.Set("Authorization", "Bearer "+config.AppConfig.FlutterwaveSecretKey)
+		httpReq.Header.Set("Content-Type", "application/json")
+
+		resp, err := s.client.Do(httpReq)
+		if err != nil {
+			return nil, fmt.Errorf("flutterwave HTTP request failed: %w", err)
+		}
+		b, err := io.ReadAll(resp.Body)
+		resp.Body.Close()
+		if err != nil {
+			return nil, fmt.Errorf("failed to read response body: %w", err)
+		}
+		if resp.StatusCode >= 300 {
+			log.Printf("ERROR: Flutterwave transaction list returned %d: %s\n", resp.StatusCode, string(b))
+			return nil, fmt.Errorf("flutterwave error %d", resp.StatusCode)
+		}
+
+		var fr FWTransactionListResp
+		if err := json.Unmarshal(b, &fr); err != nil {
+			return nil, fmt.Errorf("failed to unmarshal flutterwave response: %w", err)
+		}
+		if fr.Status != "success" {
+			return nil, fmt.Errorf("flutterwave transaction list failed: status='%s', message='%s'", fr.Status, fr.Message)
+		}
+
+		all = append(all, fr.Data...)
+		if page >= fr.Meta.PageInfo.TotalPages {
+			return all, nil
+		}
+	}
+}
+
 // func (s *PaymentService) GetBanks() ([]Bank, error) {
 //     url := config.AppConfig.FlutterwaveApiBase + "banks/NG?include_provider_type=1"
 
@@ -0,0 +1,120 @@
+package gateway
+
+import (
+	"net/http"
+	"strings"
+	"time"
+)
+
+// flutterwaveProvider adapts the Flutterwave client to PaymentProvider.
+// Payouts and bank lookups go through Nomba, so they are not offered here.
+type flutterwaveProvider struct{}
+
+func init() {
+	Register(flutterwaveProvider{})
+}
+
+func (flutterwaveProvider) Name() string { return "flutterwave" }
+
+func (flutterwaveProvider) CreateCheckout(req CheckoutRequest) (*CheckoutResult, error) {
+	fwReq := FWPaymentRequest{
+		Reference:   req.Reference,
+		Amount:      req.Amount,
+		Currency:    req.Currency,
+		RedirectURL: req.CallbackURL,
+		Customer: FWCustomer{
+			Email:    req.Email,
+			FullName: req.FullName,
+		},
+	}
+	link, err := FWInstance().CreateCheckout(fwReq)
+	if err != nil {
+		return nil, err
+	}
+	return &CheckoutResult{CheckoutLink: link, Reference: req.Reference}, nil
+}
+
+func (flutterwaveProvider) ChargeSavedCard(req CheckoutRequest, token string) (*CheckoutResult, error) {
+	return nil, ErrNotSupported
+}
+
+func (flutterwaveProvider) Verify(reference string) (*VerifyResult, error) {
+	resp, err := FWInstance().VerifyByReference(reference)
+	if err != nil {
+		return nil, err
+	}
+
+	result := &VerifyResult{
+		Reference:    resp.Data.TxRef,
+		ProviderID:   resp.Data.FlwRef,
+		Status:       PaymentPending,
+		Amount:       resp.Data.Amount,
+		CreditAmount: resp.Data.Amount,
+		Currency:     resp.Data.Currency,
+	}
+	switch status := strings.ToLower(resp.Data.Status); status {
+	case "successful":
+		result.Status = PaymentSucceeded
+	case "failed", "cancelled":
+		result.Status = PaymentFailed
+		result.Reason = "charge " + status
+	}
+	return result, nil
+}
+
+// CreateVirtualAccount opens a permanent account number. Flutterwave only
+// issues those against the customer's BVN.
+func (flutterwaveProvider) CreateVirtualAccount(req VirtualAccountRequest) (*VirtualAccount, error) {
+	if !strings.EqualFold(req.Currency, "NGN") {
+		return nil, ErrUnsupportedCurrency
+	}
+
+	firstName, lastName := req.AccountName, ""
+	if i := strings.Index(req.AccountName, " "); i > 0 {
+		firstName, lastName = req.AccountName[:i], strings.TrimSpace(req.AccountName[i+1:])
+	}
+	resp, err := FWInstance().CreateVirtualAccount(FWVirtualAccountRequest{
+		Email:       req.Email,
+		TxRef:       req.Reference,
+		IsPermanent: true,
+		BVN:         req.BVN,
+		PhoneNumber: req.PhoneNumber,
+		FirstName:   firstName,
+		LastName:    lastName,
+		Narration:   req.AccountName,
+		Currency:    "NGN",
+	})
+	if err != nil {
+		return nil, err
+	}
+	return &VirtualAccount{
+		AccountNumber: resp.Data.AccountNumber,
+		AccountName:   req.AccountName,
+		BankName:      resp.Data.BankName,
+		Reference:     req.Reference,
+	}, nil
+}
+
+func (flutterwaveProvider) ListBanks() ([]Bank, error) {
+	return nil, ErrNotSupported
+}
+
+func (flutterwaveProvider) ResolveAccount(accountNumber, bankCode string) (*AccountDetails, error) {
+	return nil, ErrNotSupported
+}
+
+func (flutterwaveProvider) InitiatePayout(req PayoutRequest) error {
+	return ErrNotSupported
+}
+
+func (flutterwaveProvider) VerifyPayout(reference string, since time.Time) (*VerifyResult, error) {
+	return nil, ErrNotSupported
+}
+
+func (flutterwaveProvider) VerifyWebhook(headers http.Header, body []byte) error {
+	return VerifyFlutterwaveHash(headers.Get(FlutterwaveSignatureHeader))
+}
+
+func (flutterwaveProvider) ParseWebhook(body []byte) (*WebhookEvent, error) {
+	return parseFlutterwaveWebhook(body)
+}
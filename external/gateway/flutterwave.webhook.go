@@ -0,0 +1,104 @@
+package gateway
+
+import (
+	"crypto/subtle"
+	"encoding/json"
+	"fmt"
+	"strings"
+
+	"github.com/dblaq/buzzycash/internal/config"
+)
+
+const FlutterwaveSignatureHeader = "verif-hash"
+
+type FlutterwaveWebhook struct {
+	ID            int     `json:"id"`
+	TxRef         string  `json:"txRef"`
+	FlwRef        string  `json:"flwRef"`
+	OrderRef      string  `json:"orderRef"`
+	Amount        float64 `json:"amount"`
+	ChargedAmount float64 `json:"charged_amount"`
+	Status        string  `json:"status"`
+	Currency      string  `json:"currency"`
+	EventType     string  `json:"event.type"`
+	Customer      struct {
+		ID    int    `json:"id"`
+		Phone string `json:"phone"`
+		Email string `json:"email"`
+		Name  string `json:"fullName"`
+	} `json:"customer"`
+}
+
+// VerifyFlutterwaveHash compares the verif-hash header with our secret hash.
+func VerifyFlutterwaveHash(sent string) error {
+	secret := config.AppConfig.FlutterwaveHashKey
+	if secret == "" {
+		return ErrSecretNotSet
+	}
+	if subtle.ConstantTimeCompare([]byte(secret), []byte(sent)) != 1 {
+		return ErrInvalidSignature
+	}
+	return nil
+}
+
+func isVirtualAccountRef(txRef string) bool {
+	return strings.HasPrefix(txRef, VirtualAccountRefPrefix)
+}
+
+// flutterwaveReference is the reference a transaction is recorded under.
+// Every transfer into a static account repeats the txRef the account was
+// opened with, so the transfer itself is keyed by its flwRef.
+func flutterwaveReference(txRef, flwRef string) string {
+	if isVirtualAccountRef(txRef) {
+		return flwRef
+	}
+	return txRef
+}
+
+// parseFlutterwaveWebhook translates a Flutterwave charge, virtual account
+// transfer or refund webhook.
+func parseFlutterwaveWebhook(body []byte) (*WebhookEvent, error) {
+	var evt FlutterwaveWebhook
+	if err := json.Unmarshal(body, &evt); err != nil {
+		return nil, fmt.Errorf("bad payload: %w", err)
+	}
+
+	// Normalize checks
+	event := strings.ToUpper(evt.EventType)
+	status := strings.ToLower(evt.Status)
+
+	out := &WebhookEvent{
+		Type:      evt.EventType,
+		Kind:      IgnoredEvent,
+		Reference: evt.TxRef,
+	}
+	// A charge's status changes over its life, so the status is part of
+	// the event identity.
+	if evt.ID != 0 {
+		out.ID = fmt.Sprintf("%d:%s", evt.ID, status)
+	}
+
+	isCharge := event == "CHARGE.COMPLETED" || event == "BANK_TRANSFER_TRANSACTION"
+	isTransfer := isCharge && isVirtualAccountRef(evt.TxRef)
+	if isTransfer {
+		out.Reference = flutterwaveReference(evt.TxRef, evt.FlwRef)
+		out.Account = evt.TxRef
+	}
+
+	switch {
+	case isTransfer && status == "successful":
+		out.Kind = VirtualAccountCredited
+		out.CreditAmount = evt.Amount
+	case isCharge && status == "successful":
+		out.Kind = DepositSucceeded
+		out.CreditAmount = evt.Amount
+	case isCharge && (status == "failed" || status == "cancelled"):
+		out.Kind = DepositFailed
+		out.Reason = "charge " + status
+	case strings.Contains(event, "REFUND") || status == "reversed" || status == "refunded":
+		out.Kind = DepositReversed
+		out.Reason = strings.ToLower(event) + " " + status
+	}
+
+	return out, nil
+}
@@ -0,0 +1,79 @@
+package gateway
+
+// Hubtel amounts are decimal cedis and phone numbers are in international
+// format without the plus (233XXXXXXXXX).
+
+type HubtelReceiveRequest struct {
+	CustomerName       string  `json:"CustomerName,omitempty"`
+	CustomerMsisdn     string  `json:"CustomerMsisdn"`
+	CustomerEmail      string  `json:"CustomerEmail,omitempty"`
+	Channel            string  `json:"Channel"`
+	Amount             float64 `json:"Amount"`
+	PrimaryCallbackURL string  `json:"PrimaryCallbackUrl"`
+	Description        string  `json:"Description"`
+	ClientReference    string  `json:"ClientReference"`
+}
+
+type HubtelSendRequest struct {
+	RecipientName      string  `json:"RecipientName"`
+	RecipientMsisdn    string  `json:"RecipientMsisdn"`
+	CustomerEmail      string  `json:"CustomerEmail,omitempty"`
+	Channel            string  `json:"Channel"`
+	Amount             float64 `json:"Amount"`
+	PrimaryCallbackURL string  `json:"PrimaryCallbackURL"`
+	Description        string  `json:"Description"`
+	ClientReference    string  `json:"ClientReference"`
+}
+
+// HubtelTransaction is the Data block of Receive and Send Money replies and
+// of their callbacks.
+type HubtelTransaction struct {
+	TransactionID         string  `json:"TransactionId"`
+	ExternalTransactionID string  `json:"ExternalTransactionId"`
+	ClientReference       string  `json:"ClientReference"`
+	Description           string  `json:"Description"`
+	Amount                float64 `json:"Amount"`
+	Charges               float64 `json:"Charges"`
+	AmountAfterCharges    float64 `json:"AmountAfterCharges"`
+	AmountCharged         float64 `json:"AmountCharged"`
+}
+
+type HubtelResponse struct {
+	ResponseCode string            `json:"ResponseCode"`
+	Message      string            `json:"Message"`
+	Data         HubtelTransaction `json:"Data"`
+}
+
+type HubtelStatusResponse struct {
+	ResponseCode string `json:"responseCode"`
+	Message      string `json:"message"`
+	Data         struct {
+		Date                  string  `json:"date"`
+		Status                string  `json:"status"`
+		TransactionID         string  `json:"transactionId"`
+		ExternalTransactionID string  `json:"externalTransactionId"`
+		ClientReference       string  `json:"clientReference"`
+		CurrencyCode          string  `json:"currencyCode"`
+		Amount                float64 `json:"amount"`
+		Charges               float64 `json:"charges"`
+		AmountAfterCharges    float64 `json:"amountAfterCharges"`
+	} `json:"data"`
+}
+
+type HubtelVerifyResponse struct {
+	ResponseCode string `json:"responseCode"`
+	Message      string `json:"message"`
+	Data         struct {
+		IsRegistered bool   `json:"isRegistered"`
+		Name         string `json:"name"`
+		Status       string `json:"status"`
+	} `json:"data"`
+}
+
+// HubtelCallback is posted to PrimaryCallbackUrl once a Receive or Send
+// Money transaction completes.
+type HubtelCallback struct {
+	ResponseCode string            `json:"ResponseCode"`
+	Message      string            `json:"Message"`
+	Data         HubtelTransaction `json:"Data"`
+}
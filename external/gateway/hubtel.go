@@ -0,0 +1,209 @@
+package gateway
+
+import (
+	"bytes"
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"io"
+	"log"
+	"net/http"
+	neturl "net/url"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/dblaq/buzzycash/internal/config"
+)
+
+// Hubtel response codes
+const (
+	hubtelSuccess = "0000"
+	hubtelPending = "0001" // accepted; the outcome arrives on the callback
+)
+
+// Mobile money networks, as Hubtel names them
+const (
+	HubtelMTN        = "mtn-gh"
+	HubtelVodafone   = "vodafone-gh"
+	HubtelAirtelTigo = "tigo-gh"
+)
+
+var (
+	hbService     *HBService
+	hbServiceOnce sync.Once
+)
+
+func HBInstance() *HBService {
+	hbServiceOnce.Do(func() {
+		hbService = &HBService{
+			client: &http.Client{Timeout: 60 * time.Second},
+		}
+	})
+	return hbService
+}
+
+type HBService struct {
+	client *http.Client
+}
+
+// do sends a Basic-authenticated request to one of Hubtel's APIs and decodes
+// the JSON reply into out. A 404 is reported as ErrTransactionNotFound.
+func (s *HBService) do(method, url string, payload interface{}, out interface{}) error {
+	var body io.Reader
+	if payload != nil {
+		b, err := json.Marshal(payload)
+		if err != nil {
+			return fmt.Errorf("failed to marshal request: %w", err)
+		}
+		body = bytes.NewReader(b)
+	}
+
+	httpReq, err := http.NewRequest(method, url, body)
+	if err != nil {
+		return fmt.Errorf("failed to create HTTP request: %w", err)
+	}
+	auth := base64.StdEncoding.EncodeToString([]byte(config.AppConfig.HubtelClientID + ":" + config.AppConfig.HubtelClientSecret))
+	httpReq.Header.Set("Authorization", "Basic "+auth)
+	httpReq.Header.Set("Content-Type", "application/json")
+
+	resp, err := s.client.Do(httpReq)
+	if err != nil {
+		return fmt.Errorf("hubtel HTTP request failed: %w", err)
+	}
+	defer resp.Body.Close()
+
+	b, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return fmt.Errorf("failed to read response body: %w", err)
+	}
+
+	if resp.StatusCode == http.StatusNotFound {
+		return ErrTransactionNotFound
+	}
+	if resp.StatusCode >= 300 {
+		log.Printf("ERROR: Hubtel %s %s returned %d: %s\n", method, url, resp.StatusCode, string(b))
+		return fmt.Errorf("hubtel error %d", resp.StatusCode)
+	}
+
+	if err := json.Unmarshal(b, out); err != nil {
+		return fmt.Errorf("failed to unmarshal hubtel response: %w", err)
+	}
+	return nil
+}
+
+// ReceiveMoney sends a payment prompt to the customer's mobile money wallet.
+// Hubtel accepts the request straight away; the result comes on the callback.
+func (s *HBService) ReceiveMoney(req HubtelReceiveRequest) (*HubtelResponse, error) {
+	url := fmt.Sprintf("%smerchantaccount/merchants/%s/receive/mobilemoney",
+		config.AppConfig.HubtelReceiveApiBase, config.AppConfig.HubtelPosSalesID)
+
+	var resp HubtelResponse
+	if err := s.do(http.MethodPost, url, req, &resp); err != nil {
+		return nil, err
+	}
+	if resp.ResponseCode != hubtelPending && resp.ResponseCode != hubtelSuccess {
+		return nil, fmt.Errorf("hubtel receive money failed: code=%s message='%s'", resp.ResponseCode, resp.Message)
+	}
+	return &resp, nil
+}
+
+// SendMoney pays out from our prepaid deposit account to a mobile money wallet.
+func (s *HBService) SendMoney(req HubtelSendRequest) (*HubtelResponse, error) {
+	url := fmt.Sprintf("%sapi/merchants/%s/send/mobilemoney",
+		config.AppConfig.HubtelSendApiBase, config.AppConfig.HubtelPrepaidDepositID)
+
+	var resp HubtelResponse
+	if err := s.do(http.MethodPost, url, req, &resp); err != nil {
+		return nil, err
+	}
+	if resp.ResponseCode != hubtelPending && resp.ResponseCode != hubtelSuccess {
+		return nil, fmt.Errorf("hubtel send money failed: code=%s message='%s'", resp.ResponseCode, resp.Message)
+	}
+	return &resp, nil
+}
+
+// CollectionStatus looks up a Receive Money transaction by our reference.
+func (s *HBService) CollectionStatus(clientReference string) (*HubtelStatusResponse, error) {
+	url := fmt.Sprintf("%stransactions/%s/status?clientReference=%s",
+		config.AppConfig.HubtelStatusApiBase, config.AppConfig.HubtelPosSalesID, neturl.QueryEscape(clientReference))
+	return s.status(url)
+}
+
+// PayoutStatus looks up a Send Money transaction by our reference.
+func (s *HBService) PayoutStatus(clientReference string) (*HubtelStatusResponse, error) {
+	url := fmt.Sprintf("%sapi/merchants/%s/transactions/status?clientReference=%s",
+		config.AppConfig.HubtelSendStatusApiBase, config.AppConfig.HubtelPrepaidDepositID, neturl.QueryEscape(clientReference))
+	return s.status(url)
+}
+
+func (s *HBService) status(url string) (*HubtelStatusResponse, error) {
+	var resp HubtelStatusResponse
+	if err := s.do(http.MethodGet, url, nil, &resp); err != nil {
+		return nil, err
+	}
+	if resp.ResponseCode != hubtelSuccess {
+		return nil, fmt.Errorf("hubtel status check failed: code=%s message='%s'", resp.ResponseCode, resp.Message)
+	}
+	if resp.Data.ClientReference == "" && resp.Data.TransactionID == "" {
+		return nil, ErrTransactionNotFound
+	}
+	return &resp, nil
+}
+
+// VerifyMobileMoneyAccount returns the name registered to a mobile money wallet.
+func (s *HBService) VerifyMobileMoneyAccount(msisdn, channel string) (*HubtelVerifyResponse, error) {
+	q := neturl.Values{}
+	q.Set("channel", channel)
+	q.Set("customerMsisdn", msisdn)
+	url := fmt.Sprintf("%sv2/merchantaccount/merchants/%s/mobilemoney/verify?%s",
+		config.AppConfig.HubtelVerifyApiBase, config.AppConfig.HubtelPosSalesID, q.Encode())
+
+	var resp HubtelVerifyResponse
+	if err := s.do(http.MethodGet, url, nil, &resp); err != nil {
+		return nil, err
+	}
+	if resp.ResponseCode != hubtelSuccess || !resp.Data.IsRegistered {
+		return nil, fmt.Errorf("hubtel account verification failed: code=%s message='%s'", resp.ResponseCode, resp.Message)
+	}
+	return &resp, nil
+}
+
+// hubtelMsisdn converts a local (0XXXXXXXXX) or +233 number to 233XXXXXXXXX.
+func hubtelMsisdn(phone string) string {
+	phone = strings.TrimPrefix(strings.ReplaceAll(strings.TrimSpace(phone), " ", ""), "+")
+	if strings.HasPrefix(phone, "0") {
+		return "233" + phone[1:]
+	}
+	return phone
+}
+
+// hubtelChannel picks the network for a wallet. An explicit network wins;
+// otherwise it is worked out from the number's prefix.
+func hubtelChannel(network, msisdn string) (string, error) {
+	switch strings.ToLower(strings.TrimSpace(network)) {
+	case "mtn", HubtelMTN:
+		return HubtelMTN, nil
+	case "vodafone", "telecel", HubtelVodafone:
+		return HubtelVodafone, nil
+	case "airteltigo", "airtel", "tigo", "at", HubtelAirtelTigo:
+		return HubtelAirtelTigo, nil
+	case "":
+	default:
+		return "", fmt.Errorf("unknown mobile money network %q", network)
+	}
+
+	local := strings.TrimPrefix(msisdn, "233")
+	if len(local) < 2 {
+		return "", fmt.Errorf("cannot determine mobile money network for %q", msisdn)
+	}
+	switch local[:2] {
+	case "24", "25", "53", "54", "55", "59":
+		return HubtelMTN, nil
+	case "20", "50":
+		return HubtelVodafone, nil
+	case "26", "27", "56", "57":
+		return HubtelAirtelTigo, nil
+	}
+	return "", fmt.Errorf("cannot determine mobile money network for %q", msisdn)
+}
@@ -0,0 +1,158 @@
+package gateway
+
+import (
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/dblaq/buzzycash/internal/config"
+)
+
+// hubtelProvider adapts Hubtel's Receive and Send Money APIs to
+// PaymentProvider. It only moves Ghana cedis, to and from mobile money
+// wallets, so there is no hosted checkout page: the customer approves a
+// prompt on their phone instead.
+type hubtelProvider struct{}
+
+func init() {
+	Register(hubtelProvider{})
+}
+
+func (hubtelProvider) Name() string { return "hubtel" }
+
+func (hubtelProvider) CreateCheckout(req CheckoutRequest) (*CheckoutResult, error) {
+	if !strings.EqualFold(req.Currency, "GHS") {
+		return nil, ErrUnsupportedCurrency
+	}
+
+	msisdn := hubtelMsisdn(req.PhoneNumber)
+	channel, err := hubtelChannel(req.Channel, msisdn)
+	if err != nil {
+		return nil, err
+	}
+
+	_, err = HBInstance().ReceiveMoney(HubtelReceiveRequest{
+		CustomerName:       req.FullName,
+		CustomerMsisdn:     msisdn,
+		CustomerEmail:      req.Email,
+		Channel:            channel,
+		Amount:             float64(req.Amount),
+		PrimaryCallbackURL: config.AppConfig.HubtelCallbackURL,
+		Description:        "Buzzycash wallet top-up",
+		ClientReference:    req.Reference,
+	})
+	if err != nil {
+		return nil, err
+	}
+	return &CheckoutResult{Reference: req.Reference}, nil
+}
+
+func (hubtelProvider) ChargeSavedCard(req CheckoutRequest, token string) (*CheckoutResult, error) {
+	return nil, ErrNotSupported
+}
+
+func (hubtelProvider) Verify(reference string) (*VerifyResult, error) {
+	resp, err := HBInstance().CollectionStatus(reference)
+	if err != nil {
+		return nil, err
+	}
+
+	result := &VerifyResult{
+		Reference:    reference,
+		ProviderID:   resp.Data.TransactionID,
+		Status:       PaymentPending,
+		Amount:       resp.Data.Amount,
+		CreditAmount: hubtelCreditAmount(resp),
+		Currency:     firstNonEmpty(resp.Data.CurrencyCode, "GHS"),
+	}
+	switch status := strings.ToLower(resp.Data.Status); {
+	case hubtelStatusPaid(status):
+		result.Status = PaymentSucceeded
+	case hubtelStatusFailed(status):
+		result.Status = PaymentFailed
+		result.Reason = "payment " + status
+	}
+	return result, nil
+}
+
+func (hubtelProvider) CreateVirtualAccount(req VirtualAccountRequest) (*VirtualAccount, error) {
+	return nil, ErrNotSupported
+}
+
+// ListBanks returns the mobile money networks; a network's code is used as
+// the bank code for lookups and payouts.
+func (hubtelProvider) ListBanks() ([]Bank, error) {
+	return []Bank{
+		{Code: HubtelMTN, Name: "MTN Mobile Money"},
+		{Code: HubtelVodafone, Name: "Telecel Cash"},
+		{Code: HubtelAirtelTigo, Name: "AirtelTigo Money"},
+	}, nil
+}
+
+func (hubtelProvider) ResolveAccount(accountNumber, bankCode string) (*AccountDetails, error) {
+	msisdn := hubtelMsisdn(accountNumber)
+	channel, err := hubtelChannel(bankCode, msisdn)
+	if err != nil {
+		return nil, err
+	}
+
+	resp, err := HBInstance().VerifyMobileMoneyAccount(msisdn, channel)
+	if err != nil {
+		return nil, err
+	}
+	return &AccountDetails{AccountNumber: accountNumber, AccountName: resp.Data.Name}, nil
+}
+
+func (hubtelProvider) InitiatePayout(req PayoutRequest) error {
+	if !strings.EqualFold(req.Currency, "GHS") {
+		return ErrUnsupportedCurrency
+	}
+
+	msisdn := hubtelMsisdn(req.AccountNumber)
+	channel, err := hubtelChannel(req.BankCode, msisdn)
+	if err != nil {
+		return err
+	}
+
+	_, err = HBInstance().SendMoney(HubtelSendRequest{
+		RecipientName:      req.AccountName,
+		RecipientMsisdn:    msisdn,
+		Channel:            channel,
+		Amount:             float64(req.Amount),
+		PrimaryCallbackURL: config.AppConfig.HubtelCallbackURL,
+		Description:        req.Narration,
+		ClientReference:    hubtelPayoutPrefix + req.Reference,
+	})
+	return err
+}
+
+func (hubtelProvider) VerifyPayout(reference string, since time.Time) (*VerifyResult, error) {
+	resp, err := HBInstance().PayoutStatus(hubtelPayoutPrefix + reference)
+	if err != nil {
+		return nil, err
+	}
+
+	result := &VerifyResult{
+		Reference:  reference,
+		ProviderID: resp.Data.TransactionID,
+		Status:     PaymentPending,
+		Amount:     resp.Data.Amount,
+		Currency:   firstNonEmpty(resp.Data.CurrencyCode, "GHS"),
+	}
+	switch status := strings.ToLower(resp.Data.Status); {
+	case hubtelStatusPaid(status):
+		result.Status = PaymentSucceeded
+	case hubtelStatusFailed(status):
+		result.Status = PaymentFailed
+		result.Reason = "payout " + status
+	}
+	return result, nil
+}
+
+func (hubtelProvider) VerifyWebhook(headers http.Header, body []byte) error {
+	return VerifyHubtelCallback(body)
+}
+
+func (hubtelProvider) ParseWebhook(body []byte) (*WebhookEvent, error) {
+	return parseHubtelWebhook(body)
+}
@@ -0,0 +1,126 @@
+package gateway
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strings"
+)
+
+// Hubtel client references are capped at 32 characters; payouts carry this
+// prefix so a callback can be told apart from a collection.
+const hubtelPayoutPrefix = "payout-"
+
+var ErrUnconfirmedCallback = errors.New("callback not confirmed by hubtel")
+
+// VerifyHubtelCallback authenticates a callback. Hubtel does not sign them,
+// so a callback is only accepted for a reference Hubtel's status API knows.
+// Nothing else in the body is trusted; parseHubtelWebhook reads the outcome
+// from the status API as well.
+func VerifyHubtelCallback(body []byte) error {
+	_, err := confirmHubtelCallback(body)
+	return err
+}
+
+// parseHubtelWebhook translates a Receive or Send Money callback. The
+// outcome, amounts and transaction ID come from the status API, never from
+// the callback body.
+func parseHubtelWebhook(body []byte) (*WebhookEvent, error) {
+	status, err := confirmHubtelCallback(body)
+	if err != nil {
+		return nil, err
+	}
+	return hubtelEvent(status), nil
+}
+
+// confirmHubtelCallback looks up the callback's client reference with
+// Hubtel's status API.
+func confirmHubtelCallback(body []byte) (*HubtelStatusResponse, error) {
+	var cb HubtelCallback
+	if err := json.Unmarshal(body, &cb); err != nil {
+		return nil, fmt.Errorf("bad payload: %w", err)
+	}
+	ref := cb.Data.ClientReference
+	if ref == "" {
+		return nil, ErrUnconfirmedCallback
+	}
+
+	hb := HBInstance()
+	var status *HubtelStatusResponse
+	var err error
+	if strings.HasPrefix(ref, hubtelPayoutPrefix) {
+		status, err = hb.PayoutStatus(ref)
+	} else {
+		status, err = hb.CollectionStatus(ref)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrUnconfirmedCallback, err)
+	}
+	if status.Data.ClientReference != "" && status.Data.ClientReference != ref {
+		return nil, fmt.Errorf("%w: status is for %s, not %s", ErrUnconfirmedCallback, status.Data.ClientReference, ref)
+	}
+	status.Data.ClientReference = ref
+	return status, nil
+}
+
+// hubtelEvent builds the event for a transaction from its status. Only a
+// final status produces an outcome; anything else is ignored until the next
+// callback or requery.
+func hubtelEvent(status *HubtelStatusResponse) *WebhookEvent {
+	ref := status.Data.ClientReference
+	state := strings.ToLower(status.Data.Status)
+	payout := strings.HasPrefix(ref, hubtelPayoutPrefix)
+	out := &WebhookEvent{
+		Type:      "collection:" + state,
+		Kind:      IgnoredEvent,
+		Reference: strings.TrimPrefix(ref, hubtelPayoutPrefix),
+	}
+	if payout {
+		out.Type = "payout:" + state
+	}
+	if status.Data.TransactionID != "" {
+		out.ID = status.Data.TransactionID + ":" + state
+	}
+
+	switch {
+	case payout && hubtelStatusPaid(state):
+		out.Kind = PayoutSucceeded
+	case payout && hubtelStatusFailed(state):
+		out.Kind = PayoutFailed
+		out.Reason = "payout " + state
+	case hubtelStatusPaid(state):
+		out.Kind = DepositSucceeded
+		out.CreditAmount = hubtelCreditAmount(status)
+	case hubtelStatusFailed(state):
+		out.Kind = DepositFailed
+		out.Reason = "payment " + state
+	}
+	return out
+}
+
+// hubtelCreditAmount is what reached the merchant account for a collection.
+func hubtelCreditAmount(status *HubtelStatusResponse) float64 {
+	if status.Data.AmountAfterCharges > 0 {
+		return status.Data.AmountAfterCharges
+	}
+	if status.Data.Charges > 0 && status.Data.Charges < status.Data.Amount {
+		return status.Data.Amount - status.Data.Charges
+	}
+	return status.Data.Amount
+}
+
+func hubtelStatusPaid(status string) bool {
+	switch strings.ToLower(status) {
+	case "paid", "success", "successful":
+		return true
+	}
+	return false
+}
+
+func hubtelStatusFailed(status string) bool {
+	switch strings.ToLower(status) {
+	case "failed", "refunded", "cancelled", "expired", "declined":
+		return true
+	}
+	return false
+}
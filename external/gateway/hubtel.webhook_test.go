@@ -0,0 +1,48 @@
+package gateway
+
+import (
+	"strings"
+	"testing"
+)
+
+func hubtelStatus(ref, state string, amount, charges, afterCharges float64) *HubtelStatusResponse {
+	var s HubtelStatusResponse
+	s.Data.ClientReference = ref
+	s.Data.Status = state
+	s.Data.TransactionID = "hb-1"
+	s.Data.Amount = amount
+	s.Data.Charges = charges
+	s.Data.AmountAfterCharges = afterCharges
+	return &s
+}
+
+func TestHubtelEventTrustsOnlyFinalStatus(t *testing.T) {
+	tests := []struct {
+		name       string
+		status     *HubtelStatusResponse
+		wantKind   WebhookEventKind
+		wantRef    string
+		wantCredit float64
+	}{
+		{"deposit paid", hubtelStatus("dep-1", "Paid", 100, 2, 98), DepositSucceeded, "dep-1", 98},
+		{"deposit paid without net amount", hubtelStatus("dep-1", "Paid", 100, 2, 0), DepositSucceeded, "dep-1", 98},
+		{"deposit paid without charges", hubtelStatus("dep-1", "Success", 100, 0, 0), DepositSucceeded, "dep-1", 100},
+		{"deposit failed", hubtelStatus("dep-1", "Failed", 100, 0, 0), DepositFailed, "dep-1", 0},
+		{"deposit still pending", hubtelStatus("dep-1", "Pending", 100, 0, 0), IgnoredEvent, "dep-1", 0},
+		{"deposit unpaid", hubtelStatus("dep-1", "Unpaid", 100, 0, 0), IgnoredEvent, "dep-1", 0},
+		{"payout paid", hubtelStatus("payout-wd-1", "Successful", 50, 0, 0), PayoutSucceeded, "wd-1", 0},
+		{"payout failed", hubtelStatus("payout-wd-1", "Failed", 50, 0, 0), PayoutFailed, "wd-1", 0},
+		{"payout still pending", hubtelStatus("payout-wd-1", "Processing", 50, 0, 0), IgnoredEvent, "wd-1", 0},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := hubtelEvent(tt.status)
+			if got.Kind != tt.wantKind || got.Reference != tt.wantRef || got.CreditAmount != tt.wantCredit {
+				t.Fatalf("event = %+v, want kind %v reference %s credit %v", got, tt.wantKind, tt.wantRef, tt.wantCredit)
+			}
+			if got.ID != "hb-1:"+strings.ToLower(tt.status.Data.Status) {
+				t.Errorf("ID = %q, want the status API's transaction ID", got.ID)
+			}
+		})
+	}
+}
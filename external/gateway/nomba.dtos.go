@@ -1,6 +1,7 @@
 package gateway
 
 import (
+	"strings"
 	"sync"
 
 )
@@ -39,6 +40,54 @@ type NBPaymentRequest struct {
 	TokenizeCard bool        `json:"tokenizeCard"`
 }
 
+// NBTokenizedCardPaymentRequest charges a card saved in an earlier checkout.
+type NBTokenizedCardPaymentRequest struct {
+	Order    NBTokenizedOrder `json:"order"`
+	TokenKey string           `json:"tokenKey"`
+}
+
+type NBTokenizedOrder struct {
+	OrderReference string `json:"orderReference"`
+	CallbackURL    string `json:"callbackUrl"`
+	CustomerEmail  string `json:"customerEmail"`
+	Amount         int64  `json:"amount"`
+	Currency       string `json:"currency"`
+	CustomerID     string `json:"customerId"`
+	AccountID      string `json:"accountId"`
+}
+
+type NBTokenizedCardPaymentResponse struct {
+	Code        string `json:"code"`
+	Description string `json:"description"`
+	Data        struct {
+		Status  bool   `json:"status"`
+		Message string `json:"message"`
+	} `json:"data"`
+}
+
+// NBVirtualAccountRequest opens a dedicated account; transfers into it are
+// reported with the account number as the alias account.
+type NBVirtualAccountRequest struct {
+	AccountRef  string `json:"accountRef"`
+	AccountName string `json:"accountName"`
+	Currency    string `json:"currency"`
+	BVN         string `json:"bvn,omitempty"`
+}
+
+type NBVirtualAccountResponse struct {
+	Code        string `json:"code"`
+	Description string `json:"description"`
+	Data        struct {
+		AccountHolderID   string `json:"accountHolderId"`
+		AccountRef        string `json:"accountRef"`
+		AccountName       string `json:"accountName"`
+		BankName          string `json:"bankName"`
+		BankAccountNumber string `json:"bankAccountNumber"`
+		BankAccountName   string `json:"bankAccountName"`
+		Currency          string `json:"currency"`
+	} `json:"data"`
+}
+
 type NBCheckoutResponse struct {
 	Status bool `json:"status"`
 	Data   struct {
@@ -47,6 +96,31 @@ type NBCheckoutResponse struct {
 	} `json:"data"`
 }
 
+type NBCheckoutTransactionResponse struct {
+	Code        string                `json:"code"`
+	Description string                `json:"description"`
+	Data        NBCheckoutTransaction `json:"data"`
+}
+
+// NBCheckoutTransaction is the outcome of a checkout order. Success is only
+// true once the customer has paid.
+type NBCheckoutTransaction struct {
+	Success bool   `json:"success"`
+	Message string `json:"message"`
+	Order   struct {
+		OrderID        string  `json:"orderId"`
+		OrderReference string  `json:"orderReference"`
+		Amount         float64 `json:"amount"`
+		Currency       string  `json:"currency"`
+	} `json:"order"`
+	TransactionDetails struct {
+		TransactionID string  `json:"transactionId"`
+		Status        string  `json:"status"`
+		Fee           float64 `json:"fee"`
+		ResponseCode  string  `json:"responseCode"`
+	} `json:"transactionDetails"`
+}
+
 type NBBankResponse struct {
 	Status  bool   `json:"status"`
 	Message string `json:"message"`
@@ -95,3 +169,36 @@ type NBWithdrawalRequest struct {
 	Narration     string         `json:"narration,omitempty"`
 	Meta          map[string]any `json:"meta,omitempty"`
 }
+
+// NBTransaction is one entry on the account's transaction list. Checkout
+// payments carry our OrderReference; payouts carry our MerchantTxRef;
+// transfers into a virtual account carry neither and are known by their ID,
+// the transactionId their webhook reports.
+type NBTransaction struct {
+	ID             string  `json:"id"`
+	Type           string  `json:"type"`
+	Status         string  `json:"status"`
+	Amount         float64 `json:"amount"`
+	MerchantTxRef  string  `json:"merchantTxRef"`
+	OrderReference string  `json:"orderReference"`
+	TimeCreated    string  `json:"timeCreated"`
+}
+
+// Reference is the reference the transaction is recorded under, matching
+// the one its webhook is stored by. It is empty for entries that are not
+// ours, such as fees.
+func (t NBTransaction) Reference() string {
+	if strings.EqualFold(t.Type, "vact_transfer") {
+		return t.ID
+	}
+	return firstNonEmpty(t.OrderReference, t.MerchantTxRef)
+}
+
+type NBTransactionListResponse struct {
+	Code        string `json:"code"`
+	Description string `json:"description"`
+	Data        struct {
+		Results []NBTransaction `json:"results"`
+		Cursor  string          `json:"cursor"`
+	} `json:"data"`
+}
@@ -3,10 +3,12 @@ package gateway
 import (
 	"bytes"
 	"encoding/json"
+	"errors"
 	"fmt"
 	"io"
 	"log"
 	"net/http"
+	neturl "net/url"
 	"sync"
       "time"
 	"github.com/dblaq/buzzycash/internal/config"
@@ -14,6 +16,8 @@ import (
 
 
 
+var ErrTransactionNotFound = errors.New("transaction not found at gateway")
+
 var (
 	nbService     *NBService
 	nbServiceOnce sync.Once
@@ -47,6 +51,11 @@ func NBInstance() *NBService {
 type NBService struct {
 	client *http.Client
 	auth   *NombaAuthService
+
+	// The bank list rarely changes, so it is cached for BankListCacheMinutes
+	banksMu      sync.Mutex
+	banks        []Bank
+	banksExpires time.Time
 }
 
 
@@ -105,7 +114,137 @@ func (s *NBService) CreateNBCheckout(req NBPaymentRequest) (string, string, erro
 	return nb.Data.CheckoutLink, nb.Data.OrderReference, nil
 }
 
+// ChargeTokenizedCard charges a saved card without a checkout page. Nomba
+// confirms the outcome with the usual payment webhook for the order.
+func (s *NBService) ChargeTokenizedCard(req NBTokenizedCardPaymentRequest) error {
+	token, err := s.auth.GetToken()
+	if err != nil {
+		return fmt.Errorf("failed to retrieve access token: %w", err)
+	}
+
+	body, err := json.Marshal(req)
+	if err != nil {
+		return fmt.Errorf("failed to marshal tokenized card request: %w", err)
+	}
+
+	url := config.AppConfig.NombaApiBase + "checkout/tokenized-card-payment"
+	httpReq, err := http.NewRequest(http.MethodPost, url, bytes.NewReader(body))
+	if err != nil {
+		return fmt.Errorf("failed to create HTTP request: %w", err)
+	}
+	httpReq.Header.Set("accountId", config.AppConfig.NombaAccountID)
+	httpReq.Header.Set("Authorization", "Bearer "+token.AccessToken)
+	httpReq.Header.Set("Content-Type", "application/json")
+
+	resp, err := s.client.Do(httpReq)
+	if err != nil {
+		return fmt.Errorf("nomba HTTP request failed: %w", err)
+	}
+	defer resp.Body.Close()
+
+	b, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return fmt.Errorf("failed to read response body: %w", err)
+	}
+
+	if resp.StatusCode >= 300 {
+		log.Printf("ERROR: Nomba tokenized card charge for ref=%s returned %d: %s\n", req.Order.OrderReference, resp.StatusCode, string(b))
+		if nombaRejected(resp.StatusCode) {
+			return fmt.Errorf("%w: nomba error %d", ErrChargeDeclined, resp.StatusCode)
+		}
+		return fmt.Errorf("nomba error %d", resp.StatusCode)
+	}
+
+	var nb NBTokenizedCardPaymentResponse
+	if err := json.Unmarshal(b, &nb); err != nil {
+		return fmt.Errorf("failed to unmarshal Nomba response: %w", err)
+	}
+	if nb.Code != "00" || !nb.Data.Status {
+		return fmt.Errorf("%w: nomba code=%s message='%s'", ErrChargeDeclined, nb.Code, firstNonEmpty(nb.Data.Message, nb.Description))
+	}
+	return nil
+}
+
+// nombaRejected reports whether an HTTP status means Nomba turned the
+// request down without acting on it. Timeouts, throttling and server errors
+// leave the outcome unknown.
+func nombaRejected(status int) bool {
+	return status >= 400 && status < 500 &&
+		status != http.StatusRequestTimeout && status != http.StatusTooManyRequests
+}
+
+// CreateVirtualAccount opens a dedicated account under our Nomba account.
+func (s *NBService) CreateVirtualAccount(req NBVirtualAccountRequest) (*NBVirtualAccountResponse, error) {
+	token, err := s.auth.GetToken()
+	if err != nil {
+		return nil, fmt.Errorf("failed to retrieve access token: %w", err)
+	}
+
+	body, err := json.Marshal(req)
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal virtual account request: %w", err)
+	}
+
+	url := config.AppConfig.NombaApiBase + "accounts/virtual"
+	httpReq, err := http.NewRequest(http.MethodPost, url, bytes.NewReader(body))
+	if err != nil {
+		return nil, fmt.Errorf("failed to create HTTP request: %w", err)
+	}
+	httpReq.Header.Set("accountId", config.AppConfig.NombaAccountID)
+	httpReq.Header.Set("Authorization", "Bearer "+token.AccessToken)
+	httpReq.Header.Set("Content-Type", "application/json")
+
+	resp, err := s.client.Do(httpReq)
+	if err != nil {
+		return nil, fmt.Errorf("nomba HTTP request failed: %w", err)
+	}
+	defer resp.Body.Close()
+
+	b, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read response body: %w", err)
+	}
+
+	if resp.StatusCode >= 300 {
+		log.Printf("ERROR: Nomba virtual account creation for ref=%s returned %d: %s\n", req.AccountRef, resp.StatusCode, string(b))
+		return nil, fmt.Errorf("nomba error %d", resp.StatusCode)
+	}
+
+	var nb NBVirtualAccountResponse
+	if err := json.Unmarshal(b, &nb); err != nil {
+		return nil, fmt.Errorf("failed to unmarshal Nomba response: %w", err)
+	}
+	if nb.Code != "00" || nb.Data.BankAccountNumber == "" {
+		return nil, fmt.Errorf("nomba virtual account creation failed: code=%s message='%s'", nb.Code, nb.Description)
+	}
+	return &nb, nil
+}
+
+// ListNBBanks returns the banks Nomba can pay out to, from the cache while
+// it is fresh. A failed refresh falls back to the stale list if there is one.
 func (s *NBService) ListNBBanks() ([]Bank, error) {
+	s.banksMu.Lock()
+	defer s.banksMu.Unlock()
+
+	if s.banks != nil && time.Now().Before(s.banksExpires) {
+		return s.banks, nil
+	}
+
+	banks, err := s.fetchNBBanks()
+	if err != nil {
+		if s.banks != nil {
+			log.Printf("WARNING: Nomba bank list refresh failed, serving cached list: %v\n", err)
+			return s.banks, nil
+		}
+		return nil, err
+	}
+
+	s.banks = banks
+	s.banksExpires = time.Now().Add(time.Duration(config.AppConfig.BankListCacheMinutes) * time.Minute)
+	return banks, nil
+}
+
+func (s *NBService) fetchNBBanks() ([]Bank, error) {
 
 	// 1. Get token
 	token, err := s.auth.GetToken()
@@ -254,3 +393,102 @@ func (s *NBService) InitiateWithdrawal(req NBWithdrawalRequest) (string, error)
 
 	return nb.Message, nil
 }
+
+// FetchCheckoutTransaction looks up a checkout order by its order reference
+// so we can learn its outcome when the webhook never arrived.
+func (s *NBService) FetchCheckoutTransaction(orderReference string) (*NBCheckoutTransaction, error) {
+	token, err := s.auth.GetToken()
+	if err != nil {
+		return nil, fmt.Errorf("failed to retrieve access token: %w", err)
+	}
+
+	url := config.AppConfig.NombaApiBase + "checkout/transaction?idType=ORDER_REFERENCE&id=" + neturl.QueryEscape(orderReference)
+	httpReq, err := http.NewRequest(http.MethodGet, url, nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create HTTP request: %w", err)
+	}
+	httpReq.Header.Set("accountId", config.AppConfig.NombaAccountID)
+	httpReq.Header.Set("Authorization", "Bearer "+token.AccessToken)
+	httpReq.Header.Set("Content-Type", "application/json")
+
+	resp, err := s.client.Do(httpReq)
+	if err != nil {
+		return nil, fmt.Errorf("nomba HTTP request failed: %w", err)
+	}
+	defer resp.Body.Close()
+
+	b, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read response body: %w", err)
+	}
+
+	if resp.StatusCode == http.StatusNotFound {
+		return nil, ErrTransactionNotFound
+	}
+	if resp.StatusCode >= 300 {
+		log.Printf("ERROR: Nomba checkout lookup for ref=%s returned %d: %s\n", orderReference, resp.StatusCode, string(b))
+		return nil, fmt.Errorf("nomba error %d", resp.StatusCode)
+	}
+
+	var nb NBCheckoutTransactionResponse
+	if err := json.Unmarshal(b, &nb); err != nil {
+		return nil, fmt.Errorf("failed to unmarshal Nomba response: %w", err)
+	}
+
+	return &nb.Data, nil
+}
+
+// ListTransactions returns every transaction on our Nomba account between
+// from and to, following the cursor to the end.
+func (s *NBService) ListTransactions(from, to time.Time) ([]NBTransaction, error) {
+	token, err := s.auth.GetToken()
+	if err != nil {
+		return nil, fmt.Errorf("failed to retrieve access token: %w", err)
+	}
+
+	var all []NBTransaction
+	cursor := ""
+	for {
+		q := neturl.Values{}
+		q.Set("dateFrom", from.Format(time.RFC3339))
+		q.Set("dateTo", to.Format(time.RFC3339))
+		q.Set("limit", "100")
+		if cursor != "" {
+			q.Set("cursor", cursor)
+		}
+		url := config.AppConfig.NombaApiBase + "transactions/accounts?" + q.Encode()
+
+		httpReq, err := http.NewRequest(http.MethodGet, url, nil)
+		if err != nil {
+			return nil, fmt.Errorf("failed to create HTTP request: %w", err)
+		}
+		httpReq.Header.Set("accountId", config.AppConfig.NombaAccountID)
+		httpReq.Header.Set("Authorization", "Bearer "+token.AccessToken)
+		httpReq.Header.Set("Content-Type", "application/json")
+
+		resp, err := s.client.Do(httpReq)
+		if err != nil {
+			return nil, fmt.Errorf("nomba HTTP request failed: %w", err)
+		}
+		b, err := io.ReadAll(resp.Body)
+		resp.Body.Close()
+		if err != nil {
+			return nil, fmt.Errorf("failed to read response body: %w", err)
+		}
+		if resp.StatusCode >= 300 {
+			log.Printf("ERROR: Nomba transaction list returned %d: %s\n", resp.StatusCode, string(b))
+			return nil, fmt.Errorf("nomba error %d", resp.StatusCode)
+		}
+
+		var nb NBTransactionListResponse
+		if err := json.Unmarshal(b, &nb); err != nil {
+			return nil, fmt.Errorf("failed to unmarshal Nomba response: %w", err)
+		}
+
+		all = append(all, nb.Data.Results...)
+		if nb.Data.Cursor == "" || len(nb.Data.Results) == 0 {
+			return all, nil
+		}
+		cursor = nb.Data.Cursor
+	}
+}
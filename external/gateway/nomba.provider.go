@@ -0,0 +1,169 @@
+package gateway
+
+import (
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/dblaq/buzzycash/internal/config"
+)
+
+// nombaProvider adapts the Nomba client to PaymentProvider. The client is
+// fetched lazily so registering does not start its token refresh loop.
+type nombaProvider struct{}
+
+func init() {
+	Register(nombaProvider{})
+}
+
+func (nombaProvider) Name() string { return "nomba" }
+
+func (nombaProvider) CreateCheckout(req CheckoutRequest) (*CheckoutResult, error) {
+	nbReq := NBPaymentRequest{
+		Order: NBOrder{
+			CallbackURL:   req.CallbackURL,
+			CustomerEmail: req.Email,
+			Amount:        req.Amount,
+			Currency:      req.Currency,
+			CustomerID:    req.CustomerID,
+		},
+		TokenizeCard: true,
+	}
+	link, orderRef, err := NBInstance().CreateNBCheckout(nbReq)
+	if err != nil {
+		return nil, err
+	}
+	return &CheckoutResult{CheckoutLink: link, Reference: orderRef}, nil
+}
+
+func (nombaProvider) ChargeSavedCard(req CheckoutRequest, token string) (*CheckoutResult, error) {
+	err := NBInstance().ChargeTokenizedCard(NBTokenizedCardPaymentRequest{
+		Order: NBTokenizedOrder{
+			OrderReference: req.Reference,
+			CallbackURL:    req.CallbackURL,
+			CustomerEmail:  req.Email,
+			Amount:         req.Amount,
+			Currency:       req.Currency,
+			CustomerID:     req.CustomerID,
+			AccountID:      config.AppConfig.NombaAccountID,
+		},
+		TokenKey: token,
+	})
+	if err != nil {
+		return nil, err
+	}
+	return &CheckoutResult{Reference: req.Reference}, nil
+}
+
+func (nombaProvider) Verify(reference string) (*VerifyResult, error) {
+	txn, err := NBInstance().FetchCheckoutTransaction(reference)
+	if err != nil {
+		return nil, err
+	}
+
+	result := &VerifyResult{
+		Reference:    reference,
+		ProviderID:   txn.TransactionDetails.TransactionID,
+		Status:       PaymentPending,
+		Amount:       txn.Order.Amount,
+		CreditAmount: txn.Order.Amount - txn.TransactionDetails.Fee,
+		Currency:     txn.Order.Currency,
+	}
+	status := strings.ToUpper(txn.TransactionDetails.Status)
+	switch {
+	case txn.Success:
+		result.Status = PaymentSucceeded
+	case status == "FAILED" || status == "CANCELLED" || status == "DECLINED":
+		result.Status = PaymentFailed
+		result.Reason = "payment " + strings.ToLower(status)
+	}
+	return result, nil
+}
+
+func (nombaProvider) CreateVirtualAccount(req VirtualAccountRequest) (*VirtualAccount, error) {
+	if !strings.EqualFold(req.Currency, "NGN") {
+		return nil, ErrUnsupportedCurrency
+	}
+
+	resp, err := NBInstance().CreateVirtualAccount(NBVirtualAccountRequest{
+		AccountRef:  req.Reference,
+		AccountName: req.AccountName,
+		Currency:    "NGN",
+		BVN:         req.BVN,
+	})
+	if err != nil {
+		return nil, err
+	}
+	return &VirtualAccount{
+		AccountNumber: resp.Data.BankAccountNumber,
+		AccountName:   firstNonEmpty(resp.Data.BankAccountName, resp.Data.AccountName),
+		BankName:      resp.Data.BankName,
+		Reference:     firstNonEmpty(resp.Data.AccountRef, req.Reference),
+	}, nil
+}
+
+func (nombaProvider) ListBanks() ([]Bank, error) {
+	return NBInstance().ListNBBanks()
+}
+
+func (nombaProvider) ResolveAccount(accountNumber, bankCode string) (*AccountDetails, error) {
+	details, err := NBInstance().FetchAccountDetails(NBRetrieveAccountDetails{
+		AccountNumber: accountNumber,
+		BankCode:      bankCode,
+	})
+	if err != nil {
+		return nil, err
+	}
+	return &AccountDetails{AccountNumber: details.AccountNumber, AccountName: details.AccountName}, nil
+}
+
+func (nombaProvider) InitiatePayout(req PayoutRequest) error {
+	_, err := NBInstance().InitiateWithdrawal(NBWithdrawalRequest{
+		MerchantTxRef: req.Reference,
+		Amount:        req.Amount,
+		BankCode:      req.BankCode,
+		AccountNumber: req.AccountNumber,
+		AccountName:   req.AccountName,
+		Narration:     req.Narration,
+		SenderName:    "BuzzyCash",
+	})
+	return err
+}
+
+// VerifyPayout finds the transfer in the account's transaction list, since
+// Nomba has no lookup of a transfer by our reference.
+func (nombaProvider) VerifyPayout(reference string, since time.Time) (*VerifyResult, error) {
+	txns, err := NBInstance().ListTransactions(since.Add(-time.Hour), time.Now())
+	if err != nil {
+		return nil, err
+	}
+	for _, t := range txns {
+		if t.MerchantTxRef != reference {
+			continue
+		}
+		result := &VerifyResult{
+			Reference:  reference,
+			ProviderID: t.ID,
+			Status:     PaymentPending,
+			Amount:     t.Amount,
+			Currency:   "NGN",
+		}
+		switch status := strings.ToUpper(t.Status); status {
+		case "SUCCESS", "SUCCESSFUL", "PAYMENT_SUCCESSFUL":
+			result.Status = PaymentSucceeded
+		case "FAILED", "PAYMENT_FAILED", "REVERSED", "REFUND":
+			result.Status = PaymentFailed
+			result.Reason = "transfer " + strings.ToLower(status)
+		}
+		return result, nil
+	}
+	return nil, ErrTransactionNotFound
+}
+
+func (nombaProvider) VerifyWebhook(headers http.Header, body []byte) error {
+	return VerifyNombaSignature(body, headers.Get(NombaSignatureHeader), headers.Get(NombaTimestampHeader), time.Now())
+}
+
+func (nombaProvider) ParseWebhook(body []byte) (*WebhookEvent, error) {
+	return parseNombaWebhook(body)
+}
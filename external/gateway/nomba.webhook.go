@@ -0,0 +1,328 @@
+package gateway
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/dblaq/buzzycash/internal/config"
+)
+
+const (
+	NombaSignatureHeader = "nomba-signature"
+	NombaTimestampHeader = "nomba-timestamp"
+)
+
+var (
+	ErrMissingSignature = errors.New("missing signature headers")
+	ErrInvalidTimestamp = errors.New("invalid signature timestamp")
+	ErrStaleTimestamp   = errors.New("signature timestamp outside tolerance window")
+	ErrInvalidSignature = errors.New("invalid signature")
+	ErrSecretNotSet     = errors.New("webhook secret not configured")
+)
+
+type NombaWebhook struct {
+	EventType string `json:"event_type"`
+	RequestID string `json:"requestId"`
+	Data      struct {
+		Customer struct {
+			BillerID  string `json:"billerId"`
+			ProductID string `json:"productId"`
+		} `json:"customer"`
+
+		Merchant struct {
+			UserID        string  `json:"userId"`
+			WalletBalance float64 `json:"walletBalance"`
+			WalletID      string  `json:"walletId"`
+		} `json:"merchant"`
+
+		Order struct {
+			AccountID              string  `json:"accountId"`
+			Amount                 float64 `json:"amount"`
+			CallbackURL            string  `json:"callbackUrl"`
+			CardCurrency           string  `json:"cardCurrency"`
+			CardLast4Digits        string  `json:"cardLast4Digits"`
+			CardType               string  `json:"cardType"`
+			Currency               string  `json:"currency"`
+			CustomerEmail          string  `json:"customerEmail"`
+			CustomerID             string  `json:"customerId"`
+			IsTokenizedCardPayment string  `json:"isTokenizedCardPayment"`
+			OrderID                string  `json:"orderId"`
+			OrderReference         string  `json:"orderReference"`
+			PaymentMethod          string  `json:"paymentMethod"`
+		} `json:"order"`
+
+		Terminal struct{} `json:"terminal"`
+
+		TokenizedCardData struct {
+			CardPan         string `json:"cardPan"`
+			CardType        string `json:"cardType"`
+			TokenExpiryMonth string `json:"tokenExpiryMonth"`
+			TokenExpiryYear  string `json:"tokenExpiryYear"`
+			TokenKey        string `json:"tokenKey"`
+		} `json:"tokenizedCardData"`
+
+		Transaction struct {
+			AliasAccountNumber    string  `json:"aliasAccountNumber"`
+			AliasAccountReference string  `json:"aliasAccountReference"`
+			Fee              float64 `json:"fee"`
+			MerchantTxRef    string  `json:"merchantTxRef"`
+			OriginatingFrom  string  `json:"originatingFrom"`
+			ResponseCode     string  `json:"responseCode"`
+			Time             string  `json:"time"`
+			TransactionAmount float64 `json:"transactionAmount"`
+			TransactionID    string  `json:"transactionId"`
+			Type             string  `json:"type"`
+		} `json:"transaction"`
+	} `json:"data"`
+}
+
+
+type NombaWithdrawalResponse struct {
+	Code        string `json:"code"`
+	Description string `json:"description"`
+	Message     string `json:"message"`
+	Status      bool   `json:"status"`
+	Data        struct {
+		Amount      float64 `json:"amount"`
+		Fee         string  `json:"fee"`
+		TimeCreated string  `json:"timeCreated"`
+		ID          string  `json:"id"`
+		Type        string  `json:"type"`
+		Status      string  `json:"status"`
+		Meta        struct {
+			MerchantTxRef string `json:"merchantTxRef"`
+			ApiClientID   string `json:"api_client_id"`
+			ApiAccountID  string `json:"api_account_id"`
+			RecipientName string `json:"recipientName"`
+			RRN           string `json:"rrn"`
+		} `json:"meta"`
+		Transaction struct {
+			MerchantTxRef string `json:"merchantTxRef"`
+			ResponseCode  string `json:"responseCode"`
+		} `json:"transaction"`
+	} `json:"data"`
+}
+
+// MerchantTxRef returns our payout reference from either the transfer
+// response shape (data.meta) or the payout event shape (data.transaction).
+func (r NombaWithdrawalResponse) MerchantTxRef() string {
+	if r.Data.Meta.MerchantTxRef != "" {
+		return r.Data.Meta.MerchantTxRef
+	}
+	return r.Data.Transaction.MerchantTxRef
+}
+
+// NombaWebhookWrapper is enough of any Nomba webhook to tell which kind it is.
+type NombaWebhookWrapper struct {
+	EventType    string `json:"event_type,omitempty"` // present in payment and payout webhooks
+	EventTypeAlt string `json:"eventType,omitempty"`  // older payloads use camelCase
+	Code         string `json:"code,omitempty"`       // present in withdrawal response
+	RequestID    string `json:"requestId,omitempty"`
+	Data         struct {
+		Status      string `json:"status,omitempty"` // for withdrawal
+		Transaction struct {
+			TransactionID string `json:"transactionId,omitempty"`
+		} `json:"transaction"`
+	} `json:"data"`
+}
+
+// Event returns the normalised event type.
+func (w NombaWebhookWrapper) Event() string {
+	if w.EventType != "" {
+		return strings.ToLower(w.EventType)
+	}
+	return strings.ToLower(w.EventTypeAlt)
+}
+
+// nombaSignedFields are the payload fields Nomba includes in its signature.
+type nombaSignedFields struct {
+	EventType string `json:"event_type"`
+	RequestID string `json:"requestId"`
+	Data      struct {
+		Merchant struct {
+			UserID   string `json:"userId"`
+			WalletID string `json:"walletId"`
+		} `json:"merchant"`
+		Transaction struct {
+			TransactionID string `json:"transactionId"`
+			Type          string `json:"type"`
+			Time          string `json:"time"`
+			ResponseCode  string `json:"responseCode"`
+		} `json:"transaction"`
+	} `json:"data"`
+}
+
+// nombaSignaturePayload builds the string Nomba signs:
+// event_type:requestId:userId:walletId:transactionId:type:time:responseCode:timestamp
+func nombaSignaturePayload(body []byte, timestamp string) (string, error) {
+	var f nombaSignedFields
+	if err := json.Unmarshal(body, &f); err != nil {
+		return "", fmt.Errorf("decode signed fields: %w", err)
+	}
+	return fmt.Sprintf("%s:%s:%s:%s:%s:%s:%s:%s:%s",
+		f.EventType,
+		f.RequestID,
+		f.Data.Merchant.UserID,
+		f.Data.Merchant.WalletID,
+		f.Data.Transaction.TransactionID,
+		f.Data.Transaction.Type,
+		f.Data.Transaction.Time,
+		f.Data.Transaction.ResponseCode,
+		timestamp,
+	), nil
+}
+
+// VerifyNombaSignature checks the HMAC-SHA256 signature and rejects
+// deliveries whose timestamp falls outside the configured window, so a
+// captured request cannot be replayed later.
+func VerifyNombaSignature(body []byte, signature, timestamp string, now time.Time) error {
+	secret := config.AppConfig.NombaWebhookSecret
+	if secret == "" {
+		return ErrSecretNotSet
+	}
+	if signature == "" || timestamp == "" {
+		return ErrMissingSignature
+	}
+
+	sentAt, err := time.Parse(time.RFC3339, timestamp)
+	if err != nil {
+		return ErrInvalidTimestamp
+	}
+	tolerance := time.Duration(config.AppConfig.NombaWebhookToleranceSeconds) * time.Second
+	if skew := now.Sub(sentAt); skew > tolerance || skew < -tolerance {
+		return ErrStaleTimestamp
+	}
+
+	payload, err := nombaSignaturePayload(body, timestamp)
+	if err != nil {
+		return ErrInvalidSignature
+	}
+
+	mac := hmac.New(sha256.New, []byte(secret))
+	mac.Write([]byte(payload))
+	expected := base64.StdEncoding.EncodeToString(mac.Sum(nil))
+
+	if !hmac.Equal([]byte(expected), []byte(signature)) {
+		return ErrInvalidSignature
+	}
+	return nil
+}
+
+// parseNombaWebhook translates a Nomba payment, virtual account transfer or
+// payout webhook.
+func parseNombaWebhook(body []byte) (*WebhookEvent, error) {
+	// Step 1: Peek into the payload
+	var wrapper NombaWebhookWrapper
+	if err := json.Unmarshal(body, &wrapper); err != nil {
+		return nil, fmt.Errorf("bad payload: %w", err)
+	}
+
+	event := wrapper.Event()
+	payoutStatus := strings.ToUpper(wrapper.Data.Status)
+	out := &WebhookEvent{
+		ID:   firstNonEmpty(wrapper.RequestID, wrapper.Data.Transaction.TransactionID),
+		Type: event,
+		Kind: IgnoredEvent,
+	}
+	if out.Type == "" {
+		out.Type = wrapper.Data.Status
+	}
+
+	// Step 2: Branch logic
+	switch {
+	// ----------- Deposit flow ------------
+	case event == "payment_success" || event == "payment_failed" || event == "payment_reversal" || event == "payment_refund":
+		var evt NombaWebhook
+		if err := json.Unmarshal(body, &evt); err != nil {
+			return nil, fmt.Errorf("bad deposit payload: %w", err)
+		}
+		// Saved-card charges carry the order reference we chose
+		out.Reference = firstNonEmpty(evt.Data.Order.OrderID, evt.Data.Order.OrderReference)
+
+		// A transfer into a dedicated account has no order; the account it
+		// landed in identifies the user, and each transfer is its own deposit
+		// keyed by Nomba's transaction ID.
+		txn := evt.Data.Transaction
+		isTransfer := strings.EqualFold(txn.Type, "vact_transfer") || (out.Reference == "" && txn.AliasAccountNumber != "")
+		if isTransfer {
+			out.Reference = txn.TransactionID
+		}
+
+		switch {
+		case isTransfer && event == "payment_success":
+			out.Kind = VirtualAccountCredited
+			out.Account = firstNonEmpty(txn.AliasAccountNumber, txn.AliasAccountReference)
+			out.CreditAmount = txn.TransactionAmount - txn.Fee
+		case event == "payment_success":
+			out.Kind = DepositSucceeded
+			out.CreditAmount = evt.Data.Order.Amount - evt.Data.Transaction.Fee
+			if card := evt.Data.TokenizedCardData; card.TokenKey != "" {
+				out.Card = &CardToken{
+					Token:       card.TokenKey,
+					MaskedPan:   card.CardPan,
+					Brand:       card.CardType,
+					ExpiryMonth: card.TokenExpiryMonth,
+					ExpiryYear:  card.TokenExpiryYear,
+				}
+			}
+		case event == "payment_failed":
+			out.Kind = DepositFailed
+			out.Reason = "payment failed: " + evt.Data.Transaction.ResponseCode
+		default:
+			out.Kind = DepositReversed
+			out.Reason = event
+		}
+
+	// ----------- Withdrawal flow ----------
+	case event == "payout_success" || payoutStatus == "SUCCESS":
+		evt, err := decodeNombaWithdrawal(body)
+		if err != nil {
+			return nil, err
+		}
+		out.Kind = PayoutSucceeded
+		out.Reference = evt.MerchantTxRef()
+
+	case event == "payout_failed" || payoutStatus == "FAILED" || payoutStatus == "REJECTED":
+		evt, err := decodeNombaWithdrawal(body)
+		if err != nil {
+			return nil, err
+		}
+		out.Kind = PayoutFailed
+		out.Reference = evt.MerchantTxRef()
+		out.Reason = "payout failed: " + firstNonEmpty(evt.Message, evt.Description, evt.Data.Transaction.ResponseCode, "unknown")
+
+	case event == "payout_refund" || payoutStatus == "REFUND" || payoutStatus == "REFUNDED" || payoutStatus == "REVERSED":
+		evt, err := decodeNombaWithdrawal(body)
+		if err != nil {
+			return nil, err
+		}
+		out.Kind = PayoutReversed
+		out.Reference = evt.MerchantTxRef()
+		out.Reason = "payout refunded"
+	}
+
+	return out, nil
+}
+
+func decodeNombaWithdrawal(body []byte) (*NombaWithdrawalResponse, error) {
+	var evt NombaWithdrawalResponse
+	if err := json.Unmarshal(body, &evt); err != nil {
+		return nil, fmt.Errorf("bad withdrawal payload: %w", err)
+	}
+	return &evt, nil
+}
+
+func firstNonEmpty(values ...string) string {
+	for _, v := range values {
+		if v != "" {
+			return v
+		}
+	}
+	return ""
+}
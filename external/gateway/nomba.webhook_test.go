@@ -0,0 +1,159 @@
+package gateway
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/base64"
+	"errors"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/dblaq/buzzycash/internal/config"
+)
+
+const testNombaSecret = "test-webhook-secret"
+
+const testNombaBody = `{
+	"event_type": "payment_success",
+	"requestId": "req-123",
+	"data": {
+		"merchant": {"userId": "user-1", "walletId": "wallet-1"},
+		"transaction": {
+			"transactionId": "txn-1",
+			"type": "online_checkout",
+			"time": "2025-01-01T10:00:00Z",
+			"responseCode": "00"
+		}
+	}
+}`
+
+// signNomba signs the fields in the order Nomba documents, independently of
+// nombaSignaturePayload.
+func signNomba(timestamp string) string {
+	payload := "payment_success:req-123:user-1:wallet-1:txn-1:online_checkout:2025-01-01T10:00:00Z:00:" + timestamp
+	mac := hmac.New(sha256.New, []byte(testNombaSecret))
+	mac.Write([]byte(payload))
+	return base64.StdEncoding.EncodeToString(mac.Sum(nil))
+}
+
+func TestVerifyNombaSignature(t *testing.T) {
+	saved := config.AppConfig
+	t.Cleanup(func() { config.AppConfig = saved })
+	config.AppConfig.NombaWebhookSecret = testNombaSecret
+	config.AppConfig.NombaWebhookToleranceSeconds = 300
+
+	now := time.Date(2025, 1, 1, 10, 0, 30, 0, time.UTC)
+	timestamp := now.Add(-30 * time.Second).Format(time.RFC3339)
+	stale := now.Add(-10 * time.Minute).Format(time.RFC3339)
+
+	tests := []struct {
+		name      string
+		body      string
+		signature string
+		timestamp string
+		want      error
+	}{
+		{
+			name:      "valid",
+			body:      testNombaBody,
+			signature: signNomba(timestamp),
+			timestamp: timestamp,
+		},
+		{
+			name:      "tampered signed field",
+			body:      strings.Replace(testNombaBody, `"responseCode": "00"`, `"responseCode": "01"`, 1),
+			signature: signNomba(timestamp),
+			timestamp: timestamp,
+			want:      ErrInvalidSignature,
+		},
+		{
+			name:      "timestamp changed after signing",
+			body:      testNombaBody,
+			signature: signNomba(timestamp),
+			timestamp: now.Format(time.RFC3339),
+			want:      ErrInvalidSignature,
+		},
+		{
+			name:      "stale timestamp",
+			body:      testNombaBody,
+			signature: signNomba(stale),
+			timestamp: stale,
+			want:      ErrStaleTimestamp,
+		},
+		{
+			name:      "timestamp in the future",
+			body:      testNombaBody,
+			signature: signNomba(now.Add(10 * time.Minute).Format(time.RFC3339)),
+			timestamp: now.Add(10 * time.Minute).Format(time.RFC3339),
+			want:      ErrStaleTimestamp,
+		},
+		{
+			name:      "malformed timestamp",
+			body:      testNombaBody,
+			signature: signNomba("yesterday"),
+			timestamp: "yesterday",
+			want:      ErrInvalidTimestamp,
+		},
+		{
+			name:      "missing signature",
+			body:      testNombaBody,
+			timestamp: timestamp,
+			want:      ErrMissingSignature,
+		},
+		{
+			name:      "body is not JSON",
+			body:      "not json",
+			signature: signNomba(timestamp),
+			timestamp: timestamp,
+			want:      ErrInvalidSignature,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := VerifyNombaSignature([]byte(tt.body), tt.signature, tt.timestamp, now)
+			if tt.want == nil && err != nil {
+				t.Fatalf("VerifyNombaSignature = %v, want nil", err)
+			}
+			if tt.want != nil && !errors.Is(err, tt.want) {
+				t.Fatalf("VerifyNombaSignature = %v, want %v", err, tt.want)
+			}
+		})
+	}
+}
+
+func TestVerifyNombaSignatureWithoutSecret(t *testing.T) {
+	saved := config.AppConfig
+	t.Cleanup(func() { config.AppConfig = saved })
+	config.AppConfig.NombaWebhookSecret = ""
+
+	now := time.Now()
+	err := VerifyNombaSignature([]byte(testNombaBody), signNomba(now.Format(time.RFC3339)), now.Format(time.RFC3339), now)
+	if !errors.Is(err, ErrSecretNotSet) {
+		t.Fatalf("VerifyNombaSignature = %v, want ErrSecretNotSet", err)
+	}
+}
+
+func TestNombaTransferReferenceMatchesWebhook(t *testing.T) {
+	body := `{
+		"event_type": "payment_success",
+		"requestId": "req-456",
+		"data": {
+			"transaction": {
+				"transactionId": "API-VACT_TRA-123",
+				"type": "vact_transfer",
+				"aliasAccountNumber": "0123456789",
+				"transactionAmount": 2000
+			}
+		}
+	}`
+	evt, err := parseNombaWebhook([]byte(body))
+	if err != nil {
+		t.Fatalf("parseNombaWebhook: %v", err)
+	}
+
+	listed := NBTransaction{ID: "API-VACT_TRA-123", Type: "vact_transfer"}
+	if got := listed.Reference(); got != evt.Reference {
+		t.Errorf("listed transfer reference = %q, webhook stored %q", got, evt.Reference)
+	}
+}
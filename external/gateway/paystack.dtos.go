@@ -0,0 +1,109 @@
+package gateway
+
+// Paystack amounts are in the currency's subunit (kobo for NGN).
+
+type PSInitializeRequest struct {
+	Email       string         `json:"email"`
+	Amount      int64          `json:"amount"`
+	Currency    string         `json:"currency,omitempty"`
+	Reference   string         `json:"reference"`
+	CallbackURL string         `json:"callback_url,omitempty"`
+	Metadata    map[string]any `json:"metadata,omitempty"`
+}
+
+type PSInitializeResponse struct {
+	Status  bool   `json:"status"`
+	Message string `json:"message"`
+	Data    struct {
+		AuthorizationURL string `json:"authorization_url"`
+		AccessCode       string `json:"access_code"`
+		Reference        string `json:"reference"`
+	} `json:"data"`
+}
+
+type PSTransaction struct {
+	ID              int64  `json:"id"`
+	Status          string `json:"status"`
+	Reference       string `json:"reference"`
+	Amount          int64  `json:"amount"`
+	Fees            int64  `json:"fees"`
+	Currency        string `json:"currency"`
+	GatewayResponse string `json:"gateway_response"`
+	PaidAt          string `json:"paid_at"`
+}
+
+type PSVerifyResponse struct {
+	Status  bool          `json:"status"`
+	Message string        `json:"message"`
+	Data    PSTransaction `json:"data"`
+}
+
+type PSBankResponse struct {
+	Status  bool   `json:"status"`
+	Message string `json:"message"`
+	Data    []struct {
+		Name string `json:"name"`
+		Code string `json:"code"`
+	} `json:"data"`
+}
+
+type PSResolveResponse struct {
+	Status  bool   `json:"status"`
+	Message string `json:"message"`
+	Data    struct {
+		AccountNumber string `json:"account_number"`
+		AccountName   string `json:"account_name"`
+	} `json:"data"`
+}
+
+type PSRecipientRequest struct {
+	Type          string `json:"type"`
+	Name          string `json:"name"`
+	AccountNumber string `json:"account_number"`
+	BankCode      string `json:"bank_code"`
+	Currency      string `json:"currency"`
+}
+
+type PSRecipientResponse struct {
+	Status  bool   `json:"status"`
+	Message string `json:"message"`
+	Data    struct {
+		RecipientCode string `json:"recipient_code"`
+	} `json:"data"`
+}
+
+type PSTransferRequest struct {
+	Source    string `json:"source"`
+	Amount    int64  `json:"amount"`
+	Recipient string `json:"recipient"`
+	Reference string `json:"reference"`
+	Reason    string `json:"reason,omitempty"`
+	Currency  string `json:"currency,omitempty"`
+}
+
+type PSTransferResponse struct {
+	Status  bool   `json:"status"`
+	Message string `json:"message"`
+	Data    struct {
+		TransferCode string `json:"transfer_code"`
+		Reference    string `json:"reference"`
+		Status       string `json:"status"`
+		Amount       int64  `json:"amount"`
+		Currency     string `json:"currency"`
+	} `json:"data"`
+}
+
+// PaystackWebhook covers the charge, refund and transfer events we handle.
+type PaystackWebhook struct {
+	Event string `json:"event"`
+	Data  struct {
+		ID                   int64  `json:"id"`
+		Status               string `json:"status"`
+		Reference            string `json:"reference"`
+		Amount               int64  `json:"amount"`
+		Currency             string `json:"currency"`
+		Reason               string `json:"reason"`
+		TransactionReference string `json:"transaction_reference"` // refund events
+		GatewayResponse      string `json:"gateway_response"`
+	} `json:"data"`
+}
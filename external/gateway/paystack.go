@@ -0,0 +1,166 @@
+package gateway
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"log"
+	"net/http"
+	neturl "net/url"
+	"sync"
+	"time"
+
+	"github.com/dblaq/buzzycash/internal/config"
+)
+
+var (
+	psService     *PSService
+	psServiceOnce sync.Once
+)
+
+func PSInstance() *PSService {
+	psServiceOnce.Do(func() {
+		psService = &PSService{
+			client: &http.Client{Timeout: 30 * time.Second},
+		}
+	})
+	return psService
+}
+
+type PSService struct {
+	client *http.Client
+}
+
+// do sends an authenticated request to Paystack and decodes the JSON reply
+// into out. A 404 is reported as ErrTransactionNotFound.
+func (s *PSService) do(method, path string, payload interface{}, out interface{}) error {
+	var body io.Reader
+	if payload != nil {
+		b, err := json.Marshal(payload)
+		if err != nil {
+			return fmt.Errorf("failed to marshal request: %w", err)
+		}
+		body = bytes.NewReader(b)
+	}
+
+	httpReq, err := http.NewRequest(method, config.AppConfig.PaystackApiBase+path, body)
+	if err != nil {
+		return fmt.Errorf("failed to create HTTP request: %w", err)
+	}
+	httpReq.Header.Set("Authorization", "Bearer "+config.AppConfig.PaystackSecretKey)
+	httpReq.Header.Set("Content-Type", "application/json")
+
+	resp, err := s.client.Do(httpReq)
+	if err != nil {
+		return fmt.Errorf("paystack HTTP request failed: %w", err)
+	}
+	defer resp.Body.Close()
+
+	b, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return fmt.Errorf("failed to read response body: %w", err)
+	}
+
+	if resp.StatusCode == http.StatusNotFound {
+		return ErrTransactionNotFound
+	}
+	if resp.StatusCode >= 300 {
+		log.Printf("ERROR: Paystack %s %s returned %d: %s\n", method, path, resp.StatusCode, string(b))
+		return fmt.Errorf("paystack error %d", resp.StatusCode)
+	}
+
+	if err := json.Unmarshal(b, out); err != nil {
+		return fmt.Errorf("failed to unmarshal paystack response: %w", err)
+	}
+	return nil
+}
+
+// InitializeTransaction creates a checkout and returns its authorization URL.
+func (s *PSService) InitializeTransaction(req PSInitializeRequest) (*PSInitializeResponse, error) {
+	var resp PSInitializeResponse
+	if err := s.do(http.MethodPost, "transaction/initialize", req, &resp); err != nil {
+		return nil, err
+	}
+	if !resp.Status || resp.Data.AuthorizationURL == "" {
+		return nil, fmt.Errorf("paystack initialize failed: message='%s'", resp.Message)
+	}
+	return &resp, nil
+}
+
+func (s *PSService) VerifyTransaction(reference string) (*PSTransaction, error) {
+	var resp PSVerifyResponse
+	if err := s.do(http.MethodGet, "transaction/verify/"+neturl.PathEscape(reference), nil, &resp); err != nil {
+		return nil, err
+	}
+	if !resp.Status {
+		return nil, fmt.Errorf("paystack verify failed: message='%s'", resp.Message)
+	}
+	return &resp.Data, nil
+}
+
+func (s *PSService) ListBanks() ([]Bank, error) {
+	var resp PSBankResponse
+	if err := s.do(http.MethodGet, "bank?country=nigeria&perPage=100", nil, &resp); err != nil {
+		return nil, err
+	}
+	if !resp.Status {
+		return nil, fmt.Errorf("paystack bank list failed: message='%s'", resp.Message)
+	}
+
+	banks := make([]Bank, 0, len(resp.Data))
+	for _, b := range resp.Data {
+		banks = append(banks, Bank{Code: b.Code, Name: b.Name})
+	}
+	return banks, nil
+}
+
+func (s *PSService) ResolveAccount(accountNumber, bankCode string) (*PSResolveResponse, error) {
+	q := neturl.Values{}
+	q.Set("account_number", accountNumber)
+	q.Set("bank_code", bankCode)
+
+	var resp PSResolveResponse
+	if err := s.do(http.MethodGet, "bank/resolve?"+q.Encode(), nil, &resp); err != nil {
+		return nil, err
+	}
+	if !resp.Status || resp.Data.AccountName == "" {
+		return nil, fmt.Errorf("paystack account resolution failed: message='%s'", resp.Message)
+	}
+	return &resp, nil
+}
+
+// CreateTransferRecipient registers a bank account to send transfers to.
+func (s *PSService) CreateTransferRecipient(req PSRecipientRequest) (string, error) {
+	var resp PSRecipientResponse
+	if err := s.do(http.MethodPost, "transferrecipient", req, &resp); err != nil {
+		return "", err
+	}
+	if !resp.Status || resp.Data.RecipientCode == "" {
+		return "", fmt.Errorf("paystack recipient creation failed: message='%s'", resp.Message)
+	}
+	return resp.Data.RecipientCode, nil
+}
+
+// VerifyTransfer looks up a transfer by the reference it was sent with.
+func (s *PSService) VerifyTransfer(reference string) (*PSTransferResponse, error) {
+	var resp PSTransferResponse
+	if err := s.do(http.MethodGet, "transfer/verify/"+neturl.PathEscape(reference), nil, &resp); err != nil {
+		return nil, err
+	}
+	if !resp.Status {
+		return nil, fmt.Errorf("paystack transfer lookup failed: message='%s'", resp.Message)
+	}
+	return &resp, nil
+}
+
+func (s *PSService) InitiateTransfer(req PSTransferRequest) (*PSTransferResponse, error) {
+	var resp PSTransferResponse
+	if err := s.do(http.MethodPost, "transfer", req, &resp); err != nil {
+		return nil, err
+	}
+	if !resp.Status {
+		return nil, fmt.Errorf("paystack transfer failed: message='%s'", resp.Message)
+	}
+	return &resp, nil
+}
@@ -0,0 +1,135 @@
+package gateway
+
+import (
+	"net/http"
+	"strings"
+	"time"
+)
+
+// paystackProvider adapts the Paystack client to PaymentProvider.
+type paystackProvider struct{}
+
+func init() {
+	Register(paystackProvider{})
+}
+
+func (paystackProvider) Name() string { return "paystack" }
+
+func (paystackProvider) CreateCheckout(req CheckoutRequest) (*CheckoutResult, error) {
+	resp, err := PSInstance().InitializeTransaction(PSInitializeRequest{
+		Email:       req.Email,
+		Amount:      toSubunit(req.Amount),
+		Currency:    req.Currency,
+		Reference:   req.Reference,
+		CallbackURL: req.CallbackURL,
+		Metadata: map[string]any{
+			"customerId": req.CustomerID,
+		},
+	})
+	if err != nil {
+		return nil, err
+	}
+	return &CheckoutResult{CheckoutLink: resp.Data.AuthorizationURL, Reference: resp.Data.Reference}, nil
+}
+
+func (paystackProvider) ChargeSavedCard(req CheckoutRequest, token string) (*CheckoutResult, error) {
+	return nil, ErrNotSupported
+}
+
+func (paystackProvider) Verify(reference string) (*VerifyResult, error) {
+	txn, err := PSInstance().VerifyTransaction(reference)
+	if err != nil {
+		return nil, err
+	}
+
+	// Paystack fees are charged to the merchant, so the whole amount is credited
+	result := &VerifyResult{
+		Reference:    txn.Reference,
+		ProviderID:   txn.Reference,
+		Status:       PaymentPending,
+		Amount:       fromSubunit(txn.Amount),
+		CreditAmount: fromSubunit(txn.Amount),
+		Currency:     txn.Currency,
+	}
+	switch status := strings.ToLower(txn.Status); status {
+	case "success":
+		result.Status = PaymentSucceeded
+	case "failed", "abandoned", "reversed":
+		result.Status = PaymentFailed
+		result.Reason = "charge " + status
+	}
+	return result, nil
+}
+
+func (paystackProvider) CreateVirtualAccount(req VirtualAccountRequest) (*VirtualAccount, error) {
+	return nil, ErrNotSupported
+}
+
+func (paystackProvider) ListBanks() ([]Bank, error) {
+	return PSInstance().ListBanks()
+}
+
+func (paystackProvider) ResolveAccount(accountNumber, bankCode string) (*AccountDetails, error) {
+	resp, err := PSInstance().ResolveAccount(accountNumber, bankCode)
+	if err != nil {
+		return nil, err
+	}
+	return &AccountDetails{AccountNumber: resp.Data.AccountNumber, AccountName: resp.Data.AccountName}, nil
+}
+
+// InitiatePayout registers the account as a transfer recipient and sends
+// the transfer under our (prefixed) reference so transfer webhooks match.
+func (paystackProvider) InitiatePayout(req PayoutRequest) error {
+	ps := PSInstance()
+	recipient, err := ps.CreateTransferRecipient(PSRecipientRequest{
+		Type:          "nuban",
+		Name:          req.AccountName,
+		AccountNumber: req.AccountNumber,
+		BankCode:      req.BankCode,
+		Currency:      req.Currency,
+	})
+	if err != nil {
+		return err
+	}
+
+	_, err = ps.InitiateTransfer(PSTransferRequest{
+		Source:    "balance",
+		Amount:    toSubunit(req.Amount),
+		Recipient: recipient,
+		Reference: paystackTransferPrefix + req.Reference,
+		Reason:    req.Narration,
+		Currency:  req.Currency,
+	})
+	return err
+}
+
+func (paystackProvider) VerifyPayout(reference string, since time.Time) (*VerifyResult, error) {
+	transfer, err := PSInstance().VerifyTransfer(paystackTransferPrefix + reference)
+	if err != nil {
+		return nil, err
+	}
+
+	result := &VerifyResult{
+		Reference:  reference,
+		ProviderID: transfer.Data.TransferCode,
+		Status:     PaymentPending,
+		Amount:     fromSubunit(transfer.Data.Amount),
+		Currency:   transfer.Data.Currency,
+	}
+	switch status := strings.ToLower(transfer.Data.Status); status {
+	case "success":
+		result.Status = PaymentSucceeded
+	case "failed", "reversed", "rejected":
+		result.Status = PaymentFailed
+		result.Reason = "transfer " + status
+	}
+	return result, nil
+}
+
+func (paystackProvider) VerifyWebhook(headers http.Header, body []byte) error {
+	return VerifyPaystackSignature(body, headers.Get(PaystackSignatureHeader))
+}
+
+func (paystackProvider) ParseWebhook(body []byte) (*WebhookEvent, error) {
+	return parsePaystackWebhook(body)
+}
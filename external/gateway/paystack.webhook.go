@@ -0,0 +1,91 @@
+package gateway
+
+import (
+	"crypto/hmac"
+	"crypto/sha512"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"strings"
+
+	"github.com/dblaq/buzzycash/internal/config"
+)
+
+const PaystackSignatureHeader = "x-paystack-signature"
+
+// Paystack transfer references must be 16-50 characters, longer than ours,
+// so payouts are sent with this prefix and it is stripped from webhooks.
+const paystackTransferPrefix = "bzc-payout-"
+
+// VerifyPaystackSignature checks the hex HMAC-SHA512 of the raw body,
+// keyed with our secret key.
+func VerifyPaystackSignature(body []byte, signature string) error {
+	secret := config.AppConfig.PaystackSecretKey
+	if secret == "" {
+		return ErrSecretNotSet
+	}
+	if signature == "" {
+		return ErrMissingSignature
+	}
+
+	mac := hmac.New(sha512.New, []byte(secret))
+	mac.Write(body)
+	expected := hex.EncodeToString(mac.Sum(nil))
+
+	if !hmac.Equal([]byte(expected), []byte(strings.ToLower(signature))) {
+		return ErrInvalidSignature
+	}
+	return nil
+}
+
+// parsePaystackWebhook translates a Paystack charge, refund or transfer event.
+func parsePaystackWebhook(body []byte) (*WebhookEvent, error) {
+	var evt PaystackWebhook
+	if err := json.Unmarshal(body, &evt); err != nil {
+		return nil, fmt.Errorf("bad payload: %w", err)
+	}
+
+	out := &WebhookEvent{
+		Type:      evt.Event,
+		Kind:      IgnoredEvent,
+		Reference: evt.Data.Reference,
+	}
+	if evt.Data.ID != 0 {
+		out.ID = fmt.Sprintf("%s:%d", evt.Event, evt.Data.ID)
+	}
+
+	switch strings.ToLower(evt.Event) {
+	case "charge.success":
+		out.Kind = DepositSucceeded
+		out.CreditAmount = fromSubunit(evt.Data.Amount)
+	case "charge.failed":
+		out.Kind = DepositFailed
+		out.Reason = "charge failed: " + evt.Data.GatewayResponse
+	case "refund.processed":
+		out.Kind = DepositReversed
+		out.Reference = evt.Data.TransactionReference
+		out.Reason = "refund processed"
+	case "transfer.success":
+		out.Kind = PayoutSucceeded
+		out.Reference = strings.TrimPrefix(evt.Data.Reference, paystackTransferPrefix)
+	case "transfer.failed":
+		out.Kind = PayoutFailed
+		out.Reference = strings.TrimPrefix(evt.Data.Reference, paystackTransferPrefix)
+		out.Reason = "payout failed: " + firstNonEmpty(evt.Data.Reason, evt.Data.Status, "unknown")
+	case "transfer.reversed":
+		out.Kind = PayoutReversed
+		out.Reference = strings.TrimPrefix(evt.Data.Reference, paystackTransferPrefix)
+		out.Reason = "payout reversed"
+	}
+
+	return out, nil
+}
+
+// toSubunit converts a whole-currency amount to kobo/pesewas.
+func toSubunit(amount int64) int64 {
+	return amount * 100
+}
+
+func fromSubunit(amount int64) float64 {
+	return float64(amount) / 100
+}
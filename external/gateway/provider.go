@@ -0,0 +1,205 @@
+package gateway
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+)
+
+var (
+	ErrNotSupported    = errors.New("operation not supported by this provider")
+	ErrUnknownProvider = errors.New("unknown payment provider")
+	// ErrUnsupportedCurrency is returned when a provider cannot move money
+	// in the requested currency.
+	ErrUnsupportedCurrency = errors.New("currency not supported by this provider")
+	// ErrChargeDeclined is returned when the provider definitely refused a
+	// charge. Any other error leaves the outcome unknown.
+	ErrChargeDeclined = errors.New("charge declined by provider")
+)
+
+// CheckoutRequest asks a provider for a hosted payment page.
+type CheckoutRequest struct {
+	Reference   string // our reference; providers that issue their own return it in CheckoutResult
+	Amount      int64
+	Currency    string
+	Email       string
+	FullName    string
+	PhoneNumber string
+	Channel     string // mobile money network, for providers that charge a wallet directly
+	CustomerID  string
+	CallbackURL string
+}
+
+type CheckoutResult struct {
+	CheckoutLink string
+	Reference    string // the reference the provider will report back in webhooks
+}
+
+type PaymentStatus string
+
+const (
+	PaymentSucceeded PaymentStatus = "SUCCEEDED"
+	PaymentFailed    PaymentStatus = "FAILED"
+	PaymentPending   PaymentStatus = "PENDING"
+)
+
+// VerifyResult is a provider's current view of a payment.
+type VerifyResult struct {
+	Reference    string
+	ProviderID   string
+	Status       PaymentStatus
+	Amount       float64 // gross amount paid
+	CreditAmount float64 // amount to credit the wallet, net of any provider fee
+	Currency     string
+	Reason       string
+}
+
+type AccountDetails struct {
+	AccountNumber string `json:"accountNumber"`
+	AccountName   string `json:"accountName"`
+}
+
+// PayoutRequest sends money to a bank account or wallet.
+type PayoutRequest struct {
+	Reference     string
+	Amount        int64
+	Currency      string
+	BankCode      string // bank code, or the mobile money network for wallet payouts
+	AccountNumber string
+	AccountName   string
+	Narration     string
+}
+
+// VirtualAccountRefPrefix starts the reference of every dedicated account
+// we open, so transfers into one can be told apart from checkout payments.
+const VirtualAccountRefPrefix = "bzc-va-"
+
+// VirtualAccountRequest asks a provider for a dedicated bank account that
+// always pays into the same customer's wallet.
+type VirtualAccountRequest struct {
+	Reference   string // our reference for the account; providers report transfers against it
+	AccountName string
+	Email       string
+	PhoneNumber string
+	Currency    string
+	BVN         string // required by some providers for permanent accounts
+}
+
+type VirtualAccount struct {
+	AccountNumber string
+	AccountName   string
+	BankName      string
+	Reference     string
+}
+
+type WebhookEventKind string
+
+const (
+	DepositSucceeded WebhookEventKind = "DEPOSIT_SUCCEEDED"
+	DepositFailed    WebhookEventKind = "DEPOSIT_FAILED"
+	DepositReversed  WebhookEventKind = "DEPOSIT_REVERSED"
+	PayoutSucceeded  WebhookEventKind = "PAYOUT_SUCCEEDED"
+	PayoutFailed     WebhookEventKind = "PAYOUT_FAILED"
+	PayoutReversed   WebhookEventKind = "PAYOUT_REVERSED"
+	IgnoredEvent     WebhookEventKind = "IGNORED"
+
+	// VirtualAccountCredited is a transfer into a dedicated account. There
+	// is no pending deposit for it; the account says whose wallet to credit.
+	VirtualAccountCredited WebhookEventKind = "VIRTUAL_ACCOUNT_CREDITED"
+)
+
+// CardToken is a card the provider tokenized while taking a payment.
+type CardToken struct {
+	Token       string
+	MaskedPan   string
+	Brand       string
+	ExpiryMonth string
+	ExpiryYear  string
+}
+
+// WebhookEvent is a provider webhook translated into what it means for us.
+type WebhookEvent struct {
+	ID           string // provider event ID, used to drop redeliveries
+	Type         string // provider's own event type, for logging
+	Kind         WebhookEventKind
+	Reference    string
+	Account      string  // for VirtualAccountCredited: the account number, or the reference it was opened with
+	CreditAmount float64 // for DepositSucceeded and VirtualAccountCredited: amount to credit, net of fees
+	Reason       string
+	Card         *CardToken // for DepositSucceeded: the card, when it was tokenized
+}
+
+// PaymentProvider is implemented by every payment gateway. Operations a
+// provider does not offer return ErrNotSupported.
+type PaymentProvider interface {
+	Name() string
+	CreateCheckout(req CheckoutRequest) (*CheckoutResult, error)
+	// ChargeSavedCard charges a card tokenized in an earlier checkout. The
+	// outcome arrives on the webhook like any other deposit. An error that
+	// does not wrap ErrChargeDeclined may still have reached the card.
+	ChargeSavedCard(req CheckoutRequest, token string) (*CheckoutResult, error)
+	// Verify returns ErrTransactionNotFound when the provider has no record
+	// of the reference.
+	Verify(reference string) (*VerifyResult, error)
+	// CreateVirtualAccount opens a dedicated account for bank transfer
+	// funding. Transfers into it arrive as VirtualAccountCredited events.
+	CreateVirtualAccount(req VirtualAccountRequest) (*VirtualAccount, error)
+	ListBanks() ([]Bank, error)
+	ResolveAccount(accountNumber, bankCode string) (*AccountDetails, error)
+	InitiatePayout(req PayoutRequest) error
+	// VerifyPayout looks up a payout sent with InitiatePayout. since bounds
+	// the search for providers that can only list transactions. It returns
+	// ErrTransactionNotFound when the provider never received the payout.
+	VerifyPayout(reference string, since time.Time) (*VerifyResult, error)
+	// VerifyWebhook authenticates a delivery before it is stored.
+	VerifyWebhook(headers http.Header, body []byte) error
+	ParseWebhook(body []byte) (*WebhookEvent, error)
+}
+
+var (
+	providersMu sync.RWMutex
+	providers   = map[string]PaymentProvider{}
+)
+
+// Register makes a provider available by its name. Providers register
+// themselves from init.
+func Register(p PaymentProvider) {
+	providersMu.Lock()
+	defer providersMu.Unlock()
+	providers[strings.ToLower(p.Name())] = p
+}
+
+// Unregister removes the provider registered under name, so a test can
+// undo the Register of a fake.
+func Unregister(name string) {
+	providersMu.Lock()
+	defer providersMu.Unlock()
+	delete(providers, strings.ToLower(name))
+}
+
+// GetProvider looks a provider up by name, case-insensitively.
+func GetProvider(name string) (PaymentProvider, error) {
+	providersMu.RLock()
+	defer providersMu.RUnlock()
+	p, ok := providers[strings.ToLower(name)]
+	if !ok {
+		return nil, fmt.Errorf("%w: %s", ErrUnknownProvider, name)
+	}
+	return p, nil
+}
+
+// ProviderNames lists the registered providers in alphabetical order.
+func ProviderNames() []string {
+	providersMu.RLock()
+	defer providersMu.RUnlock()
+	names := make([]string, 0, len(providers))
+	for name := range providers {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
@@ -0,0 +1,159 @@
+package identity
+
+import (
+	"bytes"
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"io"
+	"log"
+	"net/http"
+	neturl "net/url"
+	"sync"
+	"time"
+
+	"github.com/dblaq/buzzycash/internal/config"
+)
+
+var (
+	djService     *DJService
+	djServiceOnce sync.Once
+)
+
+func DJInstance() *DJService {
+	djServiceOnce.Do(func() {
+		djService = &DJService{
+			client: &http.Client{Timeout: 60 * time.Second},
+		}
+	})
+	return djService
+}
+
+type DJService struct {
+	client *http.Client
+}
+
+type DJIdentity struct {
+	FirstName          string `json:"first_name"`
+	LastName           string `json:"last_name"`
+	MiddleName         string `json:"middle_name"`
+	DateOfBirth        string `json:"date_of_birth"`
+	PhoneNumber        string `json:"phone_number1"`
+	SelfieVerification struct {
+		ConfidenceValue float64 `json:"confidence_value"`
+		Match           bool    `json:"match"`
+	} `json:"selfie_verification"`
+}
+
+type DJResponse struct {
+	Entity DJIdentity `json:"entity"`
+	Error  string     `json:"error"`
+}
+
+// do sends an authenticated request to Dojah. A 400 or 404 means the ID was
+// not found and is returned as a response with Error set, not an error.
+func (s *DJService) do(method, url string, payload interface{}) (*DJResponse, error) {
+	var body io.Reader
+	if payload != nil {
+		b, err := json.Marshal(payload)
+		if err != nil {
+			return nil, fmt.Errorf("failed to marshal request: %w", err)
+		}
+		body = bytes.NewReader(b)
+	}
+
+	httpReq, err := http.NewRequest(method, url, body)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create HTTP request: %w", err)
+	}
+	httpReq.Header.Set("AppId", config.AppConfig.DojahAppID)
+	httpReq.Header.Set("Authorization", config.AppConfig.DojahSecretKey)
+	httpReq.Header.Set("Content-Type", "application/json")
+
+	resp, err := s.client.Do(httpReq)
+	if err != nil {
+		return nil, fmt.Errorf("dojah HTTP request failed: %w", err)
+	}
+	defer resp.Body.Close()
+
+	b, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read response body: %w", err)
+	}
+
+	var dj DJResponse
+	switch {
+	case resp.StatusCode == http.StatusBadRequest || resp.StatusCode == http.StatusNotFound:
+		_ = json.Unmarshal(b, &dj)
+		if dj.Error == "" {
+			dj.Error = fmt.Sprintf("dojah returned %d", resp.StatusCode)
+		}
+		return &dj, nil
+	case resp.StatusCode >= 300:
+		log.Printf("ERROR: Dojah %s returned %d: %s\n", method, resp.StatusCode, string(b))
+		return nil, fmt.Errorf("dojah error %d", resp.StatusCode)
+	}
+
+	if err := json.Unmarshal(b, &dj); err != nil {
+		return nil, fmt.Errorf("failed to unmarshal dojah response: %w", err)
+	}
+	return &dj, nil
+}
+
+// LookupBVN returns the identity the BVN is registered to.
+func (s *DJService) LookupBVN(bvn string) (*DJResponse, error) {
+	url := config.AppConfig.DojahApiBase + "api/v1/kyc/bvn/full?bvn=" + neturl.QueryEscape(bvn)
+	return s.do(http.MethodGet, url, nil)
+}
+
+// VerifyNINSelfie looks the NIN up and compares the selfie with the photo
+// on record.
+func (s *DJService) VerifyNINSelfie(nin string, selfie []byte) (*DJResponse, error) {
+	url := config.AppConfig.DojahApiBase + "api/v1/kyc/nin/verify"
+	return s.do(http.MethodPost, url, map[string]string{
+		"nin":          nin,
+		"selfie_image": base64.StdEncoding.EncodeToString(selfie),
+	})
+}
+
+// dojahProvider adapts Dojah's KYC APIs to Provider.
+type dojahProvider struct{}
+
+func init() {
+	Register(dojahProvider{})
+}
+
+func (dojahProvider) Name() string { return "dojah" }
+
+func (dojahProvider) VerifyBVN(req Request) (*Result, error) {
+	resp, err := DJInstance().LookupBVN(req.IDNumber)
+	if err != nil {
+		return nil, err
+	}
+	return dojahResult(resp), nil
+}
+
+func (dojahProvider) VerifyNIN(req Request) (*Result, error) {
+	resp, err := DJInstance().VerifyNINSelfie(req.IDNumber, req.Selfie)
+	if err != nil {
+		return nil, err
+	}
+	result := dojahResult(resp)
+	result.MatchScore = resp.Entity.SelfieVerification.ConfidenceValue
+	return result, nil
+}
+
+func dojahResult(resp *DJResponse) *Result {
+	if resp.Error != "" {
+		return &Result{Outcome: NotFound, Reason: resp.Error}
+	}
+	if resp.Entity.FirstName == "" && resp.Entity.LastName == "" {
+		return &Result{Outcome: Inconclusive, Reason: "empty identity returned"}
+	}
+	return &Result{
+		Outcome:     Found,
+		FirstName:   resp.Entity.FirstName,
+		LastName:    resp.Entity.LastName,
+		DateOfBirth: resp.Entity.DateOfBirth,
+	}
+}
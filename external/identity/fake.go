@@ -0,0 +1,57 @@
+package identity
+
+import (
+	"strings"
+)
+
+// fakeProvider answers from the ID number alone so every outcome can be
+// exercised without a real registry. Select it with KYC_PROVIDER=fake; it
+// must never be configured in production.
+//
+//   - IDs ending in 0000 are not found
+//   - IDs ending in 9999 are inconclusive
+//   - IDs ending in 8888 belong to someone else (a different name)
+//   - any other ID is found under the name and date of birth requested
+//
+// Selfie checks score 95, or 40 for IDs ending in 7777.
+type fakeProvider struct{}
+
+func init() {
+	Register(fakeProvider{})
+}
+
+func (fakeProvider) Name() string { return "fake" }
+
+func (fakeProvider) VerifyBVN(req Request) (*Result, error) {
+	return fakeLookup(req), nil
+}
+
+func (fakeProvider) VerifyNIN(req Request) (*Result, error) {
+	result := fakeLookup(req)
+	if result.Outcome == Found {
+		result.MatchScore = 95
+		if strings.HasSuffix(req.IDNumber, "7777") {
+			result.MatchScore = 40
+		}
+	}
+	return result, nil
+}
+
+func fakeLookup(req Request) *Result {
+	result := &Result{
+		Outcome:     Found,
+		Reference:   "fake-" + req.IDNumber,
+		FirstName:   req.FirstName,
+		LastName:    req.LastName,
+		DateOfBirth: req.DateOfBirth,
+	}
+	switch {
+	case strings.HasSuffix(req.IDNumber, "0000"):
+		return &Result{Outcome: NotFound, Reference: result.Reference, Reason: "no record for ID"}
+	case strings.HasSuffix(req.IDNumber, "9999"):
+		return &Result{Outcome: Inconclusive, Reference: result.Reference, Reason: "registry unavailable"}
+	case strings.HasSuffix(req.IDNumber, "8888"):
+		result.FirstName, result.LastName = "Someone", "Else"
+	}
+	return result
+}
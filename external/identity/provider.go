@@ -0,0 +1,80 @@
+package identity
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+	"sync"
+)
+
+var ErrUnknownProvider = errors.New("unknown identity provider")
+
+// Request identifies the person being checked. Names and date of birth are
+// what the user gave us; providers that match on them use them, others
+// just return what the registry holds.
+type Request struct {
+	IDNumber    string
+	FirstName   string
+	LastName    string
+	DateOfBirth string
+	PhoneNumber string
+	Selfie      []byte // NIN checks only: a photo to match against the registry's
+}
+
+type Outcome string
+
+const (
+	// Found means the registry has a record for the ID, and for a selfie
+	// check, that the provider compared the faces.
+	Found Outcome = "FOUND"
+	// NotFound means the ID is invalid or unknown to the registry.
+	NotFound Outcome = "NOT_FOUND"
+	// Inconclusive means the provider could not decide, e.g. the registry
+	// was unavailable or the photo was unusable.
+	Inconclusive Outcome = "INCONCLUSIVE"
+)
+
+// Result is the registry's view of an ID. Deciding whether it matches the
+// user is left to the caller.
+type Result struct {
+	Outcome     Outcome
+	Reference   string // provider's reference for the check
+	FirstName   string
+	LastName    string
+	DateOfBirth string
+	MatchScore  float64 // selfie checks: how closely the faces match, 0-100
+	Reason      string
+}
+
+// Provider is implemented by every identity verification service.
+type Provider interface {
+	Name() string
+	VerifyBVN(req Request) (*Result, error)
+	// VerifyNIN looks the NIN up and matches req.Selfie against the photo
+	// on record.
+	VerifyNIN(req Request) (*Result, error)
+}
+
+var (
+	providersMu sync.RWMutex
+	providers   = map[string]Provider{}
+)
+
+// Register makes a provider available by its name. Providers register
+// themselves from init.
+func Register(p Provider) {
+	providersMu.Lock()
+	defer providersMu.Unlock()
+	providers[strings.ToLower(p.Name())] = p
+}
+
+// GetProvider looks a provider up by name, case-insensitively.
+func GetProvider(name string) (Provider, error) {
+	providersMu.RLock()
+	defer providersMu.RUnlock()
+	p, ok := providers[strings.ToLower(name)]
+	if !ok {
+		return nil, fmt.Errorf("%w: %s", ErrUnknownProvider, name)
+	}
+	return p, nil
+}
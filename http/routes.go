@@ -7,15 +7,27 @@ import (
 	// "io"
 	// "fmt"
 	// "encoding/json"
+	"github.com/dblaq/buzzycash/internal/core/admin"
+	"github.com/dblaq/buzzycash/internal/core/audit"
 	"github.com/dblaq/buzzycash/internal/core/auth"
+	"github.com/dblaq/buzzycash/internal/core/fx"
+	"github.com/dblaq/buzzycash/internal/core/games"
+	"github.com/dblaq/buzzycash/internal/core/kyc"
+	"github.com/dblaq/buzzycash/internal/core/ledger"
 	"github.com/dblaq/buzzycash/internal/core/notifications"
+	"github.com/dblaq/buzzycash/internal/core/outbox"
 	"github.com/dblaq/buzzycash/internal/core/payments"
+	"github.com/dblaq/buzzycash/internal/core/payouts"
 	"github.com/dblaq/buzzycash/internal/core/profile"
+	"github.com/dblaq/buzzycash/internal/core/reconciliation"
 	"github.com/dblaq/buzzycash/internal/core/referrals"
+	"github.com/dblaq/buzzycash/internal/core/responsiblegaming"
 	"github.com/dblaq/buzzycash/internal/core/results"
 	"github.com/dblaq/buzzycash/internal/core/tickets"
 	"github.com/dblaq/buzzycash/internal/core/transaction"
 	"github.com/dblaq/buzzycash/internal/core/upload-images"
+	"github.com/dblaq/buzzycash/internal/core/users"
+	"github.com/dblaq/buzzycash/internal/core/velocity"
 	"github.com/dblaq/buzzycash/internal/core/virtual"
 	"github.com/dblaq/buzzycash/internal/core/wallets"
 	"github.com/dblaq/buzzycash/internal/core/withdrawal"
@@ -69,4 +81,16 @@ func RegisterRoutes(r *gin.Engine, db *gorm.DB) {
 	withdrawal.WithdrawalRoutes(api,db)
 	transaction.TransactionRoutes(api,db)
 	payments.PaymentRoutes(api, db)
+	outbox.OutboxRoutes(api, db)
+	ledger.LedgerRoutes(api, db)
+	reconciliation.ReconciliationRoutes(api, db)
+	fx.FXRoutes(api, db)
+	kyc.KycRoutes(api, db)
+	velocity.VelocityRoutes(api, db)
+	responsiblegaming.ResponsibleGamingRoutes(api, db)
+	admin.AdminRoutes(api, db)
+	games.GamesRoutes(api, db)
+	payouts.PayoutsRoutes(api, db)
+	users.UsersRoutes(api, db)
+	audit.AuditRoutes(api, db)
 }
\ No newline at end of file
@@ -33,12 +33,24 @@ type ConfigStruct struct {
 	
 	// Maekandex Gaming
 	MaekandexGamingUrl string `envconfig:"MAEKANDEX_GAMING_URL"`
+	// Currency game prices are set in on the gaming platform
+	GamingBaseCurrency string `envconfig:"GAMING_BASE_CURRENCY" default:"NGN"`
 	
 	// Hubtel
 	HubtelClientID     string `envconfig:"HUBTEL_CLIENT_ID"`
 	HubtelClientSecret string `envconfig:"HUBTEL_CLIENT_SECRET"`
 	HubtelSenderID     string `envconfig:"HUBTEL_SENDER_ID"`
 	HubtelApiBase      string `envconfig:"HUBTEL_API_BASE"`
+
+	// Hubtel mobile money
+	HubtelPosSalesID        string `envconfig:"HUBTEL_POS_SALES_ID"`
+	HubtelPrepaidDepositID  string `envconfig:"HUBTEL_PREPAID_DEPOSIT_ID"`
+	HubtelCallbackURL       string `envconfig:"HUBTEL_CALLBACK_URL"`
+	HubtelReceiveApiBase    string `envconfig:"HUBTEL_RECEIVE_API_BASE" default:"https://rmp.hubtel.com/"`
+	HubtelSendApiBase       string `envconfig:"HUBTEL_SEND_API_BASE" default:"https://smp.hubtel.com/"`
+	HubtelStatusApiBase     string `envconfig:"HUBTEL_STATUS_API_BASE" default:"https://api-txnstatus.hubtel.com/"`
+	HubtelSendStatusApiBase string `envconfig:"HUBTEL_SEND_STATUS_API_BASE" default:"https://smrsc.hubtel.com/"`
+	HubtelVerifyApiBase     string `envconfig:"HUBTEL_VERIFY_API_BASE" default:"https://rnv.hubtel.com/"`
 	
 	//Flutterwave
 	FlutterwaveSecretKey string `envconfig:"FLUTTERWAVE_SECRET_KEY"`
@@ -52,10 +64,94 @@ type ConfigStruct struct {
 	NombaApiBase  string `envconfig:"NOMBA_API_BASE"`
 	NombaClientID string `envconfig:"NOMBA_CLIENT_ID"`
 	NombaAccountID string `envconfig:"NOMBA_ACCOUNT_ID"`
+	NombaWebhookSecret string `envconfig:"NOMBA_WEBHOOK_SECRET"`
+	NombaWebhookToleranceSeconds int `envconfig:"NOMBA_WEBHOOK_TOLERANCE_SECONDS" default:"300"`
+
+	// Paystack
+	PaystackSecretKey string `envconfig:"PAYSTACK_SECRET_KEY"`
+	PaystackApiBase   string `envconfig:"PAYSTACK_API_BASE" default:"https://api.paystack.co/"`
+
+	// Gateway used for bank payouts
+	PayoutProvider string `envconfig:"PAYOUT_PROVIDER" default:"nomba"`
+	// Gateway used for mobile money payouts to Ghanaian users
+	GhanaPayoutProvider string `envconfig:"GHANA_PAYOUT_PROVIDER" default:"hubtel"`
+	// How long the payout bank list is cached
+	BankListCacheMinutes int `envconfig:"BANK_LIST_CACHE_MINUTES" default:"720"`
+	// Gateway that issues users their dedicated funding account
+	VirtualAccountProvider string `envconfig:"VIRTUAL_ACCOUNT_PROVIDER" default:"nomba"`
+	
+	// KYC: identity verification provider and per-tier limits in naira.
+	// Tier 0 is phone-only, tier 1 adds a BVN, tier 2 a NIN with a selfie.
+	KycProvider               string  `envconfig:"KYC_PROVIDER" default:"dojah"`
+	KycSelfieMatchThreshold   float64 `envconfig:"KYC_SELFIE_MATCH_THRESHOLD" default:"80"`
+	KycTier0SingleLimit       int64   `envconfig:"KYC_TIER0_SINGLE_LIMIT" default:"200000"`
+	KycTier0DailyLimit        int64   `envconfig:"KYC_TIER0_DAILY_LIMIT" default:"250000"`
+	KycTier1SingleLimit       int64   `envconfig:"KYC_TIER1_SINGLE_LIMIT" default:"1000000"`
+	KycTier1DailyLimit        int64   `envconfig:"KYC_TIER1_DAILY_LIMIT" default:"5000000"`
+	KycTier2SingleLimit       int64   `envconfig:"KYC_TIER2_SINGLE_LIMIT" default:"5000000"`
+	KycTier2DailyLimit        int64   `envconfig:"KYC_TIER2_DAILY_LIMIT" default:"25000000"`
+
+	// Velocity limits per user and per device over rolling windows: how many
+	// attempts, and how much in naira, an hour and a day. 0 disables a limit.
+	VelocityDepositHourlyCount     int64 `envconfig:"VELOCITY_DEPOSIT_HOURLY_COUNT" default:"10"`
+	VelocityDepositDailyCount      int64 `envconfig:"VELOCITY_DEPOSIT_DAILY_COUNT" default:"50"`
+	VelocityDepositHourlyAmount    int64 `envconfig:"VELOCITY_DEPOSIT_HOURLY_AMOUNT" default:"0"`
+	VelocityDepositDailyAmount     int64 `envconfig:"VELOCITY_DEPOSIT_DAILY_AMOUNT" default:"0"`
+	VelocityTicketHourlyCount      int64 `envconfig:"VELOCITY_TICKET_HOURLY_COUNT" default:"60"`
+	VelocityTicketDailyCount       int64 `envconfig:"VELOCITY_TICKET_DAILY_COUNT" default:"500"`
+	VelocityTicketHourlyAmount     int64 `envconfig:"VELOCITY_TICKET_HOURLY_AMOUNT" default:"0"`
+	VelocityTicketDailyAmount      int64 `envconfig:"VELOCITY_TICKET_DAILY_AMOUNT" default:"1000000"`
+	VelocityWithdrawalHourlyCount  int64 `envconfig:"VELOCITY_WITHDRAWAL_HOURLY_COUNT" default:"3"`
+	VelocityWithdrawalDailyCount   int64 `envconfig:"VELOCITY_WITHDRAWAL_DAILY_COUNT" default:"10"`
+	VelocityWithdrawalHourlyAmount int64 `envconfig:"VELOCITY_WITHDRAWAL_HOURLY_AMOUNT" default:"0"`
+	VelocityWithdrawalDailyAmount  int64 `envconfig:"VELOCITY_WITHDRAWAL_DAILY_AMOUNT" default:"0"`
+
+	// Responsible gaming: how long a raised self-set limit waits before it
+	// applies, how long a play session survives without activity, and the
+	// bounds on cool-offs and self-exclusions.
+	RgLimitIncreaseCoolingHours int `envconfig:"RG_LIMIT_INCREASE_COOLING_HOURS" default:"24"`
+	RgSessionIdleMinutes        int `envconfig:"RG_SESSION_IDLE_MINUTES" default:"30"`
+	RgMaxCoolOffDays            int `envconfig:"RG_MAX_COOL_OFF_DAYS" default:"42"`
+	RgMinExclusionMonths        int `envconfig:"RG_MIN_EXCLUSION_MONTHS" default:"6"`
+
+	// Minimum age to play or pay, by country code
+	MinimumAges map[string]int `envconfig:"MINIMUM_AGES" default:"NG:18,GH:18"`
+
+	// Withdrawal risk scoring: withdrawals scoring at or above the review
+	// score wait for an admin; the rest are paid out straight away.
+	RiskReviewScore        int     `envconfig:"RISK_REVIEW_SCORE" default:"50"`
+	RiskNewAccountDays     int     `envconfig:"RISK_NEW_ACCOUNT_DAYS" default:"7"`
+	RiskMaxWithdrawalRatio float64 `envconfig:"RISK_MAX_WITHDRAWAL_RATIO" default:"3"`
+
+	// Prize payouts at or under this amount may be approved in bulk
+	PrizeBulkApproveMax int64 `envconfig:"PRIZE_BULK_APPROVE_MAX" default:"50000"`
+
+	// Dojah
+	DojahAppID     string `envconfig:"DOJAH_APP_ID"`
+	DojahSecretKey string `envconfig:"DOJAH_SECRET_KEY"`
+	DojahApiBase   string `envconfig:"DOJAH_API_BASE" default:"https://api.dojah.io/"`
+
+	// Webhook inbox
+	WebhookWorkerIntervalSeconds int `envconfig:"WEBHOOK_WORKER_INTERVAL_SECONDS" default:"5"`
+	WebhookMaxAttempts           int `envconfig:"WEBHOOK_MAX_ATTEMPTS" default:"8"`
+
+	// Pending deposit re-query
+	DepositRequeryIntervalSeconds int `envconfig:"DEPOSIT_REQUERY_INTERVAL_SECONDS" default:"300"`
+	DepositRequeryAfterMinutes    int `envconfig:"DEPOSIT_REQUERY_AFTER_MINUTES" default:"15"`
+	DepositAbandonAfterMinutes    int `envconfig:"DEPOSIT_ABANDON_AFTER_MINUTES" default:"1440"`
+
+	// Stale withdrawal re-query
+	WithdrawalRequeryIntervalSeconds int `envconfig:"WITHDRAWAL_REQUERY_INTERVAL_SECONDS" default:"300"`
+	WithdrawalRequeryAfterMinutes    int `envconfig:"WITHDRAWAL_REQUERY_AFTER_MINUTES" default:"30"`
+
+	// Wallet credit outbox
+	OutboxDispatchIntervalSeconds int `envconfig:"OUTBOX_DISPATCH_INTERVAL_SECONDS" default:"5"`
+	OutboxMaxAttempts             int `envconfig:"OUTBOX_MAX_ATTEMPTS" default:"10"`
 	
 	
 	// Super Admin
-	SuperAdminPass string `envconfig:"SUPER_ADMIN_PASS"`
+	SuperAdminPass  string `envconfig:"SUPER_ADMIN_PASS"`
+	SuperAdminEmail string `envconfig:"SUPER_ADMIN_EMAIL" default:"admin@buzzycash.com"`
 }
 
 var AppConfig ConfigStruct
@@ -0,0 +1,117 @@
+package admin
+
+// @Summary Admin login
+// @Description Log in with an admin email and password. Returns an admin access token and a single-use refresh token
+// @Tags admin-auth
+// @Accept json
+// @Produce json
+// @Param request body LoginRequest true "Credentials"
+// @Success 200 {object} LoginResponse "Logged in"
+// @Failure 400 {object} map[string]interface{} "Invalid request"
+// @Failure 401 {object} map[string]interface{} "Invalid email or password"
+// @Router /admin/auth/login [post]
+func _() {}
+
+
+// @Summary Refresh admin token
+// @Description Exchange an admin refresh token for a new token pair. The refresh token is replaced and cannot be used again
+// @Tags admin-auth
+// @Accept json
+// @Produce json
+// @Param request body RefreshRequest true "Refresh token"
+// @Success 200 {object} TokenResponse "Token refreshed"
+// @Failure 401 {object} map[string]interface{} "Invalid or expired refresh token"
+// @Router /admin/auth/refresh [post]
+func _() {}
+
+
+// @Summary Admin logout
+// @Description Revoke the admin's refresh token and blacklist the access token
+// @Tags admin-auth
+// @Produce json
+// @Success 200 {object} map[string]interface{} "Logged out"
+// @Failure 401 {object} map[string]interface{} "Unauthorized"
+// @Router /admin/auth/logout [post]
+// @Security BearerAuth
+func _() {}
+
+
+// @Summary Current admin
+// @Description Get the logged-in admin with their role and permissions
+// @Tags admin
+// @Produce json
+// @Success 200 {object} AdminResponse "Admin"
+// @Failure 401 {object} map[string]interface{} "Unauthorized"
+// @Router /admin/me [get]
+// @Security BearerAuth
+func _() {}
+
+
+// @Summary List admins
+// @Description List every admin account. Requires admins:manage
+// @Tags admin
+// @Produce json
+// @Success 200 {array} AdminResponse "Admins"
+// @Failure 403 {object} map[string]interface{} "Missing permission"
+// @Router /admin/admins [get]
+// @Security BearerAuth
+func _() {}
+
+
+// @Summary Create admin
+// @Description Create an admin account with a role. Requires admins:manage
+// @Tags admin
+// @Accept json
+// @Produce json
+// @Param request body CreateAdminRequest true "Admin"
+// @Success 201 {object} AdminResponse "Admin created"
+// @Failure 400 {object} map[string]interface{} "Invalid request"
+// @Failure 403 {object} map[string]interface{} "Missing permission"
+// @Failure 404 {object} map[string]interface{} "Role not found"
+// @Failure 409 {object} map[string]interface{} "Email already in use"
+// @Router /admin/admins [post]
+// @Security BearerAuth
+func _() {}
+
+
+// @Summary List roles
+// @Description List roles and the permissions each grants. Requires admins:manage
+// @Tags admin
+// @Produce json
+// @Success 200 {array} RoleResponse "Roles"
+// @Failure 403 {object} map[string]interface{} "Missing permission"
+// @Router /admin/roles [get]
+// @Security BearerAuth
+func _() {}
+
+
+// @Summary Create role
+// @Description Create a role. Permissions: admins:manage, games:manage, finance:read, fx:manage, webhooks:manage, outbox:manage, kyc:review, withdrawals:review, payouts:approve, users:manage, audit:read. Requires admins:manage
+// @Tags admin
+// @Accept json
+// @Produce json
+// @Param request body CreateRoleRequest true "Role"
+// @Success 201 {object} RoleResponse "Role created"
+// @Failure 400 {object} map[string]interface{} "Invalid role or unknown permission"
+// @Failure 403 {object} map[string]interface{} "Missing permission"
+// @Failure 409 {object} map[string]interface{} "Role already exists"
+// @Router /admin/roles [post]
+// @Security BearerAuth
+func _() {}
+
+
+// @Summary Set role permissions
+// @Description Replace the permissions a role grants. The super admin role cannot be changed. Requires admins:manage
+// @Tags admin
+// @Accept json
+// @Produce json
+// @Param id path string true "Role ID"
+// @Param request body SetPermissionsRequest true "Permissions"
+// @Success 200 {object} RoleResponse "Role updated"
+// @Failure 400 {object} map[string]interface{} "Unknown permission"
+// @Failure 403 {object} map[string]interface{} "Missing permission"
+// @Failure 404 {object} map[string]interface{} "Role not found"
+// @Failure 409 {object} map[string]interface{} "Super admin role cannot be changed"
+// @Router /admin/roles/{id}/permissions [put]
+// @Security BearerAuth
+func _() {}
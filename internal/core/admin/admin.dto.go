@@ -0,0 +1,58 @@
+package admin
+
+import "time"
+
+type LoginRequest struct {
+	Email    string `json:"email" binding:"required"`
+	Password string `json:"password" binding:"required"`
+}
+
+type RefreshRequest struct {
+	RefreshToken string `json:"refresh_token" binding:"required"`
+}
+
+type CreateAdminRequest struct {
+	Name     string `json:"name" binding:"required"`
+	Email    string `json:"email" binding:"required"`
+	Password string `json:"password" binding:"required"`
+	RoleID   string `json:"role_id" binding:"required"`
+}
+
+type CreateRoleRequest struct {
+	Name        string   `json:"name" binding:"required"`
+	Description string   `json:"description"`
+	Permissions []string `json:"permissions"`
+}
+
+// SetPermissionsRequest replaces every permission a role grants.
+type SetPermissionsRequest struct {
+	Permissions []string `json:"permissions"`
+}
+
+type TokenResponse struct {
+	AccessToken           string    `json:"access_token"`
+	AccessTokenExpiresAt  time.Time `json:"access_token_expires_at"`
+	RefreshToken          string    `json:"refresh_token"`
+	RefreshTokenExpiresAt time.Time `json:"refresh_token_expires_at"`
+}
+
+type RoleResponse struct {
+	ID          string   `json:"id"`
+	Name        string   `json:"name"`
+	Description string   `json:"description,omitempty"`
+	Permissions []string `json:"permissions"`
+}
+
+type AdminResponse struct {
+	ID             string        `json:"id"`
+	Name           string        `json:"name"`
+	Email          string        `json:"email"`
+	ProfilePicture string        `json:"profile_picture,omitempty"`
+	Role           *RoleResponse `json:"role,omitempty"`
+	CreatedAt      time.Time     `json:"created_at"`
+}
+
+type LoginResponse struct {
+	Admin  AdminResponse `json:"admin"`
+	Tokens TokenResponse `json:"tokens"`
+}
@@ -0,0 +1,268 @@
+package admin
+
+import (
+	"errors"
+	"log"
+	"net/http"
+	"strings"
+
+	"github.com/dblaq/buzzycash/internal/core/audit"
+	"github.com/dblaq/buzzycash/internal/models"
+	"github.com/dblaq/buzzycash/internal/utils"
+	"github.com/gin-gonic/gin"
+	"gorm.io/gorm"
+)
+
+type AdminHandler struct {
+	db *gorm.DB
+}
+
+func NewAdminHandler(db *gorm.DB) *AdminHandler {
+	return &AdminHandler{
+		db: db,
+	}
+}
+
+func (h *AdminHandler) LoginHandler(ctx *gin.Context) {
+	var req LoginRequest
+	if err := ctx.ShouldBindJSON(&req); err != nil {
+		utils.Error(ctx, http.StatusBadRequest, utils.ValidationErrorToJSON(err))
+		return
+	}
+	if err := req.Validate(); err != nil {
+		utils.Error(ctx, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	admin, tokens, err := Login(h.db, req.Email, req.Password)
+	if err != nil {
+		if errors.Is(err, ErrInvalidCredentials) {
+			log.Printf("[Admin] Failed login for %s", req.Email)
+			audit.LogAs(ctx, h.db, audit.Actor{Type: models.AuditActorAdmin, ID: req.Email}, audit.AdminLoginFailed, audit.Target{Type: audit.TargetAdmin, ID: req.Email}, nil, nil)
+			utils.Error(ctx, http.StatusUnauthorized, err.Error())
+			return
+		}
+		log.Printf("[Admin] Login failed for %s: %v", req.Email, err)
+		utils.Error(ctx, http.StatusInternalServerError, "Failed to log in")
+		return
+	}
+
+	log.Printf("[Admin] Admin %s logged in", admin.ID)
+	audit.LogAs(ctx, h.db, audit.Actor{Type: models.AuditActorAdmin, ID: admin.ID}, audit.AdminLogin, audit.Target{Type: audit.TargetAdmin, ID: admin.ID}, nil, nil)
+	ctx.JSON(http.StatusOK, gin.H{
+		"message": "Admin logged in successfully",
+		"data": LoginResponse{
+			Admin:  toAdminResponse(*admin),
+			Tokens: *tokens,
+		},
+	})
+}
+
+func (h *AdminHandler) RefreshHandler(ctx *gin.Context) {
+	var req RefreshRequest
+	if err := ctx.ShouldBindJSON(&req); err != nil {
+		utils.Error(ctx, http.StatusBadRequest, utils.ValidationErrorToJSON(err))
+		return
+	}
+
+	tokens, err := Refresh(h.db, req.RefreshToken)
+	if err != nil {
+		if errors.Is(err, ErrInvalidRefreshToken) {
+			utils.Error(ctx, http.StatusUnauthorized, err.Error())
+			return
+		}
+		log.Printf("[Admin] Token refresh failed: %v", err)
+		utils.Error(ctx, http.StatusInternalServerError, "Failed to refresh token")
+		return
+	}
+
+	ctx.JSON(http.StatusOK, gin.H{
+		"message": "Token refreshed successfully",
+		"data":    tokens,
+	})
+}
+
+func (h *AdminHandler) LogoutHandler(ctx *gin.Context) {
+	admin := ctx.MustGet("currentAdmin").(models.Admin)
+	token := strings.TrimPrefix(ctx.GetHeader("Authorization"), "Bearer ")
+
+	if err := Logout(h.db, admin.ID, token); err != nil {
+		log.Printf("[Admin] Logout failed for admin %s: %v", admin.ID, err)
+		utils.Error(ctx, http.StatusInternalServerError, "Failed to log out")
+		return
+	}
+
+	ctx.JSON(http.StatusOK, gin.H{
+		"message": "Admin logged out successfully",
+	})
+}
+
+func (h *AdminHandler) MeHandler(ctx *gin.Context) {
+	admin := ctx.MustGet("currentAdmin").(models.Admin)
+	ctx.JSON(http.StatusOK, gin.H{
+		"message": "Admin retrieved successfully",
+		"data":    toAdminResponse(admin),
+	})
+}
+
+func (h *AdminHandler) ListAdminsHandler(ctx *gin.Context) {
+	var admins []models.Admin
+	if err := h.db.Preload("Role.Permissions").Order("created_at").Find(&admins).Error; err != nil {
+		utils.Error(ctx, http.StatusInternalServerError, "Failed to fetch admins")
+		return
+	}
+
+	response := make([]AdminResponse, 0, len(admins))
+	for _, a := range admins {
+		response = append(response, toAdminResponse(a))
+	}
+	ctx.JSON(http.StatusOK, gin.H{
+		"message": "Admins retrieved successfully",
+		"data":    response,
+	})
+}
+
+func (h *AdminHandler) CreateAdminHandler(ctx *gin.Context) {
+	var req CreateAdminRequest
+	if err := ctx.ShouldBindJSON(&req); err != nil {
+		utils.Error(ctx, http.StatusBadRequest, utils.ValidationErrorToJSON(err))
+		return
+	}
+	if err := req.Validate(); err != nil {
+		utils.Error(ctx, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	admin, err := CreateAdmin(h.db, req)
+	if err != nil {
+		switch {
+		case errors.Is(err, ErrRoleNotFound):
+			utils.Error(ctx, http.StatusNotFound, err.Error())
+		case errors.Is(err, ErrAdminExists):
+			utils.Error(ctx, http.StatusConflict, err.Error())
+		default:
+			log.Printf("[Admin] Failed to create admin %s: %v", req.Email, err)
+			utils.Error(ctx, http.StatusInternalServerError, "Failed to create admin")
+		}
+		return
+	}
+
+	creator := ctx.MustGet("currentAdmin").(models.Admin)
+	log.Printf("[Admin] Admin %s created admin %s with role %s", creator.ID, admin.ID, admin.Role.Name)
+	audit.Log(ctx, h.db, audit.AdminCreated, audit.Target{Type: audit.TargetAdmin, ID: admin.ID}, nil, toAdminResponse(*admin))
+	ctx.JSON(http.StatusCreated, gin.H{
+		"message": "Admin created successfully",
+		"data":    toAdminResponse(*admin),
+	})
+}
+
+func (h *AdminHandler) ListRolesHandler(ctx *gin.Context) {
+	var roles []models.Role
+	if err := h.db.Preload("Permissions").Order("name").Find(&roles).Error; err != nil {
+		utils.Error(ctx, http.StatusInternalServerError, "Failed to fetch roles")
+		return
+	}
+
+	response := make([]RoleResponse, 0, len(roles))
+	for _, r := range roles {
+		response = append(response, toRoleResponse(r))
+	}
+	ctx.JSON(http.StatusOK, gin.H{
+		"message": "Roles retrieved successfully",
+		"data":    response,
+	})
+}
+
+func (h *AdminHandler) CreateRoleHandler(ctx *gin.Context) {
+	var req CreateRoleRequest
+	if err := ctx.ShouldBindJSON(&req); err != nil {
+		utils.Error(ctx, http.StatusBadRequest, utils.ValidationErrorToJSON(err))
+		return
+	}
+	if err := req.Validate(); err != nil {
+		utils.Error(ctx, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	role, err := CreateRole(h.db, req)
+	if err != nil {
+		if errors.Is(err, ErrRoleExists) {
+			utils.Error(ctx, http.StatusConflict, err.Error())
+			return
+		}
+		log.Printf("[Admin] Failed to create role %s: %v", req.Name, err)
+		utils.Error(ctx, http.StatusInternalServerError, "Failed to create role")
+		return
+	}
+	audit.Log(ctx, h.db, audit.RoleCreated, audit.Target{Type: audit.TargetRole, ID: role.ID}, nil, toRoleResponse(*role))
+
+	ctx.JSON(http.StatusCreated, gin.H{
+		"message": "Role created successfully",
+		"data":    toRoleResponse(*role),
+	})
+}
+
+func (h *AdminHandler) SetPermissionsHandler(ctx *gin.Context) {
+	var req SetPermissionsRequest
+	if err := ctx.ShouldBindJSON(&req); err != nil {
+		utils.Error(ctx, http.StatusBadRequest, utils.ValidationErrorToJSON(err))
+		return
+	}
+	if err := req.Validate(); err != nil {
+		utils.Error(ctx, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	var before models.Role
+	h.db.Preload("Permissions").First(&before, "id = ?", ctx.Param("id"))
+
+	role, err := SetPermissions(h.db, ctx.Param("id"), req.Permissions)
+	if err != nil {
+		switch {
+		case errors.Is(err, ErrRoleNotFound):
+			utils.Error(ctx, http.StatusNotFound, err.Error())
+		case errors.Is(err, ErrSuperAdminRole):
+			utils.Error(ctx, http.StatusConflict, err.Error())
+		default:
+			log.Printf("[Admin] Failed to set permissions for role %s: %v", ctx.Param("id"), err)
+			utils.Error(ctx, http.StatusInternalServerError, "Failed to update role")
+		}
+		return
+	}
+
+	admin := ctx.MustGet("currentAdmin").(models.Admin)
+	log.Printf("[Admin] Admin %s set permissions of role %s to %v", admin.ID, role.Name, req.Permissions)
+	audit.Log(ctx, h.db, audit.RolePermissionsSet, audit.Target{Type: audit.TargetRole, ID: role.ID}, toRoleResponse(before), toRoleResponse(*role))
+	ctx.JSON(http.StatusOK, gin.H{
+		"message": "Role permissions updated successfully",
+		"data":    toRoleResponse(*role),
+	})
+}
+
+func toAdminResponse(a models.Admin) AdminResponse {
+	response := AdminResponse{
+		ID:             a.ID,
+		Name:           a.Name,
+		Email:          a.Email,
+		ProfilePicture: a.ProfilePicture,
+		CreatedAt:      a.CreatedAt,
+	}
+	if a.Role != nil {
+		role := toRoleResponse(*a.Role)
+		response.Role = &role
+	}
+	return response
+}
+
+func toRoleResponse(r models.Role) RoleResponse {
+	perms := make([]string, 0, len(r.Permissions))
+	for _, p := range r.Permissions {
+		perms = append(perms, string(p.Permission))
+	}
+	return RoleResponse{
+		ID:          r.ID,
+		Name:        r.Name,
+		Description: r.Description,
+		Permissions: perms,
+	}
+}
@@ -0,0 +1,30 @@
+package admin
+
+import (
+	"github.com/dblaq/buzzycash/internal/middlewares"
+	"github.com/dblaq/buzzycash/internal/models"
+	"github.com/gin-gonic/gin"
+	"gorm.io/gorm"
+)
+
+func AdminRoutes(rg *gin.RouterGroup, db *gorm.DB) {
+	adminHandler := NewAdminHandler(db)
+
+	authRoutes := rg.Group("/admin/auth")
+	{
+		authRoutes.POST("/login", adminHandler.LoginHandler)
+		authRoutes.POST("/refresh", adminHandler.RefreshHandler)
+		authRoutes.POST("/logout", middlewares.AdminAuthMiddleware, adminHandler.LogoutHandler)
+	}
+
+	rg.GET("/admin/me", middlewares.AdminAuthMiddleware, adminHandler.MeHandler)
+
+	manageRoutes := rg.Group("/admin", middlewares.AdminAuthMiddleware, middlewares.RequirePermission(models.PermAdminsManage))
+	{
+		manageRoutes.GET("/admins", adminHandler.ListAdminsHandler)
+		manageRoutes.POST("/admins", adminHandler.CreateAdminHandler)
+		manageRoutes.GET("/roles", adminHandler.ListRolesHandler)
+		manageRoutes.POST("/roles", adminHandler.CreateRoleHandler)
+		manageRoutes.PUT("/roles/:id/permissions", adminHandler.SetPermissionsHandler)
+	}
+}
@@ -0,0 +1,262 @@
+package admin
+
+import (
+	"errors"
+	"fmt"
+	"log"
+	"time"
+
+	"github.com/dblaq/buzzycash/internal/config"
+	"github.com/dblaq/buzzycash/internal/models"
+	"github.com/dblaq/buzzycash/internal/utils"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+var (
+	ErrInvalidCredentials  = errors.New("invalid email or password")
+	ErrInvalidRefreshToken = errors.New("invalid or expired refresh token")
+	ErrRoleNotFound        = errors.New("role not found")
+	ErrRoleExists          = errors.New("a role with that name already exists")
+	ErrAdminExists         = errors.New("an admin with that email already exists")
+	ErrSuperAdminRole      = errors.New("the super admin role cannot be changed")
+)
+
+// Login checks an admin's credentials and issues a fresh token pair.
+func Login(db *gorm.DB, email, password string) (*models.Admin, *TokenResponse, error) {
+	var admin models.Admin
+	if err := db.Preload("Role.Permissions").First(&admin, "email = ?", email).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, nil, ErrInvalidCredentials
+		}
+		return nil, nil, err
+	}
+	if !utils.ComparePassword(admin.Password, password) {
+		return nil, nil, ErrInvalidCredentials
+	}
+
+	tokens, err := issueTokens(db, admin.ID)
+	if err != nil {
+		return nil, nil, err
+	}
+	return &admin, tokens, nil
+}
+
+// Refresh exchanges a refresh token for a new pair. The old refresh token
+// is replaced, so it cannot be used again.
+func Refresh(db *gorm.DB, refreshToken string) (*TokenResponse, error) {
+	adminID, err := utils.VerifyAdminRefreshToken(refreshToken)
+	if err != nil {
+		return nil, ErrInvalidRefreshToken
+	}
+
+	var stored models.AdminRefreshToken
+	if err := db.First(&stored, "admin_id = ? AND token = ?", adminID, refreshToken).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, ErrInvalidRefreshToken
+		}
+		return nil, err
+	}
+	if time.Now().After(stored.ExpireAt) {
+		return nil, ErrInvalidRefreshToken
+	}
+
+	return issueTokens(db, adminID)
+}
+
+// Logout drops the admin's refresh token and blacklists the access token
+// until it would have expired anyway.
+func Logout(db *gorm.DB, adminID, accessToken string) error {
+	if err := db.Where("admin_id = ?", adminID).Delete(&models.AdminRefreshToken{}).Error; err != nil {
+		return err
+	}
+
+	expireAt := time.Now().Add(24 * time.Hour)
+	if claims, err := utils.DecodeToken(accessToken); err == nil {
+		if exp, ok := claims["exp"].(float64); ok {
+			expireAt = time.Unix(int64(exp), 0)
+		}
+	}
+	return utils.BlacklistToken(accessToken, expireAt)
+}
+
+func issueTokens(db *gorm.DB, adminID string) (*TokenResponse, error) {
+	access, accessExp, err := utils.GenerateAdminAccessToken(adminID)
+	if err != nil {
+		return nil, fmt.Errorf("generate access token: %w", err)
+	}
+	refresh, refreshExp, err := utils.GenerateAdminRefreshToken(adminID)
+	if err != nil {
+		return nil, fmt.Errorf("generate refresh token: %w", err)
+	}
+
+	stored := models.AdminRefreshToken{
+		AdminID:  adminID,
+		Token:    refresh,
+		ExpireAt: refreshExp,
+	}
+	if err := db.Clauses(clause.OnConflict{
+		Columns:   []clause.Column{{Name: "admin_id"}},
+		DoUpdates: clause.AssignmentColumns([]string{"token", "expire_at", "updated_at"}),
+	}).Create(&stored).Error; err != nil {
+		return nil, fmt.Errorf("store refresh token: %w", err)
+	}
+
+	return &TokenResponse{
+		AccessToken:           access,
+		AccessTokenExpiresAt:  accessExp,
+		RefreshToken:          refresh,
+		RefreshTokenExpiresAt: refreshExp,
+	}, nil
+}
+
+// CreateAdmin adds an admin account with the given role.
+func CreateAdmin(db *gorm.DB, req CreateAdminRequest) (*models.Admin, error) {
+	var role models.Role
+	if err := db.Preload("Permissions").First(&role, "id = ?", req.RoleID).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, ErrRoleNotFound
+		}
+		return nil, err
+	}
+
+	var existing int64
+	if err := db.Model(&models.Admin{}).Where("email = ?", req.Email).Count(&existing).Error; err != nil {
+		return nil, err
+	}
+	if existing > 0 {
+		return nil, ErrAdminExists
+	}
+
+	hashed, err := utils.HashPassword(req.Password)
+	if err != nil {
+		return nil, err
+	}
+	admin := models.Admin{
+		Name:     req.Name,
+		Email:    req.Email,
+		Password: hashed,
+		RoleID:   &role.ID,
+	}
+	if err := db.Create(&admin).Error; err != nil {
+		return nil, err
+	}
+	admin.Role = &role
+	return &admin, nil
+}
+
+// CreateRole adds a role granting perms.
+func CreateRole(db *gorm.DB, req CreateRoleRequest) (*models.Role, error) {
+	var existing int64
+	if err := db.Model(&models.Role{}).Where("name = ?", req.Name).Count(&existing).Error; err != nil {
+		return nil, err
+	}
+	if existing > 0 {
+		return nil, ErrRoleExists
+	}
+
+	role := models.Role{Name: req.Name, Description: req.Description}
+	if err := db.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Create(&role).Error; err != nil {
+			return err
+		}
+		return grant(tx, role.ID, req.Permissions)
+	}); err != nil {
+		return nil, err
+	}
+
+	if err := db.Preload("Permissions").First(&role, "id = ?", role.ID).Error; err != nil {
+		return nil, err
+	}
+	return &role, nil
+}
+
+// SetPermissions replaces the permissions a role grants.
+func SetPermissions(db *gorm.DB, roleID string, perms []string) (*models.Role, error) {
+	var role models.Role
+	if err := db.First(&role, "id = ?", roleID).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, ErrRoleNotFound
+		}
+		return nil, err
+	}
+	if role.Name == models.SuperAdminRole {
+		return nil, ErrSuperAdminRole
+	}
+
+	if err := db.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Where("role_id = ?", role.ID).Delete(&models.RolePermission{}).Error; err != nil {
+			return err
+		}
+		return grant(tx, role.ID, perms)
+	}); err != nil {
+		return nil, err
+	}
+
+	if err := db.Preload("Permissions").First(&role, "id = ?", role.ID).Error; err != nil {
+		return nil, err
+	}
+	return &role, nil
+}
+
+func grant(tx *gorm.DB, roleID string, perms []string) error {
+	for _, p := range perms {
+		if err := tx.Clauses(clause.OnConflict{DoNothing: true}).Create(&models.RolePermission{
+			RoleID:     roleID,
+			Permission: models.Permission(p),
+		}).Error; err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// BootstrapSuperAdmin makes sure the super admin role exists and, when no
+// admin holds it yet, creates the first admin from SuperAdminEmail and
+// SuperAdminPass. It does nothing once a super admin exists, so changing
+// SUPER_ADMIN_PASS later does not reset anyone's password.
+func BootstrapSuperAdmin(db *gorm.DB) {
+	if config.AppConfig.SuperAdminPass == "" {
+		log.Println("[Admin] SUPER_ADMIN_PASS not set; skipping super admin bootstrap")
+		return
+	}
+
+	err := db.Transaction(func(tx *gorm.DB) error {
+		role := models.Role{Name: models.SuperAdminRole}
+		if err := tx.Where(models.Role{Name: models.SuperAdminRole}).
+			Attrs(models.Role{Description: "Full access to the admin API"}).
+			FirstOrCreate(&role).Error; err != nil {
+			return err
+		}
+		if err := grant(tx, role.ID, []string{string(models.PermAll)}); err != nil {
+			return err
+		}
+
+		var holders int64
+		if err := tx.Model(&models.Admin{}).Where("role_id = ?", role.ID).Count(&holders).Error; err != nil {
+			return err
+		}
+		if holders > 0 {
+			return nil
+		}
+
+		hashed, err := utils.HashPassword(config.AppConfig.SuperAdminPass)
+		if err != nil {
+			return err
+		}
+		admin := models.Admin{
+			Name:     "Super Admin",
+			Email:    config.AppConfig.SuperAdminEmail,
+			Password: hashed,
+			RoleID:   &role.ID,
+		}
+		if err := tx.Create(&admin).Error; err != nil {
+			return err
+		}
+		log.Printf("[Admin] Created super admin %s", admin.Email)
+		return nil
+	})
+	if err != nil {
+		log.Fatalf("[Admin] Super admin bootstrap failed: %v", err)
+	}
+}
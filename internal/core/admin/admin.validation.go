@@ -0,0 +1,71 @@
+package admin
+
+import (
+	"errors"
+	"net/mail"
+	"regexp"
+	"strings"
+
+	"github.com/dblaq/buzzycash/internal/models"
+)
+
+// Validation errors
+var (
+	ErrInvalidEmail      = errors.New("email is not valid")
+	ErrPasswordTooShort  = errors.New("password must be at least 12 characters")
+	ErrInvalidRoleName   = errors.New("role name must be lowercase letters, digits and underscores")
+	ErrUnknownPermission = errors.New("unknown permission")
+	ErrWildcardGrant     = errors.New("the * permission is reserved for the super admin role")
+)
+
+const minAdminPasswordLength = 12
+
+var roleNamePattern = regexp.MustCompile(`^[a-z][a-z0-9_]{1,49}$`)
+
+func (r *LoginRequest) Validate() error {
+	r.Email = strings.ToLower(strings.TrimSpace(r.Email))
+	if _, err := mail.ParseAddress(r.Email); err != nil {
+		return ErrInvalidEmail
+	}
+	return nil
+}
+
+func (r *CreateAdminRequest) Validate() error {
+	r.Name = strings.TrimSpace(r.Name)
+	r.Email = strings.ToLower(strings.TrimSpace(r.Email))
+	if _, err := mail.ParseAddress(r.Email); err != nil {
+		return ErrInvalidEmail
+	}
+	if len(r.Password) < minAdminPasswordLength {
+		return ErrPasswordTooShort
+	}
+	return nil
+}
+
+func (r *CreateRoleRequest) Validate() error {
+	r.Name = strings.ToLower(strings.TrimSpace(r.Name))
+	if !roleNamePattern.MatchString(r.Name) {
+		return ErrInvalidRoleName
+	}
+	return validatePermissions(r.Permissions)
+}
+
+func (r *SetPermissionsRequest) Validate() error {
+	return validatePermissions(r.Permissions)
+}
+
+func validatePermissions(perms []string) error {
+	for _, p := range perms {
+		if models.Permission(p) == models.PermAll {
+			return ErrWildcardGrant
+		}
+		known := false
+		for _, candidate := range models.Permissions {
+			known = known || models.Permission(p) == candidate
+		}
+		if !known {
+			return ErrUnknownPermission
+		}
+	}
+	return nil
+}
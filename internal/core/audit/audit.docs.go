@@ -0,0 +1,51 @@
+package audit
+
+// @Summary List audit events
+// @Description Search the audit log, newest first. Every filter is optional. Requires audit:read
+// @Tags admin-audit
+// @Produce json
+// @Param actor_type query string false "USER, ADMIN, SYSTEM or WEBHOOK"
+// @Param actor_id query string false "User or admin ID, or provider name for webhooks"
+// @Param action query string false "Action, e.g. auth.login or payout.approved"
+// @Param target_type query string false "Target type, e.g. user or transaction"
+// @Param target_id query string false "Target ID"
+// @Param request_id query string false "X-Request-ID of the request that caused the event"
+// @Param from query string false "Start, YYYY-MM-DD or RFC3339"
+// @Param to query string false "End, YYYY-MM-DD (inclusive) or RFC3339 (exclusive)"
+// @Param page query int false "Page number" default(1)
+// @Success 200 {object} map[string]interface{} "events, page, has_more and total_count"
+// @Failure 400 {object} map[string]interface{} "Invalid filter"
+// @Failure 403 {object} map[string]interface{} "Missing permission"
+// @Router /admin/audit [get]
+// @Security BearerAuth
+func _() {}
+
+
+// @Summary Export audit events
+// @Description Download matching audit events as CSV in chain order, with their hashes, so the export can be verified offline. Takes the same filters as the list. Requires audit:read
+// @Tags admin-audit
+// @Produce text/csv
+// @Param actor_type query string false "USER, ADMIN, SYSTEM or WEBHOOK"
+// @Param actor_id query string false "User or admin ID, or provider name for webhooks"
+// @Param action query string false "Action"
+// @Param target_type query string false "Target type"
+// @Param target_id query string false "Target ID"
+// @Param request_id query string false "Request ID"
+// @Param from query string false "Start, YYYY-MM-DD or RFC3339"
+// @Param to query string false "End, YYYY-MM-DD (inclusive) or RFC3339 (exclusive)"
+// @Success 200 {file} file "CSV export"
+// @Failure 400 {object} map[string]interface{} "Invalid filter"
+// @Router /admin/audit/export [get]
+// @Security BearerAuth
+func _() {}
+
+
+// @Summary Verify audit log
+// @Description Recompute the hash chain over the whole log. valid is false if any entry was altered, removed or inserted, and broken_at is the first entry affected. Requires audit:read
+// @Tags admin-audit
+// @Produce json
+// @Success 200 {object} VerifyResponse "Verification result"
+// @Failure 403 {object} map[string]interface{} "Missing permission"
+// @Router /admin/audit/verify [get]
+// @Security BearerAuth
+func _() {}
@@ -0,0 +1,53 @@
+package audit
+
+import (
+	"time"
+
+	"github.com/dblaq/buzzycash/internal/models"
+)
+
+type AuditEventResponse struct {
+	ID         string                 `json:"id"`
+	Sequence   int64                  `json:"sequence"`
+	ActorType  string                 `json:"actor_type"`
+	ActorID    string                 `json:"actor_id"`
+	Action     string                 `json:"action"`
+	TargetType string                 `json:"target_type,omitempty"`
+	TargetID   string                 `json:"target_id,omitempty"`
+	Before     map[string]interface{} `json:"before,omitempty"`
+	After      map[string]interface{} `json:"after,omitempty"`
+	IPAddress  string                 `json:"ip_address,omitempty"`
+	UserAgent  string                 `json:"user_agent,omitempty"`
+	RequestID  string                 `json:"request_id,omitempty"`
+	PrevHash   string                 `json:"prev_hash"`
+	Hash       string                 `json:"hash"`
+	CreatedAt  time.Time              `json:"created_at"`
+}
+
+type VerifyResponse struct {
+	Valid        bool   `json:"valid"`
+	Checked      int64  `json:"checked"`
+	LastSequence int64  `json:"last_sequence"`
+	BrokenAt     *int64 `json:"broken_at,omitempty"`
+	Reason       string `json:"reason,omitempty"`
+}
+
+func toAuditEventResponse(e models.AuditEvent) AuditEventResponse {
+	return AuditEventResponse{
+		ID:         e.ID,
+		Sequence:   e.Sequence,
+		ActorType:  string(e.ActorType),
+		ActorID:    e.ActorID,
+		Action:     e.Action,
+		TargetType: e.TargetType,
+		TargetID:   e.TargetID,
+		Before:     e.Before,
+		After:      e.After,
+		IPAddress:  e.IPAddress,
+		UserAgent:  e.UserAgent,
+		RequestID:  e.RequestID,
+		PrevHash:   e.PrevHash,
+		Hash:       e.Hash,
+		CreatedAt:  e.CreatedAt,
+	}
+}
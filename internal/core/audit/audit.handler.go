@@ -0,0 +1,129 @@
+package audit
+
+import (
+	"encoding/csv"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/dblaq/buzzycash/internal/models"
+	"github.com/dblaq/buzzycash/internal/utils"
+	"github.com/gin-gonic/gin"
+	"gorm.io/gorm"
+)
+
+// maxExportRows caps a single CSV export; narrow the filters for more.
+const maxExportRows = 100000
+
+type AuditHandler struct {
+	db *gorm.DB
+}
+
+func NewAuditHandler(db *gorm.DB) *AuditHandler {
+	return &AuditHandler{
+		db: db,
+	}
+}
+
+func (h *AuditHandler) ListEventsHandler(ctx *gin.Context) {
+	filter, err := parseFilter(ctx)
+	if err != nil {
+		utils.Error(ctx, http.StatusBadRequest, err.Error())
+		return
+	}
+	page := 1
+	if p, err := strconv.Atoi(ctx.Query("page")); err == nil && p > 0 {
+		page = p
+	}
+	limit := 20
+
+	events, total, err := Query(h.db, filter, limit, (page-1)*limit)
+	if err != nil {
+		log.Printf("[Audit] Failed to query events: %v", err)
+		utils.Error(ctx, http.StatusInternalServerError, "Failed to fetch audit events")
+		return
+	}
+
+	response := make([]AuditEventResponse, 0, len(events))
+	for _, e := range events {
+		response = append(response, toAuditEventResponse(e))
+	}
+	ctx.JSON(http.StatusOK, gin.H{
+		"events":      response,
+		"page":        page,
+		"has_more":    int64(page*limit) < total,
+		"total_count": total,
+	})
+}
+
+func (h *AuditHandler) ExportEventsHandler(ctx *gin.Context) {
+	filter, err := parseFilter(ctx)
+	if err != nil {
+		utils.Error(ctx, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	filename := fmt.Sprintf("audit-%s.csv", time.Now().Format("20060102-150405"))
+	ctx.Header("Content-Type", "text/csv")
+	ctx.Header("Content-Disposition", "attachment; filename="+filename)
+	ctx.Status(http.StatusOK)
+
+	w := csv.NewWriter(ctx.Writer)
+	w.Write([]string{"sequence", "created_at", "actor_type", "actor_id", "action", "target_type", "target_id", "before", "after", "ip_address", "user_agent", "request_id", "prev_hash", "hash"})
+	err = Export(h.db, filter, maxExportRows, func(e models.AuditEvent) error {
+		before, _ := json.Marshal(e.Before)
+		after, _ := json.Marshal(e.After)
+		return w.Write([]string{
+			strconv.FormatInt(e.Sequence, 10),
+			e.CreatedAt.UTC().Format(time.RFC3339Nano),
+			string(e.ActorType),
+			e.ActorID,
+			e.Action,
+			e.TargetType,
+			e.TargetID,
+			string(before),
+			string(after),
+			e.IPAddress,
+			e.UserAgent,
+			e.RequestID,
+			e.PrevHash,
+			e.Hash,
+		})
+	})
+	w.Flush()
+	if err == nil {
+		err = w.Error()
+	}
+	if err != nil {
+		log.Printf("[Audit] CSV export failed: %v", err)
+	}
+}
+
+// VerifyChainHandler recomputes every hash in the log and reports the
+// first entry that was altered, removed or inserted out of order.
+func (h *AuditHandler) VerifyChainHandler(ctx *gin.Context) {
+	result, err := Verify(h.db)
+	if err != nil && !errors.Is(err, ErrChainBroken) {
+		log.Printf("[Audit] Chain verification failed: %v", err)
+		utils.Error(ctx, http.StatusInternalServerError, "Failed to verify audit log")
+		return
+	}
+	if result.BrokenAt != nil {
+		log.Printf("[Audit] WARNING: chain broken at sequence %d: %s", *result.BrokenAt, result.Reason)
+	}
+
+	ctx.JSON(http.StatusOK, gin.H{
+		"message": "Audit log verified",
+		"data": VerifyResponse{
+			Valid:        result.BrokenAt == nil,
+			Checked:      result.Checked,
+			LastSequence: result.LastSequence,
+			BrokenAt:     result.BrokenAt,
+			Reason:       result.Reason,
+		},
+	})
+}
@@ -0,0 +1,18 @@
+package audit
+
+import (
+	"github.com/dblaq/buzzycash/internal/middlewares"
+	"github.com/dblaq/buzzycash/internal/models"
+	"github.com/gin-gonic/gin"
+	"gorm.io/gorm"
+)
+
+func AuditRoutes(rg *gin.RouterGroup, db *gorm.DB) {
+	auditHandler := NewAuditHandler(db)
+	auditRoutes := rg.Group("/admin/audit", middlewares.AdminAuthMiddleware, middlewares.RequirePermission(models.PermAuditRead))
+	{
+		auditRoutes.GET("", auditHandler.ListEventsHandler)
+		auditRoutes.GET("/export", auditHandler.ExportEventsHandler)
+		auditRoutes.GET("/verify", auditHandler.VerifyChainHandler)
+	}
+}
@@ -0,0 +1,432 @@
+package audit
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log"
+	"strings"
+	"time"
+
+	"github.com/dblaq/buzzycash/internal/models"
+	"github.com/gin-gonic/gin"
+	"gorm.io/gorm"
+)
+
+// Actions recorded in the audit log.
+const (
+	UserLogin                = "auth.login"
+	UserLoginFailed          = "auth.login_failed"
+	UserPasswordChanged      = "auth.password_changed"
+	UserPasswordReset        = "auth.password_reset"
+	ProfileUpdated           = "profile.updated"
+	DepositInitiated         = "deposit.initiated"
+	DepositSettled           = "deposit.settled"
+	DepositFailed            = "deposit.failed"
+	DepositReversed          = "deposit.reversed"
+	DepositSuccessIgnored    = "deposit.success_ignored" // success reported after the deposit failed or was reversed
+	WithdrawalInitiated      = "withdrawal.initiated"
+	WithdrawalSettled        = "withdrawal.settled"
+	WithdrawalReversed       = "withdrawal.reversed"
+	WithdrawalCancelled      = "withdrawal.cancelled" // wallet debit refused; nothing to return
+	WithdrawalApproved       = "withdrawal.approved"
+	WithdrawalSubmitRetried  = "withdrawal.submit_retried" // an approved payout that never went out was sent again
+	WithdrawalRejected       = "withdrawal.rejected"
+	TicketPurchased          = "ticket.purchased"
+	AdminLogin               = "admin.login"
+	AdminLoginFailed         = "admin.login_failed"
+	AdminCreated             = "admin.created"
+	RoleCreated              = "role.created"
+	RolePermissionsSet       = "role.permissions_set"
+	GameCreated              = "game.created"
+	GameStarted              = "game.started"
+	GameStopped              = "game.stopped"
+	GamesSynced              = "game.synced" // the catalogue was brought in line with the gaming provider
+	PayoutApproved           = "payout.approved"
+	PayoutRejected           = "payout.rejected"
+	PayoutReconciled         = "payout.reconciled" // an unfinished approval settled against the provider
+	AccountSuspended         = "user.suspended"
+	AccountReactivated       = "user.reactivated"
+	AccountVerificationReset = "user.verification_reset"
+	AccountSessionsRevoked   = "user.sessions_revoked"
+	KycApproved              = "kyc.approved"
+	KycRejected              = "kyc.rejected"
+	FxRateSet                = "fx.rate_set"
+	FxRateDeleted            = "fx.rate_deleted"
+	VelocityOverrideSet      = "velocity.override_set"
+	VelocityOverrideDeleted  = "velocity.override_deleted"
+	WebhookReplayed          = "webhook.replayed"
+	OutboxResolved           = "outbox.resolved" // a credit or debit parked for review was settled by hand
+)
+
+// Target types.
+const (
+	TargetUser        = "user"
+	TargetAdmin       = "admin"
+	TargetRole        = "role"
+	TargetTransaction = "transaction"
+	TargetGame        = "game"
+	TargetPayout      = "payout"
+	TargetKyc         = "kyc_verification"
+	TargetFxRate      = "fx_rate"
+	TargetWebhook     = "webhook_event"
+	TargetOutbox      = "wallet_credit_outbox"
+)
+
+// chainLockKey is the advisory lock that serialises appends, so each entry
+// sees the one before it.
+const chainLockKey = 727101
+
+type Actor struct {
+	Type models.AuditActorType
+	ID   string
+}
+
+type Target struct {
+	Type string
+	ID   string
+}
+
+// Entry is an audit event before it is chained.
+type Entry struct {
+	Actor     Actor
+	Action    string
+	Target    Target
+	Before    interface{}
+	After     interface{}
+	IPAddress string
+	UserAgent string
+	RequestID string
+}
+
+// System is the actor for background jobs.
+var System = Actor{Type: models.AuditActorSystem, ID: "system"}
+
+// Webhook is the actor for changes a payment provider's webhook caused.
+func Webhook(provider string) Actor {
+	return Actor{Type: models.AuditActorWebhook, ID: strings.ToUpper(provider)}
+}
+
+// Record appends an entry to the log. Pass a transaction to make the entry
+// part of the change it records; the chain stays locked until it commits.
+func Record(db *gorm.DB, e Entry) (*models.AuditEvent, error) {
+	event := models.AuditEvent{
+		ActorType:  e.Actor.Type,
+		ActorID:    e.Actor.ID,
+		Action:     e.Action,
+		TargetType: e.Target.Type,
+		TargetID:   e.Target.ID,
+		Before:     snapshot(e.Before),
+		After:      snapshot(e.After),
+		IPAddress:  e.IPAddress,
+		UserAgent:  truncate(e.UserAgent, 500),
+		RequestID:  e.RequestID,
+		CreatedAt:  time.Now().UTC().Truncate(time.Microsecond),
+	}
+
+	err := db.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Exec("SELECT pg_advisory_xact_lock(?)", chainLockKey).Error; err != nil {
+			return err
+		}
+		var last models.AuditEvent
+		if err := tx.Order("sequence DESC").Limit(1).Find(&last).Error; err != nil {
+			return err
+		}
+		event.Sequence = last.Sequence + 1
+		event.PrevHash = last.Hash
+		event.Hash = hash(event)
+		return tx.Create(&event).Error
+	})
+	if err != nil {
+		return nil, fmt.Errorf("append audit event failed: %w", err)
+	}
+	return &event, nil
+}
+
+// Log records an action the current admin or user took in this request.
+// The action has already happened, so a failure is logged, not returned.
+func Log(ctx *gin.Context, db *gorm.DB, action string, target Target, before, after interface{}) {
+	LogAs(ctx, db, currentActor(ctx), action, target, before, after)
+}
+
+// LogAs is Log for requests where the actor is not yet in the context,
+// such as logins.
+func LogAs(ctx *gin.Context, db *gorm.DB, actor Actor, action string, target Target, before, after interface{}) {
+	e := Entry{
+		Actor:     actor,
+		Action:    action,
+		Target:    target,
+		Before:    before,
+		After:     after,
+		IPAddress: ctx.ClientIP(),
+		UserAgent: ctx.Request.UserAgent(),
+		RequestID: ctx.GetString("requestID"),
+	}
+	if _, err := Record(db, e); err != nil {
+		log.Printf("[Audit] ERROR: could not record %s on %s %s: %v", action, target.Type, target.ID, err)
+	}
+}
+
+func currentActor(ctx *gin.Context) Actor {
+	if admin, ok := ctx.Get("currentAdmin"); ok {
+		if a, ok := admin.(models.Admin); ok {
+			return Actor{Type: models.AuditActorAdmin, ID: a.ID}
+		}
+	}
+	if user, ok := ctx.Get("currentUser"); ok {
+		if u, ok := user.(models.User); ok {
+			return Actor{Type: models.AuditActorUser, ID: u.ID}
+		}
+	}
+	return Actor{Type: models.AuditActorUser}
+}
+
+// hashedFields is what an entry's hash covers, in a fixed order.
+type hashedFields struct {
+	Sequence   int64                 `json:"sequence"`
+	ActorType  models.AuditActorType `json:"actor_type"`
+	ActorID    string                `json:"actor_id"`
+	Action     string                `json:"action"`
+	TargetType string                `json:"target_type"`
+	TargetID   string                `json:"target_id"`
+	Before     models.JSONB          `json:"before"`
+	After      models.JSONB          `json:"after"`
+	IPAddress  string                `json:"ip_address"`
+	UserAgent  string                `json:"user_agent"`
+	RequestID  string                `json:"request_id"`
+	PrevHash   string                `json:"prev_hash"`
+	CreatedAt  string                `json:"created_at"`
+}
+
+func hash(e models.AuditEvent) string {
+	// encoding/json sorts map keys, so the snapshots encode the same way
+	// after a round trip through the database.
+	payload, _ := json.Marshal(hashedFields{
+		Sequence:   e.Sequence,
+		ActorType:  e.ActorType,
+		ActorID:    e.ActorID,
+		Action:     e.Action,
+		TargetType: e.TargetType,
+		TargetID:   e.TargetID,
+		Before:     e.Before,
+		After:      e.After,
+		IPAddress:  e.IPAddress,
+		UserAgent:  e.UserAgent,
+		RequestID:  e.RequestID,
+		PrevHash:   e.PrevHash,
+		CreatedAt:  e.CreatedAt.UTC().Format(time.RFC3339Nano),
+	})
+	sum := sha256.Sum256(payload)
+	return hex.EncodeToString(sum[:])
+}
+
+// TransactionState is the part of a transaction worth recording, without
+// the preloaded user or provider metadata.
+func TransactionState(t models.Transaction) map[string]interface{} {
+	return map[string]interface{}{
+		"user_id":               t.UserID,
+		"amount":                t.Amount,
+		"currency":              t.Currency,
+		"category":              t.Category,
+		"transaction_type":      t.TransactionType,
+		"payment_status":        t.PaymentStatus,
+		"payment_method":        t.PaymentMethod,
+		"reference":             t.Reference,
+		"transaction_reference": t.TransactionReference,
+	}
+}
+
+// redactedKeys are never written to the log, at any depth.
+var redactedKeys = map[string]bool{
+	"password": true, "token": true, "accesstoken": true, "refreshtoken": true,
+	"secret": true, "pin": true, "otp": true, "bvn": true, "nin": true,
+	"cvv": true, "cardnumber": true, "authorizationcode": true,
+}
+
+// snapshot turns v into a JSON object with secrets removed. Values that are
+// not objects are wrapped as {"value": v}. A missing snapshot is an empty
+// object, which is how the column reads back.
+func snapshot(v interface{}) models.JSONB {
+	if v == nil {
+		return models.JSONB{}
+	}
+	raw, err := json.Marshal(v)
+	if err != nil {
+		return models.JSONB{"error": "unserialisable snapshot"}
+	}
+	var decoded interface{}
+	if err := json.Unmarshal(raw, &decoded); err != nil {
+		return models.JSONB{"error": "unserialisable snapshot"}
+	}
+	decoded = redact(decoded)
+	if m, ok := decoded.(map[string]interface{}); ok {
+		return m
+	}
+	return models.JSONB{"value": decoded}
+}
+
+func redact(v interface{}) interface{} {
+	switch val := v.(type) {
+	case map[string]interface{}:
+		for k, inner := range val {
+			normalised := strings.ToLower(strings.NewReplacer("_", "", "-", "").Replace(k))
+			if redactedKeys[normalised] {
+				val[k] = "[REDACTED]"
+				continue
+			}
+			val[k] = redact(inner)
+		}
+		return val
+	case []interface{}:
+		for i := range val {
+			val[i] = redact(val[i])
+		}
+		return val
+	}
+	return v
+}
+
+func truncate(s string, n int) string {
+	if len(s) > n {
+		return s[:n]
+	}
+	return s
+}
+
+// Filter narrows a query of the log. Zero values match everything.
+type Filter struct {
+	ActorType  models.AuditActorType
+	ActorID    string
+	Action     string
+	TargetType string
+	TargetID   string
+	RequestID  string
+	From       time.Time
+	To         time.Time
+}
+
+func (f Filter) apply(q *gorm.DB) *gorm.DB {
+	if f.ActorType != "" {
+		q = q.Where("actor_type = ?", f.ActorType)
+	}
+	if f.ActorID != "" {
+		q = q.Where("actor_id = ?", f.ActorID)
+	}
+	if f.Action != "" {
+		q = q.Where("action = ?", f.Action)
+	}
+	if f.TargetType != "" {
+		q = q.Where("target_type = ?", f.TargetType)
+	}
+	if f.TargetID != "" {
+		q = q.Where("target_id = ?", f.TargetID)
+	}
+	if f.RequestID != "" {
+		q = q.Where("request_id = ?", f.RequestID)
+	}
+	if !f.From.IsZero() {
+		q = q.Where("created_at >= ?", f.From)
+	}
+	if !f.To.IsZero() {
+		q = q.Where("created_at < ?", f.To)
+	}
+	return q
+}
+
+// Query returns matching events, newest first, and the total match count.
+func Query(db *gorm.DB, f Filter, limit, offset int) ([]models.AuditEvent, int64, error) {
+	q := f.apply(db.Model(&models.AuditEvent{}))
+
+	var total int64
+	if err := q.Count(&total).Error; err != nil {
+		return nil, 0, err
+	}
+	var events []models.AuditEvent
+	if err := q.Order("sequence DESC").Limit(limit).Offset(offset).Find(&events).Error; err != nil {
+		return nil, 0, err
+	}
+	return events, total, nil
+}
+
+// Export calls fn with each matching event in chain order, in batches so a
+// large export does not sit in memory. It stops after max events.
+func Export(db *gorm.DB, f Filter, max int, fn func(models.AuditEvent) error) error {
+	var after int64
+	sent := 0
+	for sent < max {
+		var batch []models.AuditEvent
+		if err := f.apply(db.Model(&models.AuditEvent{})).
+			Where("sequence > ?", after).
+			Order("sequence ASC").
+			Limit(min(exportBatchSize, max-sent)).
+			Find(&batch).Error; err != nil {
+			return err
+		}
+		if len(batch) == 0 {
+			return nil
+		}
+		for _, e := range batch {
+			if err := fn(e); err != nil {
+				return err
+			}
+		}
+		sent += len(batch)
+		after = batch[len(batch)-1].Sequence
+	}
+	return nil
+}
+
+const exportBatchSize = 500
+
+// ErrChainBroken is returned by Verify when an entry does not match its
+// hash or does not follow the entry before it.
+var ErrChainBroken = errors.New("audit chain is broken")
+
+// VerifyResult reports how much of the chain was checked and where it
+// first broke, if it did.
+type VerifyResult struct {
+	Checked      int64
+	LastSequence int64
+	BrokenAt     *int64
+	Reason       string
+}
+
+// Verify walks the whole chain recomputing every hash.
+func Verify(db *gorm.DB) (*VerifyResult, error) {
+	result := &VerifyResult{}
+	var prevHash string
+	var prevSeq int64
+
+	for {
+		var batch []models.AuditEvent
+		if err := db.Where("sequence > ?", prevSeq).Order("sequence ASC").Limit(exportBatchSize).Find(&batch).Error; err != nil {
+			return nil, err
+		}
+		if len(batch) == 0 {
+			return result, nil
+		}
+		for _, e := range batch {
+			reason := ""
+			switch {
+			case e.Sequence != prevSeq+1:
+				reason = fmt.Sprintf("expected sequence %d, found %d", prevSeq+1, e.Sequence)
+			case e.PrevHash != prevHash:
+				reason = "previous hash does not match the entry before it"
+			case hash(e) != e.Hash:
+				reason = "entry does not match its hash"
+			}
+			if reason != "" {
+				seq := e.Sequence
+				result.BrokenAt = &seq
+				result.Reason = reason
+				return result, ErrChainBroken
+			}
+			result.Checked++
+			result.LastSequence = e.Sequence
+			prevHash = e.Hash
+			prevSeq = e.Sequence
+		}
+	}
+}
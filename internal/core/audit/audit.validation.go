@@ -0,0 +1,66 @@
+package audit
+
+import (
+	"errors"
+	"strings"
+	"time"
+
+	"github.com/dblaq/buzzycash/internal/models"
+	"github.com/gin-gonic/gin"
+)
+
+// Validation errors
+var (
+	ErrInvalidActorType = errors.New("actor_type must be one of USER, ADMIN, SYSTEM or WEBHOOK")
+	ErrInvalidFrom      = errors.New("from must be in YYYY-MM-DD or RFC3339 format")
+	ErrInvalidTo        = errors.New("to must be in YYYY-MM-DD or RFC3339 format")
+	ErrInvalidRange     = errors.New("from must be before to")
+)
+
+// parseFilter reads the query filters shared by the list and export
+// endpoints. A bare date for "to" includes the whole of that day.
+func parseFilter(ctx *gin.Context) (Filter, error) {
+	f := Filter{
+		ActorType:  models.AuditActorType(strings.ToUpper(strings.TrimSpace(ctx.Query("actor_type")))),
+		ActorID:    strings.TrimSpace(ctx.Query("actor_id")),
+		Action:     strings.TrimSpace(ctx.Query("action")),
+		TargetType: strings.TrimSpace(ctx.Query("target_type")),
+		TargetID:   strings.TrimSpace(ctx.Query("target_id")),
+		RequestID:  strings.TrimSpace(ctx.Query("request_id")),
+	}
+	switch f.ActorType {
+	case "", models.AuditActorUser, models.AuditActorAdmin, models.AuditActorSystem, models.AuditActorWebhook:
+	default:
+		return f, ErrInvalidActorType
+	}
+
+	if from := ctx.Query("from"); from != "" {
+		t, _, err := parseTime(from)
+		if err != nil {
+			return f, ErrInvalidFrom
+		}
+		f.From = t
+	}
+	if to := ctx.Query("to"); to != "" {
+		t, dateOnly, err := parseTime(to)
+		if err != nil {
+			return f, ErrInvalidTo
+		}
+		if dateOnly {
+			t = t.AddDate(0, 0, 1)
+		}
+		f.To = t
+	}
+	if !f.From.IsZero() && !f.To.IsZero() && !f.From.Before(f.To) {
+		return f, ErrInvalidRange
+	}
+	return f, nil
+}
+
+func parseTime(s string) (time.Time, bool, error) {
+	if t, err := time.ParseInLocation("2006-01-02", s, time.Local); err == nil {
+		return t, true, nil
+	}
+	t, err := time.Parse(time.RFC3339, s)
+	return t, false, err
+}
@@ -9,7 +9,9 @@ import (
 	"github.com/dblaq/buzzycash/external/mailers"
 	"github.com/dblaq/buzzycash/external/sms"
 	"github.com/dblaq/buzzycash/internal/config"
+	"github.com/dblaq/buzzycash/internal/core/audit"
 	"github.com/dblaq/buzzycash/internal/helpers"
+	"github.com/dblaq/buzzycash/internal/middlewares"
 	"github.com/dblaq/buzzycash/internal/models"
 	"github.com/dblaq/buzzycash/internal/utils"
 
@@ -373,7 +375,7 @@ func (h *AuthHandler)VerifyAccountHandler(ctx *gin.Context) {
 			"isProfileCreated":   user.IsProfileCreated,
 			"countryOfResidence": user.CountryOfResidence,
 			"gender":             user.Gender,
-			"dateOfBirth":        user.DateOfBirth,
+			"dateOfBirth":        user.DateOfBirthString(),
 			"profilePicture":     user.ProfilePicture,
 			"accessToken":        accessToken,
 			"refreshToken":       refreshToken,
@@ -420,7 +422,7 @@ func (h *AuthHandler)ResendOtpHandler(ctx *gin.Context) {
 	// Check lockout
 	if otp != nil && otp.LockedUntil != nil && currentTime.Before(*otp.LockedUntil) {
 		remainingTime := int((*otp.LockedUntil).Sub(currentTime).Minutes())
-		log.Printf("User ID %d is locked out. Remaining time: %d minute(s)\n", user.ID, remainingTime)
+		log.Printf("User ID %s is locked out. Remaining time: %d minute(s)\n", user.ID, remainingTime)
 		utils.Error(ctx, http.StatusBadRequest, fmt.Sprintf("Please wait %d minute(s) before requesting a new OTP.", remainingTime))
 		return
 	}
@@ -430,7 +432,7 @@ func (h *AuthHandler)ResendOtpHandler(ctx *gin.Context) {
 		timeSinceLastOtp := currentTime.Sub(otp.CreatedAt)
 		if timeSinceLastOtp < time.Duration(OTP_RESEND_COOLDOWN)*time.Second {
 			remainingCooldown := OTP_RESEND_COOLDOWN - int(timeSinceLastOtp.Seconds())
-			log.Printf("User ID %d is in cooldown period. Remaining cooldown: %d seconds\n", user.ID, remainingCooldown)
+			log.Printf("User ID %s is in cooldown period. Remaining cooldown: %d seconds\n", user.ID, remainingCooldown)
 			utils.Error(ctx, http.StatusTooManyRequests, fmt.Sprintf("Please wait %d seconds before requesting a new OTP.", remainingCooldown))
 			return
 		}
@@ -438,7 +440,7 @@ func (h *AuthHandler)ResendOtpHandler(ctx *gin.Context) {
 
 	// Check retry limit
 	if otp != nil && otp.RetryCount >= MAX_OTP_RETRIES {
-		log.Printf("User ID %d has exceeded maximum OTP retries. Locking out for %d minutes\n", user.ID, OTP_LOCKOUT_DURATION.Minutes())
+		log.Printf("User ID %s has exceeded maximum OTP retries. Locking out for %.0f minutes\n", user.ID, OTP_LOCKOUT_DURATION.Minutes())
 		h.db.Model(&models.UserOtpSecurity{}).
 			Where("user_id = ?", user.ID).
 			Updates(map[string]interface{}{
@@ -478,7 +480,7 @@ func (h *AuthHandler)ResendOtpHandler(ctx *gin.Context) {
 	}
 
 	// Update retry count + lockout timestamp
-	log.Printf("Updating OTP retry count and lockout timestamp for user ID %d\n", user.ID)
+	log.Printf("Updating OTP retry count and lockout timestamp for user ID %s\n", user.ID)
 	h.db.Model(&models.UserOtpSecurity{}).
 		Where("user_id = ?", user.ID).
 		Updates(map[string]interface{}{
@@ -500,7 +502,7 @@ func (h *AuthHandler)ResendOtpHandler(ctx *gin.Context) {
 			"isProfileCreated":   user.IsProfileCreated,
 			"countryOfResidence": user.CountryOfResidence,
 			"gender":             user.Gender,
-			"dateOfBirth":        user.DateOfBirth,
+			"dateOfBirth":        user.DateOfBirthString(),
 		},
 	})
 }
@@ -537,12 +539,6 @@ func (h *AuthHandler)LoginHandler(ctx *gin.Context) {
 		return
 	}
 
-	if !user.IsActive {
-		log.Println("Account is blocked for user ID:", user.ID)
-		utils.Error(ctx, http.StatusBadRequest, "Account is blocked, please contact support")
-		return
-	}
-
 	if req.Email != "" && !user.IsEmailVerified {
 		log.Println("Login attempt with unverified email for user ID:", user.ID)
 		utils.Error(ctx, http.StatusBadRequest, "Your email is not verified. Please visit your profile to complete verification.")
@@ -580,10 +576,18 @@ func (h *AuthHandler)LoginHandler(ctx *gin.Context) {
 
 	if !utils.ComparePassword(user.Password, req.Password) {
 		log.Println("Invalid credentials for user ID:", user.ID)
+		audit.LogAs(ctx, h.db, audit.Actor{Type: models.AuditActorUser, ID: user.ID}, audit.UserLoginFailed, audit.Target{Type: audit.TargetUser, ID: user.ID}, nil, gin.H{"reason": "invalid credentials"})
 		utils.Error(ctx, http.StatusForbidden, "Invalid credentials")
 		return
 	}
 
+	if !user.IsActive {
+		log.Println("Login attempt on suspended account for user ID:", user.ID)
+		audit.LogAs(ctx, h.db, audit.Actor{Type: models.AuditActorUser, ID: user.ID}, audit.UserLoginFailed, audit.Target{Type: audit.TargetUser, ID: user.ID}, nil, gin.H{"reason": "account suspended"})
+		utils.ErrorWithCode(ctx, http.StatusForbidden, middlewares.AccountSuspendedCode, "Your account has been suspended, please contact support")
+		return
+	}
+
 	log.Println("Generating access token for user ID:", user.ID)
 	accessToken, err := utils.GenerateAccessToken(user.ID)
 	if err != nil {
@@ -616,6 +620,7 @@ func (h *AuthHandler)LoginHandler(ctx *gin.Context) {
 		DoUpdates: clause.AssignmentColumns([]string{"token", "expire_at"}),
 	}).Create(&rt)
 
+	audit.LogAs(ctx, h.db, audit.Actor{Type: models.AuditActorUser, ID: user.ID}, audit.UserLogin, audit.Target{Type: audit.TargetUser, ID: user.ID}, nil, nil)
 	log.Println("LoginHandler completed successfully for user ID:", user.ID)
 	ctx.JSON(http.StatusOK, gin.H{
 		"message": "User logged in successfully",
@@ -629,7 +634,7 @@ func (h *AuthHandler)LoginHandler(ctx *gin.Context) {
 			"gender":             user.Gender,
 			"isProfileCreated":   user.IsProfileCreated,
 			"countryOfResidence": user.CountryOfResidence,
-			"dateOfBirth":        user.DateOfBirth,
+			"dateOfBirth":        user.DateOfBirthString(),
 			"isVerified":         user.IsVerified,
 			"isEmailVerified":    user.IsEmailVerified,
 			"lastLogin":          user.LastLogin,
@@ -730,6 +735,7 @@ func (h *AuthHandler)ChangePasswordHandler(ctx *gin.Context) {
 		return
 	}
 
+	audit.Log(ctx, h.db, audit.UserPasswordChanged, audit.Target{Type: audit.TargetUser, ID: currentUser.ID}, nil, nil)
 	log.Println("Password change successful for user ID:", currentUser.ID)
 	ctx.JSON(http.StatusOK, gin.H{
 		"message": "Password changed successfully",
@@ -779,7 +785,7 @@ func (h *AuthHandler)ForgotPasswordHandler(ctx *gin.Context) {
 	if user.OtpSecurity != nil && user.OtpSecurity.LockedUntil != nil &&
 		now.Before(*user.OtpSecurity.LockedUntil) {
 		remaining := int(user.OtpSecurity.LockedUntil.Sub(now).Minutes())
-		log.Printf("User ID %d is locked out. Remaining time: %d minute(s)\n", user.ID, remaining)
+		log.Printf("User ID %s is locked out. Remaining time: %d minute(s)\n", user.ID, remaining)
 		utils.Error(ctx, http.StatusBadRequest,
 			fmt.Sprintf("Please wait %d minute(s) before requesting a new OTP.", remaining))
 		return
@@ -789,7 +795,7 @@ func (h *AuthHandler)ForgotPasswordHandler(ctx *gin.Context) {
 	if user.OtpSecurity != nil && user.OtpSecurity.ExpiresAt.IsZero() &&
 		now.Before(user.OtpSecurity.ExpiresAt) {
 		remaining := int(user.OtpSecurity.ExpiresAt.Sub(now).Minutes())
-		log.Printf("Active OTP exists for user ID %d. Remaining time: %d minute(s)\n", user.ID, remaining)
+		log.Printf("Active OTP exists for user ID %s. Remaining time: %d minute(s)\n", user.ID, remaining)
 		utils.Error(ctx, http.StatusBadRequest,
 			fmt.Sprintf("An active OTP already exists. Please wait %d minute(s) before requesting a new OTP.", remaining))
 		return
@@ -798,7 +804,7 @@ func (h *AuthHandler)ForgotPasswordHandler(ctx *gin.Context) {
 	// Retry count
 	if user.OtpSecurity != nil && user.OtpSecurity.RetryCount >= MAX_OTP_RETRIES {
 		lockUntil := now.Add(OTP_LOCKOUT_DURATION)
-		log.Printf("User ID %d has exceeded maximum OTP retries. Locking out until: %v\n", user.ID, lockUntil)
+		log.Printf("User ID %s has exceeded maximum OTP retries. Locking out until: %v\n", user.ID, lockUntil)
 		h.db.Model(&models.UserOtpSecurity{}).
 			Where("user_id = ?", user.ID).
 			Updates(map[string]interface{}{
@@ -863,7 +869,7 @@ func (h *AuthHandler)ForgotPasswordHandler(ctx *gin.Context) {
 
 	// Update retry count + lock until
 	lockUntil := now.Add(FORGOT_PASSWORD_OTP_LOCKED_DURATION)
-	log.Printf("Updating OTP retry count and lockout timestamp for user ID %d\n", user.ID)
+	log.Printf("Updating OTP retry count and lockout timestamp for user ID %s\n", user.ID)
 	h.db.Model(&models.UserOtpSecurity{}).
 		Where("user_id = ?", user.ID).
 		Updates(map[string]interface{}{
@@ -1031,6 +1037,7 @@ func (h *AuthHandler)ResetPasswordHandler(ctx *gin.Context) {
 		return
 	}
 
+	audit.LogAs(ctx, h.db, audit.Actor{Type: models.AuditActorUser, ID: user.ID}, audit.UserPasswordReset, audit.Target{Type: audit.TargetUser, ID: user.ID}, nil, nil)
 	log.Println("Password reset successful for user ID:", user.ID)
 	ctx.JSON(http.StatusOK, gin.H{
 		"message": "Password reset successful",
@@ -1150,6 +1157,12 @@ func (h *AuthHandler)RefreshTokenHandler(ctx *gin.Context) {
 		return
 	}
 
+	if !user.IsActive {
+		log.Println("Token refresh on suspended account for user ID:", user.ID)
+		utils.ErrorWithCode(ctx, http.StatusForbidden, middlewares.AccountSuspendedCode, "Your account has been suspended, please contact support")
+		return
+	}
+
 	log.Println("Generating new access token for user ID:", user.ID)
 	accessToken, err := utils.GenerateAccessToken(user.ID)
 
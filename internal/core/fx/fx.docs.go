@@ -0,0 +1,41 @@
+package fx
+
+// @Summary List exchange rates
+// @Description List the FX table used for cross-currency conversions
+// @Tags admin-fx
+// @Accept json
+// @Produce json
+// @Success 200 {object} map[string]interface{} "Exchange rates"
+// @Failure 401 {object} map[string]interface{} "Unauthorized"
+// @Failure 500 {object} map[string]interface{} "Failed to fetch exchange rates"
+// @Router /admin/fx-rates [get]
+// @Security BearerAuth
+func _() {}
+
+// @Summary Set exchange rate
+// @Description Create or replace the rate for a currency pair; one unit of base buys rate units of quote
+// @Tags admin-fx
+// @Accept json
+// @Produce json
+// @Param request body SetRateRequest true "Exchange rate"
+// @Success 200 {object} map[string]interface{} "Exchange rate saved"
+// @Failure 400 {object} map[string]interface{} "Invalid request payload"
+// @Failure 401 {object} map[string]interface{} "Unauthorized"
+// @Failure 500 {object} map[string]interface{} "Failed to save exchange rate"
+// @Router /admin/fx-rates [put]
+// @Security BearerAuth
+func _() {}
+
+// @Summary Delete exchange rate
+// @Description Remove a currency pair from the FX table
+// @Tags admin-fx
+// @Accept json
+// @Produce json
+// @Param id path string true "Exchange rate ID"
+// @Success 200 {object} map[string]interface{} "Exchange rate deleted"
+// @Failure 401 {object} map[string]interface{} "Unauthorized"
+// @Failure 404 {object} map[string]interface{} "Exchange rate not found"
+// @Failure 500 {object} map[string]interface{} "Failed to delete exchange rate"
+// @Router /admin/fx-rates/{id} [delete]
+// @Security BearerAuth
+func _() {}
@@ -0,0 +1,18 @@
+package fx
+
+import "time"
+
+type SetRateRequest struct {
+	BaseCurrency  string  `json:"base_currency" binding:"required"`
+	QuoteCurrency string  `json:"quote_currency" binding:"required"`
+	Rate          float64 `json:"rate" binding:"required"`
+}
+
+type RateResponse struct {
+	ID            string    `json:"id"`
+	BaseCurrency  string    `json:"base_currency"`
+	QuoteCurrency string    `json:"quote_currency"`
+	Rate          float64   `json:"rate"`
+	UpdatedBy     *string   `json:"updated_by,omitempty"`
+	UpdatedAt     time.Time `json:"updated_at"`
+}
@@ -0,0 +1,125 @@
+package fx
+
+import (
+	"log"
+	"net/http"
+
+	"github.com/dblaq/buzzycash/internal/core/audit"
+	"github.com/dblaq/buzzycash/internal/models"
+	"github.com/dblaq/buzzycash/internal/utils"
+	"github.com/gin-gonic/gin"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+type FXHandler struct {
+	db *gorm.DB
+}
+
+func NewFXHandler(db *gorm.DB) *FXHandler {
+	return &FXHandler{
+		db: db,
+	}
+}
+
+func (h *FXHandler) ListRatesHandler(ctx *gin.Context) {
+	var rates []models.ExchangeRate
+	if err := h.db.Order("base_currency, quote_currency").Find(&rates).Error; err != nil {
+		utils.Error(ctx, http.StatusInternalServerError, "Failed to fetch exchange rates")
+		return
+	}
+
+	response := make([]RateResponse, 0, len(rates))
+	for _, r := range rates {
+		response = append(response, toRateResponse(r))
+	}
+
+	ctx.JSON(http.StatusOK, gin.H{
+		"message": "Exchange rates retrieved successfully",
+		"data":    response,
+	})
+}
+
+// SetRateHandler creates or replaces the rate for a currency pair.
+func (h *FXHandler) SetRateHandler(ctx *gin.Context) {
+	var req SetRateRequest
+	if err := ctx.ShouldBindJSON(&req); err != nil {
+		utils.Error(ctx, http.StatusBadRequest, utils.ValidationErrorToJSON(err))
+		return
+	}
+	if err := req.Validate(); err != nil {
+		utils.Error(ctx, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	admin := ctx.MustGet("currentAdmin").(models.Admin)
+	base, _ := models.ParseCurrency(req.BaseCurrency)
+	quote, _ := models.ParseCurrency(req.QuoteCurrency)
+
+	var before models.ExchangeRate
+	h.db.First(&before, "base_currency = ? AND quote_currency = ?", base, quote)
+
+	rate := models.ExchangeRate{
+		BaseCurrency:  base,
+		QuoteCurrency: quote,
+		Rate:          req.Rate,
+		UpdatedBy:     &admin.ID,
+	}
+	if err := h.db.Clauses(clause.OnConflict{
+		Columns:   []clause.Column{{Name: "base_currency"}, {Name: "quote_currency"}},
+		DoUpdates: clause.AssignmentColumns([]string{"rate", "updated_by", "updated_at"}),
+	}).Create(&rate).Error; err != nil {
+		log.Printf("[FX] Failed to save %s/%s rate: %v", base, quote, err)
+		utils.Error(ctx, http.StatusInternalServerError, "Failed to save exchange rate")
+		return
+	}
+	if err := h.db.First(&rate, "base_currency = ? AND quote_currency = ?", base, quote).Error; err != nil {
+		utils.Error(ctx, http.StatusInternalServerError, "Failed to save exchange rate")
+		return
+	}
+
+	log.Printf("[FX] %s/%s set to %f by admin %s", base, quote, req.Rate, admin.ID)
+	var previous interface{}
+	if before.ID != "" {
+		previous = toRateResponse(before)
+	}
+	audit.Log(ctx, h.db, audit.FxRateSet, audit.Target{Type: audit.TargetFxRate, ID: rate.ID}, previous, toRateResponse(rate))
+	ctx.JSON(http.StatusOK, gin.H{
+		"message": "Exchange rate saved successfully",
+		"data":    toRateResponse(rate),
+	})
+}
+
+func (h *FXHandler) DeleteRateHandler(ctx *gin.Context) {
+	var before models.ExchangeRate
+	h.db.First(&before, "id = ?", ctx.Param("id"))
+
+	result := h.db.Delete(&models.ExchangeRate{}, "id = ?", ctx.Param("id"))
+	if result.Error != nil {
+		utils.Error(ctx, http.StatusInternalServerError, "Failed to delete exchange rate")
+		return
+	}
+	if result.RowsAffected == 0 {
+		utils.Error(ctx, http.StatusNotFound, "Exchange rate not found")
+		return
+	}
+
+	if admin, ok := ctx.Get("currentAdmin"); ok {
+		log.Printf("[FX] Rate %s deleted by admin %s", ctx.Param("id"), admin.(models.Admin).ID)
+	}
+	audit.Log(ctx, h.db, audit.FxRateDeleted, audit.Target{Type: audit.TargetFxRate, ID: ctx.Param("id")}, toRateResponse(before), nil)
+	ctx.JSON(http.StatusOK, gin.H{
+		"message": "Exchange rate deleted successfully",
+	})
+}
+
+func toRateResponse(r models.ExchangeRate) RateResponse {
+	return RateResponse{
+		ID:            r.ID,
+		BaseCurrency:  string(r.BaseCurrency),
+		QuoteCurrency: string(r.QuoteCurrency),
+		Rate:          r.Rate,
+		UpdatedBy:     r.UpdatedBy,
+		UpdatedAt:     r.UpdatedAt,
+	}
+}
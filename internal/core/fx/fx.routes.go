@@ -0,0 +1,18 @@
+package fx
+
+import (
+	"github.com/dblaq/buzzycash/internal/middlewares"
+	"github.com/dblaq/buzzycash/internal/models"
+	"github.com/gin-gonic/gin"
+	"gorm.io/gorm"
+)
+
+func FXRoutes(rg *gin.RouterGroup, db *gorm.DB) {
+	fxHandler := NewFXHandler(db)
+	fxRoutes := rg.Group("/admin/fx-rates", middlewares.AdminAuthMiddleware, middlewares.RequirePermission(models.PermFxManage))
+	{
+		fxRoutes.GET("", fxHandler.ListRatesHandler)
+		fxRoutes.PUT("", fxHandler.SetRateHandler)
+		fxRoutes.DELETE("/:id", fxHandler.DeleteRateHandler)
+	}
+}
@@ -0,0 +1,65 @@
+package fx
+
+import (
+	"errors"
+	"fmt"
+	"math"
+
+	"github.com/dblaq/buzzycash/internal/models"
+	"gorm.io/gorm"
+)
+
+var ErrNoRate = errors.New("no exchange rate configured for currency pair")
+
+// Rates is a snapshot of the FX table, loaded once and reused for every
+// conversion in a request.
+type Rates map[[2]models.ECurrency]float64
+
+// LoadRates reads the whole FX table. It is a handful of rows.
+func LoadRates(db *gorm.DB) (Rates, error) {
+	var rows []models.ExchangeRate
+	if err := db.Find(&rows).Error; err != nil {
+		return nil, fmt.Errorf("load exchange rates failed: %w", err)
+	}
+	rates := Rates{}
+	for _, r := range rows {
+		rates[[2]models.ECurrency{r.BaseCurrency, r.QuoteCurrency}] = r.Rate
+	}
+	return rates, nil
+}
+
+// Rate returns how many units of to one unit of from buys. A pair only
+// configured the other way round is inverted.
+func (r Rates) Rate(from, to models.ECurrency) (float64, error) {
+	if from == to {
+		return 1, nil
+	}
+	if rate, ok := r[[2]models.ECurrency{from, to}]; ok && rate > 0 {
+		return rate, nil
+	}
+	if rate, ok := r[[2]models.ECurrency{to, from}]; ok && rate > 0 {
+		return 1 / rate, nil
+	}
+	return 0, fmt.Errorf("%w: %s/%s", ErrNoRate, from, to)
+}
+
+// Convert converts a whole-unit amount, rounding to the nearest unit.
+func (r Rates) Convert(amount int64, from, to models.ECurrency) (int64, float64, error) {
+	rate, err := r.Rate(from, to)
+	if err != nil {
+		return 0, 0, err
+	}
+	return int64(math.Round(float64(amount) * rate)), rate, nil
+}
+
+// Convert converts amount using the current FX table.
+func Convert(db *gorm.DB, amount int64, from, to models.ECurrency) (int64, float64, error) {
+	if from == to {
+		return amount, 1, nil
+	}
+	rates, err := LoadRates(db)
+	if err != nil {
+		return 0, 0, err
+	}
+	return rates.Convert(amount, from, to)
+}
@@ -0,0 +1,32 @@
+package fx
+
+import (
+	"errors"
+
+	"github.com/dblaq/buzzycash/internal/models"
+)
+
+// Validation errors
+var (
+	ErrUnsupportedCurrency = errors.New("unsupported currency")
+	ErrSameCurrency        = errors.New("base and quote currency must differ")
+	ErrInvalidRate         = errors.New("rate must be greater than zero")
+)
+
+func (r *SetRateRequest) Validate() error {
+	base, ok := models.ParseCurrency(r.BaseCurrency)
+	if !ok {
+		return ErrUnsupportedCurrency
+	}
+	quote, ok := models.ParseCurrency(r.QuoteCurrency)
+	if !ok {
+		return ErrUnsupportedCurrency
+	}
+	if base == quote {
+		return ErrSameCurrency
+	}
+	if r.Rate <= 0 {
+		return ErrInvalidRate
+	}
+	return nil
+}
@@ -0,0 +1,108 @@
+package games
+
+// @Summary List games
+// @Description List the local game catalogue, newest first. Requires games:manage
+// @Tags admin-games
+// @Produce json
+// @Param status query string false "CREATED, RUNNING or STOPPED"
+// @Success 200 {array} GameResponse "Games"
+// @Failure 400 {object} map[string]interface{} "Invalid status"
+// @Failure 403 {object} map[string]interface{} "Missing permission"
+// @Router /admin/games [get]
+// @Security BearerAuth
+func _() {}
+
+
+// @Summary Create game
+// @Description Create a game with the gaming provider and add it to the catalogue. Requires games:manage
+// @Tags admin-games
+// @Accept json
+// @Produce json
+// @Param request body CreateGameRequest true "Game"
+// @Success 201 {object} GameResponse "Game created"
+// @Failure 400 {object} map[string]interface{} "Invalid game"
+// @Failure 403 {object} map[string]interface{} "Missing permission"
+// @Failure 502 {object} map[string]interface{} "Gaming provider rejected the request"
+// @Router /admin/games [post]
+// @Security BearerAuth
+func _() {}
+
+
+// @Summary Get game
+// @Description Get a game with its create, start and stop history. Requires games:manage
+// @Tags admin-games
+// @Produce json
+// @Param id path string true "Game ID"
+// @Success 200 {object} GameResponse "Game"
+// @Failure 404 {object} map[string]interface{} "Game not found"
+// @Router /admin/games/{id} [get]
+// @Security BearerAuth
+func _() {}
+
+
+// @Summary Start game
+// @Description Start a created or stopped game. Requires games:manage
+// @Tags admin-games
+// @Accept json
+// @Produce json
+// @Param id path string true "Game ID"
+// @Param request body GameActionRequest false "Reason"
+// @Success 200 {object} GameResponse "Game started"
+// @Failure 404 {object} map[string]interface{} "Game not found"
+// @Failure 409 {object} map[string]interface{} "Game is already running"
+// @Failure 502 {object} map[string]interface{} "Gaming provider rejected the request"
+// @Router /admin/games/{id}/start [post]
+// @Security BearerAuth
+func _() {}
+
+
+// @Summary Sync games from the provider
+// @Description Import the gaming provider's games into the catalogue and refresh the price, settings and state of known games. Games without an ID or ticket price are skipped. Requires games:manage
+// @Tags admin-games
+// @Produce json
+// @Success 200 {object} SyncResult "Games synced"
+// @Failure 403 {object} map[string]interface{} "Missing permission"
+// @Failure 502 {object} map[string]interface{} "Gaming provider rejected the request"
+// @Router /admin/games/sync [post]
+// @Security BearerAuth
+func _() {}
+
+
+// @Summary Stop game
+// @Description Stop a running game. Requires games:manage
+// @Tags admin-games
+// @Accept json
+// @Produce json
+// @Param id path string true "Game ID"
+// @Param request body GameActionRequest false "Reason"
+// @Success 200 {object} GameResponse "Game stopped"
+// @Failure 404 {object} map[string]interface{} "Game not found"
+// @Failure 409 {object} map[string]interface{} "Game is not running"
+// @Failure 502 {object} map[string]interface{} "Gaming provider rejected the request"
+// @Router /admin/games/{id}/stop [post]
+// @Security BearerAuth
+func _() {}
+
+
+// @Summary List game draws
+// @Description Fetch a game's draws from the gaming provider. Requires games:manage
+// @Tags admin-games
+// @Produce json
+// @Param id path string true "Game ID"
+// @Success 200 {object} map[string]interface{} "Draws"
+// @Failure 404 {object} map[string]interface{} "Game not found"
+// @Failure 502 {object} map[string]interface{} "Failed to fetch draws"
+// @Router /admin/games/{id}/draws [get]
+// @Security BearerAuth
+func _() {}
+
+
+// @Summary List all tickets
+// @Description Fetch every ticket sold across all games from the gaming provider. Requires games:manage
+// @Tags admin-games
+// @Produce json
+// @Success 200 {object} map[string]interface{} "Tickets"
+// @Failure 502 {object} map[string]interface{} "Failed to fetch tickets"
+// @Router /admin/games/tickets [get]
+// @Security BearerAuth
+func _() {}
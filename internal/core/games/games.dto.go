@@ -0,0 +1,42 @@
+package games
+
+import "time"
+
+type CreateGameRequest struct {
+	GameName             string  `json:"game_name" binding:"required"`
+	Amount               int64   `json:"amount" binding:"required"`        // ticket price in the gaming base currency
+	DrawInterval         int     `json:"draw_interval" binding:"required"` // minutes between draws
+	WinningPercentage    float64 `json:"winning_percentage" binding:"required"`
+	MaxWinners           int     `json:"max_winners" binding:"required"`
+	Date                 string  `json:"date" binding:"required"` // YYYY-MM-DD
+	WeightedDistribution bool    `json:"weighted_distribution"`
+}
+
+// GameActionRequest is the optional body of a start or stop.
+type GameActionRequest struct {
+	Reason string `json:"reason"`
+}
+
+type GameEventResponse struct {
+	ID        string    `json:"id"`
+	Action    string    `json:"action"`
+	AdminID   *string   `json:"admin_id,omitempty"`
+	Reason    string    `json:"reason,omitempty"`
+	CreatedAt time.Time `json:"created_at"`
+}
+
+type GameResponse struct {
+	ID                   string              `json:"id"`
+	ProviderGameID       string              `json:"provider_game_id"`
+	Name                 string              `json:"name"`
+	Amount               int64               `json:"amount"`
+	DrawInterval         int                 `json:"draw_interval"`
+	WinningPercentage    float64             `json:"winning_percentage"`
+	MaxWinners           int                 `json:"max_winners"`
+	StartDate            string              `json:"start_date"`
+	WeightedDistribution bool                `json:"weighted_distribution"`
+	Status               string              `json:"status"`
+	CreatedBy            *string             `json:"created_by,omitempty"`
+	CreatedAt            time.Time           `json:"created_at"`
+	Events               []GameEventResponse `json:"events,omitempty"`
+}
@@ -0,0 +1,238 @@
+package games
+
+import (
+	"errors"
+	"log"
+	"net/http"
+
+	"github.com/dblaq/buzzycash/external/gaming"
+	"github.com/dblaq/buzzycash/internal/core/audit"
+	"github.com/dblaq/buzzycash/internal/models"
+	"github.com/dblaq/buzzycash/internal/utils"
+	"github.com/gin-gonic/gin"
+	"gorm.io/gorm"
+)
+
+type GamesHandler struct {
+	db *gorm.DB
+}
+
+func NewGamesHandler(db *gorm.DB) *GamesHandler {
+	return &GamesHandler{
+		db: db,
+	}
+}
+
+// ListGamesHandler lists the local game catalogue, newest first,
+// optionally filtered by status.
+func (h *GamesHandler) ListGamesHandler(ctx *gin.Context) {
+	query := h.db.Order("created_at DESC")
+	if status := ctx.Query("status"); status != "" {
+		parsed, err := parseStatus(status)
+		if err != nil {
+			utils.Error(ctx, http.StatusBadRequest, err.Error())
+			return
+		}
+		query = query.Where("status = ?", parsed)
+	}
+
+	var games []models.Game
+	if err := query.Find(&games).Error; err != nil {
+		utils.Error(ctx, http.StatusInternalServerError, "Failed to fetch games")
+		return
+	}
+
+	response := make([]GameResponse, 0, len(games))
+	for _, g := range games {
+		response = append(response, toGameResponse(g))
+	}
+	ctx.JSON(http.StatusOK, gin.H{
+		"message": "Games retrieved successfully",
+		"data":    response,
+	})
+}
+
+func (h *GamesHandler) GetGameHandler(ctx *gin.Context) {
+	var game models.Game
+	if err := h.db.Preload("Events", func(db *gorm.DB) *gorm.DB {
+		return db.Order("created_at ASC")
+	}).First(&game, "id = ?", ctx.Param("id")).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			utils.Error(ctx, http.StatusNotFound, ErrGameNotFound.Error())
+			return
+		}
+		utils.Error(ctx, http.StatusInternalServerError, "Failed to fetch game")
+		return
+	}
+
+	ctx.JSON(http.StatusOK, gin.H{
+		"message": "Game retrieved successfully",
+		"data":    toGameResponse(game),
+	})
+}
+
+func (h *GamesHandler) CreateGameHandler(ctx *gin.Context) {
+	var req CreateGameRequest
+	if err := ctx.ShouldBindJSON(&req); err != nil {
+		utils.Error(ctx, http.StatusBadRequest, utils.ValidationErrorToJSON(err))
+		return
+	}
+	startDate, err := req.Validate()
+	if err != nil {
+		utils.Error(ctx, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	admin := ctx.MustGet("currentAdmin").(models.Admin)
+	game, err := Create(h.db, admin, req, startDate)
+	if err != nil {
+		gameError(ctx, err, "Failed to create game")
+		return
+	}
+	audit.Log(ctx, h.db, audit.GameCreated, audit.Target{Type: audit.TargetGame, ID: game.ID}, nil, toGameResponse(*game))
+
+	ctx.JSON(http.StatusCreated, gin.H{
+		"message": "Game created successfully",
+		"data":    toGameResponse(*game),
+	})
+}
+
+// SyncGamesHandler imports the provider's games into the catalogue and
+// refreshes the ones it already holds.
+func (h *GamesHandler) SyncGamesHandler(ctx *gin.Context) {
+	admin := ctx.MustGet("currentAdmin").(models.Admin)
+	result, err := Sync(h.db, admin)
+	if err != nil {
+		gameError(ctx, err, "Failed to sync games")
+		return
+	}
+	audit.Log(ctx, h.db, audit.GamesSynced, audit.Target{Type: audit.TargetGame}, nil, result)
+
+	ctx.JSON(http.StatusOK, gin.H{
+		"message": "Games synced successfully",
+		"data":    result,
+	})
+}
+
+func (h *GamesHandler) StartGameHandler(ctx *gin.Context) {
+	h.changeState(ctx, Start, audit.GameStarted, "Game started successfully", "Failed to start game")
+}
+
+func (h *GamesHandler) StopGameHandler(ctx *gin.Context) {
+	h.changeState(ctx, Stop, audit.GameStopped, "Game stopped successfully", "Failed to stop game")
+}
+
+func (h *GamesHandler) changeState(ctx *gin.Context, change func(*gorm.DB, string, models.Admin, string) (*models.Game, error), action, success, failure string) {
+	var req GameActionRequest
+	if ctx.Request.ContentLength > 0 {
+		if err := ctx.ShouldBindJSON(&req); err != nil {
+			utils.Error(ctx, http.StatusBadRequest, utils.ValidationErrorToJSON(err))
+			return
+		}
+	}
+	if err := req.Validate(); err != nil {
+		utils.Error(ctx, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	admin := ctx.MustGet("currentAdmin").(models.Admin)
+	var before models.Game
+	h.db.First(&before, "id = ?", ctx.Param("id"))
+
+	game, err := change(h.db, ctx.Param("id"), admin, req.Reason)
+	if err != nil {
+		gameError(ctx, err, failure)
+		return
+	}
+	audit.Log(ctx, h.db, action, audit.Target{Type: audit.TargetGame, ID: game.ID},
+		gin.H{"status": before.Status}, gin.H{"status": game.Status, "reason": req.Reason})
+
+	ctx.JSON(http.StatusOK, gin.H{
+		"message": success,
+		"data":    toGameResponse(*game),
+	})
+}
+
+// GetDrawsHandler fetches a game's draws from the gaming provider.
+func (h *GamesHandler) GetDrawsHandler(ctx *gin.Context) {
+	var game models.Game
+	if err := h.db.First(&game, "id = ?", ctx.Param("id")).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			utils.Error(ctx, http.StatusNotFound, ErrGameNotFound.Error())
+			return
+		}
+		utils.Error(ctx, http.StatusInternalServerError, "Failed to fetch game")
+		return
+	}
+
+	gs := gaming.GMInstance()
+	draws, err := gs.GetDraws(game.ProviderGameID)
+	if err != nil {
+		log.Printf("[Games] Failed to fetch draws for game %s: %v", game.ID, err)
+		utils.Error(ctx, http.StatusBadGateway, "Failed to fetch draws")
+		return
+	}
+
+	ctx.JSON(http.StatusOK, gin.H{
+		"message": "Draws retrieved successfully",
+		"data":    draws,
+	})
+}
+
+// ListTicketsHandler fetches every ticket sold across all games from the
+// gaming provider.
+func (h *GamesHandler) ListTicketsHandler(ctx *gin.Context) {
+	gs := gaming.GMInstance()
+	tickets, err := gs.GetAllTickets()
+	if err != nil {
+		log.Printf("[Games] Failed to fetch tickets: %v", err)
+		utils.Error(ctx, http.StatusBadGateway, "Failed to fetch tickets")
+		return
+	}
+
+	ctx.JSON(http.StatusOK, gin.H{
+		"message": "Tickets retrieved successfully",
+		"data":    tickets,
+	})
+}
+
+func gameError(ctx *gin.Context, err error, fallback string) {
+	switch {
+	case errors.Is(err, ErrGameNotFound):
+		utils.Error(ctx, http.StatusNotFound, err.Error())
+	case errors.Is(err, ErrGameAlreadyInState):
+		utils.Error(ctx, http.StatusConflict, err.Error())
+	case errors.Is(err, ErrProviderFailed), errors.Is(err, ErrNoProviderGameID):
+		utils.Error(ctx, http.StatusBadGateway, err.Error())
+	default:
+		log.Printf("[Games] %s: %v", fallback, err)
+		utils.Error(ctx, http.StatusInternalServerError, fallback)
+	}
+}
+
+func toGameResponse(g models.Game) GameResponse {
+	response := GameResponse{
+		ID:                   g.ID,
+		ProviderGameID:       g.ProviderGameID,
+		Name:                 g.Name,
+		Amount:               g.Amount,
+		DrawInterval:         g.DrawInterval,
+		WinningPercentage:    g.WinningPercentage,
+		MaxWinners:           g.MaxWinners,
+		StartDate:            g.StartDate.Format("2006-01-02"),
+		WeightedDistribution: g.WeightedDistribution,
+		Status:               string(g.Status),
+		CreatedBy:            g.CreatedBy,
+		CreatedAt:            g.CreatedAt,
+	}
+	for _, e := range g.Events {
+		response.Events = append(response.Events, GameEventResponse{
+			ID:        e.ID,
+			Action:    string(e.Action),
+			AdminID:   e.AdminID,
+			Reason:    e.Reason,
+			CreatedAt: e.CreatedAt,
+		})
+	}
+	return response
+}
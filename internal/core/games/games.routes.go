@@ -0,0 +1,23 @@
+package games
+
+import (
+	"github.com/dblaq/buzzycash/internal/middlewares"
+	"github.com/dblaq/buzzycash/internal/models"
+	"github.com/gin-gonic/gin"
+	"gorm.io/gorm"
+)
+
+func GamesRoutes(rg *gin.RouterGroup, db *gorm.DB) {
+	gamesHandler := NewGamesHandler(db)
+	gamesRoutes := rg.Group("/admin/games", middlewares.AdminAuthMiddleware, middlewares.RequirePermission(models.PermGamesManage))
+	{
+		gamesRoutes.GET("", gamesHandler.ListGamesHandler)
+		gamesRoutes.POST("", gamesHandler.CreateGameHandler)
+		gamesRoutes.POST("/sync", gamesHandler.SyncGamesHandler)
+		gamesRoutes.GET("/tickets", gamesHandler.ListTicketsHandler)
+		gamesRoutes.GET("/:id", gamesHandler.GetGameHandler)
+		gamesRoutes.POST("/:id/start", gamesHandler.StartGameHandler)
+		gamesRoutes.POST("/:id/stop", gamesHandler.StopGameHandler)
+		gamesRoutes.GET("/:id/draws", gamesHandler.GetDrawsHandler)
+	}
+}
@@ -0,0 +1,150 @@
+package games
+
+import (
+	"errors"
+	"fmt"
+	"log"
+	"time"
+
+	"github.com/dblaq/buzzycash/external/gaming"
+	"github.com/dblaq/buzzycash/internal/models"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+var (
+	ErrGameNotFound       = errors.New("game not found")
+	ErrGameAlreadyInState = errors.New("game is already in that state")
+	ErrProviderFailed     = errors.New("gaming provider rejected the request")
+	ErrNoProviderGameID   = errors.New("gaming provider did not return a game ID")
+)
+
+// gameTransitions lists the states each action may be taken from.
+var gameTransitions = map[models.GameAction][]models.GameState{
+	models.GameActionStart: {models.GameCreated, models.GameStopped},
+	models.GameActionStop:  {models.GameRunning},
+}
+
+// Create creates the game with the gaming provider and records it in the
+// local catalogue.
+func Create(db *gorm.DB, admin models.Admin, req CreateGameRequest, startDate time.Time) (*models.Game, error) {
+	gs := gaming.GMInstance()
+	resp, err := gs.CreateGames(req.GameName, req.Amount, req.DrawInterval, req.WinningPercentage, req.MaxWinners, req.Date, req.WeightedDistribution)
+	if err != nil {
+		log.Printf("[Games] Provider failed to create %q: %v", req.GameName, err)
+		return nil, ErrProviderFailed
+	}
+
+	providerID := providerGameID(resp)
+	if providerID == "" {
+		log.Printf("[Games] No game ID in provider response for %q: %v", req.GameName, resp)
+		return nil, ErrNoProviderGameID
+	}
+
+	game := models.Game{
+		ProviderGameID:       providerID,
+		Name:                 req.GameName,
+		Amount:               req.Amount,
+		DrawInterval:         req.DrawInterval,
+		WinningPercentage:    req.WinningPercentage,
+		MaxWinners:           req.MaxWinners,
+		StartDate:            startDate,
+		WeightedDistribution: req.WeightedDistribution,
+		Status:               models.GameCreated,
+		CreatedBy:            &admin.ID,
+	}
+	if err := db.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Create(&game).Error; err != nil {
+			return err
+		}
+		return tx.Create(&models.GameEvent{GameID: game.ID, Action: models.GameActionCreate, AdminID: &admin.ID}).Error
+	}); err != nil {
+		return nil, fmt.Errorf("record game %s failed: %w", providerID, err)
+	}
+
+	log.Printf("[Games] Admin %s created game %s (provider %s)", admin.ID, game.ID, providerID)
+	return &game, nil
+}
+
+// Start starts a created or stopped game with the provider.
+func Start(db *gorm.DB, id string, admin models.Admin, reason string) (*models.Game, error) {
+	return transition(db, id, admin, models.GameActionStart, models.GameRunning, reason)
+}
+
+// Stop stops a running game with the provider.
+func Stop(db *gorm.DB, id string, admin models.Admin, reason string) (*models.Game, error) {
+	return transition(db, id, admin, models.GameActionStop, models.GameStopped, reason)
+}
+
+func transition(db *gorm.DB, id string, admin models.Admin, action models.GameAction, target models.GameState, reason string) (*models.Game, error) {
+	var game models.Game
+	err := db.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Clauses(clause.Locking{Strength: "UPDATE"}).First(&game, "id = ?", id).Error; err != nil {
+			if errors.Is(err, gorm.ErrRecordNotFound) {
+				return ErrGameNotFound
+			}
+			return err
+		}
+		if !canTransition(action, game.Status) {
+			return ErrGameAlreadyInState
+		}
+
+		// The row stays locked while the provider is called so two admins
+		// cannot start and stop the same game at once.
+		gs := gaming.GMInstance()
+		var providerErr error
+		if action == models.GameActionStart {
+			_, providerErr = gs.StartGame(game.ProviderGameID)
+		} else {
+			_, providerErr = gs.StopGame(game.ProviderGameID)
+		}
+		if providerErr != nil {
+			log.Printf("[Games] Provider failed to %s game %s: %v", action, game.ID, providerErr)
+			return ErrProviderFailed
+		}
+
+		if err := tx.Model(&game).Update("status", target).Error; err != nil {
+			return err
+		}
+		return tx.Create(&models.GameEvent{GameID: game.ID, Action: action, AdminID: &admin.ID, Reason: reason}).Error
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	log.Printf("[Games] Admin %s moved game %s to %s", admin.ID, game.ID, target)
+	return &game, nil
+}
+
+func canTransition(action models.GameAction, from models.GameState) bool {
+	for _, allowed := range gameTransitions[action] {
+		if allowed == from {
+			return true
+		}
+	}
+	return false
+}
+
+// providerGameID finds the game ID in the provider's create response, which
+// is returned either at the top level or wrapped in data or game.
+func providerGameID(resp map[string]interface{}) string {
+	candidates := []map[string]interface{}{resp}
+	for _, key := range []string{"data", "game"} {
+		if nested, ok := resp[key].(map[string]interface{}); ok {
+			candidates = append(candidates, nested)
+		}
+	}
+	for _, c := range candidates {
+		for _, key := range []string{"game_id", "id"} {
+			switch v := c[key].(type) {
+			case string:
+				if v != "" {
+					return v
+				}
+			case float64:
+				return fmt.Sprintf("%.0f", v)
+			}
+		}
+	}
+	return ""
+}
@@ -0,0 +1,197 @@
+package games
+
+import (
+	"errors"
+	"fmt"
+	"log"
+	"strings"
+	"time"
+
+	"github.com/dblaq/buzzycash/external/gaming"
+	"github.com/dblaq/buzzycash/internal/models"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+// SyncResult counts what a sync did to the catalogue. Skipped games could
+// not be read and are logged.
+type SyncResult struct {
+	Imported int `json:"imported"`
+	Updated  int `json:"updated"`
+	Skipped  int `json:"skipped"`
+}
+
+// Sync brings the local catalogue in line with the gaming provider's game
+// list. Games created on the provider directly, or before the catalogue
+// existed, are imported; known games take the provider's price, settings
+// and state.
+func Sync(db *gorm.DB, admin models.Admin) (*SyncResult, error) {
+	gs := gaming.GMInstance()
+	resp, err := gs.GetGames()
+	if err != nil {
+		log.Printf("[Games] Provider failed to list games: %v", err)
+		return nil, ErrProviderFailed
+	}
+
+	result := &SyncResult{}
+	for _, entry := range providerGames(resp) {
+		game, ok := fromProvider(entry)
+		if !ok {
+			log.Printf("[Games] Skipping unreadable provider game: %v", entry)
+			result.Skipped++
+			continue
+		}
+		imported, err := upsert(db, admin, game, entry)
+		if err != nil {
+			return result, fmt.Errorf("sync game %s failed: %w", game.ProviderGameID, err)
+		}
+		if imported {
+			result.Imported++
+		} else {
+			result.Updated++
+		}
+	}
+
+	log.Printf("[Games] Admin %s synced the catalogue: %d imported, %d updated, %d skipped",
+		admin.ID, result.Imported, result.Updated, result.Skipped)
+	return result, nil
+}
+
+// upsert records a provider game, reporting whether it was new. The row is
+// locked so a sync cannot overwrite a start or stop in progress.
+func upsert(db *gorm.DB, admin models.Admin, game models.Game, entry map[string]interface{}) (bool, error) {
+	imported := false
+	err := db.Transaction(func(tx *gorm.DB) error {
+		var existing models.Game
+		err := tx.Clauses(clause.Locking{Strength: "UPDATE"}).
+			First(&existing, "provider_game_id = ?", game.ProviderGameID).Error
+		switch {
+		case errors.Is(err, gorm.ErrRecordNotFound):
+			imported = true
+			if game.Name == "" {
+				game.Name = game.ProviderGameID
+			}
+			if game.Status == "" {
+				// The provider decides whether a listed game sells tickets
+				game.Status = models.GameRunning
+			}
+			if err := tx.Create(&game).Error; err != nil {
+				return err
+			}
+			return tx.Create(&models.GameEvent{
+				GameID:  game.ID,
+				Action:  models.GameActionCreate,
+				AdminID: &admin.ID,
+				Reason:  "imported from the gaming provider",
+			}).Error
+		case err != nil:
+			return err
+		}
+
+		if weighted, ok := entry["weighted_distribution"].(bool); ok {
+			if err := tx.Model(&existing).Update("weighted_distribution", weighted).Error; err != nil {
+				return err
+			}
+		}
+		// Zero fields were missing from the provider's entry and keep
+		// their local values
+		return tx.Model(&existing).Updates(models.Game{
+			Name:              game.Name,
+			Amount:            game.Amount,
+			DrawInterval:      game.DrawInterval,
+			WinningPercentage: game.WinningPercentage,
+			MaxWinners:        game.MaxWinners,
+			Status:            game.Status,
+		}).Error
+	})
+	return imported, err
+}
+
+// providerGames finds the list of games in the provider's loosely typed
+// response.
+func providerGames(resp map[string]interface{}) []map[string]interface{} {
+	var games []map[string]interface{}
+	for _, key := range []string{"games", "results", "data"} {
+		list, ok := resp[key].([]interface{})
+		if !ok {
+			continue
+		}
+		for _, entry := range list {
+			if game, ok := entry.(map[string]interface{}); ok {
+				games = append(games, game)
+			}
+		}
+	}
+	return games
+}
+
+// fromProvider reads one provider game. It fails when the game has no ID
+// or no ticket price, since the catalogue could not sell it.
+func fromProvider(entry map[string]interface{}) (models.Game, bool) {
+	game := models.Game{
+		ProviderGameID:       providerGameID(entry),
+		Name:                 firstString(entry, "game_name", "name"),
+		Amount:               int64(number(entry, "amount")),
+		DrawInterval:         int(number(entry, "draw_interval")),
+		WinningPercentage:    number(entry, "winning_percentage"),
+		MaxWinners:           int(number(entry, "max_winners")),
+		WeightedDistribution: entry["weighted_distribution"] == true,
+		Status:               providerState(entry),
+		StartDate:            time.Now().UTC().Truncate(24 * time.Hour),
+	}
+	for _, key := range []string{"date", "start_date"} {
+		raw := firstString(entry, key)
+		if len(raw) >= len("2006-01-02") {
+			if parsed, err := time.Parse("2006-01-02", raw[:len("2006-01-02")]); err == nil {
+				game.StartDate = parsed
+				break
+			}
+		}
+	}
+	return game, game.ProviderGameID != "" && game.Amount > 0
+}
+
+// providerState maps the provider's status, or its active flag, to a game
+// state. It is empty when the provider gives neither.
+func providerState(entry map[string]interface{}) models.GameState {
+	switch strings.ToLower(firstString(entry, "status", "state")) {
+	case "running", "active", "started", "ongoing", "open":
+		return models.GameRunning
+	case "stopped", "inactive", "ended", "closed", "paused":
+		return models.GameStopped
+	case "created", "pending", "scheduled":
+		return models.GameCreated
+	}
+	for _, key := range []string{"is_active", "active", "is_running"} {
+		if active, ok := entry[key].(bool); ok {
+			if active {
+				return models.GameRunning
+			}
+			return models.GameStopped
+		}
+	}
+	return ""
+}
+
+func firstString(m map[string]interface{}, keys ...string) string {
+	for _, key := range keys {
+		if s, ok := m[key].(string); ok && s != "" {
+			return s
+		}
+	}
+	return ""
+}
+
+// number reads a JSON number that may arrive as a string.
+func number(m map[string]interface{}, key string) float64 {
+	switch v := m[key].(type) {
+	case float64:
+		return v
+	case string:
+		var f float64
+		if _, err := fmt.Sscanf(v, "%g", &f); err == nil {
+			return f
+		}
+	}
+	return 0
+}
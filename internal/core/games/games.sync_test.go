@@ -0,0 +1,50 @@
+package games
+
+import (
+	"testing"
+
+	"github.com/dblaq/buzzycash/internal/models"
+)
+
+func TestProviderGamesReadsLooseResponse(t *testing.T) {
+	resp := map[string]interface{}{
+		"results": []interface{}{
+			map[string]interface{}{"game_id": "g-1", "game_name": "Daily", "amount": 200.0, "status": "active", "date": "2026-01-05"},
+			map[string]interface{}{"id": 7.0, "name": "Weekly", "amount": "500", "is_active": false},
+			map[string]interface{}{"game_name": "No ID", "amount": 100.0},
+			map[string]interface{}{"game_id": "g-free"},
+			"not a game",
+		},
+	}
+
+	entries := providerGames(resp)
+	if len(entries) != 4 {
+		t.Fatalf("expected 4 entries, got %d", len(entries))
+	}
+
+	daily, ok := fromProvider(entries[0])
+	if !ok || daily.ProviderGameID != "g-1" || daily.Amount != 200 || daily.Status != models.GameRunning {
+		t.Fatalf("unexpected daily game: %+v ok=%v", daily, ok)
+	}
+	if daily.StartDate.Format("2006-01-02") != "2026-01-05" {
+		t.Fatalf("expected start date 2026-01-05, got %s", daily.StartDate)
+	}
+
+	weekly, ok := fromProvider(entries[1])
+	if !ok || weekly.ProviderGameID != "7" || weekly.Amount != 500 || weekly.Status != models.GameStopped {
+		t.Fatalf("unexpected weekly game: %+v ok=%v", weekly, ok)
+	}
+
+	if _, ok := fromProvider(entries[2]); ok {
+		t.Fatal("a game without an ID should be skipped")
+	}
+	if _, ok := fromProvider(entries[3]); ok {
+		t.Fatal("a game without a price should be skipped")
+	}
+}
+
+func TestProviderStateUnknownKeepsLocal(t *testing.T) {
+	if state := providerState(map[string]interface{}{"status": "mystery"}); state != "" {
+		t.Fatalf("expected no state, got %q", state)
+	}
+}
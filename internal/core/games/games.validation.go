@@ -0,0 +1,77 @@
+package games
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/dblaq/buzzycash/internal/models"
+)
+
+// Validation errors
+var (
+	ErrGameNameMissing     = errors.New("game_name is required")
+	ErrInvalidAmount       = errors.New("amount must be greater than 0")
+	ErrInvalidDrawInterval = fmt.Errorf("draw_interval must be between %d and %d minutes", minDrawInterval, maxDrawInterval)
+	ErrInvalidWinningPct   = errors.New("winning_percentage must be greater than 0 and at most 100")
+	ErrInvalidMaxWinners   = fmt.Errorf("max_winners must be between 1 and %d", maxWinners)
+	ErrInvalidStartDate    = errors.New("date must be in YYYY-MM-DD format")
+	ErrStartDateInPast     = errors.New("date cannot be in the past")
+	ErrInvalidStatusFilter = errors.New("status must be CREATED, RUNNING or STOPPED")
+	ErrReasonTooLong       = errors.New("reason must be at most 500 characters")
+)
+
+const (
+	minDrawInterval = 1
+	maxDrawInterval = 7 * 24 * 60 // one week
+	maxWinners      = 10000
+	maxReasonLength = 500
+)
+
+// Validate checks the request and returns the parsed start date.
+func (r *CreateGameRequest) Validate() (time.Time, error) {
+	r.GameName = strings.TrimSpace(r.GameName)
+	if r.GameName == "" {
+		return time.Time{}, ErrGameNameMissing
+	}
+	if r.Amount <= 0 {
+		return time.Time{}, ErrInvalidAmount
+	}
+	if r.DrawInterval < minDrawInterval || r.DrawInterval > maxDrawInterval {
+		return time.Time{}, ErrInvalidDrawInterval
+	}
+	if r.WinningPercentage <= 0 || r.WinningPercentage > 100 {
+		return time.Time{}, ErrInvalidWinningPct
+	}
+	if r.MaxWinners < 1 || r.MaxWinners > maxWinners {
+		return time.Time{}, ErrInvalidMaxWinners
+	}
+
+	date, err := time.Parse("2006-01-02", strings.TrimSpace(r.Date))
+	if err != nil {
+		return time.Time{}, ErrInvalidStartDate
+	}
+	today := time.Now().UTC().Truncate(24 * time.Hour)
+	if date.Before(today) {
+		return time.Time{}, ErrStartDateInPast
+	}
+	r.Date = date.Format("2006-01-02")
+	return date, nil
+}
+
+func (r *GameActionRequest) Validate() error {
+	r.Reason = strings.TrimSpace(r.Reason)
+	if len(r.Reason) > maxReasonLength {
+		return ErrReasonTooLong
+	}
+	return nil
+}
+
+func parseStatus(status string) (models.GameState, error) {
+	switch s := models.GameState(strings.ToUpper(strings.TrimSpace(status))); s {
+	case models.GameCreated, models.GameRunning, models.GameStopped:
+		return s, nil
+	}
+	return "", ErrInvalidStatusFilter
+}
@@ -0,0 +1,98 @@
+package kyc
+
+// @Summary Get verification status
+// @Description Get the user's KYC tier, its deposit and withdrawal limits, what the next tier unlocks and their verification history
+// @Tags kyc
+// @Produce json
+// @Success 200 {object} KycStatusResponse "Verification status"
+// @Failure 401 {object} map[string]interface{} "Unauthorized"
+// @Failure 500 {object} map[string]interface{} "Failed to fetch verification status"
+// @Router /kyc [get]
+// @Security BearerAuth
+func _() {}
+
+
+// @Summary Submit BVN
+// @Description Verify a BVN to reach tier 1. Clear matches are approved immediately; unclear ones are queued for manual review
+// @Tags kyc
+// @Accept json
+// @Produce json
+// @Param request body SubmitBVNRequest true "BVN"
+// @Success 200 {object} VerificationResponse "Verification outcome"
+// @Failure 400 {object} map[string]interface{} "Invalid BVN"
+// @Failure 409 {object} map[string]interface{} "Already verified or awaiting review"
+// @Failure 502 {object} map[string]interface{} "Verification provider unavailable"
+// @Router /kyc/bvn [post]
+// @Security BearerAuth
+func _() {}
+
+
+// @Summary Submit NIN and selfie
+// @Description Verify a NIN and match a selfie against the registry photo to reach tier 2. Requires tier 1
+// @Tags kyc
+// @Accept multipart/form-data
+// @Produce json
+// @Param nin formData string true "NIN"
+// @Param selfie formData file true "Selfie (JPEG or PNG, max 5MB)"
+// @Success 200 {object} VerificationResponse "Verification outcome"
+// @Failure 400 {object} map[string]interface{} "Invalid NIN or selfie"
+// @Failure 403 {object} map[string]interface{} "Tier 1 not completed"
+// @Failure 409 {object} map[string]interface{} "Already verified or awaiting review"
+// @Failure 502 {object} map[string]interface{} "Verification provider unavailable"
+// @Router /kyc/nin [post]
+// @Security BearerAuth
+func _() {}
+
+
+// @Summary List KYC review queue
+// @Description List verifications awaiting manual review, oldest first, or those in another status
+// @Tags admin-kyc
+// @Produce json
+// @Param status query string false "MANUAL_REVIEW (default), APPROVED or REJECTED"
+// @Success 200 {array} ReviewItemResponse "Verifications"
+// @Failure 400 {object} map[string]interface{} "Invalid status"
+// @Failure 401 {object} map[string]interface{} "Unauthorized"
+// @Router /admin/kyc/reviews [get]
+// @Security BearerAuth
+func _() {}
+
+
+// @Summary Get verification selfie
+// @Description Download the selfie submitted with a verification
+// @Tags admin-kyc
+// @Produce image/jpeg,image/png
+// @Param id path string true "Verification ID"
+// @Success 200 {file} file "Selfie"
+// @Failure 404 {object} map[string]interface{} "Selfie not found"
+// @Router /admin/kyc/reviews/{id}/selfie [get]
+// @Security BearerAuth
+func _() {}
+
+
+// @Summary Approve verification
+// @Description Approve a verification awaiting manual review, raising the user's tier
+// @Tags admin-kyc
+// @Produce json
+// @Param id path string true "Verification ID"
+// @Success 200 {object} VerificationResponse "Verification approved"
+// @Failure 404 {object} map[string]interface{} "Verification not found"
+// @Failure 409 {object} map[string]interface{} "Not awaiting review"
+// @Router /admin/kyc/reviews/{id}/approve [post]
+// @Security BearerAuth
+func _() {}
+
+
+// @Summary Reject verification
+// @Description Reject a verification awaiting manual review
+// @Tags admin-kyc
+// @Accept json
+// @Produce json
+// @Param id path string true "Verification ID"
+// @Param request body RejectVerificationRequest true "Reason"
+// @Success 200 {object} VerificationResponse "Verification rejected"
+// @Failure 400 {object} map[string]interface{} "Reason missing"
+// @Failure 404 {object} map[string]interface{} "Verification not found"
+// @Failure 409 {object} map[string]interface{} "Not awaiting review"
+// @Router /admin/kyc/reviews/{id}/reject [post]
+// @Security BearerAuth
+func _() {}
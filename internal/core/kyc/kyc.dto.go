@@ -0,0 +1,51 @@
+package kyc
+
+import "time"
+
+type SubmitBVNRequest struct {
+	BVN string `json:"bvn" binding:"required"`
+}
+
+// SubmitNINRequest is sent as multipart form data with the selfie in the
+// "selfie" file field.
+type SubmitNINRequest struct {
+	NIN string `form:"nin" binding:"required"`
+}
+
+type RejectVerificationRequest struct {
+	Reason string `json:"reason" binding:"required"`
+}
+
+type VerificationResponse struct {
+	ID             string     `json:"id"`
+	Tier           int        `json:"tier"`
+	IDType         string     `json:"id_type"`
+	MaskedIDNumber string     `json:"masked_id_number"`
+	Status         string     `json:"status"`
+	Reason         string     `json:"reason,omitempty"`
+	CreatedAt      time.Time  `json:"created_at"`
+	ReviewedAt     *time.Time `json:"reviewed_at,omitempty"`
+}
+
+type KycStatusResponse struct {
+	Tier          int                    `json:"tier"`
+	Limits        Limits                 `json:"limits"`
+	NextTier      *int                   `json:"next_tier,omitempty"`
+	NextLimits    *Limits                `json:"next_limits,omitempty"`
+	Verifications []VerificationResponse `json:"verifications"`
+}
+
+// ReviewItemResponse is a verification in the admin review queue, with what
+// the reviewer needs to compare.
+type ReviewItemResponse struct {
+	VerificationResponse
+	UserID            string  `json:"user_id"`
+	FullName          string  `json:"full_name"`
+	PhoneNumber       string  `json:"phone_number"`
+	DateOfBirth       string  `json:"date_of_birth"`
+	RegisteredName    string  `json:"registered_name"`
+	MatchScore        float64 `json:"match_score"`
+	SelfiePath        string  `json:"selfie_path,omitempty"`
+	Provider          string  `json:"provider"`
+	ProviderReference string  `json:"provider_reference,omitempty"`
+}
@@ -0,0 +1,317 @@
+package kyc
+
+import (
+	"crypto/rand"
+	"encoding/hex"
+	"errors"
+	"io"
+	"log"
+	"net/http"
+	"os"
+	"path/filepath"
+
+	"github.com/dblaq/buzzycash/external/identity"
+	"github.com/dblaq/buzzycash/internal/core/audit"
+	"github.com/dblaq/buzzycash/internal/models"
+	"github.com/dblaq/buzzycash/internal/utils"
+	"github.com/gin-gonic/gin"
+	"gorm.io/gorm"
+)
+
+// Selfies are kept out of the public uploads; admins fetch them through
+// the review API.
+const (
+	selfieDir     = "uploads/kyc-selfies"
+	maxSelfieSize = 5 * 1024 * 1024 // 5 MB
+)
+
+var selfieTypes = map[string]string{
+	"image/jpeg": ".jpg",
+	"image/png":  ".png",
+}
+
+type KycHandler struct {
+	db  *gorm.DB
+	kyc *KycService
+}
+
+func NewKycHandler(db *gorm.DB) *KycHandler {
+	return &KycHandler{
+		db:  db,
+		kyc: NewKycService(db),
+	}
+}
+
+// GetKycStatusHandler returns the user's tier, its limits, what the next
+// tier would unlock and their verification history.
+func (h *KycHandler) GetKycStatusHandler(ctx *gin.Context) {
+	currentUser := ctx.MustGet("currentUser").(models.User)
+
+	tier, err := CurrentTier(h.db, currentUser.ID)
+	if err != nil {
+		log.Printf("[KYC] Failed to load tier for userID %s: %v", currentUser.ID, err)
+		utils.Error(ctx, http.StatusInternalServerError, "Failed to fetch verification status")
+		return
+	}
+
+	var verifications []models.KycVerification
+	if err := h.db.Where("user_id = ?", currentUser.ID).Order("created_at desc").Find(&verifications).Error; err != nil {
+		log.Printf("[KYC] Failed to load verifications for userID %s: %v", currentUser.ID, err)
+		utils.Error(ctx, http.StatusInternalServerError, "Failed to fetch verification status")
+		return
+	}
+
+	response := KycStatusResponse{
+		Tier:          tier,
+		Limits:        TierLimits(tier),
+		Verifications: make([]VerificationResponse, 0, len(verifications)),
+	}
+	if tier < models.MaxKycTier {
+		next := tier + 1
+		nextLimits := TierLimits(next)
+		response.NextTier, response.NextLimits = &next, &nextLimits
+	}
+	for _, v := range verifications {
+		response.Verifications = append(response.Verifications, toVerificationResponse(v))
+	}
+
+	ctx.JSON(http.StatusOK, gin.H{
+		"message": "Verification status retrieved successfully",
+		"data":    response,
+	})
+}
+
+func (h *KycHandler) SubmitBVNHandler(ctx *gin.Context) {
+	var req SubmitBVNRequest
+	if err := ctx.ShouldBindJSON(&req); err != nil {
+		utils.Error(ctx, http.StatusBadRequest, utils.ValidationErrorToJSON(err))
+		return
+	}
+	if err := req.Validate(); err != nil {
+		utils.Error(ctx, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	currentUser := ctx.MustGet("currentUser").(models.User)
+	verification, err := h.kyc.SubmitBVN(currentUser, req.BVN)
+	if err != nil {
+		log.Printf("[KYC] BVN submission failed for userID %s: %v", currentUser.ID, err)
+		submissionError(ctx, err)
+		return
+	}
+	respondSubmission(ctx, verification)
+}
+
+// SubmitNINHandler takes the NIN and a selfie as multipart form data.
+func (h *KycHandler) SubmitNINHandler(ctx *gin.Context) {
+	var req SubmitNINRequest
+	if err := ctx.ShouldBind(&req); err != nil {
+		utils.Error(ctx, http.StatusBadRequest, utils.ValidationErrorToJSON(err))
+		return
+	}
+	if err := req.Validate(); err != nil {
+		utils.Error(ctx, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	file, err := ctx.FormFile("selfie")
+	if err != nil {
+		utils.Error(ctx, http.StatusBadRequest, "A selfie is required")
+		return
+	}
+	if file.Size > maxSelfieSize {
+		utils.Error(ctx, http.StatusBadRequest, "Selfie must be at most 5MB")
+		return
+	}
+	ext, ok := selfieTypes[file.Header.Get("Content-Type")]
+	if !ok {
+		utils.Error(ctx, http.StatusBadRequest, "Selfie must be a JPEG or PNG image")
+		return
+	}
+
+	src, err := file.Open()
+	if err != nil {
+		utils.Error(ctx, http.StatusBadRequest, "Could not read selfie")
+		return
+	}
+	selfie, err := io.ReadAll(src)
+	src.Close()
+	if err != nil {
+		utils.Error(ctx, http.StatusBadRequest, "Could not read selfie")
+		return
+	}
+
+	currentUser := ctx.MustGet("currentUser").(models.User)
+	selfiePath, err := saveSelfie(selfie, ext)
+	if err != nil {
+		log.Printf("[KYC] Failed to store selfie for userID %s: %v", currentUser.ID, err)
+		utils.Error(ctx, http.StatusInternalServerError, "Failed to store selfie")
+		return
+	}
+
+	verification, err := h.kyc.SubmitNIN(currentUser, req.NIN, selfie, selfiePath)
+	if err != nil {
+		os.Remove(selfiePath)
+		log.Printf("[KYC] NIN submission failed for userID %s: %v", currentUser.ID, err)
+		submissionError(ctx, err)
+		return
+	}
+	respondSubmission(ctx, verification)
+}
+
+// ListReviewsHandler lists the verifications waiting for an admin, or those
+// in ?status= when given.
+func (h *KycHandler) ListReviewsHandler(ctx *gin.Context) {
+	status := models.KycStatus(ctx.DefaultQuery("status", string(models.KycManualReview)))
+	switch status {
+	case models.KycManualReview, models.KycApproved, models.KycRejected:
+	default:
+		utils.Error(ctx, http.StatusBadRequest, "Invalid status")
+		return
+	}
+
+	verifications, err := h.kyc.ListForReview(status)
+	if err != nil {
+		log.Printf("[KYC] Failed to load review queue: %v", err)
+		utils.Error(ctx, http.StatusInternalServerError, "Failed to fetch verifications")
+		return
+	}
+
+	response := make([]ReviewItemResponse, 0, len(verifications))
+	for _, v := range verifications {
+		response = append(response, ReviewItemResponse{
+			VerificationResponse: toVerificationResponse(v),
+			UserID:               v.UserID,
+			FullName:             v.User.FullName,
+			PhoneNumber:          v.User.PhoneNumber,
+			DateOfBirth:          v.User.DateOfBirthString(),
+			RegisteredName:       v.RegisteredName,
+			MatchScore:           v.MatchScore,
+			SelfiePath:           v.SelfiePath,
+			Provider:             v.Provider,
+			ProviderReference:    v.ProviderReference,
+		})
+	}
+
+	ctx.JSON(http.StatusOK, gin.H{
+		"message": "Verifications retrieved successfully",
+		"data":    response,
+	})
+}
+
+// GetSelfieHandler serves the selfie submitted with a verification.
+func (h *KycHandler) GetSelfieHandler(ctx *gin.Context) {
+	var verification models.KycVerification
+	if err := h.db.First(&verification, "id = ?", ctx.Param("id")).Error; err != nil || verification.SelfiePath == "" {
+		utils.Error(ctx, http.StatusNotFound, "Selfie not found")
+		return
+	}
+	ctx.File(verification.SelfiePath)
+}
+
+func (h *KycHandler) ApproveVerificationHandler(ctx *gin.Context) {
+	admin := ctx.MustGet("currentAdmin").(models.Admin)
+
+	verification, err := h.kyc.Approve(ctx.Param("id"), admin)
+	if err != nil {
+		reviewError(ctx, err)
+		return
+	}
+	audit.Log(ctx, h.db, audit.KycApproved, audit.Target{Type: audit.TargetKyc, ID: verification.ID},
+		gin.H{"status": models.KycManualReview}, toVerificationResponse(*verification))
+
+	ctx.JSON(http.StatusOK, gin.H{
+		"message": "Verification approved",
+		"data":    toVerificationResponse(*verification),
+	})
+}
+
+func (h *KycHandler) RejectVerificationHandler(ctx *gin.Context) {
+	var req RejectVerificationRequest
+	if err := ctx.ShouldBindJSON(&req); err != nil {
+		utils.Error(ctx, http.StatusBadRequest, utils.ValidationErrorToJSON(err))
+		return
+	}
+	if err := req.Validate(); err != nil {
+		utils.Error(ctx, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	admin := ctx.MustGet("currentAdmin").(models.Admin)
+	verification, err := h.kyc.Reject(ctx.Param("id"), admin, req.Reason)
+	if err != nil {
+		reviewError(ctx, err)
+		return
+	}
+	audit.Log(ctx, h.db, audit.KycRejected, audit.Target{Type: audit.TargetKyc, ID: verification.ID},
+		gin.H{"status": models.KycManualReview}, toVerificationResponse(*verification))
+
+	ctx.JSON(http.StatusOK, gin.H{
+		"message": "Verification rejected",
+		"data":    toVerificationResponse(*verification),
+	})
+}
+
+func respondSubmission(ctx *gin.Context, v *models.KycVerification) {
+	message := "Verification successful"
+	switch v.Status {
+	case models.KycManualReview:
+		message = "Verification submitted for review"
+	case models.KycRejected:
+		message = "Verification failed"
+	}
+	ctx.JSON(http.StatusOK, gin.H{
+		"message": message,
+		"data":    toVerificationResponse(*v),
+	})
+}
+
+func submissionError(ctx *gin.Context, err error) {
+	switch {
+	case errors.Is(err, ErrAlreadyVerified), errors.Is(err, ErrReviewPending):
+		utils.Error(ctx, http.StatusConflict, err.Error())
+	case errors.Is(err, ErrPreviousTierRequired):
+		utils.Error(ctx, http.StatusForbidden, err.Error())
+	case errors.Is(err, identity.ErrUnknownProvider):
+		utils.Error(ctx, http.StatusInternalServerError, "Verification is not available right now")
+	default:
+		utils.Error(ctx, http.StatusBadGateway, "Verification could not be completed; please try again")
+	}
+}
+
+func reviewError(ctx *gin.Context, err error) {
+	switch {
+	case errors.Is(err, ErrVerificationNotFound):
+		utils.Error(ctx, http.StatusNotFound, "Verification not found")
+	case errors.Is(err, ErrNotInReview):
+		utils.Error(ctx, http.StatusConflict, err.Error())
+	default:
+		log.Printf("[KYC] Review failed: %v", err)
+		utils.Error(ctx, http.StatusInternalServerError, "Failed to review verification")
+	}
+}
+
+func saveSelfie(data []byte, ext string) (string, error) {
+	if err := os.MkdirAll(selfieDir, 0o700); err != nil {
+		return "", err
+	}
+	b := make([]byte, 16)
+	if _, err := rand.Read(b); err != nil {
+		return "", err
+	}
+	path := filepath.Join(selfieDir, hex.EncodeToString(b)+ext)
+	return path, os.WriteFile(path, data, 0o600)
+}
+
+func toVerificationResponse(v models.KycVerification) VerificationResponse {
+	return VerificationResponse{
+		ID:             v.ID,
+		Tier:           v.Tier,
+		IDType:         string(v.IDType),
+		MaskedIDNumber: v.MaskedIDNumber,
+		Status:         string(v.Status),
+		Reason:         v.Reason,
+		CreatedAt:      v.CreatedAt,
+		ReviewedAt:     v.ReviewedAt,
+	}
+}
@@ -0,0 +1,121 @@
+package kyc
+
+import (
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/dblaq/buzzycash/internal/config"
+	"github.com/dblaq/buzzycash/internal/core/fx"
+	"github.com/dblaq/buzzycash/internal/helpers"
+	"github.com/dblaq/buzzycash/internal/models"
+	"gorm.io/gorm"
+)
+
+// ErrLimitExceeded is wrapped by every LimitError.
+var ErrLimitExceeded = errors.New("amount exceeds your verification tier limit")
+
+// Limits are in naira and apply separately to deposits and withdrawals.
+type Limits struct {
+	SingleTransaction int64 `json:"single_transaction"`
+	Daily             int64 `json:"daily"`
+}
+
+// TierLimits returns the configured limits for a tier.
+func TierLimits(tier int) Limits {
+	c := config.AppConfig
+	switch {
+	case tier >= models.KycTierNIN:
+		return Limits{SingleTransaction: c.KycTier2SingleLimit, Daily: c.KycTier2DailyLimit}
+	case tier == models.KycTierBVN:
+		return Limits{SingleTransaction: c.KycTier1SingleLimit, Daily: c.KycTier1DailyLimit}
+	default:
+		return Limits{SingleTransaction: c.KycTier0SingleLimit, Daily: c.KycTier0DailyLimit}
+	}
+}
+
+// LimitError reports which of a tier's limits an amount would break.
+type LimitError struct {
+	Tier      int
+	Daily     bool  // false for the single-transaction limit
+	Max       int64 // the limit, in naira
+	Remaining int64 // what is left of the daily limit, in naira
+}
+
+func (e *LimitError) Error() string {
+	if e.Daily {
+		return fmt.Sprintf("amount exceeds your tier %d daily limit of %d NGN (%d NGN left today); verify your identity to raise it", e.Tier, e.Max, e.Remaining)
+	}
+	return fmt.Sprintf("amount exceeds your tier %d single transaction limit of %d NGN; verify your identity to raise it", e.Tier, e.Max)
+}
+
+func (e *LimitError) Unwrap() error { return ErrLimitExceeded }
+
+// CurrentTier is the highest tier the user has an approved verification for.
+func CurrentTier(db *gorm.DB, userID string) (int, error) {
+	var tier int
+	if err := db.Model(&models.KycVerification{}).
+		Select("COALESCE(MAX(tier), 0)").
+		Where("user_id = ? AND status = ?", userID, models.KycApproved).
+		Scan(&tier).Error; err != nil {
+		return 0, fmt.Errorf("load kyc tier failed: %w", err)
+	}
+	return tier, nil
+}
+
+// CheckLimit checks a deposit or withdrawal of amount, in the user's
+// currency, against their tier. category is models.Deposit or
+// models.WithdrawRequest; pending and successful transactions of that
+// category since midnight count towards the daily limit. A breach is
+// returned as a *LimitError.
+func CheckLimit(db *gorm.DB, user models.User, category models.TransactionCategory, amount int64) error {
+	tier, err := CurrentTier(db, user.ID)
+	if err != nil {
+		return err
+	}
+	limits := TierLimits(tier)
+
+	rates, err := fx.LoadRates(db)
+	if err != nil {
+		return err
+	}
+	naira, _, err := rates.Convert(amount, helpers.UserCurrency(user), models.NGN)
+	if err != nil {
+		return err
+	}
+	if naira > limits.SingleTransaction {
+		return &LimitError{Tier: tier, Max: limits.SingleTransaction}
+	}
+
+	var totals []struct {
+		Currency models.ECurrency
+		Total    int64
+	}
+	now := time.Now()
+	midnight := time.Date(now.Year(), now.Month(), now.Day(), 0, 0, 0, 0, now.Location())
+	if err := db.Model(&models.Transaction{}).
+		Select("currency, COALESCE(SUM(amount), 0) AS total").
+		Where("user_id = ? AND category = ? AND payment_status IN ? AND created_at >= ?",
+			user.ID, category, []models.EPaymentStatus{models.Pending, models.Successful}, midnight).
+		Group("currency").
+		Scan(&totals).Error; err != nil {
+		return fmt.Errorf("load daily total failed: %w", err)
+	}
+
+	var today int64
+	for _, t := range totals {
+		converted, _, err := rates.Convert(t.Total, t.Currency, models.NGN)
+		if err != nil {
+			return err
+		}
+		today += converted
+	}
+	if today+naira > limits.Daily {
+		remaining := limits.Daily - today
+		if remaining < 0 {
+			remaining = 0
+		}
+		return &LimitError{Tier: tier, Daily: true, Max: limits.Daily, Remaining: remaining}
+	}
+	return nil
+}
@@ -0,0 +1,26 @@
+package kyc
+
+import (
+	"github.com/dblaq/buzzycash/internal/middlewares"
+	"github.com/dblaq/buzzycash/internal/models"
+	"github.com/gin-gonic/gin"
+	"gorm.io/gorm"
+)
+
+func KycRoutes(rg *gin.RouterGroup, db *gorm.DB) {
+	kycHandler := NewKycHandler(db)
+	kycRoutes := rg.Group("/kyc", middlewares.AuthMiddleware)
+	{
+		kycRoutes.GET("", kycHandler.GetKycStatusHandler)
+		kycRoutes.POST("/bvn", kycHandler.SubmitBVNHandler)
+		kycRoutes.POST("/nin", kycHandler.SubmitNINHandler)
+	}
+
+	reviewRoutes := rg.Group("/admin/kyc/reviews", middlewares.AdminAuthMiddleware, middlewares.RequirePermission(models.PermKycReview))
+	{
+		reviewRoutes.GET("", kycHandler.ListReviewsHandler)
+		reviewRoutes.GET("/:id/selfie", kycHandler.GetSelfieHandler)
+		reviewRoutes.POST("/:id/approve", kycHandler.ApproveVerificationHandler)
+		reviewRoutes.POST("/:id/reject", kycHandler.RejectVerificationHandler)
+	}
+}
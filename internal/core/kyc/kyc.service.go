@@ -0,0 +1,253 @@
+package kyc
+
+import (
+	"errors"
+	"fmt"
+	"log"
+	"strings"
+	"time"
+
+	"github.com/dblaq/buzzycash/external/identity"
+	"github.com/dblaq/buzzycash/internal/config"
+	"github.com/dblaq/buzzycash/internal/models"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+var (
+	ErrAlreadyVerified      = errors.New("you are already verified at this tier")
+	ErrPreviousTierRequired = errors.New("complete the previous verification tier first")
+	ErrReviewPending        = errors.New("a verification for this tier is already awaiting review")
+	ErrVerificationNotFound = errors.New("verification not found")
+	ErrNotInReview          = errors.New("verification is not awaiting review")
+)
+
+type KycService struct {
+	db *gorm.DB
+}
+
+func NewKycService(db *gorm.DB) *KycService {
+	return &KycService{
+		db: db,
+	}
+}
+
+// SubmitBVN checks a BVN with the identity provider to reach tier 1.
+func (s *KycService) SubmitBVN(user models.User, bvn string) (*models.KycVerification, error) {
+	return s.submit(user, models.KycTierBVN, models.KycBVN, identity.Request{IDNumber: bvn}, "",
+		func(p identity.Provider, req identity.Request) (*identity.Result, error) {
+			return p.VerifyBVN(req)
+		})
+}
+
+// SubmitNIN checks a NIN and matches the selfie against the registry's
+// photo to reach tier 2. selfiePath is where the selfie was stored, for the
+// review queue.
+func (s *KycService) SubmitNIN(user models.User, nin string, selfie []byte, selfiePath string) (*models.KycVerification, error) {
+	return s.submit(user, models.KycTierNIN, models.KycNIN, identity.Request{IDNumber: nin, Selfie: selfie}, selfiePath,
+		func(p identity.Provider, req identity.Request) (*identity.Result, error) {
+			return p.VerifyNIN(req)
+		})
+}
+
+type verifyFunc func(identity.Provider, identity.Request) (*identity.Result, error)
+
+// submit runs one verification and records its outcome. A clear match is
+// approved straight away and an unknown ID rejected; anything in between
+// goes to the admin review queue.
+func (s *KycService) submit(user models.User, tier int, idType models.KycIDType, req identity.Request, selfiePath string, verify verifyFunc) (*models.KycVerification, error) {
+	current, err := CurrentTier(s.db, user.ID)
+	if err != nil {
+		return nil, err
+	}
+	if current >= tier {
+		return nil, ErrAlreadyVerified
+	}
+	if current < tier-1 {
+		return nil, ErrPreviousTierRequired
+	}
+	var pending int64
+	if err := s.db.Model(&models.KycVerification{}).
+		Where("user_id = ? AND tier = ? AND status = ?", user.ID, tier, models.KycManualReview).
+		Count(&pending).Error; err != nil {
+		return nil, fmt.Errorf("load pending verifications failed: %w", err)
+	}
+	if pending > 0 {
+		return nil, ErrReviewPending
+	}
+
+	provider, err := identity.GetProvider(config.AppConfig.KycProvider)
+	if err != nil {
+		return nil, err
+	}
+	req.FirstName, req.LastName = splitName(user.FullName)
+	req.DateOfBirth = user.DateOfBirthString()
+	req.PhoneNumber = user.PhoneNumber
+
+	result, err := verify(provider, req)
+	if err != nil {
+		return nil, fmt.Errorf("%s verification failed: %w", provider.Name(), err)
+	}
+
+	verification := models.KycVerification{
+		UserID:            user.ID,
+		Tier:              tier,
+		IDType:            idType,
+		MaskedIDNumber:    maskID(req.IDNumber),
+		SelfiePath:        selfiePath,
+		Provider:          provider.Name(),
+		ProviderReference: result.Reference,
+		RegisteredName:    strings.TrimSpace(result.FirstName + " " + result.LastName),
+		MatchScore:        result.MatchScore,
+	}
+	verification.Status, verification.Reason = decide(user, tier, result)
+
+	if err := s.db.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Create(&verification).Error; err != nil {
+			return err
+		}
+		if verification.Status == models.KycApproved {
+			return markVerified(tx, user.ID)
+		}
+		return nil
+	}); err != nil {
+		return nil, fmt.Errorf("record verification failed: %w", err)
+	}
+
+	log.Printf("[KYC] Tier %d %s for userID=%s: %s %s", tier, idType, user.ID, verification.Status, verification.Reason)
+	return &verification, nil
+}
+
+func decide(user models.User, tier int, result *identity.Result) (models.KycStatus, string) {
+	switch result.Outcome {
+	case identity.NotFound:
+		return models.KycRejected, firstNonEmpty(result.Reason, "ID not found")
+	case identity.Found:
+	default:
+		return models.KycManualReview, firstNonEmpty(result.Reason, "provider could not decide")
+	}
+
+	if !namesMatch(user.FullName, result.FirstName, result.LastName) {
+		return models.KycManualReview, "name on record does not match profile"
+	}
+	if tier == models.KycTierNIN && result.MatchScore < config.AppConfig.KycSelfieMatchThreshold {
+		return models.KycManualReview, fmt.Sprintf("selfie match score %.1f below threshold", result.MatchScore)
+	}
+	return models.KycApproved, ""
+}
+
+// ListForReview returns verifications in the given status, oldest first, so
+// the queue is worked in order.
+func (s *KycService) ListForReview(status models.KycStatus) ([]models.KycVerification, error) {
+	var verifications []models.KycVerification
+	if err := s.db.Preload("User").
+		Where("status = ?", status).
+		Order("created_at ASC").
+		Find(&verifications).Error; err != nil {
+		return nil, err
+	}
+	return verifications, nil
+}
+
+// Approve accepts a verification from the review queue.
+func (s *KycService) Approve(id string, admin models.Admin) (*models.KycVerification, error) {
+	return s.review(id, admin, models.KycApproved, "")
+}
+
+// Reject turns down a verification from the review queue.
+func (s *KycService) Reject(id string, admin models.Admin, reason string) (*models.KycVerification, error) {
+	return s.review(id, admin, models.KycRejected, reason)
+}
+
+func (s *KycService) review(id string, admin models.Admin, status models.KycStatus, reason string) (*models.KycVerification, error) {
+	var verification models.KycVerification
+	err := s.db.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Clauses(clause.Locking{Strength: "UPDATE"}).First(&verification, "id = ?", id).Error; err != nil {
+			if errors.Is(err, gorm.ErrRecordNotFound) {
+				return ErrVerificationNotFound
+			}
+			return err
+		}
+		if verification.Status != models.KycManualReview {
+			return ErrNotInReview
+		}
+
+		now := time.Now()
+		verification.Status = status
+		verification.Reason = firstNonEmpty(reason, verification.Reason)
+		verification.ReviewedBy = &admin.ID
+		verification.ReviewedAt = &now
+		if err := tx.Model(&verification).Updates(map[string]interface{}{
+			"status":      verification.Status,
+			"reason":      verification.Reason,
+			"reviewed_by": admin.ID,
+			"reviewed_at": now,
+		}).Error; err != nil {
+			return err
+		}
+		if status == models.KycApproved {
+			return markVerified(tx, verification.UserID)
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	log.Printf("[KYC] Verification %s %s by admin %s", verification.ID, status, admin.ID)
+	return &verification, nil
+}
+
+// markVerified sets the user's KYC flag once they pass any tier above
+// phone-only.
+func markVerified(tx *gorm.DB, userID string) error {
+	return tx.Model(&models.User{}).Where("id = ?", userID).Update("is_kyc_verified", true).Error
+}
+
+// namesMatch requires each name the registry returned to appear in the
+// user's profile name, ignoring case and order.
+func namesMatch(fullName, first, last string) bool {
+	if first == "" && last == "" {
+		return false
+	}
+	have := map[string]bool{}
+	for _, part := range strings.Fields(strings.ToUpper(fullName)) {
+		have[part] = true
+	}
+	for _, name := range []string{first, last} {
+		for _, part := range strings.Fields(strings.ToUpper(name)) {
+			if !have[part] {
+				return false
+			}
+		}
+	}
+	return true
+}
+
+func splitName(fullName string) (string, string) {
+	parts := strings.Fields(fullName)
+	switch len(parts) {
+	case 0:
+		return "", ""
+	case 1:
+		return parts[0], ""
+	}
+	return parts[0], parts[len(parts)-1]
+}
+
+// maskID keeps only the last four digits.
+func maskID(id string) string {
+	if len(id) <= 4 {
+		return id
+	}
+	return strings.Repeat("*", len(id)-4) + id[len(id)-4:]
+}
+
+func firstNonEmpty(values ...string) string {
+	for _, v := range values {
+		if v != "" {
+			return v
+		}
+	}
+	return ""
+}
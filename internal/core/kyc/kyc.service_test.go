@@ -0,0 +1,191 @@
+package kyc
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/dblaq/buzzycash/external/identity"
+	"github.com/dblaq/buzzycash/internal/config"
+	"github.com/dblaq/buzzycash/internal/models"
+	"github.com/dblaq/buzzycash/internal/testutil"
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+// useFakeProvider points KYC at the fake identity provider, whose outcome
+// depends only on the ID number's last four digits.
+func useFakeProvider(t *testing.T) {
+	t.Helper()
+	saved := config.AppConfig
+	t.Cleanup(func() { config.AppConfig = saved })
+	config.AppConfig.KycProvider = "fake"
+	config.AppConfig.KycSelfieMatchThreshold = 80
+}
+
+func kycUser(t *testing.T, db *gorm.DB) models.User {
+	t.Helper()
+	user := testutil.User(t, db)
+	user.FullName = "Ada Okafor"
+	if err := db.Model(&user).Update("full_name", user.FullName).Error; err != nil {
+		t.Fatalf("set full name: %v", err)
+	}
+	return user
+}
+
+func TestDecideWithFakeProvider(t *testing.T) {
+	provider, err := identity.GetProvider("fake")
+	if err != nil {
+		t.Fatalf("fake provider not registered: %v", err)
+	}
+	useFakeProvider(t)
+	user := models.User{FullName: "Ada Okafor"}
+
+	tests := []struct {
+		name string
+		tier int
+		id   string
+		want models.KycStatus
+	}{
+		{"bvn found", models.KycTierBVN, "22212341234", models.KycApproved},
+		{"bvn not found", models.KycTierBVN, "22212340000", models.KycRejected},
+		{"bvn inconclusive", models.KycTierBVN, "22212349999", models.KycManualReview},
+		{"bvn belongs to someone else", models.KycTierBVN, "22212348888", models.KycManualReview},
+		{"nin found", models.KycTierNIN, "12345671234", models.KycApproved},
+		{"nin selfie mismatch", models.KycTierNIN, "12345677777", models.KycManualReview},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			req := identity.Request{IDNumber: tt.id, FirstName: "Ada", LastName: "Okafor"}
+			verify := provider.VerifyBVN
+			if tt.tier == models.KycTierNIN {
+				verify = provider.VerifyNIN
+			}
+			result, err := verify(req)
+			if err != nil {
+				t.Fatalf("verify: %v", err)
+			}
+			if got, reason := decide(user, tt.tier, result); got != tt.want {
+				t.Errorf("decide = %s (%s), want %s", got, reason, tt.want)
+			}
+		})
+	}
+}
+
+func TestSubmitAndReview(t *testing.T) {
+	db := testutil.DB(t)
+	useFakeProvider(t)
+	service := NewKycService(db)
+	user := kycUser(t, db)
+
+	if _, err := service.SubmitNIN(user, "12345671234", nil, ""); !errors.Is(err, ErrPreviousTierRequired) {
+		t.Fatalf("NIN before BVN: err = %v, want ErrPreviousTierRequired", err)
+	}
+
+	bvn, err := service.SubmitBVN(user, "22212341234")
+	if err != nil {
+		t.Fatalf("SubmitBVN: %v", err)
+	}
+	if bvn.Status != models.KycApproved || bvn.MaskedIDNumber != "*******1234" {
+		t.Fatalf("BVN verification = %s %s, want APPROVED *******1234", bvn.Status, bvn.MaskedIDNumber)
+	}
+	if _, err := service.SubmitBVN(user, "22212341234"); !errors.Is(err, ErrAlreadyVerified) {
+		t.Fatalf("second BVN: err = %v, want ErrAlreadyVerified", err)
+	}
+
+	// The registry's name differs from the profile, so a person decides
+	nin, err := service.SubmitNIN(user, "12345678888", []byte("selfie"), "selfies/test.jpg")
+	if err != nil {
+		t.Fatalf("SubmitNIN: %v", err)
+	}
+	if nin.Status != models.KycManualReview {
+		t.Fatalf("NIN status = %s, want MANUAL_REVIEW", nin.Status)
+	}
+	if _, err := service.SubmitNIN(user, "12345678888", nil, ""); !errors.Is(err, ErrReviewPending) {
+		t.Fatalf("NIN while in review: err = %v, want ErrReviewPending", err)
+	}
+
+	admin := models.Admin{ID: uuid.NewString()}
+	reviewed, err := service.Approve(nin.ID, admin)
+	if err != nil {
+		t.Fatalf("Approve: %v", err)
+	}
+	if reviewed.Status != models.KycApproved || reviewed.ReviewedBy == nil || *reviewed.ReviewedBy != admin.ID {
+		t.Fatalf("reviewed = %s by %v, want APPROVED by %s", reviewed.Status, reviewed.ReviewedBy, admin.ID)
+	}
+	if _, err := service.Reject(nin.ID, admin, "too late"); !errors.Is(err, ErrNotInReview) {
+		t.Fatalf("reject after approval: err = %v, want ErrNotInReview", err)
+	}
+
+	tier, err := CurrentTier(db, user.ID)
+	if err != nil || tier != models.KycTierNIN {
+		t.Fatalf("CurrentTier = %d, %v; want %d", tier, err, models.KycTierNIN)
+	}
+	var verified bool
+	db.Model(&models.User{}).Where("id = ?", user.ID).Pluck("is_kyc_verified", &verified)
+	if !verified {
+		t.Error("user not marked KYC verified")
+	}
+}
+
+func TestSubmitUnknownIDIsRejected(t *testing.T) {
+	db := testutil.DB(t)
+	useFakeProvider(t)
+	user := kycUser(t, db)
+
+	v, err := NewKycService(db).SubmitBVN(user, "22212340000")
+	if err != nil {
+		t.Fatalf("SubmitBVN: %v", err)
+	}
+	if v.Status != models.KycRejected {
+		t.Fatalf("status = %s, want REJECTED", v.Status)
+	}
+	if tier, _ := CurrentTier(db, user.ID); tier != 0 {
+		t.Errorf("CurrentTier = %d after rejection, want 0", tier)
+	}
+}
+
+func TestCheckLimitByTier(t *testing.T) {
+	db := testutil.DB(t)
+	useFakeProvider(t)
+	config.AppConfig.KycTier0SingleLimit = 1000
+	config.AppConfig.KycTier0DailyLimit = 1500
+	config.AppConfig.KycTier1SingleLimit = 5000
+	config.AppConfig.KycTier1DailyLimit = 10000
+	user := kycUser(t, db)
+
+	var limitErr *LimitError
+	err := CheckLimit(db, user, models.Deposit, 1200)
+	if !errors.As(err, &limitErr) || limitErr.Tier != 0 || limitErr.Daily {
+		t.Fatalf("tier 0 over single limit: err = %v, want single-transaction LimitError", err)
+	}
+
+	// A pending deposit counts towards today's total
+	if err := db.Create(&models.Transaction{
+		UserID:               user.ID,
+		Amount:               1000,
+		TransactionReference: "TEST-" + uuid.NewString(),
+		PaymentStatus:        models.Pending,
+		Currency:             models.NGN,
+		Category:             models.Deposit,
+	}).Error; err != nil {
+		t.Fatalf("create deposit: %v", err)
+	}
+	err = CheckLimit(db, user, models.Deposit, 600)
+	if !errors.As(err, &limitErr) || !limitErr.Daily || limitErr.Remaining != 500 {
+		t.Fatalf("tier 0 over daily limit: err = %v, want daily LimitError with 500 left", err)
+	}
+	if err := CheckLimit(db, user, models.WithdrawRequest, 600); err != nil {
+		t.Fatalf("withdrawals have their own daily total: %v", err)
+	}
+
+	if _, err := NewKycService(db).SubmitBVN(user, "22212341234"); err != nil {
+		t.Fatalf("SubmitBVN: %v", err)
+	}
+	if err := CheckLimit(db, user, models.Deposit, 1200); err != nil {
+		t.Fatalf("tier 1 within limits: %v", err)
+	}
+	err = CheckLimit(db, user, models.Deposit, 6000)
+	if !errors.As(err, &limitErr) || limitErr.Tier != models.KycTierBVN {
+		t.Fatalf("tier 1 over single limit: err = %v, want tier 1 LimitError", err)
+	}
+}
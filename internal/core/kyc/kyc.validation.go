@@ -0,0 +1,39 @@
+package kyc
+
+import (
+	"errors"
+	"regexp"
+	"strings"
+)
+
+// Validation errors
+var (
+	ErrInvalidBVN    = errors.New("bvn must be exactly 11 digits")
+	ErrInvalidNIN    = errors.New("nin must be exactly 11 digits")
+	ErrReasonMissing = errors.New("a reason is required to reject a verification")
+)
+
+var elevenDigits = regexp.MustCompile(`^\d{11}$`)
+
+func (r *SubmitBVNRequest) Validate() error {
+	r.BVN = strings.TrimSpace(r.BVN)
+	if !elevenDigits.MatchString(r.BVN) {
+		return ErrInvalidBVN
+	}
+	return nil
+}
+
+func (r *SubmitNINRequest) Validate() error {
+	r.NIN = strings.TrimSpace(r.NIN)
+	if !elevenDigits.MatchString(r.NIN) {
+		return ErrInvalidNIN
+	}
+	return nil
+}
+
+func (r *RejectVerificationRequest) Validate() error {
+	if strings.TrimSpace(r.Reason) == "" {
+		return ErrReasonMissing
+	}
+	return nil
+}
@@ -0,0 +1,31 @@
+package ledger
+
+// @Summary Get ledger balance
+// @Description Retrieve the authenticated user's wallet balance from the internal ledger, optionally as of a point in time
+// @Tags ledger
+// @Accept json
+// @Produce json
+// @Param at query string false "RFC3339 timestamp to compute the balance at (defaults to now)"
+// @Param currency query string false "Wallet currency, NGN or CED (defaults to the user's own)"
+// @Success 200 {object} map[string]interface{} "Ledger balance"
+// @Failure 400 {object} map[string]interface{} "Invalid timestamp or currency"
+// @Failure 401 {object} map[string]interface{} "Unauthorized"
+// @Failure 500 {object} map[string]interface{} "Failed to compute ledger balance"
+// @Router /ledger/balance [get]
+// @Security BearerAuth
+func _() {}
+
+// @Summary Get ledger account balance
+// @Description Retrieve any ledger account's balance as of a point in time. Requires finance:read
+// @Tags admin-ledger
+// @Produce json
+// @Param account query string true "Account code, e.g. USER_WALLET:<userID>:NGN or GATEWAY_CLEARING:NOMBA:NGN"
+// @Param at query string false "RFC3339 timestamp to compute the balance at (defaults to now)"
+// @Success 200 {object} BalanceResponse "Ledger balance"
+// @Failure 400 {object} map[string]interface{} "Missing account or invalid timestamp"
+// @Failure 403 {object} map[string]interface{} "Missing permission"
+// @Failure 404 {object} map[string]interface{} "Ledger account not found"
+// @Failure 500 {object} map[string]interface{} "Failed to compute ledger balance"
+// @Router /admin/ledger/balance [get]
+// @Security BearerAuth
+func _() {}
@@ -0,0 +1,10 @@
+package ledger
+
+import "time"
+
+type BalanceResponse struct {
+	AccountCode string    `json:"account_code"`
+	Currency    string    `json:"currency"`
+	Balance     int64     `json:"balance"`
+	AsOf        time.Time `json:"as_of"`
+}
@@ -0,0 +1,105 @@
+package ledger
+
+import (
+	"errors"
+	"log"
+	"net/http"
+	"time"
+
+	"github.com/dblaq/buzzycash/internal/helpers"
+	"github.com/dblaq/buzzycash/internal/models"
+	"github.com/dblaq/buzzycash/internal/utils"
+	"github.com/gin-gonic/gin"
+	"gorm.io/gorm"
+)
+
+type LedgerHandler struct {
+	db *gorm.DB
+}
+
+func NewLedgerHandler(db *gorm.DB) *LedgerHandler {
+	return &LedgerHandler{
+		db: db,
+	}
+}
+
+// parseAsOf reads the optional "at" query parameter (RFC3339), defaulting to now.
+func parseAsOf(ctx *gin.Context) (time.Time, error) {
+	at := ctx.Query("at")
+	if at == "" {
+		return time.Now(), nil
+	}
+	return time.Parse(time.RFC3339, at)
+}
+
+func (h *LedgerHandler) GetMyBalanceHandler(ctx *gin.Context) {
+	currentUser := ctx.MustGet("currentUser").(models.User)
+
+	asOf, err := parseAsOf(ctx)
+	if err != nil {
+		utils.Error(ctx, http.StatusBadRequest, "at must be an RFC3339 timestamp")
+		return
+	}
+	currency := helpers.UserCurrency(currentUser)
+	if code := ctx.Query("currency"); code != "" {
+		c, ok := models.ParseCurrency(code)
+		if !ok {
+			utils.Error(ctx, http.StatusBadRequest, "Unsupported currency")
+			return
+		}
+		currency = c
+	}
+
+	balance, err := UserBalanceAt(h.db, currentUser.ID, currency, asOf)
+	if err != nil {
+		log.Printf("[Ledger] Failed to compute balance for user %s: %v", currentUser.ID, err)
+		utils.Error(ctx, http.StatusInternalServerError, "Failed to compute ledger balance")
+		return
+	}
+
+	ctx.JSON(http.StatusOK, gin.H{
+		"message": "Ledger balance retrieved successfully",
+		"data": BalanceResponse{
+			AccountCode: UserWalletCode(currentUser.ID, currency),
+			Currency:    string(currency),
+			Balance:     balance,
+			AsOf:        asOf,
+		},
+	})
+}
+
+// GetAccountBalanceHandler returns any ledger account's balance as of a
+// point in time, for finance staff reconciling against provider statements.
+func (h *LedgerHandler) GetAccountBalanceHandler(ctx *gin.Context) {
+	code := ctx.Query("account")
+	if code == "" {
+		utils.Error(ctx, http.StatusBadRequest, "account is required")
+		return
+	}
+	asOf, err := parseAsOf(ctx)
+	if err != nil {
+		utils.Error(ctx, http.StatusBadRequest, "at must be an RFC3339 timestamp")
+		return
+	}
+
+	account, balance, err := AccountBalanceAt(h.db, code, asOf)
+	if err != nil {
+		if errors.Is(err, ErrAccountNotFound) {
+			utils.Error(ctx, http.StatusNotFound, err.Error())
+			return
+		}
+		log.Printf("[Ledger] Failed to compute balance for account %s: %v", code, err)
+		utils.Error(ctx, http.StatusInternalServerError, "Failed to compute ledger balance")
+		return
+	}
+
+	ctx.JSON(http.StatusOK, gin.H{
+		"message": "Ledger balance retrieved successfully",
+		"data": BalanceResponse{
+			AccountCode: account.Code,
+			Currency:    string(account.Currency),
+			Balance:     balance,
+			AsOf:        asOf,
+		},
+	})
+}
@@ -0,0 +1,21 @@
+package ledger
+
+import (
+	"github.com/dblaq/buzzycash/internal/middlewares"
+	"github.com/dblaq/buzzycash/internal/models"
+	"github.com/gin-gonic/gin"
+	"gorm.io/gorm"
+)
+
+func LedgerRoutes(rg *gin.RouterGroup, db *gorm.DB) {
+	ledgerHandler := NewLedgerHandler(db)
+	ledgerRoutes := rg.Group("/ledger")
+	{
+		ledgerRoutes.GET("/balance", middlewares.AuthMiddleware, ledgerHandler.GetMyBalanceHandler)
+	}
+
+	adminRoutes := rg.Group("/admin/ledger", middlewares.AdminAuthMiddleware, middlewares.RequirePermission(models.PermFinanceRead))
+	{
+		adminRoutes.GET("/balance", ledgerHandler.GetAccountBalanceHandler)
+	}
+}
@@ -0,0 +1,356 @@
+package ledger
+
+import (
+	"errors"
+	"fmt"
+	"log"
+	"strings"
+	"time"
+
+	"github.com/dblaq/buzzycash/internal/models"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+var (
+	ErrUnbalancedEntry = errors.New("journal entry debits and credits do not balance")
+	ErrEmptyEntry      = errors.New("journal entry must have at least two postings")
+	ErrInvalidAmount   = errors.New("posting amount must be greater than zero")
+	ErrAccountNotFound = errors.New("ledger account not found")
+)
+
+// Line is a single leg of a journal entry, addressed by account code.
+type Line struct {
+	AccountCode string
+	Direction   models.PostingDirection
+	Amount      int64
+}
+
+type Entry struct {
+	Reference     string
+	Description   string
+	TransactionID *string
+	Currency      models.ECurrency
+	PostedAt      time.Time
+	Lines         []Line
+}
+
+// Account codes. User wallets are liabilities (we owe the user), gateway
+// clearing accounts are assets (money held at the provider).
+func UserWalletCode(userID string, currency models.ECurrency) string {
+	return fmt.Sprintf("USER_WALLET:%s:%s", userID, currency)
+}
+
+func GatewayClearingCode(method models.EPaymentMethod, currency models.ECurrency) string {
+	return fmt.Sprintf("GATEWAY_CLEARING:%s:%s", strings.ToUpper(string(method)), currency)
+}
+
+func TicketRevenueCode(currency models.ECurrency) string {
+	return fmt.Sprintf("TICKET_REVENUE:%s", currency)
+}
+
+func PrizeExpenseCode(currency models.ECurrency) string {
+	return fmt.Sprintf("PRIZE_EXPENSE:%s", currency)
+}
+
+// EnsureAccount returns the account for code, creating it if necessary.
+func EnsureAccount(tx *gorm.DB, code, name string, accType models.LedgerAccountType, userID *string, currency models.ECurrency) (*models.LedgerAccount, error) {
+	account := models.LedgerAccount{
+		Code:     code,
+		Name:     name,
+		Type:     accType,
+		UserID:   userID,
+		Currency: currency,
+	}
+	if err := tx.Clauses(clause.OnConflict{
+		Columns:   []clause.Column{{Name: "code"}},
+		DoNothing: true,
+	}).Create(&account).Error; err != nil {
+		return nil, fmt.Errorf("create ledger account %s failed: %w", code, err)
+	}
+	if err := tx.Where("code = ?", code).First(&account).Error; err != nil {
+		return nil, fmt.Errorf("load ledger account %s failed: %w", code, err)
+	}
+	return &account, nil
+}
+
+func ensureUserWallet(tx *gorm.DB, userID string, currency models.ECurrency) error {
+	uid := userID
+	_, err := EnsureAccount(tx, UserWalletCode(userID, currency), "User wallet", models.LiabilityAccount, &uid, currency)
+	return err
+}
+
+func ensureSystemAccount(tx *gorm.DB, code, name string, accType models.LedgerAccountType, currency models.ECurrency) error {
+	_, err := EnsureAccount(tx, code, name, accType, nil, currency)
+	return err
+}
+
+// Post writes a balanced journal entry. Posting the same reference twice is
+// a no-op so callers can safely retry inside webhook handlers.
+func Post(tx *gorm.DB, e Entry) error {
+	if len(e.Lines) < 2 {
+		return ErrEmptyEntry
+	}
+
+	var debits, credits int64
+	for _, l := range e.Lines {
+		if l.Amount <= 0 {
+			return ErrInvalidAmount
+		}
+		switch l.Direction {
+		case models.DebitPosting:
+			debits += l.Amount
+		case models.CreditPosting:
+			credits += l.Amount
+		default:
+			return fmt.Errorf("invalid posting direction %q", l.Direction)
+		}
+	}
+	if debits != credits {
+		return ErrUnbalancedEntry
+	}
+
+	var existing int64
+	if err := tx.Model(&models.JournalEntry{}).Where("reference = ?", e.Reference).Count(&existing).Error; err != nil {
+		return fmt.Errorf("check journal entry failed: %w", err)
+	}
+	if existing > 0 {
+		log.Printf("[Ledger] Entry %s already posted; skipping", e.Reference)
+		return nil
+	}
+
+	postedAt := e.PostedAt
+	if postedAt.IsZero() {
+		postedAt = time.Now()
+	}
+
+	entry := models.JournalEntry{
+		TransactionID: e.TransactionID,
+		Reference:     e.Reference,
+		Description:   e.Description,
+		PostedAt:      postedAt,
+	}
+	if err := tx.Create(&entry).Error; err != nil {
+		return fmt.Errorf("create journal entry failed: %w", err)
+	}
+
+	for _, l := range e.Lines {
+		var account models.LedgerAccount
+		if err := tx.Where("code = ?", l.AccountCode).First(&account).Error; err != nil {
+			return fmt.Errorf("load ledger account %s failed: %w", l.AccountCode, err)
+		}
+		posting := models.Posting{
+			JournalEntryID: entry.ID,
+			AccountID:      account.ID,
+			Direction:      l.Direction,
+			Amount:         l.Amount,
+			Currency:       e.Currency,
+			PostedAt:       postedAt,
+		}
+		if err := tx.Create(&posting).Error; err != nil {
+			return fmt.Errorf("create posting failed: %w", err)
+		}
+	}
+
+	log.Printf("[Ledger] Posted entry %s (%d lines, %d %s)", e.Reference, len(e.Lines), debits, e.Currency)
+	return nil
+}
+
+// PostDeposit records money received at a gateway and owed to the user.
+func PostDeposit(tx *gorm.DB, history models.Transaction, amount int64) error {
+	currency := currencyOf(history)
+	clearing := GatewayClearingCode(history.PaymentMethod, currency)
+	if err := ensureSystemAccount(tx, clearing, "Gateway clearing", models.AssetAccount, currency); err != nil {
+		return err
+	}
+	if err := ensureUserWallet(tx, history.UserID, currency); err != nil {
+		return err
+	}
+	return Post(tx, Entry{
+		Reference:     "DEPOSIT:" + history.ID,
+		Description:   "Wallet deposit " + history.Reference,
+		TransactionID: &history.ID,
+		Currency:      currency,
+		Lines: []Line{
+			{AccountCode: clearing, Direction: models.DebitPosting, Amount: amount},
+			{AccountCode: UserWalletCode(history.UserID, currency), Direction: models.CreditPosting, Amount: amount},
+		},
+	})
+}
+
+// PostTicketPurchase moves the ticket price from the user's wallet to revenue.
+func PostTicketPurchase(tx *gorm.DB, history models.Transaction) error {
+	currency := currencyOf(history)
+	revenue := TicketRevenueCode(currency)
+	if err := ensureSystemAccount(tx, revenue, "Ticket revenue", models.RevenueAccount, currency); err != nil {
+		return err
+	}
+	if err := ensureUserWallet(tx, history.UserID, currency); err != nil {
+		return err
+	}
+	return Post(tx, Entry{
+		Reference:     "TICKET:" + history.ID,
+		Description:   "Ticket purchase " + history.TransactionReference,
+		TransactionID: &history.ID,
+		Currency:      currency,
+		Lines: []Line{
+			{AccountCode: UserWalletCode(history.UserID, currency), Direction: models.DebitPosting, Amount: history.Amount},
+			{AccountCode: revenue, Direction: models.CreditPosting, Amount: history.Amount},
+		},
+	})
+}
+
+// PostPrizeCredit records prize money owed to a winner.
+func PostPrizeCredit(tx *gorm.DB, history models.Transaction) error {
+	currency := currencyOf(history)
+	expense := PrizeExpenseCode(currency)
+	if err := ensureSystemAccount(tx, expense, "Prize expense", models.ExpenseAccount, currency); err != nil {
+		return err
+	}
+	if err := ensureUserWallet(tx, history.UserID, currency); err != nil {
+		return err
+	}
+	return Post(tx, Entry{
+		Reference:     "PRIZE:" + history.ID,
+		Description:   "Prize credit " + history.TransactionReference,
+		TransactionID: &history.ID,
+		Currency:      currency,
+		Lines: []Line{
+			{AccountCode: expense, Direction: models.DebitPosting, Amount: history.Amount},
+			{AccountCode: UserWalletCode(history.UserID, currency), Direction: models.CreditPosting, Amount: history.Amount},
+		},
+	})
+}
+
+// PostWithdrawal records money leaving the user's wallet for a bank payout.
+func PostWithdrawal(tx *gorm.DB, history models.Transaction) error {
+	currency := currencyOf(history)
+	clearing := GatewayClearingCode(history.PaymentMethod, currency)
+	if err := ensureSystemAccount(tx, clearing, "Gateway clearing", models.AssetAccount, currency); err != nil {
+		return err
+	}
+	if err := ensureUserWallet(tx, history.UserID, currency); err != nil {
+		return err
+	}
+	return Post(tx, Entry{
+		Reference:     "WITHDRAWAL:" + history.ID,
+		Description:   "Withdrawal " + history.Reference,
+		TransactionID: &history.ID,
+		Currency:      currency,
+		Lines: []Line{
+			{AccountCode: UserWalletCode(history.UserID, currency), Direction: models.DebitPosting, Amount: history.Amount},
+			{AccountCode: clearing, Direction: models.CreditPosting, Amount: history.Amount},
+		},
+	})
+}
+
+// PostWithdrawalReversal returns a failed or refunded withdrawal to the
+// user's wallet.
+func PostWithdrawalReversal(tx *gorm.DB, history models.Transaction) error {
+	return reverseEntry(tx, "WITHDRAWAL:"+history.ID, "WITHDRAWAL_REVERSAL:"+history.ID,
+		"Withdrawal reversal "+history.Reference)
+}
+
+// PostDepositReversal takes back a deposit the gateway reversed or refunded.
+func PostDepositReversal(tx *gorm.DB, history models.Transaction) error {
+	return reverseEntry(tx, "DEPOSIT:"+history.ID, "DEPOSIT_REVERSAL:"+history.ID,
+		"Deposit reversal "+history.Reference)
+}
+
+// reverseEntry posts the mirror image of an existing entry. It is a no-op
+// when the original was never posted.
+func reverseEntry(tx *gorm.DB, originalRef, reference, description string) error {
+	var original models.JournalEntry
+	if err := tx.Preload("Postings.Account").Where("reference = ?", originalRef).First(&original).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			log.Printf("[Ledger] No entry %s; nothing to reverse", originalRef)
+			return nil
+		}
+		return fmt.Errorf("load entry %s failed: %w", originalRef, err)
+	}
+
+	lines := make([]Line, 0, len(original.Postings))
+	var currency models.ECurrency
+	for _, p := range original.Postings {
+		direction := models.DebitPosting
+		if p.Direction == models.DebitPosting {
+			direction = models.CreditPosting
+		}
+		lines = append(lines, Line{AccountCode: p.Account.Code, Direction: direction, Amount: p.Amount})
+		currency = p.Currency
+	}
+
+	return Post(tx, Entry{
+		Reference:     reference,
+		Description:   description,
+		TransactionID: original.TransactionID,
+		Currency:      currency,
+		Lines:         lines,
+	})
+}
+
+// BalanceAt returns the balance of an account as of the given time, signed
+// according to the account's normal side.
+func BalanceAt(db *gorm.DB, code string, at time.Time) (int64, error) {
+	var account models.LedgerAccount
+	if err := db.Where("code = ?", code).First(&account).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return 0, nil
+		}
+		return 0, fmt.Errorf("load ledger account %s failed: %w", code, err)
+	}
+	return accountBalanceAt(db, account, at)
+}
+
+// AccountBalanceAt is BalanceAt for an account that must exist; it returns
+// ErrAccountNotFound rather than a zero balance for an unknown code.
+func AccountBalanceAt(db *gorm.DB, code string, at time.Time) (*models.LedgerAccount, int64, error) {
+	var account models.LedgerAccount
+	if err := db.Where("code = ?", code).First(&account).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, 0, ErrAccountNotFound
+		}
+		return nil, 0, fmt.Errorf("load ledger account %s failed: %w", code, err)
+	}
+	balance, err := accountBalanceAt(db, account, at)
+	if err != nil {
+		return nil, 0, err
+	}
+	return &account, balance, nil
+}
+
+// UserBalanceAt returns the user's wallet balance in the given currency.
+func UserBalanceAt(db *gorm.DB, userID string, currency models.ECurrency, at time.Time) (int64, error) {
+	return BalanceAt(db, UserWalletCode(userID, currency), at)
+}
+
+func accountBalanceAt(db *gorm.DB, account models.LedgerAccount, at time.Time) (int64, error) {
+	var sums struct {
+		Debits  int64
+		Credits int64
+	}
+	if err := db.Model(&models.Posting{}).
+		Select(
+			"COALESCE(SUM(CASE WHEN direction = ? THEN amount ELSE 0 END), 0) AS debits, "+
+				"COALESCE(SUM(CASE WHEN direction = ? THEN amount ELSE 0 END), 0) AS credits",
+			models.DebitPosting, models.CreditPosting,
+		).
+		Where("account_id = ? AND posted_at <= ?", account.ID, at).
+		Scan(&sums).Error; err != nil {
+		return 0, fmt.Errorf("sum postings failed: %w", err)
+	}
+
+	switch account.Type {
+	case models.AssetAccount, models.ExpenseAccount:
+		return sums.Debits - sums.Credits, nil
+	default:
+		return sums.Credits - sums.Debits, nil
+	}
+}
+
+func currencyOf(history models.Transaction) models.ECurrency {
+	if history.Currency == "" {
+		return models.NGN
+	}
+	return history.Currency
+}
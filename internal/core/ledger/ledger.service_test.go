@@ -0,0 +1,96 @@
+package ledger
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/dblaq/buzzycash/internal/models"
+	"github.com/dblaq/buzzycash/internal/testutil"
+	"github.com/google/uuid"
+)
+
+func TestPostRejectsInvalidEntries(t *testing.T) {
+	debit := func(amount int64) Line {
+		return Line{AccountCode: "A", Direction: models.DebitPosting, Amount: amount}
+	}
+	credit := func(amount int64) Line {
+		return Line{AccountCode: "B", Direction: models.CreditPosting, Amount: amount}
+	}
+
+	tests := []struct {
+		name  string
+		lines []Line
+		want  error
+	}{
+		{"no lines", nil, ErrEmptyEntry},
+		{"one line", []Line{debit(100)}, ErrEmptyEntry},
+		{"debits exceed credits", []Line{debit(100), credit(90)}, ErrUnbalancedEntry},
+		{"credits exceed debits", []Line{debit(100), credit(60), credit(60)}, ErrUnbalancedEntry},
+		{"one-sided", []Line{debit(50), debit(50)}, ErrUnbalancedEntry},
+		{"zero amount", []Line{debit(0), credit(0)}, ErrInvalidAmount},
+		{"negative amount", []Line{debit(-100), credit(-100)}, ErrInvalidAmount},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// Validation happens before the database is touched
+			err := Post(nil, Entry{Reference: "TEST", Currency: models.NGN, Lines: tt.lines})
+			if !errors.Is(err, tt.want) {
+				t.Fatalf("Post = %v, want %v", err, tt.want)
+			}
+		})
+	}
+
+	bad := []Line{debit(100), {AccountCode: "B", Direction: "SIDEWAYS", Amount: 100}}
+	if err := Post(nil, Entry{Reference: "TEST", Lines: bad}); err == nil {
+		t.Fatal("Post accepted an unknown posting direction")
+	}
+}
+
+func TestPostIsIdempotentByReference(t *testing.T) {
+	db := testutil.DB(t)
+	userID := uuid.NewString()
+	wallet := UserWalletCode(userID, models.NGN)
+	clearing := GatewayClearingCode("TEST", models.NGN)
+	if err := ensureUserWallet(db, userID, models.NGN); err != nil {
+		t.Fatalf("create wallet account: %v", err)
+	}
+	if err := ensureSystemAccount(db, clearing, "Gateway clearing", models.AssetAccount, models.NGN); err != nil {
+		t.Fatalf("create clearing account: %v", err)
+	}
+
+	entry := Entry{
+		Reference: "TEST:" + uuid.NewString(),
+		Currency:  models.NGN,
+		Lines: []Line{
+			{AccountCode: clearing, Direction: models.DebitPosting, Amount: 2500},
+			{AccountCode: wallet, Direction: models.CreditPosting, Amount: 2500},
+		},
+	}
+	for i := 0; i < 3; i++ {
+		if err := Post(db, entry); err != nil {
+			t.Fatalf("Post #%d: %v", i+1, err)
+		}
+	}
+
+	var entries int64
+	db.Model(&models.JournalEntry{}).Where("reference = ?", entry.Reference).Count(&entries)
+	if entries != 1 {
+		t.Fatalf("journal entries for %s = %d, want 1", entry.Reference, entries)
+	}
+
+	balance, err := BalanceAt(db, wallet, time.Now())
+	if err != nil {
+		t.Fatalf("BalanceAt: %v", err)
+	}
+	if balance != 2500 {
+		t.Errorf("wallet balance = %d, want 2500", balance)
+	}
+	_, clearingBalance, err := AccountBalanceAt(db, clearing, time.Now())
+	if err != nil {
+		t.Fatalf("AccountBalanceAt: %v", err)
+	}
+	if clearingBalance != 2500 {
+		t.Errorf("clearing balance = %d, want 2500", clearingBalance)
+	}
+}
@@ -0,0 +1,124 @@
+package outbox
+
+import (
+	"errors"
+	"log"
+	"net/http"
+	"strconv"
+	"strings"
+
+	"github.com/dblaq/buzzycash/internal/core/audit"
+	"github.com/dblaq/buzzycash/internal/models"
+	"github.com/dblaq/buzzycash/internal/utils"
+	"github.com/gin-gonic/gin"
+	"gorm.io/gorm"
+)
+
+type OutboxAdminHandler struct {
+	db *gorm.DB
+}
+
+func NewOutboxAdminHandler(db *gorm.DB) *OutboxAdminHandler {
+	return &OutboxAdminHandler{
+		db: db,
+	}
+}
+
+// ListOutboxHandler lists wallet credits and debits, oldest first. Without a
+// status filter it lists the ones waiting on an admin: NEEDS_REVIEW and
+// DEAD_LETTERED.
+func (h *OutboxAdminHandler) ListOutboxHandler(ctx *gin.Context) {
+	page := 1
+	if p, err := strconv.Atoi(ctx.Query("page")); err == nil && p > 0 {
+		page = p
+	}
+	limit := 20
+	offset := (page - 1) * limit
+
+	q := h.db.Model(&models.WalletCreditOutbox{})
+	if status := ctx.Query("status"); status != "" {
+		q = q.Where("status = ?", strings.ToUpper(status))
+	} else {
+		q = q.Where("status IN ?", []models.OutboxStatus{models.OutboxNeedsReview, models.OutboxDeadLettered})
+	}
+
+	var totalCount int64
+	if err := q.Count(&totalCount).Error; err != nil {
+		utils.Error(ctx, http.StatusInternalServerError, "Failed to fetch outbox entries")
+		return
+	}
+
+	var msgs []models.WalletCreditOutbox
+	if err := q.Order("created_at asc").Limit(limit).Offset(offset).Find(&msgs).Error; err != nil {
+		utils.Error(ctx, http.StatusInternalServerError, "Failed to fetch outbox entries")
+		return
+	}
+
+	response := make([]OutboxEntryResponse, 0, len(msgs))
+	for _, m := range msgs {
+		response = append(response, toOutboxEntryResponse(m))
+	}
+
+	ctx.JSON(http.StatusOK, gin.H{
+		"entries":     response,
+		"page":        page,
+		"has_more":    int64(offset+limit) < totalCount,
+		"total_count": totalCount,
+	})
+}
+
+// ResolveOutboxHandler settles a parked credit once an admin has checked the
+// gaming wallet: retry it, mark it dispatched, or cancel it.
+func (h *OutboxAdminHandler) ResolveOutboxHandler(ctx *gin.Context) {
+	var req ResolveOutboxRequest
+	if err := ctx.ShouldBindJSON(&req); err != nil {
+		utils.Error(ctx, http.StatusBadRequest, utils.ValidationErrorToJSON(err))
+		return
+	}
+
+	id := ctx.Param("id")
+	resolution := Resolution(strings.ToUpper(req.Resolution))
+	before, err := Resolve(h.db, id, resolution)
+	if err != nil {
+		switch {
+		case errors.Is(err, ErrInvalidResolution):
+			utils.Error(ctx, http.StatusBadRequest, err.Error())
+		case errors.Is(err, gorm.ErrRecordNotFound):
+			utils.Error(ctx, http.StatusNotFound, "Outbox entry not found")
+		case errors.Is(err, ErrNotResolvable):
+			utils.Error(ctx, http.StatusConflict, err.Error())
+		default:
+			log.Printf("[Outbox Admin] Failed to resolve %s: %v", id, err)
+			utils.Error(ctx, http.StatusInternalServerError, "Failed to resolve outbox entry")
+		}
+		return
+	}
+
+	admin := ctx.MustGet("currentAdmin").(models.Admin)
+	log.Printf("[Outbox Admin] %s key=%s resolved as %s by admin %s", before.Direction, before.IdempotencyKey, resolution, admin.ID)
+	audit.Log(ctx, h.db, audit.OutboxResolved, audit.Target{Type: audit.TargetOutbox, ID: id},
+		gin.H{"status": before.Status, "lastError": before.LastError},
+		gin.H{"resolution": resolution, "note": req.Note})
+
+	ctx.JSON(http.StatusOK, gin.H{
+		"message": "Outbox entry resolved",
+	})
+}
+
+func toOutboxEntryResponse(m models.WalletCreditOutbox) OutboxEntryResponse {
+	return OutboxEntryResponse{
+		ID:             m.ID,
+		IdempotencyKey: m.IdempotencyKey,
+		Direction:      string(m.Direction),
+		TransactionID:  m.TransactionID,
+		UserID:         m.UserID,
+		Username:       m.Username,
+		Amount:         m.Amount,
+		Status:         string(m.Status),
+		Attempts:       m.Attempts,
+		LastError:      m.LastError,
+		DispatchedAt:   m.DispatchedAt,
+		CreatedAt:      m.CreatedAt,
+		UpdatedAt:      m.UpdatedAt,
+	}
+}
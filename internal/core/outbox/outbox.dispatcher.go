@@ -0,0 +1,191 @@
+package outbox
+
+import (
+	"encoding/json"
+	"errors"
+	"log"
+	"time"
+
+	"github.com/dblaq/buzzycash/external/gaming"
+	"github.com/dblaq/buzzycash/internal/config"
+	"github.com/dblaq/buzzycash/internal/models"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+const (
+	dispatchBatchSize = 20
+	baseBackoff       = 15 * time.Second
+	maxBackoff        = 30 * time.Minute
+	staleClaimAge     = 5 * time.Minute
+)
+
+type Dispatcher struct {
+	db          *gorm.DB
+	interval    time.Duration
+	maxAttempts int
+}
+
+// StartDispatcher launches the background loop that performs queued
+// wallet credits.
+func StartDispatcher(db *gorm.DB) *Dispatcher {
+	d := &Dispatcher{
+		db:          db,
+		interval:    time.Duration(config.AppConfig.OutboxDispatchIntervalSeconds) * time.Second,
+		maxAttempts: config.AppConfig.OutboxMaxAttempts,
+	}
+	if d.interval <= 0 {
+		d.interval = 5 * time.Second
+	}
+	if d.maxAttempts <= 0 {
+		d.maxAttempts = 1
+	}
+
+	go d.startLoop()
+	return d
+}
+
+func (d *Dispatcher) startLoop() {
+	ticker := time.NewTicker(d.interval)
+	defer ticker.Stop()
+
+	for range ticker.C {
+		d.runOnce()
+	}
+}
+
+func (d *Dispatcher) runOnce() {
+	msgs, err := d.claim()
+	if err != nil {
+		log.Printf("[Outbox] Could not claim wallet credits: %v", err)
+		return
+	}
+	for _, msg := range msgs {
+		d.dispatch(msg)
+	}
+}
+
+// claim moves a batch of due credits to PROCESSING.
+func (d *Dispatcher) claim() ([]models.WalletCreditOutbox, error) {
+	var msgs []models.WalletCreditOutbox
+	now := time.Now()
+
+	err := d.db.Transaction(func(tx *gorm.DB) error {
+		if err := quarantineStale(tx, now); err != nil {
+			return err
+		}
+		if err := tx.Clauses(clause.Locking{Strength: "UPDATE", Options: "SKIP LOCKED"}).
+			Where("status IN ? AND next_attempt_at <= ?", []models.OutboxStatus{models.OutboxPending, models.OutboxFailed}, now).
+			Order("created_at ASC").
+			Limit(dispatchBatchSize).
+			Find(&msgs).Error; err != nil {
+			return err
+		}
+		if len(msgs) == 0 {
+			return nil
+		}
+
+		ids := make([]string, len(msgs))
+		for i, m := range msgs {
+			ids[i] = m.ID
+		}
+		return tx.Model(&models.WalletCreditOutbox{}).
+			Where("id IN ?", ids).
+			Updates(map[string]interface{}{
+				"status":     models.OutboxProcessing,
+				"updated_at": now,
+			}).Error
+	})
+	return msgs, err
+}
+
+// quarantineStale moves credits left in PROCESSING by a crashed instance to
+// NEEDS_REVIEW. The credit may or may not have reached the wallet, and the
+// provider has no lookup by idempotency key to find out, so retrying it
+// would rely on the provider deduplicating a key it does not promise to.
+func quarantineStale(tx *gorm.DB, now time.Time) error {
+	result := tx.Model(&models.WalletCreditOutbox{}).
+		Where("status = ? AND updated_at <= ?", models.OutboxProcessing, now.Add(-staleClaimAge)).
+		Updates(map[string]interface{}{
+			"status":     models.OutboxNeedsReview,
+			"last_error": "dispatch interrupted; check the gaming wallet before retrying",
+			"updated_at": now,
+		})
+	if result.Error != nil {
+		return result.Error
+	}
+	if result.RowsAffected > 0 {
+		log.Printf("[Outbox] WARNING: %d interrupted credit(s) moved to %s for manual review", result.RowsAffected, models.OutboxNeedsReview)
+	}
+	return nil
+}
+
+func (d *Dispatcher) dispatch(msg models.WalletCreditOutbox) {
+	attempts := msg.Attempts + 1
+	raw, err := send(msg)
+
+	updates := map[string]interface{}{"attempts": attempts}
+	switch {
+	case err == nil:
+		now := time.Now()
+		updates["status"] = models.OutboxDispatched
+		updates["dispatched_at"] = &now
+		updates["provider_response"] = raw
+		updates["last_error"] = ""
+		log.Printf("[Outbox] %s of %.2f for %s dispatched (key=%s)", msg.Direction, msg.Amount, msg.Username, msg.IdempotencyKey)
+	case !retryable(err):
+		log.Printf("[Outbox] WARNING: %s key=%s has an unknown outcome; moved to %s: %v", msg.Direction, msg.IdempotencyKey, models.OutboxNeedsReview, err)
+		updates["status"] = models.OutboxNeedsReview
+		updates["last_error"] = err.Error()
+	case attempts >= d.maxAttempts:
+		log.Printf("[Outbox] Dead-lettering credit key=%s after %d attempts: %v", msg.IdempotencyKey, attempts, err)
+		updates["status"] = models.OutboxDeadLettered
+		updates["last_error"] = err.Error()
+	default:
+		log.Printf("[Outbox] Credit key=%s failed (attempt %d): %v", msg.IdempotencyKey, attempts, err)
+		updates["status"] = models.OutboxFailed
+		updates["last_error"] = err.Error()
+		updates["next_attempt_at"] = time.Now().Add(backoff(attempts))
+	}
+
+	if updErr := d.db.Model(&models.WalletCreditOutbox{}).Where("id = ?", msg.ID).Updates(updates).Error; updErr != nil {
+		log.Printf("[Outbox] WARNING: could not update credit key=%s: %v", msg.IdempotencyKey, updErr)
+	}
+}
+
+// retryable reports whether err means the wallet operation certainly did not
+// happen. Anything else, such as a timeout or a 5xx, may have been applied,
+// and retrying it could credit or debit the wallet twice.
+func retryable(err error) bool {
+	return errors.Is(err, gaming.ErrRejected) || errors.Is(err, gaming.ErrNotSent)
+}
+
+// send performs the wallet operation and returns the provider's response.
+func send(msg models.WalletCreditOutbox) (string, error) {
+	gs := gaming.GMInstance()
+	if msg.Direction == models.OutboxDebit {
+		resp, err := gs.DebitUserWalletIdempotent(msg.Username, msg.Amount, msg.IdempotencyKey)
+		if err != nil {
+			return "", err
+		}
+		raw, _ := json.Marshal(resp)
+		return string(raw), nil
+	}
+	resp, err := gs.CreditUserWalletIdempotent(msg.Username, msg.Amount, msg.IdempotencyKey)
+	if err != nil {
+		return "", err
+	}
+	return resp.Raw, nil
+}
+
+// backoff doubles the delay for every attempt, capped at maxBackoff.
+func backoff(attempts int) time.Duration {
+	delay := baseBackoff
+	for i := 1; i < attempts; i++ {
+		delay *= 2
+		if delay >= maxBackoff {
+			return maxBackoff
+		}
+	}
+	return delay
+}
@@ -0,0 +1,105 @@
+package outbox
+
+import (
+	"errors"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/dblaq/buzzycash/external/gaming"
+	"github.com/dblaq/buzzycash/internal/models"
+	"github.com/dblaq/buzzycash/internal/testutil"
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+func credit(t *testing.T, db *gorm.DB, status models.OutboxStatus, updatedAt time.Time) models.WalletCreditOutbox {
+	t.Helper()
+	msg := models.WalletCreditOutbox{
+		IdempotencyKey: "test:" + uuid.NewString(),
+		TransactionID:  uuid.NewString(),
+		UserID:         uuid.NewString(),
+		Username:       "+2348000000000",
+		Amount:         500,
+		Status:         status,
+		NextAttemptAt:  updatedAt,
+		UpdatedAt:      updatedAt,
+	}
+	if err := db.Create(&msg).Error; err != nil {
+		t.Fatalf("create credit: %v", err)
+	}
+	return msg
+}
+
+func TestStaleProcessingCreditIsNotRetried(t *testing.T) {
+	db := testutil.DB(t)
+	now := time.Now()
+	stale := credit(t, db, models.OutboxProcessing, now.Add(-2*staleClaimAge))
+	live := credit(t, db, models.OutboxProcessing, now)
+
+	if err := quarantineStale(db, now); err != nil {
+		t.Fatalf("quarantineStale: %v", err)
+	}
+
+	for _, tt := range []struct {
+		msg  models.WalletCreditOutbox
+		want models.OutboxStatus
+	}{
+		{stale, models.OutboxNeedsReview},
+		{live, models.OutboxProcessing},
+	} {
+		var got models.WalletCreditOutbox
+		if err := db.First(&got, "id = ?", tt.msg.ID).Error; err != nil {
+			t.Fatalf("reload credit: %v", err)
+		}
+		if got.Status != tt.want {
+			t.Errorf("credit %s status = %s, want %s", got.IdempotencyKey, got.Status, tt.want)
+		}
+	}
+
+	if _, err := CancelCredit(db, stale.IdempotencyKey); !errors.Is(err, ErrCreditNeedsReview) {
+		t.Errorf("CancelCredit on a credit under review = %v, want ErrCreditNeedsReview", err)
+	}
+}
+
+func TestOnlyUnsentOrRejectedCreditsAreRetried(t *testing.T) {
+	for _, tt := range []struct {
+		err  error
+		want bool
+	}{
+		{fmt.Errorf("%w: gaming API error 400", gaming.ErrRejected), true},
+		{fmt.Errorf("%w: token expired", gaming.ErrNotSent), true},
+		{errors.New("gaming API error 502"), false},
+		{errors.New("failed to get wallet response: context deadline exceeded"), false},
+	} {
+		if got := retryable(tt.err); got != tt.want {
+			t.Errorf("retryable(%v) = %v, want %v", tt.err, got, tt.want)
+		}
+	}
+}
+
+func TestResolveParkedCredit(t *testing.T) {
+	db := testutil.DB(t)
+	now := time.Now()
+
+	parked := credit(t, db, models.OutboxNeedsReview, now)
+	if _, err := Resolve(db, parked.ID, ResolveRetry); err != nil {
+		t.Fatalf("Resolve: %v", err)
+	}
+	var got models.WalletCreditOutbox
+	if err := db.First(&got, "id = ?", parked.ID).Error; err != nil {
+		t.Fatalf("reload credit: %v", err)
+	}
+	if got.Status != models.OutboxPending || got.Attempts != 0 {
+		t.Errorf("retried credit = %s after %d attempts, want %s after 0", got.Status, got.Attempts, models.OutboxPending)
+	}
+
+	if _, err := Resolve(db, parked.ID, ResolveCancelled); !errors.Is(err, ErrNotResolvable) {
+		t.Errorf("Resolve on a queued credit = %v, want ErrNotResolvable", err)
+	}
+
+	dead := credit(t, db, models.OutboxDeadLettered, now)
+	if _, err := Resolve(db, dead.ID, "IGNORE"); !errors.Is(err, ErrInvalidResolution) {
+		t.Errorf("Resolve with an unknown resolution = %v, want ErrInvalidResolution", err)
+	}
+}
@@ -0,0 +1,34 @@
+package outbox
+
+// @Summary List outbox entries
+// @Description List queued gaming wallet credits and debits, oldest first. Without a status filter, lists those awaiting an admin (NEEDS_REVIEW, DEAD_LETTERED). Requires outbox:manage
+// @Tags admin-outbox
+// @Accept json
+// @Produce json
+// @Param status query string false "Filter by status (PENDING, PROCESSING, DISPATCHED, FAILED, DEAD_LETTERED, CANCELLED, NEEDS_REVIEW)"
+// @Param page query int false "Page number (defaults to 1)"
+// @Success 200 {object} map[string]interface{} "Outbox entries"
+// @Failure 401 {object} map[string]interface{} "Unauthorized"
+// @Failure 403 {object} map[string]interface{} "Missing permission"
+// @Failure 500 {object} map[string]interface{} "Failed to fetch outbox entries"
+// @Router /admin/outbox [get]
+// @Security BearerAuth
+func _() {}
+
+// @Summary Resolve outbox entry
+// @Description Settle a NEEDS_REVIEW or DEAD_LETTERED entry after checking the gaming wallet: RETRY queues it again, DISPATCHED records that it landed, CANCELLED drops it. Requires outbox:manage
+// @Tags admin-outbox
+// @Accept json
+// @Produce json
+// @Param id path string true "Outbox entry ID"
+// @Param request body ResolveOutboxRequest true "Resolution"
+// @Success 200 {object} map[string]interface{} "Outbox entry resolved"
+// @Failure 400 {object} map[string]interface{} "Invalid resolution"
+// @Failure 401 {object} map[string]interface{} "Unauthorized"
+// @Failure 403 {object} map[string]interface{} "Missing permission"
+// @Failure 404 {object} map[string]interface{} "Outbox entry not found"
+// @Failure 409 {object} map[string]interface{} "Only credits awaiting review or dead-lettered can be resolved"
+// @Failure 500 {object} map[string]interface{} "Failed to resolve outbox entry"
+// @Router /admin/outbox/{id}/resolve [post]
+// @Security BearerAuth
+func _() {}
@@ -0,0 +1,26 @@
+package outbox
+
+import "time"
+
+// ResolveOutboxRequest records an admin's verdict on a parked credit. The
+// note says how the wallet was checked and is kept in the audit log.
+type ResolveOutboxRequest struct {
+	Resolution string `json:"resolution" binding:"required"`
+	Note       string `json:"note" binding:"required,max=500"`
+}
+
+type OutboxEntryResponse struct {
+	ID             string     `json:"id"`
+	IdempotencyKey string     `json:"idempotencyKey"`
+	Direction      string     `json:"direction"`
+	TransactionID  string     `json:"transactionId"`
+	UserID         string     `json:"userId"`
+	Username       string     `json:"username"`
+	Amount         float64    `json:"amount"`
+	Status         string     `json:"status"`
+	Attempts       int        `json:"attempts"`
+	LastError      string     `json:"lastError,omitempty"`
+	DispatchedAt   *time.Time `json:"dispatchedAt,omitempty"`
+	CreatedAt      time.Time  `json:"createdAt"`
+	UpdatedAt      time.Time  `json:"updatedAt"`
+}
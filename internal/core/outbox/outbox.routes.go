@@ -0,0 +1,17 @@
+package outbox
+
+import (
+	"github.com/dblaq/buzzycash/internal/middlewares"
+	"github.com/dblaq/buzzycash/internal/models"
+	"github.com/gin-gonic/gin"
+	"gorm.io/gorm"
+)
+
+func OutboxRoutes(rg *gin.RouterGroup, db *gorm.DB) {
+	outboxAdminHandler := NewOutboxAdminHandler(db)
+	adminRoutes := rg.Group("/admin/outbox", middlewares.AdminAuthMiddleware, middlewares.RequirePermission(models.PermOutboxManage))
+	{
+		adminRoutes.GET("", outboxAdminHandler.ListOutboxHandler)
+		adminRoutes.POST("/:id/resolve", outboxAdminHandler.ResolveOutboxHandler)
+	}
+}
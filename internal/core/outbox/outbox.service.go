@@ -0,0 +1,155 @@
+package outbox
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/dblaq/buzzycash/internal/models"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+var (
+	ErrCreditInFlight = errors.New("wallet credit is being dispatched")
+	// ErrCreditNeedsReview means a credit's dispatch was interrupted and
+	// nobody knows yet whether it reached the wallet.
+	ErrCreditNeedsReview = errors.New("wallet credit outcome is unknown and needs manual review")
+	ErrNotResolvable     = errors.New("only credits awaiting review or dead-lettered can be resolved")
+	ErrInvalidResolution = errors.New("resolution must be RETRY, DISPATCHED or CANCELLED")
+)
+
+// Resolution is an admin's verdict on a credit parked for review, reached by
+// checking the gaming wallet.
+type Resolution string
+
+const (
+	// ResolveRetry queues the credit again; the wallet was not touched.
+	ResolveRetry Resolution = "RETRY"
+	// ResolveDispatched records that the credit did reach the wallet.
+	ResolveDispatched Resolution = "DISPATCHED"
+	// ResolveCancelled drops the credit without performing it.
+	ResolveCancelled Resolution = "CANCELLED"
+)
+
+// DepositCreditPrefix starts the key of every credit that pays out a deposit.
+const DepositCreditPrefix = "deposit:"
+
+// DepositCreditKey is the idempotency key for the credit that pays out a
+// deposit. It is derived from the transaction so it never changes on retry.
+func DepositCreditKey(history models.Transaction) string {
+	return DepositCreditPrefix + history.ID
+}
+
+// ReversalCreditKey is the idempotency key for the credit that refunds a
+// reversed withdrawal.
+func ReversalCreditKey(reference string) string {
+	return "REV-" + reference
+}
+
+// DepositReversalDebitKey is the idempotency key for the debit that takes
+// back a deposit reversed after it reached the wallet.
+func DepositReversalDebitKey(history models.Transaction) string {
+	return "reversal:" + history.ID
+}
+
+// EnqueueCredit records a gaming wallet credit. Call it with the tx that
+// settles the transaction so the credit exists if and only if that commits.
+// Enqueuing the same key twice is a no-op.
+func EnqueueCredit(tx *gorm.DB, key string, history models.Transaction, username string, amount float64) error {
+	return enqueue(tx, models.OutboxCredit, key, history, username, amount)
+}
+
+// EnqueueDebit records a gaming wallet debit the same way EnqueueCredit
+// records a credit.
+func EnqueueDebit(tx *gorm.DB, key string, history models.Transaction, username string, amount float64) error {
+	return enqueue(tx, models.OutboxDebit, key, history, username, amount)
+}
+
+func enqueue(tx *gorm.DB, direction models.OutboxDirection, key string, history models.Transaction, username string, amount float64) error {
+	msg := models.WalletCreditOutbox{
+		IdempotencyKey: key,
+		Direction:      direction,
+		TransactionID:  history.ID,
+		UserID:         history.UserID,
+		Username:       username,
+		Amount:         amount,
+		Status:         models.OutboxPending,
+		NextAttemptAt:  time.Now(),
+	}
+	if err := tx.Clauses(clause.OnConflict{DoNothing: true}).Create(&msg).Error; err != nil {
+		return fmt.Errorf("enqueue wallet %s failed: %w", strings.ToLower(string(direction)), err)
+	}
+	return nil
+}
+
+// CancelCredit stops a credit that has not been dispatched yet. It reports
+// whether the credit was cancelled; false means it already reached the
+// wallet. A credit that is mid-dispatch returns ErrCreditInFlight so the
+// caller can retry once its outcome is known; one awaiting manual review
+// returns ErrCreditNeedsReview.
+func CancelCredit(tx *gorm.DB, key string) (bool, error) {
+	var msg models.WalletCreditOutbox
+	if err := tx.Clauses(clause.Locking{Strength: "UPDATE"}).
+		Where("idempotency_key = ?", key).
+		First(&msg).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return false, nil
+		}
+		return false, fmt.Errorf("load wallet credit failed: %w", err)
+	}
+
+	switch msg.Status {
+	case models.OutboxDispatched:
+		return false, nil
+	case models.OutboxCancelled:
+		return true, nil
+	case models.OutboxProcessing:
+		return false, ErrCreditInFlight
+	case models.OutboxNeedsReview:
+		return false, ErrCreditNeedsReview
+	}
+
+	if err := tx.Model(&msg).Update("status", models.OutboxCancelled).Error; err != nil {
+		return false, fmt.Errorf("cancel wallet credit failed: %w", err)
+	}
+	return true, nil
+}
+
+// Resolve settles a NEEDS_REVIEW or DEAD_LETTERED credit by hand and returns
+// it as it was before. The row is locked so the dispatcher cannot pick up a
+// retried credit before the resolution commits.
+func Resolve(db *gorm.DB, id string, resolution Resolution) (*models.WalletCreditOutbox, error) {
+	now := time.Now()
+	updates := map[string]interface{}{"updated_at": now}
+	switch resolution {
+	case ResolveRetry:
+		updates["status"] = models.OutboxPending
+		updates["attempts"] = 0
+		updates["next_attempt_at"] = now
+		updates["last_error"] = ""
+	case ResolveDispatched:
+		updates["status"] = models.OutboxDispatched
+		updates["dispatched_at"] = &now
+	case ResolveCancelled:
+		updates["status"] = models.OutboxCancelled
+	default:
+		return nil, ErrInvalidResolution
+	}
+
+	var msg models.WalletCreditOutbox
+	err := db.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Clauses(clause.Locking{Strength: "UPDATE"}).First(&msg, "id = ?", id).Error; err != nil {
+			return err
+		}
+		if msg.Status != models.OutboxNeedsReview && msg.Status != models.OutboxDeadLettered {
+			return ErrNotResolvable
+		}
+		return tx.Model(&models.WalletCreditOutbox{}).Where("id = ?", msg.ID).Updates(updates).Error
+	})
+	if err != nil {
+		return nil, err
+	}
+	return &msg, nil
+}
@@ -0,0 +1,123 @@
+package payments
+
+import (
+	"errors"
+	"fmt"
+	"log"
+	"strings"
+	"time"
+
+	"github.com/dblaq/buzzycash/external/gateway"
+	"github.com/dblaq/buzzycash/internal/config"
+	"github.com/dblaq/buzzycash/internal/models"
+	"gorm.io/gorm"
+)
+
+const depositRequeryBatchSize = 50
+
+// DepositRequeryJob settles deposits whose webhook never arrived by asking
+// the gateway for the real outcome. Deposits the customer never paid are
+// failed once they pass the abandon cutoff; a payment that shows up after
+// that still settles them.
+type DepositRequeryJob struct {
+	db             *gorm.DB
+	paymentService *PaymentService
+	interval       time.Duration
+	requeryAfter   time.Duration
+	abandonAfter   time.Duration
+}
+
+func StartDepositRequeryJob(db *gorm.DB) *DepositRequeryJob {
+	j := &DepositRequeryJob{
+		db:             db,
+		paymentService: NewPaymentService(db),
+		interval:       time.Duration(config.AppConfig.DepositRequeryIntervalSeconds) * time.Second,
+		requeryAfter:   time.Duration(config.AppConfig.DepositRequeryAfterMinutes) * time.Minute,
+		abandonAfter:   time.Duration(config.AppConfig.DepositAbandonAfterMinutes) * time.Minute,
+	}
+	if j.interval <= 0 {
+		j.interval = 5 * time.Minute
+	}
+
+	go j.startLoop()
+	return j
+}
+
+func (j *DepositRequeryJob) startLoop() {
+	ticker := time.NewTicker(j.interval)
+	defer ticker.Stop()
+
+	for range ticker.C {
+		j.runOnce()
+	}
+}
+
+func (j *DepositRequeryJob) runOnce() {
+	// Deposits checked least recently go first, so ones the gateway cannot
+	// answer for do not hold up the rest
+	var deposits []models.Transaction
+	if err := j.db.
+		Where("category = ? AND payment_status = ? AND created_at <= ?", models.Deposit, models.Pending, time.Now().Add(-j.requeryAfter)).
+		Order("last_checked_at ASC NULLS FIRST").
+		Order("created_at ASC").
+		Limit(depositRequeryBatchSize).
+		Find(&deposits).Error; err != nil {
+		log.Printf("[Deposit Requery] Could not load pending deposits: %v", err)
+		return
+	}
+
+	for _, history := range deposits {
+		if err := j.db.Model(&history).UpdateColumn("last_checked_at", time.Now()).Error; err != nil {
+			log.Printf("[Deposit Requery] ref=%s: could not record check: %v", history.Reference, err)
+			continue
+		}
+		if err := j.requery(history); err != nil {
+			log.Printf("[Deposit Requery] ref=%s: %v", history.Reference, err)
+		}
+	}
+}
+
+// requery settles a deposit from the gateway's answer. A deposit still
+// unpaid at the abandon cutoff is failed, including one the gateway could
+// not be asked about; a FAILED deposit still settles if its success turns
+// up later. A verified payment whose amount does not match is never failed
+// and waits for review.
+func (j *DepositRequeryJob) requery(history models.Transaction) error {
+	source := strings.ToUpper(string(history.PaymentMethod))
+	result, err := j.verify(history)
+	if err == nil {
+		switch result.Status {
+		case gateway.PaymentSucceeded:
+			if result.Amount < float64(history.Amount) || (result.Currency != "" && !strings.EqualFold(result.Currency, history.Currency.ISOCode())) {
+				return fmt.Errorf("verified payment %.2f %s does not match deposit %d %s; leaving for review",
+					result.Amount, result.Currency, history.Amount, history.Currency)
+			}
+			return j.paymentService.HandleSuccessfulDeposit(history.Reference, result.CreditAmount, source)
+		case gateway.PaymentFailed:
+			return j.paymentService.handleFailedDeposit(history.Reference, source, "requery: "+result.Reason)
+		}
+	}
+
+	if j.abandonAfter > 0 && time.Since(history.CreatedAt) >= j.abandonAfter {
+		reason := fmt.Sprintf("abandoned: no payment after %s", j.abandonAfter)
+		if err != nil {
+			reason += "; last requery failed: " + err.Error()
+		}
+		return j.paymentService.handleFailedDeposit(history.Reference, source, reason)
+	}
+	return err
+}
+
+// verify asks the deposit's gateway for its outcome. A reference the
+// gateway has never seen is still pending.
+func (j *DepositRequeryJob) verify(history models.Transaction) (*gateway.VerifyResult, error) {
+	provider, err := gateway.GetProvider(string(history.PaymentMethod))
+	if err != nil {
+		return nil, err
+	}
+	result, err := provider.Verify(history.Reference)
+	if errors.Is(err, gateway.ErrTransactionNotFound) {
+		return &gateway.VerifyResult{Status: gateway.PaymentPending}, nil
+	}
+	return result, err
+}
@@ -1,72 +1,121 @@
 package payments
 
 import (
-	"crypto/subtle"
-	"encoding/json"
 	"io"
 	"log"
 	"net/http"
 	"strings"
-"gorm.io/gorm"
-	"github.com/dblaq/buzzycash/internal/config"
+
+	"github.com/dblaq/buzzycash/external/gateway"
+	"github.com/dblaq/buzzycash/internal/models"
 	"github.com/dblaq/buzzycash/internal/utils"
 	"github.com/gin-gonic/gin"
+	"gorm.io/gorm"
 )
 
-type NombaWebhookWrapper struct {
-	EventType string `json:"eventType,omitempty"` // present in payment webhooks
-	Code      string `json:"code,omitempty"`      // present in withdrawal response
-	Status    string `json:"status,omitempty"`    // for withdrawal
-}
-
-
+// WebhookHandler verifies deliveries and stores them in the inbox; the
+// webhook worker does the actual processing.
 type WebhookHandler struct {
-	paymentService *PaymentService
+	db *gorm.DB
 }
 
 func NewWebhookHandler(db *gorm.DB) *WebhookHandler {
 	return &WebhookHandler{
-		paymentService: NewPaymentService(db),
+		db: db,
 	}
 }
 
 // FlutterwaveWebhookHandler handles incoming FW webhooks
-func (w *WebhookHandler)FlutterwaveWebhookHandler(ctx *gin.Context) {
-	secret := config.AppConfig.FlutterwaveHashKey
-	sent := ctx.GetHeader("verif-hash")
+func (w *WebhookHandler) FlutterwaveWebhookHandler(ctx *gin.Context) {
+	w.receive(ctx, "flutterwave")
+}
+
+func (w *WebhookHandler) NombaWebhookHandler(ctx *gin.Context) {
+	w.receive(ctx, "nomba")
+}
+
+// ProviderWebhookHandler accepts webhooks for any registered provider.
+func (w *WebhookHandler) ProviderWebhookHandler(ctx *gin.Context) {
+	w.receive(ctx, ctx.Param("provider"))
+}
+
+// receive authenticates a delivery with its provider and stores it in the
+// inbox. A 200 is only returned once the event is safely stored, so the
+// provider retries anything we failed to keep.
+func (w *WebhookHandler) receive(ctx *gin.Context, name string) {
+	provider, err := gateway.GetProvider(name)
+	if err != nil {
+		utils.Error(ctx, http.StatusNotFound, "unknown provider")
+		return
+	}
+	providerName := models.WebhookProvider(strings.ToUpper(provider.Name()))
+
+	body, err := io.ReadAll(ctx.Request.Body)
+	if err != nil {
+		utils.Error(ctx, http.StatusBadRequest, "failed to read body")
+		return
+	}
 
-	if secret == "" || subtle.ConstantTimeCompare([]byte(secret), []byte(sent)) != 1 {
-		log.Printf("[FW Webhook] Invalid signature. expected=%s got=%s", secret, sent)
+	if err := provider.VerifyWebhook(ctx.Request.Header, body); err != nil {
+		log.Printf("[%s Webhook] Rejected delivery from %s: %v", providerName, ctx.ClientIP(), err)
+		recordRejection(w.db, ctx, providerName, err.Error(), body)
 		utils.Error(ctx, http.StatusUnauthorized, "invalid signature")
 		return
 	}
 
-	// Read raw body
-	body, _ := io.ReadAll(ctx.Request.Body)
-	log.Printf("[FW Webhook] Raw body: %s", string(body))
+	evt, err := provider.ParseWebhook(body)
+	if err != nil {
+		// The delivery is authentic, so keep it for replay once the parser
+		// can read it
+		log.Printf("[%s Webhook] Unparseable payload: %v", providerName, err)
+		if storeErr := storeDeadWebhookEvent(w.db, providerName, payloadHash(body), body, err); storeErr != nil {
+			log.Printf("[%s Webhook] Could not store unparseable event: %v", providerName, storeErr)
+			utils.Error(ctx, http.StatusInternalServerError, "failed to store event")
+			return
+		}
+		// Stored; a retry from the provider would only be dead-lettered again
+		ctx.Status(http.StatusOK)
+		return
+	}
 
-	// Parse
-	var evt FlutterwaveWebhook
-	if err := json.Unmarshal(body, &evt); err != nil {
-		log.Printf("[FW Webhook] JSON unmarshal error: %v", err)
-		utils.Error(ctx, http.StatusBadRequest, "bad payload")
+	eventID := evt.ID
+	if eventID == "" {
+		eventID = payloadHash(body)
+	}
+	if err := storeWebhookEvent(w.db, providerName, eventID, evt.Type, body); err != nil {
+		log.Printf("[%s Webhook] Could not store event %s: %v", providerName, eventID, err)
+		utils.Error(ctx, http.StatusInternalServerError, "failed to store event")
 		return
 	}
-	log.Printf("[FW Webhook] Parsed event: %+v", evt)
 
-	// Normalize checks
-	event := strings.ToUpper(evt.EventType)
-	status := strings.ToLower(evt.Status)
+	ctx.Status(http.StatusOK)
+}
 
-	
-	if (event == "CHARGE.COMPLETED" || event == "BANK_TRANSFER_TRANSACTION") && status == "successful" {
-		if err := w.paymentService.HandleSuccessfulPaymentFW(evt); err != nil {
-			log.Printf("[FW Webhook] Processing error for ref=%s: %v", evt.TxRef, err)
+// recordRejection stores a refused delivery. Failures are only logged so a
+// storage problem never turns a rejection into an acceptance.
+func recordRejection(db *gorm.DB, ctx *gin.Context, provider models.WebhookProvider, reason string, body []byte) {
+	var signature, timestamp string
+	for key, values := range ctx.Request.Header {
+		k := strings.ToLower(key)
+		switch {
+		case strings.Contains(k, "signature") || strings.Contains(k, "hash"):
+			signature = strings.Join(values, ",")
+		case strings.Contains(k, "timestamp"):
+			timestamp = strings.Join(values, ",")
 		}
-	} else {
-		log.Printf("[FW Webhook] Ignored event=%s status=%s", evt.EventType, evt.Status)
 	}
-	ctx.Status(http.StatusOK)
+
+	rejection := models.WebhookRejection{
+		Provider:  provider,
+		Reason:    reason,
+		Signature: signature,
+		Timestamp: timestamp,
+		RemoteIP:  ctx.ClientIP(),
+		Body:      string(body),
+	}
+	if err := db.Create(&rejection).Error; err != nil {
+		log.Printf("[%s Webhook] WARNING: could not record rejected delivery: %v", provider, err)
+	}
 }
 
 
@@ -121,52 +170,3 @@ func (w *WebhookHandler)FlutterwaveWebhookHandler(ctx *gin.Context) {
 // 	}
 // 	ctx.Status(http.StatusOK)
 // }
-
-
-
-func (w *WebhookHandler) NombaWebhookHandler(ctx *gin.Context) {
-	body, _ := io.ReadAll(ctx.Request.Body)
-	log.Printf("[NB Webhook] Raw body: %s", string(body))
-
-	// Step 1: Peek into the payload
-	var wrapper NombaWebhookWrapper
-	if err := json.Unmarshal(body, &wrapper); err != nil {
-		log.Printf("[NB Webhook] JSON peek unmarshal error: %v", err)
-		utils.Error(ctx, http.StatusBadRequest, "bad payload")
-		return
-	}
-
-	// Step 2: Branch logic
-	switch {
-	// ----------- Deposit flow ------------
-	case strings.ToLower(wrapper.EventType) == "payment_success":
-		var evt NombaWebhook
-		if err := json.Unmarshal(body, &evt); err != nil {
-			log.Printf("[NB Webhook] Deposit unmarshal error: %v", err)
-			utils.Error(ctx, http.StatusBadRequest, "bad deposit payload")
-			return
-		}
-
-		if err := w.paymentService.handleNBSuccessfulPayment(evt, "nomba"); err != nil {
-			log.Printf("[NB Webhook] Deposit processing error for ref=%s: %v", evt.Data.Order.OrderID, err)
-		}
-
-	// ----------- Withdrawal flow ----------
-	case strings.ToUpper(wrapper.Status) == "SUCCESS":
-		var evt NombaWithdrawalResponse
-		if err := json.Unmarshal(body, &evt); err != nil {
-			log.Printf("[NB Webhook] Withdrawal unmarshal error: %v", err)
-			utils.Error(ctx, http.StatusBadRequest, "bad withdrawal payload")
-			return
-		}
-
-		if err := w.paymentService.handleNBSuccessfulWithdrawal(evt); err != nil {
-			log.Printf("[NB Webhook] Withdrawal processing error for ref=%s: %v", evt.Data.Meta.MerchantTxRef, err)
-		}
-
-	default:
-		log.Printf("[NB Webhook] Ignored eventType=%s status=%s", wrapper.EventType, wrapper.Status)
-	}
-
-	ctx.Status(http.StatusOK)
-}
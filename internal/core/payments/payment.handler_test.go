@@ -0,0 +1,59 @@
+package payments
+
+import (
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/dblaq/buzzycash/external/gateway"
+	"github.com/dblaq/buzzycash/internal/models"
+	"github.com/dblaq/buzzycash/internal/testutil"
+	"github.com/gin-gonic/gin"
+)
+
+// unparseableProvider accepts every delivery and can read none of them.
+type unparseableProvider struct {
+	gateway.PaymentProvider
+}
+
+func (unparseableProvider) Name() string { return "unparseable" }
+
+func (unparseableProvider) VerifyWebhook(http.Header, []byte) error { return nil }
+
+func (unparseableProvider) ParseWebhook([]byte) (*gateway.WebhookEvent, error) {
+	return nil, errors.New("unexpected end of JSON input")
+}
+
+func TestUnparseableWebhookIsDeadLettered(t *testing.T) {
+	db := testutil.DB(t)
+	gateway.Register(unparseableProvider{})
+	t.Cleanup(func() { gateway.Unregister(unparseableProvider{}.Name()) })
+	gin.SetMode(gin.TestMode)
+
+	router := gin.New()
+	router.POST("/webhooks/:provider", NewWebhookHandler(db).ProviderWebhookHandler)
+
+	body := `{"event_type": "payment_success", "data": {`
+	rec := httptest.NewRecorder()
+	router.ServeHTTP(rec, httptest.NewRequest(http.MethodPost, "/webhooks/unparseable", strings.NewReader(body)))
+
+	if rec.Code != http.StatusOK {
+		t.Fatalf("status = %d, want 200 once the delivery is stored", rec.Code)
+	}
+
+	var event models.WebhookEvent
+	if err := db.First(&event, "provider = ? AND event_id = ?", "UNPARSEABLE", payloadHash([]byte(body))).Error; err != nil {
+		t.Fatalf("unparseable delivery not stored: %v", err)
+	}
+	if event.Status != models.WebhookDeadLettered {
+		t.Errorf("status = %s, want %s", event.Status, models.WebhookDeadLettered)
+	}
+	if event.Payload != body {
+		t.Errorf("payload = %q, want the delivery as received", event.Payload)
+	}
+	if !strings.Contains(event.LastError, "unexpected end of JSON input") {
+		t.Errorf("last error = %q, want the parse error", event.LastError)
+	}
+}
@@ -1,6 +1,8 @@
 package payments
 
 import (
+	"github.com/dblaq/buzzycash/internal/middlewares"
+	"github.com/dblaq/buzzycash/internal/models"
 	"github.com/gin-gonic/gin"
 	"gorm.io/gorm"
 )
@@ -11,5 +13,15 @@ func PaymentRoutes(rg *gin.RouterGroup,db *gorm.DB) {
 	{
 		paymentRoutes.POST("/wave", webhookHandler.FlutterwaveWebhookHandler)
 		paymentRoutes.POST("/nomba", webhookHandler.NombaWebhookHandler)
+		paymentRoutes.POST("/:provider", webhookHandler.ProviderWebhookHandler)
+	}
+
+	// Admin tokens come from /admin/auth/login; the inbox needs webhooks:manage
+	webhookAdminHandler := NewWebhookAdminHandler(db)
+	adminRoutes := rg.Group("/admin/webhooks", middlewares.AdminAuthMiddleware, middlewares.RequirePermission(models.PermWebhooksManage))
+	{
+		adminRoutes.GET("", webhookAdminHandler.ListWebhookEventsHandler)
+		adminRoutes.GET("/:id", webhookAdminHandler.GetWebhookEventHandler)
+		adminRoutes.POST("/:id/replay", webhookAdminHandler.ReplayWebhookEventHandler)
 	}
 }
@@ -5,9 +5,14 @@ import (
 	"time"
 	"errors"
 	"fmt"
-    "strings"
+	"strings"
+
+	"github.com/dblaq/buzzycash/external/gateway"
+	"github.com/dblaq/buzzycash/internal/core/audit"
+	"github.com/dblaq/buzzycash/internal/core/ledger"
+	"github.com/dblaq/buzzycash/internal/core/outbox"
+	"github.com/dblaq/buzzycash/internal/helpers"
 	"github.com/dblaq/buzzycash/internal/models"
-	"github.com/dblaq/buzzycash/external/gaming"
 	"gorm.io/gorm"
 	"gorm.io/gorm/clause"
 )
@@ -25,25 +30,18 @@ func NewPaymentService(db *gorm.DB) *PaymentService {
 }
 
 
-func (p *PaymentService) HandleSuccessfulPaymentFW(evt FlutterwaveWebhook) error {
-	return p.handleFWSuccessfulPayment(evt, "FW")
-}
-
-
-func (p *PaymentService) HandleSuccessfulPaymentNB(evt NombaWebhook) error {
-	return p.handleNBSuccessfulPayment(evt, "NB")
-}
-
-
-func (p *PaymentService) handleFWSuccessfulPayment(evt FlutterwaveWebhook, provider string) error {
-	reference := evt.TxRef
-	amount := evt.Amount
+// HandleSuccessfulDeposit settles a PENDING deposit and queues the wallet
+// credit of amount, which is net of any provider fee. Settling the same
+// reference twice is a no-op. A FAILED deposit was never credited, so a
+// success that arrives after it was given up on still settles it; one the
+// provider reversed, or that was credited and taken back, stays that way.
+func (p *PaymentService) HandleSuccessfulDeposit(reference string, amount float64, provider string) error {
 	db := p.db
 
 	var history models.Transaction
-	log.Printf("[%s Webhook] Processing payment - Reference: %s, Amount: %v (type: %T)", provider, reference, amount, amount)
+	log.Printf("[%s Webhook] Processing payment - Reference: %s, Amount: %v", provider, reference, amount)
 
-	// First: update history + credit wallet atomically
+	// First: update history + queue the wallet credit atomically
 	if err := db.Transaction(func(tx *gorm.DB) error {
 		// 1) Lock + load the history row by reference (FOR UPDATE) and preload user
 		if err := tx.Preload("User").
@@ -58,27 +56,48 @@ func (p *PaymentService) handleFWSuccessfulPayment(evt FlutterwaveWebhook, provi
 			return fmt.Errorf("load history failed: %w", err)
 		}
 
-		// 2) Idempotency check
-		if history.PaymentStatus == models.Successful {
+		// 2) Idempotency check: PENDING and FAILED deposits settle. A late
+		// or replayed success for one the provider reversed must not bring
+		// it back, since the money went back to the payer.
+		switch history.PaymentStatus {
+		case models.Pending:
+		case models.Failed:
+			if reversedByProvider(history) {
+				return ignoreSuccess(tx, provider, &history, amount)
+			}
+			log.Printf("[%s Webhook] Late success for failed ref=%s; settling", provider, reference)
+		case models.Successful:
 			log.Printf("[%s Webhook] Reference=%s already processed; skipping", provider, reference)
+			history = models.Transaction{}
 			return nil
+		default:
+			return ignoreSuccess(tx, provider, &history, amount)
 		}
 
 		// 3) Update status
 		if err := tx.Model(&history).Updates(map[string]interface{}{
 			"payment_status": models.Successful,
 			"paid_at":        time.Now(),
+			"metadata":       withCreditedAmount(history.Metadata, amount),
 		}).Error; err != nil {
 			return fmt.Errorf("update history failed: %w", err)
 		}
 
-		// 4) Credit wallet
-		gs := gaming.GMInstance()
-		if _, err := gs.CreditUserWallet(history.User.PhoneNumber, amount); err != nil {
-			return fmt.Errorf("wallet credit failed: %w", err)
+		// 4) Record the deposit in the internal ledger
+		if err := ledger.PostDeposit(tx, history, int64(amount)); err != nil {
+			return fmt.Errorf("ledger posting failed: %w", err)
 		}
 
-		return nil
+		// 5) Queue the wallet credit; the outbox dispatcher performs it
+		if err := outbox.EnqueueCredit(tx, outbox.DepositCreditKey(history), history, history.User.PhoneNumber, amount); err != nil {
+			return err
+		}
+
+		// 6) Audit the settlement with the change it records
+		after := audit.TransactionState(history)
+		after["payment_status"] = models.Successful
+		after["credited_amount"] = amount
+		return recordSettlement(tx, provider, audit.DepositSettled, history, after)
 	}); err != nil {
 		return err
 	}
@@ -98,34 +117,109 @@ func (p *PaymentService) handleFWSuccessfulPayment(evt FlutterwaveWebhook, provi
 		}
 
 		if err := db.Create(&notif).Error; err != nil {
-			// don't rollback payment, just log
 			log.Printf("[%s Webhook] WARNING: could not create notification for ref=%s: %v", provider, reference, err)
 		} else {
-			log.Printf("[%s Webhook] SUCCESS ref=%s | wallet credited & notification created", provider, reference)
+			log.Printf("[%s Webhook] SUCCESS ref=%s | wallet credit queued & notification created", provider, reference)
 		}
 	}
 
 	return nil
 }
 
-func (p *PaymentService) handleNBSuccessfulPayment(evt NombaWebhook, provider string) error {
-	reference := evt.Data.Order.OrderID
-	event_type := evt.EventType
-	amount := evt.Data.Order.Amount - evt.Data.Transaction.Fee
-	db := p.db 
 
-	var history models.Transaction
-	log.Printf("[%s Webhook] Processing payment - Reference: %s, Amount: %v (type: %T)", provider, reference, amount, amount)
-	
-	
-	if strings.ToLower(event_type) != "payment_success" {
-			log.Printf("[%s Webhook] deposit status not successful (status=%s). Skipping update.", provider, event_type)
+// HandleVirtualAccountDeposit credits a bank transfer into a user's dedicated
+// account. account is the account number, or the reference the account was
+// opened with; reference identifies the transfer. The deposit is recorded on
+// first sight and then settled like any other, so redeliveries are no-ops.
+func (p *PaymentService) HandleVirtualAccountDeposit(account, reference string, amount float64, provider string) error {
+	db := p.db
+	log.Printf("[%s Webhook] Processing virtual account transfer - Account: %s, Reference: %s, Amount: %v", provider, account, reference, amount)
+
+	if reference == "" {
+		return fmt.Errorf("transfer into account %s has no reference", account)
+	}
+
+	var va models.VirtualAccount
+	if err := db.Preload("User").
+		Where("account_number = ? OR reference = ?", account, account).
+		First(&va).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			log.Printf("[%s Webhook] No virtual account for account=%s", provider, account)
 			return nil
 		}
+		return fmt.Errorf("load virtual account failed: %w", err)
+	}
 
-	// First: update history + credit wallet atomically
+	// A redelivery racing the first delivery finds the row already there, or
+	// loses the insert to the unique (reference, category) index and skips.
 	if err := db.Transaction(func(tx *gorm.DB) error {
-		// 1) Lock + load the history row by reference (FOR UPDATE) and preload user
+		var history models.Transaction
+		err := tx.Where("reference = ? AND category = ?", reference, models.Deposit).First(&history).Error
+		if !errors.Is(err, gorm.ErrRecordNotFound) {
+			return err
+		}
+		history = models.Transaction{
+			Amount:               int64(amount),
+			CustomerEmail:        va.User.Email,
+			UserID:               va.UserID,
+			PaymentStatus:        models.Pending,
+			PaymentMethod:        va.Provider,
+			TransactionReference: helpers.GenerateTransactionReference(),
+			Reference:            reference,
+			TransactionType:      models.Credit,
+			Category:             models.Deposit,
+			PaymentType:          models.Topup,
+			Currency:             va.Currency,
+			Metadata: models.JSONB{
+				"virtualAccountId": va.ID,
+				"accountNumber":    va.AccountNumber,
+			},
+		}
+		return tx.Clauses(clause.OnConflict{
+			Columns:     []clause.Column{{Name: "reference"}, {Name: "category"}},
+			TargetWhere: clause.Where{Exprs: []clause.Expression{clause.Expr{SQL: "reference <> ''"}}},
+			DoNothing:   true,
+		}).Create(&history).Error
+	}); err != nil {
+		return fmt.Errorf("record transfer failed: %w", err)
+	}
+
+	return p.HandleSuccessfulDeposit(reference, amount, provider)
+}
+
+// saveCard stores the card tokenized during a deposit against the user who
+// made it. Seeing the same card again only refreshes its details.
+func (p *PaymentService) saveCard(reference, provider string, card gateway.CardToken) error {
+	var history models.Transaction
+	if err := p.db.Select("user_id").Where("reference = ? AND category = ?", reference, models.Deposit).
+		First(&history).Error; err != nil {
+		return fmt.Errorf("find deposit %s failed: %w", reference, err)
+	}
+
+	saved := models.SavedCard{
+		UserID:      history.UserID,
+		Provider:    models.EPaymentMethod(strings.ToUpper(provider)),
+		Token:       card.Token,
+		MaskedPan:   card.MaskedPan,
+		Brand:       card.Brand,
+		ExpiryMonth: card.ExpiryMonth,
+		ExpiryYear:  card.ExpiryYear,
+	}
+	return p.db.Clauses(clause.OnConflict{
+		Columns:   []clause.Column{{Name: "user_id"}, {Name: "provider"}, {Name: "token"}},
+		DoUpdates: clause.AssignmentColumns([]string{"masked_pan", "brand", "expiry_month", "expiry_year", "updated_at"}),
+	}).Create(&saved).Error
+}
+
+func (p *PaymentService) handleSuccessfulWithdrawal(reference, provider string) error {
+	db := p.db
+
+	var history models.Transaction
+	log.Printf("[%s Webhook] Processing withdrawal - Reference: %s", provider, reference)
+
+	// Update history atomically
+	if err := db.Transaction(func(tx *gorm.DB) error {
+		// 1) Lock + load the history row
 		if err := tx.Preload("User").
 			Clauses(clause.Locking{Strength: "UPDATE"}).
 			Where("reference = ?", reference).
@@ -138,9 +232,10 @@ func (p *PaymentService) handleNBSuccessfulPayment(evt NombaWebhook, provider st
 			return fmt.Errorf("load history failed: %w", err)
 		}
 
-		// 2) Idempotency check
-		if history.PaymentStatus == models.Successful {
-			log.Printf("[%s Webhook] Reference=%s already processed; skipping", provider, reference)
+		// 2) Idempotency check: only PENDING withdrawals can settle
+		if history.PaymentStatus != models.Pending {
+			log.Printf("[%s Webhook] Reference=%s is %s; skipping", provider, reference, history.PaymentStatus)
+			history = models.Transaction{}
 			return nil
 		}
 
@@ -152,27 +247,22 @@ func (p *PaymentService) handleNBSuccessfulPayment(evt NombaWebhook, provider st
 			return fmt.Errorf("update history failed: %w", err)
 		}
 
-		// 4) Credit wallet
-		gs := gaming.GMInstance()
-		if _, err := gs.CreditUserWallet(history.User.PhoneNumber, amount); err != nil {
-			return fmt.Errorf("wallet credit failed: %w", err)
-		}
-
-		return nil
+		after := audit.TransactionState(history)
+		after["payment_status"] = models.Successful
+		return recordSettlement(tx, provider, audit.WithdrawalSettled, history, after)
 	}); err != nil {
 		return err
 	}
 
-	// Create notification
+	// Create notification outside the transaction
 	if history.ID != "" {
-		amountInt := int64(amount)
-		log.Printf("[%s Webhook] Creating notification - UserID: %s, Amount: %d", provider, history.UserID, amountInt)
+		log.Printf("[%s Webhook] Creating withdrawal notification - UserID: %s, Amount: %d", provider, history.UserID, history.Amount)
 		notif := models.Notification{
 			UserID:   history.UserID,
 			Type:     models.Transactions,
-			Title:    "Deposit Successful",
-			Subtitle: "You have successfully deposited into your wallet.",
-			Amount:   amountInt,
+			Title:    "Withdrawal Successful",
+			Subtitle: "Your withdrawal was processed successfully.",
+			Amount:   history.Amount,
 			Currency: string(history.Currency),
 			Status:   "successful",
 		}
@@ -180,85 +270,205 @@ func (p *PaymentService) handleNBSuccessfulPayment(evt NombaWebhook, provider st
 		if err := db.Create(&notif).Error; err != nil {
 			log.Printf("[%s Webhook] WARNING: could not create notification for ref=%s: %v", provider, reference, err)
 		} else {
-			log.Printf("[%s Webhook] SUCCESS ref=%s | wallet credited & notification created", provider, reference)
+			log.Printf("[%s Webhook] SUCCESS ref=%s | withdrawal processed & notification created", provider, reference)
 		}
 	}
 
 	return nil
 }
 
+// handleFailedDeposit marks a PENDING deposit as FAILED. Nothing was
+// credited yet, so only the user needs to be told.
+func (p *PaymentService) handleFailedDeposit(reference, provider, reason string) error {
+	return p.compensateDeposit(reference, provider, models.Failed, reason)
+}
 
+// handleReversedDeposit undoes a deposit the gateway reversed or refunded.
+// A deposit that already credited the wallet is debited back and marked
+// REVERSED; one that never settled is marked FAILED and can no longer
+// settle.
+func (p *PaymentService) handleReversedDeposit(reference, provider, reason string) error {
+	return p.compensateDeposit(reference, provider, models.Reversed, reason)
+}
 
-
-func (p *PaymentService) handleNBSuccessfulWithdrawal(evt NombaWithdrawalResponse) error {
-	reference := evt.Data.Meta.MerchantTxRef
-	amount := evt.Data.Amount
-	status := evt.Data.Status
+func (p *PaymentService) compensateDeposit(reference, provider string, target models.EPaymentStatus, reason string) error {
 	db := p.db
-
 	var history models.Transaction
-	log.Printf("[%s Webhook] Processing withdrawal - Reference: %s, Amount: %v, Status: %s", 
-		reference, amount, status)
-	
-	if strings.ToUpper(status) != "SUCCESS" {
-			log.Printf("[%s Webhook] Withdrawal status not successful (status=%s). Skipping update.",status)
-			return nil
-		}
+	log.Printf("[%s Webhook] Compensating deposit - Reference: %s, Target: %s, Reason: %s", provider, reference, target, reason)
 
-	// Update history atomically
 	if err := db.Transaction(func(tx *gorm.DB) error {
-		// 1) Lock + load the history row
 		if err := tx.Preload("User").
 			Clauses(clause.Locking{Strength: "UPDATE"}).
-			Where("reference = ?", reference).
+			Where("reference = ? AND category = ?", reference, models.Deposit).
 			First(&history).Error; err != nil {
 
 			if errors.Is(err, gorm.ErrRecordNotFound) {
-				log.Printf("[%s Webhook] No TransactionHistory for reference=%s", reference)
+				log.Printf("[%s Webhook] No deposit for reference=%s", provider, reference)
 				return nil
 			}
 			return fmt.Errorf("load history failed: %w", err)
 		}
 
-		// 2) Idempotency check
-		if history.PaymentStatus == models.Successful {
-			log.Printf("[%s Webhook] Reference=%s already processed; skipping",reference)
+		// A reversal of a deposit that never settled is remembered, so a
+		// success that arrives after it cannot credit the deposit.
+		reversed := false
+		switch history.PaymentStatus {
+		case models.Pending:
+			// never credited: nothing to take back
+			reversed = target == models.Reversed
+			target = models.Failed
+		case models.Failed:
+			if target != models.Reversed || reversedByProvider(history) {
+				log.Printf("[%s Webhook] Reference=%s already failed; skipping", provider, reference)
+				history = models.Transaction{}
+				return nil
+			}
+			log.Printf("[%s Webhook] Reference=%s reversed after it failed; it can no longer settle", provider, reference)
+			err := tx.Model(&history).Update("metadata", withReversedByProvider(history.Metadata)).Error
+			history = models.Transaction{}
+			return err
+		case models.Successful:
+			if target != models.Reversed {
+				log.Printf("[%s Webhook] Reference=%s already successful; ignoring %s", provider, reference, target)
+				history = models.Transaction{}
+				return nil
+			}
+			if err := ledger.PostDepositReversal(tx, history); err != nil {
+				return fmt.Errorf("ledger reversal failed: %w", err)
+			}
+			// A credit still sitting in the outbox is cancelled rather
+			// than paid out and debited back.
+			cancelled, err := outbox.CancelCredit(tx, outbox.DepositCreditKey(history))
+			if err != nil {
+				return err
+			}
+			// Otherwise the debit is queued with the reversal, so no
+			// wallet call is made while the row is locked.
+			if !cancelled {
+				if err := outbox.EnqueueDebit(tx, outbox.DepositReversalDebitKey(history), history, history.User.PhoneNumber, creditedAmount(history)); err != nil {
+					return err
+				}
+			}
+		default:
+			log.Printf("[%s Webhook] Reference=%s already %s; skipping", provider, reference, history.PaymentStatus)
+			history = models.Transaction{}
 			return nil
 		}
 
-		// 3) Update status
+		meta := models.JSONB{}
+		for k, v := range history.Metadata {
+			meta[k] = v
+		}
+		meta["failureReason"] = reason
+		if reversed {
+			meta = withReversedByProvider(meta)
+		}
+
 		if err := tx.Model(&history).Updates(map[string]interface{}{
-			"payment_status": models.Successful,
-			"paid_at":        time.Now(),
+			"payment_status": target,
+			"metadata":       meta,
 		}).Error; err != nil {
 			return fmt.Errorf("update history failed: %w", err)
 		}
 
+		action := audit.DepositFailed
+		if target == models.Reversed {
+			action = audit.DepositReversed
+		}
+		after := audit.TransactionState(history)
+		after["payment_status"] = target
+		after["reason"] = reason
+		if err := recordSettlement(tx, provider, action, history, after); err != nil {
+			return err
+		}
+		history.PaymentStatus = target
 		return nil
 	}); err != nil {
 		return err
 	}
 
-	// Create notification outside the transaction
-	if history.ID != "" {
-		amountInt := int64(amount)
-		log.Printf("[%s Webhook] Creating withdrawal notification - UserID: %s, Amount: %d", history.UserID, amountInt)
-		notif := models.Notification{
-			UserID:   history.UserID,
-			Type:     models.Transactions,
-			Title:    "Withdrawal Successful",
-			Subtitle: "Your withdrawal was processed successfully.",
-			Amount:   amountInt,
-			Currency: string(history.Currency),
-			Status:   "successful",
-		}
+	if history.ID == "" {
+		return nil
+	}
 
-		if err := db.Create(&notif).Error; err != nil {
-			log.Printf("[%s Webhook] WARNING: could not create notification for ref=%s: %v", reference, err)
-		} else {
-			log.Printf("[%s Webhook] SUCCESS ref=%s | withdrawal processed & notification created",reference)
-		}
+	title, subtitle, status := "Deposit Failed", "Your deposit could not be completed.", "failed"
+	if history.PaymentStatus == models.Reversed {
+		title, subtitle, status = "Deposit Reversed", "Your deposit was reversed by the bank and removed from your wallet.", "reversed"
+	}
+	notif := models.Notification{
+		UserID:   history.UserID,
+		Type:     models.Transactions,
+		Title:    title,
+		Subtitle: subtitle,
+		Amount:   history.Amount,
+		Currency: string(history.Currency),
+		Status:   status,
+	}
+	if err := db.Create(&notif).Error; err != nil {
+		log.Printf("[%s Webhook] WARNING: could not create notification for ref=%s: %v", provider, reference, err)
+	} else {
+		log.Printf("[%s Webhook] ref=%s moved to %s & notification created", provider, reference, history.PaymentStatus)
 	}
 
 	return nil
 }
+
+// reversedByProviderKey marks a FAILED deposit the provider reversed before
+// it settled. A success for it that arrives later must not credit it.
+const reversedByProviderKey = "reversedByProvider"
+
+func reversedByProvider(history models.Transaction) bool {
+	reversed, _ := history.Metadata[reversedByProviderKey].(bool)
+	return reversed
+}
+
+func withReversedByProvider(meta models.JSONB) models.JSONB {
+	out := models.JSONB{}
+	for k, v := range meta {
+		out[k] = v
+	}
+	out[reversedByProviderKey] = true
+	return out
+}
+
+// ignoreSuccess audits a success that arrived for a deposit that must not
+// settle, and clears history so no notification goes out.
+func ignoreSuccess(tx *gorm.DB, provider string, history *models.Transaction, amount float64) error {
+	log.Printf("[%s Webhook] WARNING: success for ref=%s which is already %s; ignoring", provider, history.Reference, history.PaymentStatus)
+	after := audit.TransactionState(*history)
+	after["ignored_amount"] = amount
+	err := recordSettlement(tx, provider, audit.DepositSuccessIgnored, *history, after)
+	*history = models.Transaction{}
+	return err
+}
+
+// recordSettlement audits a provider-driven change to a transaction inside
+// the transaction that makes it, so the two commit or roll back together.
+func recordSettlement(tx *gorm.DB, provider, action string, history models.Transaction, after map[string]interface{}) error {
+	_, err := audit.Record(tx, audit.Entry{
+		Actor:  audit.Webhook(provider),
+		Action: action,
+		Target: audit.Target{Type: audit.TargetTransaction, ID: history.ID},
+		Before: audit.TransactionState(history),
+		After:  after,
+	})
+	return err
+}
+
+func withCreditedAmount(meta models.JSONB, amount float64) models.JSONB {
+	out := models.JSONB{}
+	for k, v := range meta {
+		out[k] = v
+	}
+	out["creditedAmount"] = amount
+	return out
+}
+
+// creditedAmount is what actually reached the gaming wallet, which for
+// Nomba is the order amount net of fees.
+func creditedAmount(history models.Transaction) float64 {
+	if v, ok := history.Metadata["creditedAmount"].(float64); ok {
+		return v
+	}
+	return float64(history.Amount)
+}
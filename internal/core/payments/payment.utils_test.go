@@ -0,0 +1,260 @@
+package payments
+
+import (
+	"testing"
+
+	"github.com/dblaq/buzzycash/internal/core/audit"
+	"github.com/dblaq/buzzycash/internal/core/outbox"
+	"github.com/dblaq/buzzycash/internal/models"
+	"github.com/dblaq/buzzycash/internal/testutil"
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+func createDeposit(t *testing.T, db *gorm.DB, userID string, status models.EPaymentStatus, meta models.JSONB) models.Transaction {
+	t.Helper()
+	deposit := models.Transaction{
+		UserID:               userID,
+		Amount:               5000,
+		TransactionReference: "TX-" + uuid.NewString(),
+		Reference:            "REF-" + uuid.NewString(),
+		PaymentStatus:        status,
+		PaymentMethod:        models.Nomba,
+		TransactionType:      models.Credit,
+		Category:             models.Deposit,
+		PaymentType:          models.Topup,
+		Currency:             models.NGN,
+		Metadata:             meta,
+	}
+	if err := db.Create(&deposit).Error; err != nil {
+		t.Fatalf("create deposit: %v", err)
+	}
+	return deposit
+}
+
+func TestHandleSuccessfulDepositAfterReversal(t *testing.T) {
+	tests := []struct {
+		name   string
+		status models.EPaymentStatus
+		meta   models.JSONB
+	}{
+		{"credited and reversed", models.Reversed, nil},
+		{"reversed before it settled", models.Failed, models.JSONB{reversedByProviderKey: true}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			db := testutil.DB(t)
+			user := testutil.User(t, db)
+			deposit := createDeposit(t, db, user.ID, tt.status, tt.meta)
+
+			if err := NewPaymentService(db).HandleSuccessfulDeposit(deposit.Reference, 5000, "NOMBA"); err != nil {
+				t.Fatalf("HandleSuccessfulDeposit: %v", err)
+			}
+
+			var got models.Transaction
+			if err := db.First(&got, "id = ?", deposit.ID).Error; err != nil {
+				t.Fatalf("reload deposit: %v", err)
+			}
+			if got.PaymentStatus != tt.status {
+				t.Errorf("status = %s, want %s", got.PaymentStatus, tt.status)
+			}
+
+			var credits int64
+			db.Model(&models.WalletCreditOutbox{}).Where("transaction_id = ?", deposit.ID).Count(&credits)
+			if credits != 0 {
+				t.Errorf("queued %d wallet credits, want none", credits)
+			}
+			var entries int64
+			db.Model(&models.JournalEntry{}).Where("transaction_id = ?", deposit.ID).Count(&entries)
+			if entries != 0 {
+				t.Errorf("posted %d ledger entries, want none", entries)
+			}
+			var notifications int64
+			db.Model(&models.Notification{}).Where("user_id = ?", user.ID).Count(&notifications)
+			if notifications != 0 {
+				t.Errorf("sent %d notifications, want none", notifications)
+			}
+
+			var events int64
+			db.Model(&models.AuditEvent{}).
+				Where("action = ? AND target_id = ?", audit.DepositSuccessIgnored, deposit.ID).
+				Count(&events)
+			if events != 1 {
+				t.Errorf("recorded %d ignored-success audit events, want 1", events)
+			}
+		})
+	}
+}
+
+func TestHandleSuccessfulDepositSettlesFailed(t *testing.T) {
+	db := testutil.DB(t)
+	user := testutil.User(t, db)
+	deposit := createDeposit(t, db, user.ID, models.Failed, models.JSONB{"failureReason": "abandoned"})
+
+	if err := NewPaymentService(db).HandleSuccessfulDeposit(deposit.Reference, 5000, "NOMBA"); err != nil {
+		t.Fatalf("HandleSuccessfulDeposit: %v", err)
+	}
+
+	var got models.Transaction
+	db.First(&got, "id = ?", deposit.ID)
+	if got.PaymentStatus != models.Successful {
+		t.Errorf("status = %s, want %s", got.PaymentStatus, models.Successful)
+	}
+	var credits int64
+	db.Model(&models.WalletCreditOutbox{}).Where("transaction_id = ?", deposit.ID).Count(&credits)
+	if credits != 1 {
+		t.Errorf("queued %d wallet credits, want 1", credits)
+	}
+	var entries int64
+	db.Model(&models.JournalEntry{}).Where("transaction_id = ?", deposit.ID).Count(&entries)
+	if entries == 0 {
+		t.Error("late success posted no ledger entry")
+	}
+}
+
+func TestReversalKeepsPendingDepositFromSettling(t *testing.T) {
+	db := testutil.DB(t)
+	user := testutil.User(t, db)
+	deposit := createDeposit(t, db, user.ID, models.Pending, nil)
+	service := NewPaymentService(db)
+
+	if err := service.handleReversedDeposit(deposit.Reference, "NOMBA", "refunded"); err != nil {
+		t.Fatalf("handleReversedDeposit: %v", err)
+	}
+	if err := service.HandleSuccessfulDeposit(deposit.Reference, 5000, "NOMBA"); err != nil {
+		t.Fatalf("HandleSuccessfulDeposit: %v", err)
+	}
+
+	var got models.Transaction
+	db.First(&got, "id = ?", deposit.ID)
+	if got.PaymentStatus != models.Failed || !reversedByProvider(got) {
+		t.Errorf("deposit = %s %v, want FAILED and marked reversed", got.PaymentStatus, got.Metadata)
+	}
+	var credits int64
+	db.Model(&models.WalletCreditOutbox{}).Where("transaction_id = ?", deposit.ID).Count(&credits)
+	if credits != 0 {
+		t.Errorf("queued %d wallet credits, want none", credits)
+	}
+}
+
+func TestHandleSuccessfulDepositSettlesPending(t *testing.T) {
+	db := testutil.DB(t)
+	user := testutil.User(t, db)
+	deposit := models.Transaction{
+		UserID:               user.ID,
+		Amount:               5000,
+		TransactionReference: "TX-" + uuid.NewString(),
+		Reference:            "REF-" + uuid.NewString(),
+		PaymentStatus:        models.Pending,
+		PaymentMethod:        models.Nomba,
+		TransactionType:      models.Credit,
+		Category:             models.Deposit,
+		PaymentType:          models.Topup,
+		Currency:             models.NGN,
+	}
+	if err := db.Create(&deposit).Error; err != nil {
+		t.Fatalf("create deposit: %v", err)
+	}
+
+	service := NewPaymentService(db)
+	for i := 0; i < 2; i++ {
+		if err := service.HandleSuccessfulDeposit(deposit.Reference, 5000, "NOMBA"); err != nil {
+			t.Fatalf("HandleSuccessfulDeposit #%d: %v", i+1, err)
+		}
+	}
+
+	var got models.Transaction
+	db.First(&got, "id = ?", deposit.ID)
+	if got.PaymentStatus != models.Successful {
+		t.Errorf("status = %s, want %s", got.PaymentStatus, models.Successful)
+	}
+	var credits int64
+	db.Model(&models.WalletCreditOutbox{}).Where("transaction_id = ?", deposit.ID).Count(&credits)
+	if credits != 1 {
+		t.Errorf("queued %d wallet credits, want 1", credits)
+	}
+	var notifications int64
+	db.Model(&models.Notification{}).Where("user_id = ?", user.ID).Count(&notifications)
+	if notifications != 1 {
+		t.Errorf("sent %d notifications, want 1", notifications)
+	}
+}
+
+func TestReversalOfCreditedDepositQueuesDebit(t *testing.T) {
+	db := testutil.DB(t)
+	user := testutil.User(t, db)
+	deposit := createDeposit(t, db, user.ID, models.Successful, models.JSONB{"creditedAmount": 4900.0})
+	if err := db.Create(&models.WalletCreditOutbox{
+		IdempotencyKey: outbox.DepositCreditKey(deposit),
+		Direction:      models.OutboxCredit,
+		TransactionID:  deposit.ID,
+		UserID:         user.ID,
+		Username:       user.PhoneNumber,
+		Amount:         4900,
+		Status:         models.OutboxDispatched,
+	}).Error; err != nil {
+		t.Fatalf("create dispatched credit: %v", err)
+	}
+
+	service := NewPaymentService(db)
+	for i := 0; i < 2; i++ {
+		if err := service.handleReversedDeposit(deposit.Reference, "NOMBA", "chargeback"); err != nil {
+			t.Fatalf("handleReversedDeposit #%d: %v", i+1, err)
+		}
+	}
+
+	var got models.Transaction
+	db.First(&got, "id = ?", deposit.ID)
+	if got.PaymentStatus != models.Reversed {
+		t.Errorf("status = %s, want %s", got.PaymentStatus, models.Reversed)
+	}
+	var debits []models.WalletCreditOutbox
+	db.Where("transaction_id = ? AND direction = ?", deposit.ID, models.OutboxDebit).Find(&debits)
+	if len(debits) != 1 {
+		t.Fatalf("queued %d wallet debits, want 1", len(debits))
+	}
+	if debits[0].Amount != 4900 || debits[0].Status != models.OutboxPending || debits[0].IdempotencyKey != outbox.DepositReversalDebitKey(deposit) {
+		t.Errorf("debit = %+v, want a PENDING debit of the credited 4900", debits[0])
+	}
+}
+
+func TestVirtualAccountTransferRecordedOnce(t *testing.T) {
+	db := testutil.DB(t)
+	user := testutil.User(t, db)
+	va := models.VirtualAccount{
+		UserID:        user.ID,
+		Provider:      models.Nomba,
+		AccountNumber: uuid.NewString()[:10],
+		Reference:     "VA-" + uuid.NewString(),
+		Currency:      models.NGN,
+	}
+	if err := db.Create(&va).Error; err != nil {
+		t.Fatalf("create virtual account: %v", err)
+	}
+
+	p := NewPaymentService(db)
+	reference := "txn-" + uuid.NewString()
+	for i := 0; i < 2; i++ {
+		if err := p.HandleVirtualAccountDeposit(va.AccountNumber, reference, 2000, "NOMBA"); err != nil {
+			t.Fatalf("HandleVirtualAccountDeposit (delivery %d): %v", i+1, err)
+		}
+	}
+
+	var deposits []models.Transaction
+	if err := db.Where("reference = ? AND category = ?", reference, models.Deposit).Find(&deposits).Error; err != nil {
+		t.Fatalf("load deposits: %v", err)
+	}
+	if len(deposits) != 1 {
+		t.Fatalf("recorded %d deposits, want 1", len(deposits))
+	}
+	if deposits[0].PaymentStatus != models.Successful {
+		t.Errorf("status = %s, want %s", deposits[0].PaymentStatus, models.Successful)
+	}
+
+	duplicate := deposits[0]
+	duplicate.ID = ""
+	duplicate.TransactionReference = "TX-" + uuid.NewString()
+	if err := db.Transaction(func(tx *gorm.DB) error { return tx.Create(&duplicate).Error }); err == nil {
+		t.Error("recorded the same transfer twice; want a unique (reference, category) violation")
+	}
+}
@@ -14,3 +14,49 @@ package payments
 // @Router /payments/verify [get]
 // @Security BearerAuth
 func _() {}
+
+// @Summary List webhook events
+// @Description List stored webhook deliveries from the inbox, newest first. Requires webhooks:manage
+// @Tags admin-webhooks
+// @Accept json
+// @Produce json
+// @Param status query string false "Filter by status (RECEIVED, PROCESSING, PROCESSED, FAILED, DEAD_LETTERED)"
+// @Param provider query string false "Filter by provider (NOMBA, FLUTTERWAVE, PAYSTACK, HUBTEL)"
+// @Param page query int false "Page number (defaults to 1)"
+// @Success 200 {object} map[string]interface{} "Webhook events"
+// @Failure 401 {object} map[string]interface{} "Unauthorized"
+// @Failure 403 {object} map[string]interface{} "Missing permission"
+// @Failure 500 {object} map[string]interface{} "Failed to fetch webhook events"
+// @Router /admin/webhooks [get]
+// @Security BearerAuth
+func _() {}
+
+// @Summary Get webhook event
+// @Description Retrieve a stored webhook delivery including its raw payload. Requires webhooks:manage
+// @Tags admin-webhooks
+// @Accept json
+// @Produce json
+// @Param id path string true "Webhook event ID"
+// @Success 200 {object} map[string]interface{} "Webhook event"
+// @Failure 401 {object} map[string]interface{} "Unauthorized"
+// @Failure 403 {object} map[string]interface{} "Missing permission"
+// @Failure 404 {object} map[string]interface{} "Webhook event not found"
+// @Failure 500 {object} map[string]interface{} "Failed to fetch webhook event"
+// @Router /admin/webhooks/{id} [get]
+// @Security BearerAuth
+func _() {}
+
+// @Summary Replay webhook event
+// @Description Queue a dead-lettered webhook event for another round of processing. Requires webhooks:manage
+// @Tags admin-webhooks
+// @Accept json
+// @Produce json
+// @Param id path string true "Webhook event ID"
+// @Success 200 {object} map[string]interface{} "Webhook event queued for replay"
+// @Failure 401 {object} map[string]interface{} "Unauthorized"
+// @Failure 403 {object} map[string]interface{} "Missing permission"
+// @Failure 409 {object} map[string]interface{} "Only dead-lettered events can be replayed"
+// @Failure 500 {object} map[string]interface{} "Failed to replay webhook event"
+// @Router /admin/webhooks/{id}/replay [post]
+// @Security BearerAuth
+func _() {}
@@ -0,0 +1,137 @@
+package payments
+
+import (
+	"errors"
+	"log"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/dblaq/buzzycash/internal/core/audit"
+	"github.com/dblaq/buzzycash/internal/models"
+	"github.com/dblaq/buzzycash/internal/utils"
+	"github.com/gin-gonic/gin"
+	"gorm.io/gorm"
+)
+
+type WebhookAdminHandler struct {
+	db *gorm.DB
+}
+
+func NewWebhookAdminHandler(db *gorm.DB) *WebhookAdminHandler {
+	return &WebhookAdminHandler{
+		db: db,
+	}
+}
+
+// ListWebhookEventsHandler lists inbox events, newest first, optionally
+// filtered by status and provider.
+func (h *WebhookAdminHandler) ListWebhookEventsHandler(ctx *gin.Context) {
+	page := 1
+	if p, err := strconv.Atoi(ctx.Query("page")); err == nil && p > 0 {
+		page = p
+	}
+	limit := 20
+	offset := (page - 1) * limit
+
+	q := h.db.Model(&models.WebhookEvent{})
+	if status := ctx.Query("status"); status != "" {
+		q = q.Where("status = ?", strings.ToUpper(status))
+	}
+	if provider := ctx.Query("provider"); provider != "" {
+		q = q.Where("provider = ?", strings.ToUpper(provider))
+	}
+
+	var totalCount int64
+	if err := q.Count(&totalCount).Error; err != nil {
+		utils.Error(ctx, http.StatusInternalServerError, "Failed to fetch webhook events")
+		return
+	}
+
+	var events []models.WebhookEvent
+	if err := q.Order("created_at desc").Limit(limit).Offset(offset).Find(&events).Error; err != nil {
+		utils.Error(ctx, http.StatusInternalServerError, "Failed to fetch webhook events")
+		return
+	}
+
+	response := make([]WebhookEventResponse, 0, len(events))
+	for _, e := range events {
+		response = append(response, toWebhookEventResponse(e, false))
+	}
+
+	ctx.JSON(http.StatusOK, gin.H{
+		"events":      response,
+		"page":        page,
+		"has_more":    int64(offset+limit) < totalCount,
+		"total_count": totalCount,
+	})
+}
+
+// GetWebhookEventHandler returns a single event including its raw payload.
+func (h *WebhookAdminHandler) GetWebhookEventHandler(ctx *gin.Context) {
+	var event models.WebhookEvent
+	if err := h.db.First(&event, "id = ?", ctx.Param("id")).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			utils.Error(ctx, http.StatusNotFound, "Webhook event not found")
+			return
+		}
+		utils.Error(ctx, http.StatusInternalServerError, "Failed to fetch webhook event")
+		return
+	}
+
+	ctx.JSON(http.StatusOK, gin.H{
+		"event": toWebhookEventResponse(event, true),
+	})
+}
+
+// ReplayWebhookEventHandler queues a dead-lettered event for another round
+// of processing by resetting its attempts.
+func (h *WebhookAdminHandler) ReplayWebhookEventHandler(ctx *gin.Context) {
+	id := ctx.Param("id")
+
+	result := h.db.Model(&models.WebhookEvent{}).
+		Where("id = ? AND status = ?", id, models.WebhookDeadLettered).
+		Updates(map[string]interface{}{
+			"status":          models.WebhookReceived,
+			"attempts":        0,
+			"next_attempt_at": time.Now(),
+			"last_error":      "",
+		})
+	if result.Error != nil {
+		utils.Error(ctx, http.StatusInternalServerError, "Failed to replay webhook event")
+		return
+	}
+	if result.RowsAffected == 0 {
+		utils.Error(ctx, http.StatusConflict, "Only dead-lettered events can be replayed")
+		return
+	}
+
+	admin := ctx.MustGet("currentAdmin").(models.Admin)
+	log.Printf("[Webhook Admin] Event %s queued for replay by admin %s", id, admin.ID)
+	audit.Log(ctx, h.db, audit.WebhookReplayed, audit.Target{Type: audit.TargetWebhook, ID: id},
+		gin.H{"status": models.WebhookDeadLettered}, gin.H{"status": models.WebhookReceived})
+
+	ctx.JSON(http.StatusOK, gin.H{
+		"message": "Webhook event queued for replay",
+	})
+}
+
+func toWebhookEventResponse(e models.WebhookEvent, withPayload bool) WebhookEventResponse {
+	resp := WebhookEventResponse{
+		ID:            e.ID,
+		Provider:      string(e.Provider),
+		EventID:       e.EventID,
+		EventType:     e.EventType,
+		Status:        string(e.Status),
+		Attempts:      e.Attempts,
+		NextAttemptAt: e.NextAttemptAt,
+		LastError:     e.LastError,
+		ProcessedAt:   e.ProcessedAt,
+		CreatedAt:     e.CreatedAt,
+	}
+	if withPayload {
+		resp.Payload = e.Payload
+	}
+	return resp
+}
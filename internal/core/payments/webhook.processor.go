@@ -0,0 +1,73 @@
+package payments
+
+import (
+	"fmt"
+	"log"
+
+	"github.com/dblaq/buzzycash/external/gateway"
+	"github.com/dblaq/buzzycash/internal/core/withdrawal"
+	"github.com/dblaq/buzzycash/internal/models"
+	"gorm.io/gorm"
+)
+
+// WebhookProcessor applies a stored webhook event. Every handler it calls is
+// idempotent, so an event may safely be processed more than once.
+type WebhookProcessor struct {
+	paymentService *PaymentService
+	withdrawals    *withdrawal.WithdrawalService
+}
+
+func NewWebhookProcessor(db *gorm.DB) *WebhookProcessor {
+	return &WebhookProcessor{
+		paymentService: NewPaymentService(db),
+		withdrawals:    withdrawal.NewWithdrawalService(db),
+	}
+}
+
+func (p *WebhookProcessor) Process(event models.WebhookEvent) error {
+	provider, err := gateway.GetProvider(string(event.Provider))
+	if err != nil {
+		return err
+	}
+
+	evt, err := provider.ParseWebhook([]byte(event.Payload))
+	if err != nil {
+		return err
+	}
+	source := string(event.Provider)
+
+	switch evt.Kind {
+	// ----------- Deposit flow ------------
+	case gateway.DepositSucceeded:
+		if err := p.paymentService.HandleSuccessfulDeposit(evt.Reference, evt.CreditAmount, source); err != nil {
+			return err
+		}
+		// A card that cannot be saved must not hold up the deposit
+		if evt.Card != nil {
+			if err := p.paymentService.saveCard(evt.Reference, source, *evt.Card); err != nil {
+				log.Printf("[%s Webhook] WARNING: could not save card for ref=%s: %v", source, evt.Reference, err)
+			}
+		}
+		return nil
+	case gateway.VirtualAccountCredited:
+		return p.paymentService.HandleVirtualAccountDeposit(evt.Account, evt.Reference, evt.CreditAmount, source)
+	case gateway.DepositFailed:
+		return p.paymentService.handleFailedDeposit(evt.Reference, source, evt.Reason)
+	case gateway.DepositReversed:
+		return p.paymentService.handleReversedDeposit(evt.Reference, source, evt.Reason)
+
+	// ----------- Withdrawal flow ----------
+	case gateway.PayoutSucceeded:
+		return p.paymentService.handleSuccessfulWithdrawal(evt.Reference, source)
+	case gateway.PayoutFailed:
+		return p.withdrawals.Fail(evt.Reference, evt.Reason)
+	case gateway.PayoutReversed:
+		return p.withdrawals.Refund(evt.Reference, evt.Reason)
+
+	case gateway.IgnoredEvent:
+		log.Printf("[%s Webhook] Ignored event=%s", source, evt.Type)
+		return nil
+	default:
+		return fmt.Errorf("unhandled event kind %q", evt.Kind)
+	}
+}
@@ -0,0 +1,175 @@
+package payments
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"log"
+	"time"
+
+	"github.com/dblaq/buzzycash/internal/config"
+	"github.com/dblaq/buzzycash/internal/models"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+const (
+	webhookBatchSize     = 20
+	webhookBaseBackoff   = 30 * time.Second
+	webhookMaxBackoff    = time.Hour
+	webhookStaleClaimAge = 5 * time.Minute
+)
+
+// storeWebhookEvent writes a verified delivery to the inbox. A redelivery of
+// an event we already hold is silently ignored.
+func storeWebhookEvent(db *gorm.DB, provider models.WebhookProvider, eventID, eventType string, body []byte) error {
+	event := models.WebhookEvent{
+		Provider:      provider,
+		EventID:       eventID,
+		EventType:     eventType,
+		Payload:       string(body),
+		Status:        models.WebhookReceived,
+		NextAttemptAt: time.Now(),
+	}
+	return db.Clauses(clause.OnConflict{DoNothing: true}).Create(&event).Error
+}
+
+// storeDeadWebhookEvent keeps a verified delivery that could not be parsed
+// in the inbox as dead-lettered, with the parse error, so it is not lost and
+// can be replayed by an admin.
+func storeDeadWebhookEvent(db *gorm.DB, provider models.WebhookProvider, eventID string, body []byte, parseErr error) error {
+	event := models.WebhookEvent{
+		Provider:      provider,
+		EventID:       eventID,
+		Payload:       string(body),
+		Status:        models.WebhookDeadLettered,
+		LastError:     "parse: " + parseErr.Error(),
+		NextAttemptAt: time.Now(),
+	}
+	return db.Clauses(clause.OnConflict{DoNothing: true}).Create(&event).Error
+}
+
+// payloadHash is the fallback event ID when a provider sends none.
+func payloadHash(body []byte) string {
+	sum := sha256.Sum256(body)
+	return hex.EncodeToString(sum[:])
+}
+
+type WebhookWorker struct {
+	db          *gorm.DB
+	processor   *WebhookProcessor
+	interval    time.Duration
+	maxAttempts int
+}
+
+// StartWebhookWorker launches the background loop that drains the inbox.
+func StartWebhookWorker(db *gorm.DB) *WebhookWorker {
+	w := &WebhookWorker{
+		db:          db,
+		processor:   NewWebhookProcessor(db),
+		interval:    time.Duration(config.AppConfig.WebhookWorkerIntervalSeconds) * time.Second,
+		maxAttempts: config.AppConfig.WebhookMaxAttempts,
+	}
+	if w.interval <= 0 {
+		w.interval = 5 * time.Second
+	}
+	if w.maxAttempts <= 0 {
+		w.maxAttempts = 1
+	}
+
+	go w.startLoop()
+	return w
+}
+
+func (w *WebhookWorker) startLoop() {
+	ticker := time.NewTicker(w.interval)
+	defer ticker.Stop()
+
+	for range ticker.C {
+		w.runOnce()
+	}
+}
+
+func (w *WebhookWorker) runOnce() {
+	events, err := w.claim()
+	if err != nil {
+		log.Printf("[Webhook Worker] Could not claim events: %v", err)
+		return
+	}
+	for _, event := range events {
+		w.handle(event)
+	}
+}
+
+// claim moves a batch of due events to PROCESSING. SKIP LOCKED lets several
+// instances run the worker without picking up the same rows. Events stuck in
+// PROCESSING by a crashed instance are picked up again once stale.
+func (w *WebhookWorker) claim() ([]models.WebhookEvent, error) {
+	var events []models.WebhookEvent
+	now := time.Now()
+
+	err := w.db.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Clauses(clause.Locking{Strength: "UPDATE", Options: "SKIP LOCKED"}).
+			Where("status = ?", models.WebhookReceived).
+			Or("status = ? AND next_attempt_at <= ?", models.WebhookFailed, now).
+			Or("status = ? AND updated_at <= ?", models.WebhookProcessing, now.Add(-webhookStaleClaimAge)).
+			Order("created_at ASC").
+			Limit(webhookBatchSize).
+			Find(&events).Error; err != nil {
+			return err
+		}
+		if len(events) == 0 {
+			return nil
+		}
+
+		ids := make([]string, len(events))
+		for i, e := range events {
+			ids[i] = e.ID
+		}
+		return tx.Model(&models.WebhookEvent{}).
+			Where("id IN ?", ids).
+			Updates(map[string]interface{}{
+				"status":     models.WebhookProcessing,
+				"updated_at": now,
+			}).Error
+	})
+	return events, err
+}
+
+func (w *WebhookWorker) handle(event models.WebhookEvent) {
+	attempts := event.Attempts + 1
+	err := w.processor.Process(event)
+
+	updates := map[string]interface{}{"attempts": attempts}
+	switch {
+	case err == nil:
+		now := time.Now()
+		updates["status"] = models.WebhookProcessed
+		updates["processed_at"] = &now
+		updates["last_error"] = ""
+	case attempts >= w.maxAttempts:
+		log.Printf("[Webhook Worker] Dead-lettering %s event %s after %d attempts: %v", event.Provider, event.EventID, attempts, err)
+		updates["status"] = models.WebhookDeadLettered
+		updates["last_error"] = err.Error()
+	default:
+		log.Printf("[Webhook Worker] %s event %s failed (attempt %d): %v", event.Provider, event.EventID, attempts, err)
+		updates["status"] = models.WebhookFailed
+		updates["last_error"] = err.Error()
+		updates["next_attempt_at"] = time.Now().Add(webhookBackoff(attempts))
+	}
+
+	if updErr := w.db.Model(&models.WebhookEvent{}).Where("id = ?", event.ID).Updates(updates).Error; updErr != nil {
+		log.Printf("[Webhook Worker] WARNING: could not update event %s: %v", event.ID, updErr)
+	}
+}
+
+// webhookBackoff doubles the delay for every attempt, capped at an hour.
+func webhookBackoff(attempts int) time.Duration {
+	delay := webhookBaseBackoff
+	for i := 1; i < attempts; i++ {
+		delay *= 2
+		if delay >= webhookMaxBackoff {
+			return webhookMaxBackoff
+		}
+	}
+	return delay
+}
@@ -0,0 +1,109 @@
+package payments
+
+import (
+	"errors"
+	"log"
+	"strings"
+	"time"
+
+	"github.com/dblaq/buzzycash/external/gateway"
+	"github.com/dblaq/buzzycash/internal/config"
+	"github.com/dblaq/buzzycash/internal/core/withdrawal"
+	"github.com/dblaq/buzzycash/internal/models"
+	"gorm.io/gorm"
+)
+
+const withdrawalRequeryBatchSize = 50
+
+// WithdrawalRequeryJob resolves withdrawals left PENDING with no outcome,
+// such as one whose process stopped between the wallet debit and the
+// payout. It asks the payout provider what became of each one; a payout the
+// provider never received is abandoned and its funds returned. Withdrawals
+// held for review, or approved but never submitted, are left to the
+// reviewers.
+type WithdrawalRequeryJob struct {
+	db             *gorm.DB
+	paymentService *PaymentService
+	withdrawals    *withdrawal.WithdrawalService
+	interval       time.Duration
+	requeryAfter   time.Duration
+}
+
+func StartWithdrawalRequeryJob(db *gorm.DB) *WithdrawalRequeryJob {
+	j := &WithdrawalRequeryJob{
+		db:             db,
+		paymentService: NewPaymentService(db),
+		withdrawals:    withdrawal.NewWithdrawalService(db),
+		interval:       time.Duration(config.AppConfig.WithdrawalRequeryIntervalSeconds) * time.Second,
+		requeryAfter:   time.Duration(config.AppConfig.WithdrawalRequeryAfterMinutes) * time.Minute,
+	}
+	if j.interval <= 0 {
+		j.interval = 5 * time.Minute
+	}
+	if j.requeryAfter <= 0 {
+		j.requeryAfter = 30 * time.Minute
+	}
+
+	go j.startLoop()
+	return j
+}
+
+func (j *WithdrawalRequeryJob) startLoop() {
+	ticker := time.NewTicker(j.interval)
+	defer ticker.Stop()
+
+	for range ticker.C {
+		j.runOnce()
+	}
+}
+
+func (j *WithdrawalRequeryJob) runOnce() {
+	var stale []models.Transaction
+	if err := j.db.
+		Where("category = ? AND payment_status = ? AND created_at <= ?", models.WithdrawRequest, models.Pending, time.Now().Add(-j.requeryAfter)).
+		Where(`NOT EXISTS (SELECT 1 FROM withdrawal_risks r WHERE r.transaction_id = transactions.id
+			AND (r.decision = ? OR (r.decision = ? AND r.payout_submitted_at IS NULL)))`, models.RiskPendingReview, models.RiskApproved).
+		Order("last_checked_at ASC NULLS FIRST").
+		Order("created_at ASC").
+		Limit(withdrawalRequeryBatchSize).
+		Find(&stale).Error; err != nil {
+		log.Printf("[Withdrawal Requery] Could not load stale withdrawals: %v", err)
+		return
+	}
+
+	for _, history := range stale {
+		if err := j.db.Model(&history).UpdateColumn("last_checked_at", time.Now()).Error; err != nil {
+			log.Printf("[Withdrawal Requery] ref=%s: could not record check: %v", history.Reference, err)
+			continue
+		}
+		if err := j.requery(history); err != nil {
+			log.Printf("[Withdrawal Requery] ref=%s: %v", history.Reference, err)
+		}
+	}
+}
+
+func (j *WithdrawalRequeryJob) requery(history models.Transaction) error {
+	provider, err := gateway.GetProvider(string(history.PaymentMethod))
+	if err != nil {
+		return err
+	}
+	source := strings.ToUpper(provider.Name())
+
+	result, err := provider.VerifyPayout(history.Reference, history.CreatedAt)
+	switch {
+	case errors.Is(err, gateway.ErrTransactionNotFound):
+		log.Printf("[Withdrawal Requery] ref=%s never reached %s; abandoning", history.Reference, source)
+		return j.withdrawals.Abandon(history, "requery: payout never submitted")
+	case err != nil:
+		return err
+	}
+
+	switch result.Status {
+	case gateway.PaymentSucceeded:
+		return j.paymentService.handleSuccessfulWithdrawal(history.Reference, source)
+	case gateway.PaymentFailed:
+		return j.withdrawals.Fail(history.Reference, "requery: "+result.Reason)
+	}
+	// Still in flight at the provider
+	return nil
+}
@@ -0,0 +1,84 @@
+package payouts
+
+// @Summary List pending prize payouts
+// @Description List prize payouts the gaming provider holds for approval that have not been approved or rejected yet, plus payouts held by an unfinished approval (claimed_at set; claim_stale once it can be retried or reconciled). Requires payouts:approve
+// @Tags admin-payouts
+// @Produce json
+// @Param username query string false "Winner's username (phone number)"
+// @Success 200 {array} PayoutResponse "Pending payouts"
+// @Failure 403 {object} map[string]interface{} "Missing permission"
+// @Failure 502 {object} map[string]interface{} "Failed to fetch payouts"
+// @Router /admin/payouts [get]
+// @Security BearerAuth
+func _() {}
+
+
+// @Summary Get prize payout
+// @Description Get a payout with its winner, winning ticket and any decision taken on it. Requires payouts:approve
+// @Tags admin-payouts
+// @Produce json
+// @Param id path string true "Payout ID"
+// @Success 200 {object} PayoutDetailResponse "Payout"
+// @Failure 404 {object} map[string]interface{} "Payout not found"
+// @Router /admin/payouts/{id} [get]
+// @Security BearerAuth
+func _() {}
+
+
+// @Summary Approve prize payout
+// @Description Pay a pending prize through the gaming provider, record it as prize money and notify the winner. Requires payouts:approve
+// @Tags admin-payouts
+// @Produce json
+// @Param id path string true "Payout ID"
+// @Success 200 {object} DecisionResponse "Payout approved"
+// @Failure 404 {object} map[string]interface{} "Payout or winner not found"
+// @Failure 409 {object} map[string]interface{} "Payout already decided, not pending, or being approved"
+// @Failure 422 {object} map[string]interface{} "No exchange rate to the winner's currency"
+// @Failure 502 {object} map[string]interface{} "Gaming provider rejected the payout"
+// @Router /admin/payouts/{id}/approve [post]
+// @Security BearerAuth
+func _() {}
+
+
+// @Summary Reject prize payout
+// @Description Reject a pending payout with a reason and notify the winner. Requires payouts:approve
+// @Tags admin-payouts
+// @Accept json
+// @Produce json
+// @Param id path string true "Payout ID"
+// @Param request body RejectPayoutRequest true "Reason"
+// @Success 200 {object} DecisionResponse "Payout rejected"
+// @Failure 400 {object} map[string]interface{} "Reason missing"
+// @Failure 404 {object} map[string]interface{} "Payout or winner not found"
+// @Failure 409 {object} map[string]interface{} "Payout already decided or not pending"
+// @Router /admin/payouts/{id}/reject [post]
+// @Security BearerAuth
+func _() {}
+
+
+// @Summary Reconcile prize payout
+// @Description Settle an approval that never finished against the gaming provider. If the provider paid, the payout is recorded as SUCCESSFUL; if not, the claim is released so it can be approved again. Requires payouts:approve
+// @Tags admin-payouts
+// @Produce json
+// @Param id path string true "Payout ID"
+// @Success 200 {object} DecisionResponse "Payout recorded as paid, or claim released (no data)"
+// @Failure 404 {object} map[string]interface{} "Payout not found or not claimed"
+// @Failure 409 {object} map[string]interface{} "Approval still in progress or already decided"
+// @Failure 422 {object} map[string]interface{} "Provider status needs manual review"
+// @Router /admin/payouts/{id}/reconcile [post]
+// @Security BearerAuth
+func _() {}
+
+
+// @Summary Bulk-approve prize payouts
+// @Description Approve every pending payout of at most max_amount. max_amount cannot exceed PRIZE_BULK_APPROVE_MAX. Requires payouts:approve
+// @Tags admin-payouts
+// @Accept json
+// @Produce json
+// @Param request body BulkApproveRequest true "Threshold"
+// @Success 200 {object} BulkApproveResponse "Approved, failed and skipped payouts"
+// @Failure 400 {object} map[string]interface{} "Invalid threshold"
+// @Failure 502 {object} map[string]interface{} "Failed to fetch payouts"
+// @Router /admin/payouts/bulk-approve [post]
+// @Security BearerAuth
+func _() {}
@@ -0,0 +1,63 @@
+package payouts
+
+import "time"
+
+type RejectPayoutRequest struct {
+	Reason string `json:"reason" binding:"required"`
+}
+
+// BulkApproveRequest approves every pending payout of at most MaxAmount.
+type BulkApproveRequest struct {
+	MaxAmount int64 `json:"max_amount" binding:"required"`
+}
+
+type PayoutResponse struct {
+	ID        string `json:"id"`
+	Username  string `json:"username"`
+	Amount    int64  `json:"amount"`
+	Status    string `json:"status"`
+	GameID    string `json:"game_id,omitempty"`
+	TicketID  string `json:"ticket_id,omitempty"`
+	CreatedAt string `json:"created_at,omitempty"`
+
+	// Set while an approval holds the payout; a stale claim can be retried
+	// or reconciled.
+	ClaimedAt  *time.Time `json:"claimed_at,omitempty"`
+	ClaimStale bool       `json:"claim_stale,omitempty"`
+}
+
+type WinnerResponse struct {
+	UserID      string `json:"user_id"`
+	FullName    string `json:"full_name"`
+	Username    string `json:"username"`
+	PhoneNumber string `json:"phone_number"`
+	Email       string `json:"email"`
+}
+
+type DecisionResponse struct {
+	TransactionID string    `json:"transaction_id"`
+	Status        string    `json:"status"`
+	Amount        int64     `json:"amount"`
+	Currency      string    `json:"currency"`
+	Reason        string    `json:"reason,omitempty"`
+	DecidedBy     string    `json:"decided_by,omitempty"`
+	DecidedAt     time.Time `json:"decided_at"`
+}
+
+type PayoutDetailResponse struct {
+	Payout   PayoutResponse         `json:"payout"`
+	Winner   *WinnerResponse        `json:"winner,omitempty"`
+	Ticket   map[string]interface{} `json:"ticket,omitempty"`
+	Decision *DecisionResponse      `json:"decision,omitempty"`
+}
+
+type BulkFailure struct {
+	PayoutID string `json:"payout_id"`
+	Error    string `json:"error"`
+}
+
+type BulkApproveResponse struct {
+	Approved []DecisionResponse `json:"approved"`
+	Failed   []BulkFailure      `json:"failed"`
+	Skipped  int                `json:"skipped"` // pending payouts above max_amount
+}
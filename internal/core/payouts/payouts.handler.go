@@ -0,0 +1,235 @@
+package payouts
+
+import (
+	"errors"
+	"log"
+	"net/http"
+	"strings"
+
+	"github.com/dblaq/buzzycash/internal/core/audit"
+	"github.com/dblaq/buzzycash/internal/core/fx"
+	"github.com/dblaq/buzzycash/internal/models"
+	"github.com/dblaq/buzzycash/internal/utils"
+	"github.com/gin-gonic/gin"
+	"gorm.io/gorm"
+)
+
+type PayoutsHandler struct {
+	db *gorm.DB
+}
+
+func NewPayoutsHandler(db *gorm.DB) *PayoutsHandler {
+	return &PayoutsHandler{
+		db: db,
+	}
+}
+
+// ListPendingHandler lists prize payouts waiting for approval, optionally
+// for one winner.
+func (h *PayoutsHandler) ListPendingHandler(ctx *gin.Context) {
+	pending, err := ListPending(h.db, ctx.Query("username"))
+	if err != nil {
+		log.Printf("[Payouts] Failed to list pending payouts: %v", err)
+		utils.Error(ctx, http.StatusBadGateway, "Failed to fetch payouts")
+		return
+	}
+
+	response := make([]PayoutResponse, 0, len(pending))
+	for _, p := range pending {
+		response = append(response, toPayoutResponse(p))
+	}
+	ctx.JSON(http.StatusOK, gin.H{
+		"message": "Pending payouts retrieved successfully",
+		"data":    response,
+	})
+}
+
+func (h *PayoutsHandler) GetPayoutHandler(ctx *gin.Context) {
+	detail, err := Get(h.db, ctx.Param("id"))
+	if err != nil {
+		payoutError(ctx, err, "Failed to fetch payout")
+		return
+	}
+
+	response := PayoutDetailResponse{
+		Payout: toPayoutResponse(detail.Payout),
+		Ticket: detail.Ticket,
+	}
+	if detail.Winner != nil {
+		response.Winner = &WinnerResponse{
+			UserID:      detail.Winner.ID,
+			FullName:    detail.Winner.FullName,
+			Username:    detail.Winner.Username,
+			PhoneNumber: detail.Winner.PhoneNumber,
+			Email:       detail.Winner.Email,
+		}
+	}
+	if detail.Decision != nil {
+		decision := toDecisionResponse(*detail.Decision)
+		response.Decision = &decision
+	}
+
+	ctx.JSON(http.StatusOK, gin.H{
+		"message": "Payout retrieved successfully",
+		"data":    response,
+	})
+}
+
+func (h *PayoutsHandler) ApprovePayoutHandler(ctx *gin.Context) {
+	admin := ctx.MustGet("currentAdmin").(models.Admin)
+	history, err := Approve(h.db, ctx.Param("id"), admin)
+	if err != nil {
+		payoutError(ctx, err, "Failed to approve payout")
+		return
+	}
+	auditDecision(ctx, h.db, audit.PayoutApproved, *history)
+
+	ctx.JSON(http.StatusOK, gin.H{
+		"message": "Payout approved successfully",
+		"data":    toDecisionResponse(*history),
+	})
+}
+
+func (h *PayoutsHandler) RejectPayoutHandler(ctx *gin.Context) {
+	var req RejectPayoutRequest
+	if err := ctx.ShouldBindJSON(&req); err != nil {
+		utils.Error(ctx, http.StatusBadRequest, utils.ValidationErrorToJSON(err))
+		return
+	}
+	if err := req.Validate(); err != nil {
+		utils.Error(ctx, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	admin := ctx.MustGet("currentAdmin").(models.Admin)
+	history, err := Reject(h.db, ctx.Param("id"), admin, req.Reason)
+	if err != nil {
+		payoutError(ctx, err, "Failed to reject payout")
+		return
+	}
+	auditDecision(ctx, h.db, audit.PayoutRejected, *history)
+
+	ctx.JSON(http.StatusOK, gin.H{
+		"message": "Payout rejected successfully",
+		"data":    toDecisionResponse(*history),
+	})
+}
+
+// ReconcilePayoutHandler settles an approval that never finished against
+// the gaming provider.
+func (h *PayoutsHandler) ReconcilePayoutHandler(ctx *gin.Context) {
+	history, err := Reconcile(h.db, ctx.Param("id"))
+	if err != nil {
+		payoutError(ctx, err, "Failed to reconcile payout")
+		return
+	}
+
+	if history == nil {
+		audit.Log(ctx, h.db, audit.PayoutReconciled, audit.Target{Type: audit.TargetPayout, ID: ctx.Param("id")}, nil, nil)
+		ctx.JSON(http.StatusOK, gin.H{
+			"message": "Provider has not paid this payout; claim released for approval",
+		})
+		return
+	}
+	auditDecision(ctx, h.db, audit.PayoutReconciled, *history)
+
+	ctx.JSON(http.StatusOK, gin.H{
+		"message": "Payout recorded as paid",
+		"data":    toDecisionResponse(*history),
+	})
+}
+
+func (h *PayoutsHandler) BulkApproveHandler(ctx *gin.Context) {
+	var req BulkApproveRequest
+	if err := ctx.ShouldBindJSON(&req); err != nil {
+		utils.Error(ctx, http.StatusBadRequest, utils.ValidationErrorToJSON(err))
+		return
+	}
+	if err := req.Validate(); err != nil {
+		utils.Error(ctx, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	admin := ctx.MustGet("currentAdmin").(models.Admin)
+	result, err := BulkApprove(h.db, admin, req.MaxAmount)
+	if err != nil {
+		log.Printf("[Payouts] Bulk approval failed: %v", err)
+		utils.Error(ctx, http.StatusBadGateway, "Failed to fetch payouts")
+		return
+	}
+
+	response := BulkApproveResponse{
+		Approved: make([]DecisionResponse, 0, len(result.Approved)),
+		Failed:   make([]BulkFailure, 0, len(result.Failed)),
+		Skipped:  result.Skipped,
+	}
+	for _, t := range result.Approved {
+		auditDecision(ctx, h.db, audit.PayoutApproved, t)
+		response.Approved = append(response.Approved, toDecisionResponse(t))
+	}
+	for id, err := range result.Failed {
+		response.Failed = append(response.Failed, BulkFailure{PayoutID: id, Error: err.Error()})
+	}
+
+	ctx.JSON(http.StatusOK, gin.H{
+		"message": "Bulk approval completed",
+		"data":    response,
+	})
+}
+
+// auditDecision records a payout decision against the gaming platform's
+// payout ID, which the claiming transaction's reference carries.
+func auditDecision(ctx *gin.Context, db *gorm.DB, action string, t models.Transaction) {
+	payoutID := strings.TrimPrefix(t.TransactionReference, "PRIZE-")
+	audit.Log(ctx, db, action, audit.Target{Type: audit.TargetPayout, ID: payoutID}, nil, toDecisionResponse(t))
+}
+
+func payoutError(ctx *gin.Context, err error, fallback string) {
+	switch {
+	case errors.Is(err, ErrPayoutNotFound), errors.Is(err, ErrWinnerNotFound), errors.Is(err, ErrNoClaim):
+		utils.Error(ctx, http.StatusNotFound, err.Error())
+	case errors.Is(err, ErrPayoutNotPending), errors.Is(err, ErrPayoutDecided), errors.Is(err, ErrClaimInProgress):
+		utils.Error(ctx, http.StatusConflict, err.Error())
+	case errors.Is(err, ErrProviderFailed), errors.Is(err, ErrPayoutUnconfirmed):
+		utils.Error(ctx, http.StatusBadGateway, err.Error())
+	case errors.Is(err, ErrClaimUnresolved):
+		utils.Error(ctx, http.StatusUnprocessableEntity, err.Error())
+	case errors.Is(err, fx.ErrNoRate):
+		utils.Error(ctx, http.StatusUnprocessableEntity, "No exchange rate to pay this prize in the winner's currency")
+	default:
+		log.Printf("[Payouts] %s: %v", fallback, err)
+		utils.Error(ctx, http.StatusInternalServerError, fallback)
+	}
+}
+
+func toPayoutResponse(p Payout) PayoutResponse {
+	response := PayoutResponse{
+		ID:        p.ID,
+		Username:  p.Username,
+		Amount:    p.Amount,
+		Status:    p.Status,
+		GameID:    p.GameID,
+		TicketID:  p.TicketID,
+		CreatedAt: p.CreatedAt,
+	}
+	if p.Claim != nil {
+		claimedAt := p.Claim.UpdatedAt
+		response.ClaimedAt = &claimedAt
+		response.ClaimStale = p.Stale()
+	}
+	return response
+}
+
+func toDecisionResponse(t models.Transaction) DecisionResponse {
+	reason, _ := t.Metadata["reason"].(string)
+	decidedBy, _ := t.Metadata["decidedBy"].(string)
+	return DecisionResponse{
+		TransactionID: t.ID,
+		Status:        string(t.PaymentStatus),
+		Amount:        t.Amount,
+		Currency:      string(t.Currency),
+		Reason:        reason,
+		DecidedBy:     decidedBy,
+		DecidedAt:     t.CreatedAt,
+	}
+}
@@ -0,0 +1,89 @@
+package payouts
+
+import (
+	"errors"
+	"log"
+	"time"
+
+	"github.com/dblaq/buzzycash/internal/core/audit"
+	"github.com/dblaq/buzzycash/internal/models"
+	"gorm.io/gorm"
+)
+
+const (
+	reconcileInterval  = 5 * time.Minute
+	reconcileBatchSize = 100
+)
+
+// StartClaimReconciler periodically settles approvals that never finished
+// against the gaming provider's payout status.
+func StartClaimReconciler(db *gorm.DB) {
+	go func() {
+		ticker := time.NewTicker(reconcileInterval)
+		defer ticker.Stop()
+
+		for range ticker.C {
+			reconcileStale(db)
+		}
+	}()
+}
+
+func reconcileStale(db *gorm.DB) {
+	var claims []models.Transaction
+	if err := db.
+		Where("category = ? AND payment_type = ? AND payment_status = ? AND updated_at <= ?",
+			models.PrizeMoney, models.Payout, models.Pending, time.Now().Add(-claimTimeout)).
+		Order("updated_at ASC").
+		Limit(reconcileBatchSize).
+		Find(&claims).Error; err != nil {
+		log.Printf("[Payouts] Could not load stale claims: %v", err)
+		return
+	}
+	if len(claims) == 0 {
+		return
+	}
+
+	payouts, err := fetch("")
+	if err != nil {
+		log.Printf("[Payouts] Could not fetch payouts to reconcile: %v", err)
+		return
+	}
+	byID := make(map[string]Payout, len(payouts))
+	for _, p := range payouts {
+		byID[p.ID] = p
+	}
+
+	for _, claim := range claims {
+		payout, ok := byID[claim.Reference]
+		if !ok {
+			log.Printf("[Payouts] WARNING: claimed payout %s is unknown to the provider; needs manual review", claim.Reference)
+			continue
+		}
+		before := audit.TransactionState(claim)
+		history, err := resolveClaim(db, payout, claim)
+		if err != nil {
+			if !errors.Is(err, ErrClaimInProgress) {
+				log.Printf("[Payouts] Could not reconcile payout %s: %v", payout.ID, err)
+			}
+			continue
+		}
+		recordReconciliation(db, payout.ID, before, history)
+	}
+}
+
+// recordReconciliation audits a claim the reconciler settled or released.
+func recordReconciliation(db *gorm.DB, payoutID string, before map[string]interface{}, history *models.Transaction) {
+	var after interface{}
+	if history != nil {
+		after = audit.TransactionState(*history)
+	}
+	if _, err := audit.Record(db, audit.Entry{
+		Actor:  audit.System,
+		Action: audit.PayoutReconciled,
+		Target: audit.Target{Type: audit.TargetPayout, ID: payoutID},
+		Before: before,
+		After:  after,
+	}); err != nil {
+		log.Printf("[Payouts] WARNING: could not audit reconciliation of payout %s: %v", payoutID, err)
+	}
+}
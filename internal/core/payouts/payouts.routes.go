@@ -0,0 +1,21 @@
+package payouts
+
+import (
+	"github.com/dblaq/buzzycash/internal/middlewares"
+	"github.com/dblaq/buzzycash/internal/models"
+	"github.com/gin-gonic/gin"
+	"gorm.io/gorm"
+)
+
+func PayoutsRoutes(rg *gin.RouterGroup, db *gorm.DB) {
+	payoutsHandler := NewPayoutsHandler(db)
+	payoutRoutes := rg.Group("/admin/payouts", middlewares.AdminAuthMiddleware, middlewares.RequirePermission(models.PermPayoutsApprove))
+	{
+		payoutRoutes.GET("", payoutsHandler.ListPendingHandler)
+		payoutRoutes.POST("/bulk-approve", payoutsHandler.BulkApproveHandler)
+		payoutRoutes.GET("/:id", payoutsHandler.GetPayoutHandler)
+		payoutRoutes.POST("/:id/approve", payoutsHandler.ApprovePayoutHandler)
+		payoutRoutes.POST("/:id/reject", payoutsHandler.RejectPayoutHandler)
+		payoutRoutes.POST("/:id/reconcile", payoutsHandler.ReconcilePayoutHandler)
+	}
+}
@@ -0,0 +1,557 @@
+package payouts
+
+import (
+	"errors"
+	"fmt"
+	"log"
+	"math"
+	"strings"
+	"time"
+
+	"github.com/dblaq/buzzycash/external/gaming"
+	"github.com/dblaq/buzzycash/internal/config"
+	"github.com/dblaq/buzzycash/internal/core/fx"
+	"github.com/dblaq/buzzycash/internal/core/ledger"
+	"github.com/dblaq/buzzycash/internal/helpers"
+	"github.com/dblaq/buzzycash/internal/models"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+var (
+	ErrPayoutNotFound   = errors.New("payout not found")
+	ErrPayoutNotPending = errors.New("payout is not pending")
+	ErrPayoutDecided    = errors.New("payout has already been approved or rejected")
+	ErrWinnerNotFound   = errors.New("no user matches the payout's winner")
+	ErrProviderFailed   = errors.New("gaming provider rejected the payout")
+	// ErrPayoutUnconfirmed means the provider's answer was lost; the claim
+	// stays PENDING until reconciliation learns whether it paid.
+	ErrPayoutUnconfirmed = errors.New("payout outcome unknown; it will be reconciled")
+	ErrClaimInProgress   = errors.New("payout approval is already in progress")
+	ErrNoClaim           = errors.New("payout has no unfinished approval")
+	// ErrClaimUnresolved means the provider's view of a claimed payout
+	// neither confirms nor rules out that it was paid.
+	ErrClaimUnresolved = errors.New("payout claim needs manual review")
+)
+
+// claimTimeout is how long an approval may hold a PENDING claim. An older
+// claim was abandoned: the process stopped mid-approval, the claim could not
+// be released after the provider refused, or the provider paid and the
+// credit could not be recorded.
+const claimTimeout = 15 * time.Minute
+
+// Payout is one prize payout as the gaming provider reports it. Username
+// is the winner's phone number, which is what we register players under.
+type Payout struct {
+	ID        string
+	Username  string
+	Amount    int64
+	Status    string
+	GameID    string
+	TicketID  string
+	CreatedAt string
+
+	// Claim is the PENDING decision of an approval that has not finished.
+	Claim *models.Transaction
+}
+
+// Pending reports whether the provider still holds the payout for approval.
+func (p Payout) Pending() bool {
+	return p.Status == "" || strings.EqualFold(p.Status, "pending")
+}
+
+// Paid reports whether the provider has paid the payout out.
+func (p Payout) Paid() bool {
+	switch strings.ToLower(p.Status) {
+	case "paid", "approved", "completed", "successful", "success":
+		return true
+	}
+	return false
+}
+
+// Stale reports whether the payout is held by an abandoned approval.
+func (p Payout) Stale() bool {
+	return p.Claim != nil && time.Since(p.Claim.UpdatedAt) >= claimTimeout
+}
+
+// transactionReference is the TransactionReference of the PRIZE_MONEY
+// transaction recording the decision on a payout. Its unique index is what
+// stops a payout being decided twice.
+func transactionReference(payoutID string) string {
+	return "PRIZE-" + payoutID
+}
+
+// ListPending returns the provider's pending payouts that have not been
+// approved or rejected here yet, optionally only those for one winner.
+// Payouts still claimed by an unfinished approval are included with their
+// Claim, whatever the provider reports, so they can be retried or settled.
+func ListPending(db *gorm.DB, username string) ([]Payout, error) {
+	payouts, err := fetch(username)
+	if err != nil {
+		return nil, err
+	}
+
+	refs := make([]string, 0, len(payouts))
+	for _, p := range payouts {
+		refs = append(refs, transactionReference(p.ID))
+	}
+	decided := map[string]bool{}
+	claims := map[string]*models.Transaction{}
+	if len(refs) > 0 {
+		var existing []models.Transaction
+		if err := db.Where("transaction_reference IN ?", refs).Find(&existing).Error; err != nil {
+			return nil, err
+		}
+		for i, t := range existing {
+			switch t.PaymentStatus {
+			case models.Successful, models.Rejected:
+				decided[t.TransactionReference] = true
+			default:
+				claims[t.TransactionReference] = &existing[i]
+			}
+		}
+	}
+
+	pending := []Payout{}
+	for _, p := range payouts {
+		p.Claim = claims[transactionReference(p.ID)]
+		if p.Claim != nil || (p.Pending() && !decided[transactionReference(p.ID)]) {
+			pending = append(pending, p)
+		}
+	}
+	return pending, nil
+}
+
+// Detail is a payout with its winner, ticket and any decision taken on it.
+type Detail struct {
+	Payout   Payout
+	Winner   *models.User
+	Ticket   map[string]interface{}
+	Decision *models.Transaction
+}
+
+func Get(db *gorm.DB, id string) (*Detail, error) {
+	payout, err := find(id)
+	if err != nil {
+		return nil, err
+	}
+	detail := &Detail{Payout: *payout}
+
+	var winner models.User
+	if err := db.First(&winner, "phone_number = ?", payout.Username).Error; err == nil {
+		detail.Winner = &winner
+	} else if !errors.Is(err, gorm.ErrRecordNotFound) {
+		return nil, err
+	}
+
+	var decision models.Transaction
+	if err := db.First(&decision, "transaction_reference = ?", transactionReference(id)).Error; err == nil {
+		detail.Decision = &decision
+	} else if !errors.Is(err, gorm.ErrRecordNotFound) {
+		return nil, err
+	}
+
+	if payout.TicketID != "" {
+		tickets, err := gaming.GMInstance().GetUserTickets(payout.Username)
+		if err != nil {
+			log.Printf("[Payouts] Could not fetch tickets for payout %s: %v", id, err)
+		} else {
+			detail.Ticket = findEntry(tickets, payout.TicketID, "tickets", "results", "data")
+		}
+	}
+	return detail, nil
+}
+
+// Approve pays out a pending prize through the gaming provider and records
+// it as a PRIZE_MONEY credit.
+func Approve(db *gorm.DB, id string, admin models.Admin) (*models.Transaction, error) {
+	payout, err := find(id)
+	if err != nil {
+		return nil, err
+	}
+	return approve(db, *payout, admin)
+}
+
+func approve(db *gorm.DB, payout Payout, admin models.Admin) (*models.Transaction, error) {
+	// An earlier approval that never finished is settled first: if the
+	// provider paid, that is this approval's result; if not, its claim is
+	// released and the payout is claimed afresh below.
+	existing, err := findClaim(db, payout.ID)
+	if err != nil {
+		return nil, err
+	}
+	if existing != nil {
+		history, err := resolveClaim(db, payout, *existing)
+		if err != nil || history != nil {
+			return history, err
+		}
+	}
+
+	if !payout.Pending() {
+		return nil, ErrPayoutNotPending
+	}
+	winner, err := findWinner(db, payout)
+	if err != nil {
+		return nil, err
+	}
+
+	// Claim the payout before calling the provider so two admins cannot
+	// both pay it; the claim is dropped only if the provider refuses.
+	history, err := claim(db, payout, *winner, admin, models.Pending, "")
+	if err != nil {
+		return nil, err
+	}
+
+	if _, err := gaming.GMInstance().PayoutByAdmin(payout.ID); err != nil {
+		if !errors.Is(err, gaming.ErrRejected) {
+			// The provider may have paid; Reconcile settles the claim
+			log.Printf("[Payouts] Outcome of payout %s unknown: %v", payout.ID, err)
+			return nil, ErrPayoutUnconfirmed
+		}
+		log.Printf("[Payouts] Provider refused payout %s: %v", payout.ID, err)
+		if delErr := db.Delete(history).Error; delErr != nil {
+			log.Printf("[Payouts] ERROR: could not release claim on payout %s: %v", payout.ID, delErr)
+		}
+		return nil, ErrProviderFailed
+	}
+
+	if err := recordPaid(db, history); err != nil {
+		// The provider has paid; leave the claim PENDING for reconciliation
+		log.Printf("[Payouts] ERROR: payout %s paid but not recorded: %v", payout.ID, err)
+		return nil, fmt.Errorf("record payout failed: %w", err)
+	}
+
+	notify(db, *history, "Prize Paid", "Your prize has been paid into your wallet.", "successful")
+	log.Printf("[Payouts] Admin %s approved payout %s of %d to userID %s", admin.ID, payout.ID, payout.Amount, winner.ID)
+	return history, nil
+}
+
+// Reconcile settles an abandoned approval of a payout against the provider.
+// It returns the SUCCESSFUL decision when the provider paid, or nil when it
+// did not and the claim was released so the payout can be approved again.
+func Reconcile(db *gorm.DB, id string) (*models.Transaction, error) {
+	payout, err := find(id)
+	if err != nil {
+		return nil, err
+	}
+	existing, err := findClaim(db, id)
+	if err != nil {
+		return nil, err
+	}
+	if existing == nil {
+		return nil, ErrNoClaim
+	}
+	return resolveClaim(db, *payout, *existing)
+}
+
+// resolveClaim settles a PENDING claim left by an approval that has not
+// finished. A claim younger than claimTimeout may still be in flight and is
+// left alone.
+func resolveClaim(db *gorm.DB, payout Payout, claim models.Transaction) (*models.Transaction, error) {
+	if claim.PaymentStatus != models.Pending {
+		return nil, ErrPayoutDecided
+	}
+	if time.Since(claim.UpdatedAt) < claimTimeout {
+		return nil, ErrClaimInProgress
+	}
+
+	switch {
+	case payout.Paid():
+		if err := recordPaid(db, &claim); err != nil {
+			return nil, fmt.Errorf("record payout failed: %w", err)
+		}
+		notify(db, claim, "Prize Paid", "Your prize has been paid into your wallet.", "successful")
+		log.Printf("[Payouts] Reconciled payout %s: provider paid, recorded as SUCCESSFUL", payout.ID)
+		return &claim, nil
+	case payout.Pending():
+		result := db.Where("id = ? AND payment_status = ? AND updated_at = ?", claim.ID, models.Pending, claim.UpdatedAt).
+			Delete(&models.Transaction{})
+		if result.Error != nil {
+			return nil, fmt.Errorf("release claim failed: %w", result.Error)
+		}
+		if result.RowsAffected == 0 {
+			return nil, ErrClaimInProgress
+		}
+		log.Printf("[Payouts] Reconciled payout %s: provider has not paid, claim released", payout.ID)
+		return nil, nil
+	}
+	return nil, fmt.Errorf("%w: provider reports status %q", ErrClaimUnresolved, payout.Status)
+}
+
+// recordPaid marks a claimed payout SUCCESSFUL and posts the prize to the
+// ledger. It fails with ErrPayoutDecided if the claim is no longer PENDING.
+func recordPaid(db *gorm.DB, history *models.Transaction) error {
+	paidAt := time.Now()
+	err := db.Transaction(func(tx *gorm.DB) error {
+		result := tx.Model(&models.Transaction{}).
+			Where("id = ? AND payment_status = ?", history.ID, models.Pending).
+			Updates(map[string]interface{}{
+				"payment_status": models.Successful,
+				"paid_at":        paidAt,
+			})
+		if result.Error != nil {
+			return result.Error
+		}
+		if result.RowsAffected == 0 {
+			return ErrPayoutDecided
+		}
+		settled := *history
+		settled.PaymentStatus = models.Successful
+		settled.PaidAt = paidAt
+		return ledger.PostPrizeCredit(tx, settled)
+	})
+	if err != nil {
+		return err
+	}
+	history.PaymentStatus = models.Successful
+	history.PaidAt = paidAt
+	return nil
+}
+
+func findClaim(db *gorm.DB, payoutID string) (*models.Transaction, error) {
+	var claim models.Transaction
+	if err := db.First(&claim, "transaction_reference = ?", transactionReference(payoutID)).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, nil
+		}
+		return nil, err
+	}
+	return &claim, nil
+}
+
+// Reject turns down a pending payout. Nothing is paid; the decision is
+// recorded as a REJECTED PRIZE_MONEY transaction and the winner is told.
+func Reject(db *gorm.DB, id string, admin models.Admin, reason string) (*models.Transaction, error) {
+	payout, err := find(id)
+	if err != nil {
+		return nil, err
+	}
+	if !payout.Pending() {
+		return nil, ErrPayoutNotPending
+	}
+	winner, err := findWinner(db, *payout)
+	if err != nil {
+		return nil, err
+	}
+
+	history, err := claim(db, *payout, *winner, admin, models.Rejected, reason)
+	if err != nil {
+		return nil, err
+	}
+
+	notify(db, *history, "Prize Payout Rejected", "Your prize payout could not be approved. Please contact support.", "rejected")
+	log.Printf("[Payouts] Admin %s rejected payout %s: %s", admin.ID, payout.ID, reason)
+	return history, nil
+}
+
+// BulkResult is the outcome of a bulk approval.
+type BulkResult struct {
+	Approved []models.Transaction
+	Failed   map[string]error
+	Skipped  int
+}
+
+// BulkApprove approves every pending payout of at most maxAmount, one at a
+// time. A failure on one payout does not stop the rest.
+func BulkApprove(db *gorm.DB, admin models.Admin, maxAmount int64) (*BulkResult, error) {
+	pending, err := ListPending(db, "")
+	if err != nil {
+		return nil, err
+	}
+
+	result := &BulkResult{Failed: map[string]error{}}
+	for _, p := range pending {
+		if p.Amount > maxAmount {
+			result.Skipped++
+			continue
+		}
+		history, err := approve(db, p, admin)
+		if err != nil {
+			result.Failed[p.ID] = err
+			continue
+		}
+		result.Approved = append(result.Approved, *history)
+	}
+
+	log.Printf("[Payouts] Admin %s bulk-approved %d payouts under %d (%d failed, %d skipped)",
+		admin.ID, len(result.Approved), maxAmount, len(result.Failed), result.Skipped)
+	return result, nil
+}
+
+// claim records the decision on a payout. Prizes are reported in the gaming
+// base currency; the transaction is in the winner's wallet currency, so the
+// amount is converted and the original kept in the metadata.
+func claim(db *gorm.DB, payout Payout, winner models.User, admin models.Admin, status models.EPaymentStatus, reason string) (*models.Transaction, error) {
+	currency := helpers.UserCurrency(winner)
+	amount := payout.Amount
+	metadata := models.JSONB{
+		"payoutId":  payout.ID,
+		"gameId":    payout.GameID,
+		"ticketId":  payout.TicketID,
+		"decidedBy": admin.ID,
+	}
+	if base, ok := models.ParseCurrency(config.AppConfig.GamingBaseCurrency); ok && base != currency {
+		converted, rate, err := fx.Convert(db, payout.Amount, base, currency)
+		if err != nil {
+			return nil, fmt.Errorf("convert prize from %s to %s: %w", base, currency, err)
+		}
+		amount = converted
+		metadata["prizeAmount"] = payout.Amount
+		metadata["prizeCurrency"] = base
+		metadata["fxRate"] = rate
+	}
+
+	history := models.Transaction{
+		UserID:               winner.ID,
+		Amount:               amount,
+		CustomerEmail:        winner.Email,
+		PaymentStatus:        status,
+		PaymentMethod:        models.Wallet,
+		TransactionReference: transactionReference(payout.ID),
+		Reference:            payout.ID,
+		TransactionType:      models.Credit,
+		Category:             models.PrizeMoney,
+		PaymentType:          models.Payout,
+		Currency:             currency,
+		Metadata:             metadata,
+	}
+	if reason != "" {
+		history.Metadata["reason"] = reason
+	}
+
+	result := db.Clauses(clause.OnConflict{
+		Columns:   []clause.Column{{Name: "transaction_reference"}},
+		DoNothing: true,
+	}).Create(&history)
+	if result.Error != nil {
+		return nil, fmt.Errorf("record payout decision failed: %w", result.Error)
+	}
+	if result.RowsAffected == 0 {
+		return nil, ErrPayoutDecided
+	}
+	return &history, nil
+}
+
+func notify(db *gorm.DB, history models.Transaction, title, subtitle, status string) {
+	notif := models.Notification{
+		UserID:   history.UserID,
+		Type:     models.Games,
+		Title:    title,
+		Subtitle: subtitle,
+		Amount:   history.Amount,
+		Currency: string(history.Currency),
+		Status:   status,
+	}
+	if err := db.Create(&notif).Error; err != nil {
+		log.Printf("[Payouts] WARNING: could not notify userID %s about payout %s: %v", history.UserID, history.Reference, err)
+	}
+}
+
+func findWinner(db *gorm.DB, payout Payout) (*models.User, error) {
+	var winner models.User
+	if err := db.First(&winner, "phone_number = ?", payout.Username).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, ErrWinnerNotFound
+		}
+		return nil, err
+	}
+	return &winner, nil
+}
+
+func find(id string) (*Payout, error) {
+	payouts, err := fetch("")
+	if err != nil {
+		return nil, err
+	}
+	for _, p := range payouts {
+		if p.ID == id {
+			return &p, nil
+		}
+	}
+	return nil, ErrPayoutNotFound
+}
+
+func fetch(username string) ([]Payout, error) {
+	gs := gaming.GMInstance()
+	var raw map[string]interface{}
+	var err error
+	if username != "" {
+		raw, err = gs.ListUserPayout(username)
+	} else {
+		raw, err = gs.ListPayouts()
+	}
+	if err != nil {
+		return nil, fmt.Errorf("gaming: %w", err)
+	}
+	return parsePayouts(raw), nil
+}
+
+// parsePayouts reads the gaming API's loosely typed payout list.
+func parsePayouts(raw map[string]interface{}) []Payout {
+	var out []Payout
+	for _, entry := range list(raw, "payouts", "results", "data") {
+		p, ok := entry.(map[string]interface{})
+		if !ok {
+			continue
+		}
+		id := firstString(p, "payout_id", "id", "reference")
+		if id == "" {
+			continue
+		}
+		out = append(out, Payout{
+			ID:        id,
+			Username:  firstString(p, "username", "user", "phone_number"),
+			Amount:    int64(math.Round(toFloat(p["amount"]))),
+			Status:    firstString(p, "status"),
+			GameID:    firstString(p, "game_id", "game"),
+			TicketID:  firstString(p, "ticket_id", "ticket"),
+			CreatedAt: firstString(p, "created_at", "date", "timestamp"),
+		})
+	}
+	return out
+}
+
+func findEntry(raw map[string]interface{}, id string, keys ...string) map[string]interface{} {
+	for _, entry := range list(raw, keys...) {
+		m, ok := entry.(map[string]interface{})
+		if ok && firstString(m, "ticket_id", "id") == id {
+			return m
+		}
+	}
+	return nil
+}
+
+func list(raw map[string]interface{}, keys ...string) []interface{} {
+	for _, key := range keys {
+		if v, ok := raw[key].([]interface{}); ok {
+			return v
+		}
+	}
+	return nil
+}
+
+func firstString(m map[string]interface{}, keys ...string) string {
+	for _, k := range keys {
+		switch v := m[k].(type) {
+		case string:
+			if v != "" {
+				return v
+			}
+		case float64:
+			return fmt.Sprintf("%.0f", v)
+		}
+	}
+	return ""
+}
+
+func toFloat(v interface{}) float64 {
+	switch n := v.(type) {
+	case float64:
+		return n
+	case string:
+		var f float64
+		fmt.Sscanf(n, "%f", &f)
+		return f
+	}
+	return 0
+}
@@ -0,0 +1,66 @@
+package payouts
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/dblaq/buzzycash/internal/models"
+)
+
+func TestResolveClaimLeavesLiveAndDecidedClaims(t *testing.T) {
+	payout := Payout{ID: "p1", Status: "paid"}
+
+	tests := []struct {
+		name  string
+		claim models.Transaction
+		want  error
+	}{
+		{
+			name:  "claim still in flight",
+			claim: models.Transaction{PaymentStatus: models.Pending, UpdatedAt: time.Now()},
+			want:  ErrClaimInProgress,
+		},
+		{
+			name:  "already approved",
+			claim: models.Transaction{PaymentStatus: models.Successful, UpdatedAt: time.Now().Add(-time.Hour)},
+			want:  ErrPayoutDecided,
+		},
+		{
+			name:  "already rejected",
+			claim: models.Transaction{PaymentStatus: models.Rejected, UpdatedAt: time.Now().Add(-time.Hour)},
+			want:  ErrPayoutDecided,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// Neither case may touch the database or the provider
+			history, err := resolveClaim(nil, payout, tt.claim)
+			if !errors.Is(err, tt.want) || history != nil {
+				t.Fatalf("resolveClaim = %v, %v; want nil, %v", history, err, tt.want)
+			}
+		})
+	}
+}
+
+func TestResolveClaimUnknownProviderStatus(t *testing.T) {
+	claim := models.Transaction{PaymentStatus: models.Pending, UpdatedAt: time.Now().Add(-2 * claimTimeout)}
+	_, err := resolveClaim(nil, Payout{ID: "p1", Status: "on_hold"}, claim)
+	if !errors.Is(err, ErrClaimUnresolved) {
+		t.Fatalf("err = %v, want ErrClaimUnresolved", err)
+	}
+}
+
+func TestPayoutStale(t *testing.T) {
+	if (Payout{}).Stale() {
+		t.Error("unclaimed payout reported stale")
+	}
+	fresh := Payout{Claim: &models.Transaction{UpdatedAt: time.Now()}}
+	if fresh.Stale() {
+		t.Error("fresh claim reported stale")
+	}
+	old := Payout{Claim: &models.Transaction{UpdatedAt: time.Now().Add(-claimTimeout - time.Minute)}}
+	if !old.Stale() {
+		t.Error("abandoned claim not reported stale")
+	}
+}
@@ -0,0 +1,39 @@
+package payouts
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/dblaq/buzzycash/internal/config"
+)
+
+// Validation errors
+var (
+	ErrReasonMissing    = errors.New("a reason is required")
+	ErrReasonTooLong    = errors.New("reason must be at most 500 characters")
+	ErrInvalidMaxAmount = errors.New("max_amount must be greater than 0")
+)
+
+const maxReasonLength = 500
+
+func (r *RejectPayoutRequest) Validate() error {
+	r.Reason = strings.TrimSpace(r.Reason)
+	if r.Reason == "" {
+		return ErrReasonMissing
+	}
+	if len(r.Reason) > maxReasonLength {
+		return ErrReasonTooLong
+	}
+	return nil
+}
+
+func (r *BulkApproveRequest) Validate() error {
+	if r.MaxAmount <= 0 {
+		return ErrInvalidMaxAmount
+	}
+	if limit := config.AppConfig.PrizeBulkApproveMax; r.MaxAmount > limit {
+		return fmt.Errorf("max_amount cannot exceed %d", limit)
+	}
+	return nil
+}
@@ -33,7 +33,7 @@ func _() {}
 
 
 // @Summary Update user profile
-// @Description Update fields of the authenticated user’s profile
+// @Description Update fields of the authenticated user’s profile. The date of birth (YYYY-MM-DD) is needed to play and cannot be changed once set
 // @Tags profile
 // @Accept json
 // @Produce json
@@ -42,6 +42,7 @@ func _() {}
 // @Failure 400 {object} map[string]interface{} "Validation error"
 // @Failure 401 {object} map[string]interface{} "Unauthorized"
 // @Failure 404 {object} map[string]interface{} "Profile not found"
+// @Failure 409 {object} map[string]interface{} "Date of birth already set"
 // @Failure 500 {object} map[string]interface{} "Internal server error"
 // @Router /profile/update-profile [patch]
 // @Security BearerAuth
@@ -12,6 +12,7 @@ import (
 	"github.com/dblaq/buzzycash/external/mailers"
 	"github.com/dblaq/buzzycash/internal/utils"
 	"github.com/dblaq/buzzycash/external/gaming"
+	"github.com/dblaq/buzzycash/internal/core/audit"
 	"github.com/gin-gonic/gin"
 	"errors"
 	"gorm.io/gorm"
@@ -105,6 +106,7 @@ func (h *ProfileHandler) CreateProfileHandler(ctx *gin.Context) {
 		return
 	}
 
+	audit.Log(ctx, h.db, audit.ProfileUpdated, audit.Target{Type: audit.TargetUser, ID: currentUser.ID}, nil, updateData)
 	log.Printf("Profile created successfully for user %s", currentUser.ID)
 	ctx.JSON(http.StatusCreated, gin.H{
 		"message": "User profile created successfully",
@@ -113,7 +115,7 @@ func (h *ProfileHandler) CreateProfileHandler(ctx *gin.Context) {
 			"phoneNumber":      existingUser.PhoneNumber,
 			"fullName":         existingUser.FullName,
 			"gender":           existingUser.Gender,
-			"dateOfBirth":      existingUser.DateOfBirth,
+			"dateOfBirth":      existingUser.DateOfBirthString(),
 			"email":            existingUser.Email,
 			"isProfileCreated": existingUser.IsProfileCreated,
 			"isVerified":       existingUser.IsVerified,
@@ -141,7 +143,7 @@ func (h *ProfileHandler) GetUserProfileHandler(ctx *gin.Context) {
 			"phoneNumber":        currentUser.PhoneNumber,
 			"fullName":           currentUser.FullName,
 			"gender":             currentUser.Gender,
-			"dateOfBirth":        currentUser.DateOfBirth,
+			"dateOfBirth":        currentUser.DateOfBirthString(),
 			"email":              currentUser.Email,
 			"isProfileCreated":   currentUser.IsProfileCreated,
 			"isVerified":         currentUser.IsVerified,
@@ -185,9 +187,20 @@ func (h *ProfileHandler) UpdateUserProfileHandler(ctx *gin.Context) {
 		updateData["gender"] = req.Gender
 	}
 	if req.DateOfBirth != "" {
-		updateData["date_of_birth"] = req.DateOfBirth
+		dob, _ := parseDateOfBirth(req.DateOfBirth)
+		// The age check relies on this, so it cannot be edited to get past it
+		if existingUser.DateOfBirth != nil && !existingUser.DateOfBirth.Equal(dob) {
+			utils.Error(ctx, http.StatusConflict, ErrDateOfBirthLocked.Error())
+			return
+		}
+		updateData["date_of_birth"] = dob
 	}
 
+	before := gin.H{
+		"full_name":     existingUser.FullName,
+		"gender":        existingUser.Gender,
+		"date_of_birth": existingUser.DateOfBirthString(),
+	}
 	var updatedUser models.User
 	if err := h.db.Model(&existingUser).Updates(updateData).Scan(&updatedUser).Error; err != nil {
 		log.Printf("Error updating profile for user %s: %v", currentUser.ID, err)
@@ -195,6 +208,7 @@ func (h *ProfileHandler) UpdateUserProfileHandler(ctx *gin.Context) {
 		return
 	}
 
+	audit.Log(ctx, h.db, audit.ProfileUpdated, audit.Target{Type: audit.TargetUser, ID: currentUser.ID}, before, updateData)
 	log.Printf("Profile updated successfully for user %s", currentUser.ID)
 	ctx.JSON(http.StatusOK, gin.H{
 		"message": "Profile updated successfully",
@@ -202,7 +216,7 @@ func (h *ProfileHandler) UpdateUserProfileHandler(ctx *gin.Context) {
 			"id":            updatedUser.ID,
 			"fullName":     updatedUser.FullName,
 			"gender":        updatedUser.Gender,
-			"dateOfBirth": updatedUser.DateOfBirth,
+			"dateOfBirth": updatedUser.DateOfBirthString(),
 		},
 	})
 }
@@ -350,7 +364,7 @@ func (h *ProfileHandler) VerifyAccountEmailHandler(ctx *gin.Context) {
 			"isProfileCreated":   user.IsProfileCreated,
 			"countryOfResidence": user.CountryOfResidence,
 			"gender":             user.Gender,
-			"dateOfBirth":        user.DateOfBirth,
+			"dateOfBirth":        user.DateOfBirthString(),
 			"profilePicture":     user.ProfilePicture,
 		},
 	})
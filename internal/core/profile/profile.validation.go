@@ -4,6 +4,7 @@ import (
 	"errors"
 	"regexp"
 	"strings"
+	"time"
 )
 
 // Validation errors
@@ -26,6 +27,10 @@ var (
 	ErrUsernameContainsDotMail = errors.New("username cannot contain '.mail'")
 	ErrUsernameIsEmail       = errors.New("username cannot be an email address")
 	ErrNoFieldsToUpdate      = errors.New("at least one field must be provided to update")
+	ErrInvalidDateOfBirth    = errors.New("date of birth must be a date in the format YYYY-MM-DD")
+	ErrDateOfBirthInFuture   = errors.New("date of birth cannot be in the future")
+	ErrDateOfBirthTooOld     = errors.New("date of birth is not plausible")
+	ErrDateOfBirthLocked     = errors.New("date of birth cannot be changed once set; please contact support")
 )
 
 // ValidateCreateProfile validates CreateProfileRequest
@@ -93,9 +98,33 @@ func (r *ProfileUpdateRequest) Validate() error {
 		return ErrInvalidGender
 	}
 
+	// Validate date of birth if provided
+	if strings.TrimSpace(r.DateOfBirth) != "" {
+		if _, err := parseDateOfBirth(r.DateOfBirth); err != nil {
+			return err
+		}
+	}
+
 	return nil
 }
 
+// parseDateOfBirth reads a YYYY-MM-DD date of birth. Whether the user is
+// old enough depends on their country and is checked when they play.
+func parseDateOfBirth(value string) (time.Time, error) {
+	dob, err := time.Parse("2006-01-02", strings.TrimSpace(value))
+	if err != nil {
+		return time.Time{}, ErrInvalidDateOfBirth
+	}
+	now := time.Now()
+	if dob.After(now) {
+		return time.Time{}, ErrDateOfBirthInFuture
+	}
+	if dob.Before(now.AddDate(-120, 0, 0)) {
+		return time.Time{}, ErrDateOfBirthTooOld
+	}
+	return dob, nil
+}
+
 // validateUsername implements all username validation rules
 func validateUsername(username string) error {
 	if strings.TrimSpace(username) == "" {
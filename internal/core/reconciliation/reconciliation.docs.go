@@ -0,0 +1,28 @@
+package reconciliation
+
+// @Summary Get reconciliation report
+// @Description Match a day's Nomba, Flutterwave and gaming wallet records against our transactions by reference
+// @Tags admin-reconciliation
+// @Accept json
+// @Produce json
+// @Param date query string false "Settlement day as YYYY-MM-DD (defaults to yesterday)"
+// @Success 200 {object} map[string]interface{} "Reconciliation report"
+// @Failure 400 {object} map[string]interface{} "Invalid date"
+// @Failure 401 {object} map[string]interface{} "Unauthorized"
+// @Failure 500 {object} map[string]interface{} "Failed to build reconciliation report"
+// @Router /admin/reconciliation [get]
+// @Security BearerAuth
+func _() {}
+
+// @Summary Export reconciliation report
+// @Description Download a day's reconciliation report as CSV
+// @Tags admin-reconciliation
+// @Produce text/csv
+// @Param date query string false "Settlement day as YYYY-MM-DD (defaults to yesterday)"
+// @Success 200 {file} file "Reconciliation CSV"
+// @Failure 400 {object} map[string]interface{} "Invalid date"
+// @Failure 401 {object} map[string]interface{} "Unauthorized"
+// @Failure 500 {object} map[string]interface{} "Failed to build reconciliation report"
+// @Router /admin/reconciliation/export [get]
+// @Security BearerAuth
+func _() {}
@@ -0,0 +1,48 @@
+package reconciliation
+
+import "time"
+
+type Source string
+type MatchResult string
+
+const (
+	FlutterwaveSource   Source = "FLUTTERWAVE"
+	NombaSource         Source = "NOMBA"
+	GamingCreditsSource Source = "GAMING_CREDITS"
+	GamingPayoutsSource Source = "GAMING_PAYOUTS"
+)
+
+const (
+	Matched         MatchResult = "MATCHED"
+	MissingInternal MatchResult = "MISSING_INTERNAL" // provider has it, we do not
+	MissingProvider MatchResult = "MISSING_PROVIDER" // we have it, provider does not
+	AmountMismatch  MatchResult = "AMOUNT_MISMATCH"
+)
+
+// Item is one reference compared between our records and a provider.
+type Item struct {
+	Source         Source      `json:"source"`
+	Reference      string      `json:"reference"`
+	Result         MatchResult `json:"result"`
+	OurAmount      float64     `json:"ourAmount"`
+	ProviderAmount float64     `json:"providerAmount"`
+	OurStatus      string      `json:"ourStatus,omitempty"`
+	ProviderStatus string      `json:"providerStatus,omitempty"`
+	TransactionID  string      `json:"transactionId,omitempty"`
+}
+
+type SourceSummary struct {
+	Source          Source `json:"source"`
+	Matched         int    `json:"matched"`
+	MissingInternal int    `json:"missingInternal"`
+	MissingProvider int    `json:"missingProvider"`
+	AmountMismatch  int    `json:"amountMismatch"`
+	Error           string `json:"error,omitempty"`
+}
+
+type Report struct {
+	Date        string          `json:"date"`
+	GeneratedAt time.Time       `json:"generatedAt"`
+	Summary     []SourceSummary `json:"summary"`
+	Items       []Item          `json:"items"`
+}
@@ -0,0 +1,100 @@
+package reconciliation
+
+import (
+	"encoding/csv"
+	"fmt"
+	"log"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/dblaq/buzzycash/internal/utils"
+	"github.com/gin-gonic/gin"
+	"gorm.io/gorm"
+)
+
+type ReconciliationHandler struct {
+	service *ReconciliationService
+}
+
+func NewReconciliationHandler(db *gorm.DB) *ReconciliationHandler {
+	return &ReconciliationHandler{
+		service: NewReconciliationService(db),
+	}
+}
+
+// parseDay reads the optional "date" query parameter (YYYY-MM-DD),
+// defaulting to yesterday, the most recent complete settlement day.
+func parseDay(ctx *gin.Context) (time.Time, error) {
+	date := ctx.Query("date")
+	if date == "" {
+		return time.Now().AddDate(0, 0, -1), nil
+	}
+	return time.ParseInLocation("2006-01-02", date, time.Local)
+}
+
+func (h *ReconciliationHandler) GetReportHandler(ctx *gin.Context) {
+	day, err := parseDay(ctx)
+	if err != nil {
+		utils.Error(ctx, http.StatusBadRequest, "date must be in YYYY-MM-DD format")
+		return
+	}
+
+	report, err := h.service.Run(day)
+	if err != nil {
+		log.Printf("[Reconciliation] Failed to build report for %s: %v", day.Format("2006-01-02"), err)
+		utils.Error(ctx, http.StatusInternalServerError, "Failed to build reconciliation report")
+		return
+	}
+
+	ctx.JSON(http.StatusOK, gin.H{
+		"message": "Reconciliation report generated successfully",
+		"data":    report,
+	})
+}
+
+func (h *ReconciliationHandler) ExportReportHandler(ctx *gin.Context) {
+	day, err := parseDay(ctx)
+	if err != nil {
+		utils.Error(ctx, http.StatusBadRequest, "date must be in YYYY-MM-DD format")
+		return
+	}
+
+	report, err := h.service.Run(day)
+	if err != nil {
+		log.Printf("[Reconciliation] Failed to build report for %s: %v", day.Format("2006-01-02"), err)
+		utils.Error(ctx, http.StatusInternalServerError, "Failed to build reconciliation report")
+		return
+	}
+
+	filename := fmt.Sprintf("reconciliation-%s.csv", report.Date)
+	ctx.Header("Content-Type", "text/csv")
+	ctx.Header("Content-Disposition", "attachment; filename="+filename)
+	ctx.Status(http.StatusOK)
+
+	w := csv.NewWriter(ctx.Writer)
+	w.Write([]string{"source", "reference", "result", "our_amount", "provider_amount", "our_status", "provider_status", "transaction_id"})
+	for _, item := range report.Items {
+		w.Write([]string{
+			string(item.Source),
+			item.Reference,
+			string(item.Result),
+			strconv.FormatFloat(item.OurAmount, 'f', 2, 64),
+			strconv.FormatFloat(item.ProviderAmount, 'f', 2, 64),
+			item.OurStatus,
+			item.ProviderStatus,
+			item.TransactionID,
+		})
+	}
+	// Sources that could not be fetched are flagged so a partial export
+	// is never mistaken for a clean one.
+	for _, s := range report.Summary {
+		if s.Error != "" {
+			w.Write([]string{string(s.Source), "", "SOURCE_ERROR", "", "", "", s.Error, ""})
+		}
+	}
+	w.Flush()
+	if err := w.Error(); err != nil {
+		log.Printf("[Reconciliation] CSV export for %s failed: %v", report.Date, err)
+	}
+}
@@ -0,0 +1,17 @@
+package reconciliation
+
+import (
+	"github.com/dblaq/buzzycash/internal/middlewares"
+	"github.com/dblaq/buzzycash/internal/models"
+	"github.com/gin-gonic/gin"
+	"gorm.io/gorm"
+)
+
+func ReconciliationRoutes(rg *gin.RouterGroup, db *gorm.DB) {
+	reconciliationHandler := NewReconciliationHandler(db)
+	reconciliationRoutes := rg.Group("/admin/reconciliation", middlewares.AdminAuthMiddleware, middlewares.RequirePermission(models.PermFinanceRead))
+	{
+		reconciliationRoutes.GET("", reconciliationHandler.GetReportHandler)
+		reconciliationRoutes.GET("/export", reconciliationHandler.ExportReportHandler)
+	}
+}
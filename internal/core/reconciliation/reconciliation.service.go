@@ -0,0 +1,360 @@
+package reconciliation
+
+import (
+	"fmt"
+	"math"
+	"strings"
+	"time"
+
+	"github.com/dblaq/buzzycash/external/gaming"
+	"github.com/dblaq/buzzycash/external/gateway"
+	"github.com/dblaq/buzzycash/internal/core/outbox"
+	"github.com/dblaq/buzzycash/internal/models"
+	"gorm.io/gorm"
+)
+
+// amountTolerance absorbs float rounding on provider amounts.
+const amountTolerance = 0.01
+
+type record struct {
+	Reference     string
+	Amount        float64
+	Status        string
+	TransactionID string
+}
+
+type ReconciliationService struct {
+	db *gorm.DB
+}
+
+func NewReconciliationService(db *gorm.DB) *ReconciliationService {
+	return &ReconciliationService{
+		db: db,
+	}
+}
+
+// Run builds the report for the calendar day starting at day. A provider
+// that cannot be reached is reported in its summary instead of failing the
+// whole report.
+func (s *ReconciliationService) Run(day time.Time) (*Report, error) {
+	from := time.Date(day.Year(), day.Month(), day.Day(), 0, 0, 0, 0, day.Location())
+	to := from.Add(24 * time.Hour)
+
+	report := &Report{
+		Date:        from.Format("2006-01-02"),
+		GeneratedAt: time.Now(),
+		Items:       []Item{},
+	}
+
+	sources := []struct {
+		source Source
+		run    func(from, to time.Time) ([]Item, error)
+	}{
+		{FlutterwaveSource, s.reconcileFlutterwave},
+		{NombaSource, s.reconcileNomba},
+		{GamingCreditsSource, s.reconcileGamingCredits},
+		{GamingPayoutsSource, s.reconcileGamingPayouts},
+	}
+
+	for _, src := range sources {
+		items, err := src.run(from, to)
+		summary := summarise(src.source, items)
+		if err != nil {
+			summary.Error = err.Error()
+		}
+		report.Summary = append(report.Summary, summary)
+		report.Items = append(report.Items, items...)
+	}
+
+	return report, nil
+}
+
+func (s *ReconciliationService) reconcileFlutterwave(from, to time.Time) ([]Item, error) {
+	ours, expected, err := s.loadTransactions(from, to, models.Flutterwave, models.Deposit)
+	if err != nil {
+		return nil, err
+	}
+
+	txns, err := gateway.FWInstance().ListTransactions(from, to.Add(-time.Second))
+	if err != nil {
+		return nil, fmt.Errorf("flutterwave: %w", err)
+	}
+
+	var theirs []record
+	for _, t := range txns {
+		if !strings.EqualFold(t.Status, "successful") {
+			continue
+		}
+		theirs = append(theirs, record{Reference: t.Reference(), Amount: t.Amount, Status: t.Status})
+	}
+
+	return match(FlutterwaveSource, ours, expected, theirs), nil
+}
+
+func (s *ReconciliationService) reconcileNomba(from, to time.Time) ([]Item, error) {
+	ours, expected, err := s.loadTransactions(from, to, models.Nomba, models.Deposit, models.WithdrawRequest)
+	if err != nil {
+		return nil, err
+	}
+
+	txns, err := gateway.NBInstance().ListTransactions(from, to)
+	if err != nil {
+		return nil, fmt.Errorf("nomba: %w", err)
+	}
+
+	var theirs []record
+	for _, t := range txns {
+		status := strings.ToUpper(t.Status)
+		if status != "SUCCESS" && status != "SUCCESSFUL" && status != "PAYMENT_SUCCESSFUL" {
+			continue
+		}
+		ref := t.Reference()
+		if ref == "" {
+			continue
+		}
+		theirs = append(theirs, record{Reference: ref, Amount: t.Amount, Status: t.Status})
+	}
+
+	return match(NombaSource, ours, expected, theirs), nil
+}
+
+// reconcileGamingCredits checks every settled deposit reached the gaming
+// wallet, using the provider acknowledgements recorded by the outbox.
+func (s *ReconciliationService) reconcileGamingCredits(from, to time.Time) ([]Item, error) {
+	var deposits []models.Transaction
+	if err := s.db.
+		Where("category = ? AND payment_status = ? AND created_at >= ? AND created_at < ?", models.Deposit, models.Successful, from, to).
+		Find(&deposits).Error; err != nil {
+		return nil, fmt.Errorf("load deposits failed: %w", err)
+	}
+
+	ours := map[string]record{}
+	expected := map[string]bool{}
+	ids := make([]string, 0, len(deposits))
+	for _, d := range deposits {
+		ours[d.ID] = record{Reference: d.ID, Amount: creditedAmount(d), Status: string(d.PaymentStatus), TransactionID: d.ID}
+		expected[d.ID] = true
+		ids = append(ids, d.ID)
+	}
+
+	var credits []models.WalletCreditOutbox
+	if err := s.db.
+		Where("status = ? AND direction = ?", models.OutboxDispatched, models.OutboxCredit).
+		// Refunds of reversed withdrawals are credits too, but not deposits
+		Where("idempotency_key LIKE ?", outbox.DepositCreditPrefix+"%").
+		Where(s.db.Where("transaction_id IN ?", ids).Or("dispatched_at >= ? AND dispatched_at < ?", from, to)).
+		Find(&credits).Error; err != nil {
+		return nil, fmt.Errorf("load wallet credits failed: %w", err)
+	}
+
+	var theirs []record
+	for _, c := range credits {
+		theirs = append(theirs, record{Reference: c.TransactionID, Amount: c.Amount, Status: string(c.Status)})
+	}
+
+	items := match(GamingCreditsSource, ours, expected, theirs)
+
+	// Report deposits by their gateway reference rather than row ID
+	refs := map[string]string{}
+	for _, d := range deposits {
+		refs[d.ID] = d.Reference
+	}
+	for i := range items {
+		if ref, ok := refs[items[i].Reference]; ok {
+			items[i].Reference = ref
+		}
+	}
+	return items, nil
+}
+
+func (s *ReconciliationService) reconcileGamingPayouts(from, to time.Time) ([]Item, error) {
+	var prizes []models.Transaction
+	if err := s.db.
+		Where("category = ? AND created_at >= ? AND created_at < ?", models.PrizeMoney, from, to).
+		Find(&prizes).Error; err != nil {
+		return nil, fmt.Errorf("load prize transactions failed: %w", err)
+	}
+	ours, expected := index(prizes)
+
+	raw, err := gaming.GMInstance().ListPayouts()
+	if err != nil {
+		return nil, fmt.Errorf("gaming: %w", err)
+	}
+
+	return match(GamingPayoutsSource, ours, expected, gamingPayoutRecords(raw, from, to)), nil
+}
+
+// loadTransactions returns every transaction for the day keyed by reference,
+// plus the set of references the provider is expected to hold.
+func (s *ReconciliationService) loadTransactions(from, to time.Time, method models.EPaymentMethod, categories ...models.TransactionCategory) (map[string]record, map[string]bool, error) {
+	var txns []models.Transaction
+	if err := s.db.
+		Where("UPPER(payment_method) = ? AND category IN ? AND created_at >= ? AND created_at < ?", method, categories, from, to).
+		Find(&txns).Error; err != nil {
+		return nil, nil, fmt.Errorf("load transactions failed: %w", err)
+	}
+	ours, expected := index(txns)
+	return ours, expected, nil
+}
+
+func index(txns []models.Transaction) (map[string]record, map[string]bool) {
+	ours := map[string]record{}
+	expected := map[string]bool{}
+	for _, t := range txns {
+		ours[t.Reference] = record{
+			Reference:     t.Reference,
+			Amount:        float64(t.Amount),
+			Status:        string(t.PaymentStatus),
+			TransactionID: t.ID,
+		}
+		// Reversed money did move at the provider on the day
+		if t.PaymentStatus == models.Successful || t.PaymentStatus == models.Reversed {
+			expected[t.Reference] = true
+		}
+	}
+	return ours, expected
+}
+
+func match(source Source, ours map[string]record, expected map[string]bool, theirs []record) []Item {
+	items := []Item{}
+	seen := map[string]bool{}
+
+	for _, p := range theirs {
+		seen[p.Reference] = true
+		o, ok := ours[p.Reference]
+		item := Item{
+			Source:         source,
+			Reference:      p.Reference,
+			ProviderAmount: p.Amount,
+			ProviderStatus: p.Status,
+		}
+		if ok {
+			item.OurAmount = o.Amount
+			item.OurStatus = o.Status
+			item.TransactionID = o.TransactionID
+		}
+
+		switch {
+		case !ok || !expected[p.Reference]:
+			item.Result = MissingInternal
+		case math.Abs(o.Amount-p.Amount) > amountTolerance:
+			item.Result = AmountMismatch
+		default:
+			item.Result = Matched
+		}
+		items = append(items, item)
+	}
+
+	for ref := range expected {
+		if seen[ref] {
+			continue
+		}
+		o := ours[ref]
+		items = append(items, Item{
+			Source:        source,
+			Reference:     ref,
+			Result:        MissingProvider,
+			OurAmount:     o.Amount,
+			OurStatus:     o.Status,
+			TransactionID: o.TransactionID,
+		})
+	}
+
+	return items
+}
+
+func summarise(source Source, items []Item) SourceSummary {
+	summary := SourceSummary{Source: source}
+	for _, item := range items {
+		switch item.Result {
+		case Matched:
+			summary.Matched++
+		case MissingInternal:
+			summary.MissingInternal++
+		case MissingProvider:
+			summary.MissingProvider++
+		case AmountMismatch:
+			summary.AmountMismatch++
+		}
+	}
+	return summary
+}
+
+// gamingPayoutRecords pulls the day's payouts out of the gaming API's
+// loosely typed list response.
+func gamingPayoutRecords(raw map[string]interface{}, from, to time.Time) []record {
+	var list []interface{}
+	for _, key := range []string{"payouts", "results", "data"} {
+		if v, ok := raw[key].([]interface{}); ok {
+			list = v
+			break
+		}
+	}
+
+	var out []record
+	for _, entry := range list {
+		p, ok := entry.(map[string]interface{})
+		if !ok {
+			continue
+		}
+		if created, ok := parseTime(firstString(p, "created_at", "date", "timestamp")); ok {
+			if created.Before(from) || !created.Before(to) {
+				continue
+			}
+		}
+		ref := firstString(p, "reference", "payout_id", "id")
+		if ref == "" {
+			continue
+		}
+		out = append(out, record{
+			Reference: ref,
+			Amount:    toFloat(p["amount"]),
+			Status:    firstString(p, "status"),
+		})
+	}
+	return out
+}
+
+func firstString(m map[string]interface{}, keys ...string) string {
+	for _, k := range keys {
+		switch v := m[k].(type) {
+		case string:
+			if v != "" {
+				return v
+			}
+		case float64:
+			return fmt.Sprintf("%.0f", v)
+		}
+	}
+	return ""
+}
+
+func toFloat(v interface{}) float64 {
+	switch n := v.(type) {
+	case float64:
+		return n
+	case string:
+		var f float64
+		fmt.Sscanf(n, "%f", &f)
+		return f
+	}
+	return 0
+}
+
+func parseTime(s string) (time.Time, bool) {
+	for _, layout := range []string{time.RFC3339Nano, "2006-01-02T15:04:05", "2006-01-02 15:04:05", "2006-01-02"} {
+		if t, err := time.Parse(layout, s); err == nil {
+			return t, true
+		}
+	}
+	return time.Time{}, false
+}
+
+// creditedAmount is what the deposit put in the gaming wallet, which for
+// Nomba is net of fees.
+func creditedAmount(history models.Transaction) float64 {
+	if v, ok := history.Metadata["creditedAmount"].(float64); ok {
+		return v
+	}
+	return float64(history.Amount)
+}
@@ -0,0 +1,64 @@
+package reconciliation
+
+import (
+	"testing"
+	"time"
+
+	"github.com/dblaq/buzzycash/internal/core/outbox"
+	"github.com/dblaq/buzzycash/internal/models"
+	"github.com/dblaq/buzzycash/internal/testutil"
+	"github.com/google/uuid"
+)
+
+func TestGamingCreditsIgnoreWithdrawalRefunds(t *testing.T) {
+	db := testutil.DB(t)
+	user := testutil.User(t, db)
+	now := time.Now()
+
+	deposit := models.Transaction{
+		UserID:               user.ID,
+		Amount:               5000,
+		TransactionReference: "TX-" + uuid.NewString(),
+		Reference:            "REF-" + uuid.NewString(),
+		PaymentStatus:        models.Successful,
+		PaymentMethod:        models.Nomba,
+		TransactionType:      models.Credit,
+		Category:             models.Deposit,
+		Currency:             models.NGN,
+	}
+	if err := db.Create(&deposit).Error; err != nil {
+		t.Fatalf("create deposit: %v", err)
+	}
+	// The deposit's own credit, and a refund of some reversed withdrawal
+	for key, transactionID := range map[string]string{
+		outbox.DepositCreditKey(deposit):                   deposit.ID,
+		outbox.ReversalCreditKey("WD-" + uuid.NewString()): uuid.NewString(),
+	} {
+		credit := models.WalletCreditOutbox{
+			IdempotencyKey: key,
+			Direction:      models.OutboxCredit,
+			TransactionID:  transactionID,
+			UserID:         user.ID,
+			Username:       user.PhoneNumber,
+			Amount:         5000,
+			Status:         models.OutboxDispatched,
+			DispatchedAt:   &now,
+		}
+		if err := db.Create(&credit).Error; err != nil {
+			t.Fatalf("create credit %s: %v", key, err)
+		}
+	}
+
+	items, err := NewReconciliationService(db).reconcileGamingCredits(now.Add(-time.Hour), now.Add(time.Hour))
+	if err != nil {
+		t.Fatalf("reconcileGamingCredits: %v", err)
+	}
+	for _, item := range items {
+		if item.Result != Matched {
+			t.Errorf("%s reconciled as %s, want only matched deposits", item.Reference, item.Result)
+		}
+	}
+	if len(items) != 1 {
+		t.Errorf("reconciled %d items, want 1", len(items))
+	}
+}
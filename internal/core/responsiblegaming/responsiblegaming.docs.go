@@ -0,0 +1,67 @@
+package responsiblegaming
+
+// @Summary Get responsible gaming settings
+// @Description Get the user's deposit and spend limits with what has been used this period, any cool-off or self-exclusion in force and their reality-check interval
+// @Tags responsible-gaming
+// @Produce json
+// @Success 200 {object} StatusResponse "Settings"
+// @Failure 401 {object} map[string]interface{} "Unauthorized"
+// @Failure 500 {object} map[string]interface{} "Failed to fetch responsible gaming settings"
+// @Router /responsible-gaming [get]
+// @Security BearerAuth
+func _() {}
+
+
+// @Summary Set deposit or spend limit
+// @Description Set a daily, weekly or monthly deposit or spend limit in naira; 0 removes it. Lower limits apply immediately, higher ones and removals after a cooling period
+// @Tags responsible-gaming
+// @Accept json
+// @Produce json
+// @Param request body SetLimitRequest true "Limit"
+// @Success 200 {object} LimitResponse "Limit set or change scheduled"
+// @Failure 400 {object} map[string]interface{} "Invalid limit"
+// @Failure 404 {object} map[string]interface{} "No limit to remove"
+// @Router /responsible-gaming/limits [put]
+// @Security BearerAuth
+func _() {}
+
+
+// @Summary Start a cool-off
+// @Description Stop play and deposits for a number of days. A cool-off cannot be ended early
+// @Tags responsible-gaming
+// @Accept json
+// @Produce json
+// @Param request body CoolOffRequest true "Cool-off"
+// @Success 201 {object} ExclusionResponse "Cool-off started"
+// @Failure 400 {object} map[string]interface{} "Invalid duration"
+// @Failure 409 {object} map[string]interface{} "Already on a longer break"
+// @Router /responsible-gaming/cool-off [post]
+// @Security BearerAuth
+func _() {}
+
+
+// @Summary Self-exclude
+// @Description Stop play and deposits for a number of months, or permanently when months is omitted. Withdrawals stay available. Cannot be reversed through the app
+// @Tags responsible-gaming
+// @Accept json
+// @Produce json
+// @Param request body SelfExclusionRequest true "Self-exclusion"
+// @Success 201 {object} ExclusionResponse "Self-exclusion started"
+// @Failure 400 {object} map[string]interface{} "Invalid duration"
+// @Failure 409 {object} map[string]interface{} "Already on a longer break"
+// @Router /responsible-gaming/self-exclusion [post]
+// @Security BearerAuth
+func _() {}
+
+
+// @Summary Set reality check
+// @Description Set how often, in minutes of play, the app is sent a reality-check reminder in the X-Reality-Check header. 0 turns reminders off
+// @Tags responsible-gaming
+// @Accept json
+// @Produce json
+// @Param request body RealityCheckRequest true "Reality check"
+// @Success 200 {object} RealityCheckResponse "Reality check updated"
+// @Failure 400 {object} map[string]interface{} "Invalid interval"
+// @Router /responsible-gaming/reality-check [put]
+// @Security BearerAuth
+func _() {}
@@ -0,0 +1,52 @@
+package responsiblegaming
+
+import "time"
+
+// SetLimitRequest sets a deposit or spend limit in naira; 0 removes it.
+type SetLimitRequest struct {
+	Type   string `json:"type" binding:"required"`   // DEPOSIT or SPEND
+	Period string `json:"period" binding:"required"` // DAILY, WEEKLY or MONTHLY
+	Amount *int64 `json:"amount" binding:"required"`
+}
+
+type CoolOffRequest struct {
+	Days   int    `json:"days" binding:"required"`
+	Reason string `json:"reason"`
+}
+
+// SelfExclusionRequest excludes the user for a number of months, or for
+// good when months is 0 or omitted.
+type SelfExclusionRequest struct {
+	Months int    `json:"months"`
+	Reason string `json:"reason"`
+}
+
+type RealityCheckRequest struct {
+	IntervalMinutes *int `json:"interval_minutes" binding:"required"` // 0 turns reminders off
+}
+
+type LimitResponse struct {
+	Type          string     `json:"type"`
+	Period        string     `json:"period"`
+	Amount        int64      `json:"amount"`
+	Used          int64      `json:"used"`
+	PendingAmount *int64     `json:"pending_amount,omitempty"`
+	PendingFrom   *time.Time `json:"pending_from,omitempty"`
+}
+
+type ExclusionResponse struct {
+	Kind     string     `json:"kind"`
+	StartsAt time.Time  `json:"starts_at"`
+	EndsAt   *time.Time `json:"ends_at"` // null when permanent
+}
+
+type RealityCheckResponse struct {
+	IntervalMinutes  int        `json:"interval_minutes"`
+	SessionStartedAt *time.Time `json:"session_started_at,omitempty"`
+}
+
+type StatusResponse struct {
+	Limits       []LimitResponse      `json:"limits"`
+	Exclusion    *ExclusionResponse   `json:"exclusion"`
+	RealityCheck RealityCheckResponse `json:"reality_check"`
+}
@@ -0,0 +1,256 @@
+package responsiblegaming
+
+import (
+	"errors"
+	"log"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/dblaq/buzzycash/internal/core/fx"
+	"github.com/dblaq/buzzycash/internal/models"
+	"github.com/dblaq/buzzycash/internal/utils"
+	"github.com/gin-gonic/gin"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+// RealityCheckHeader carries a due reality-check reminder on the response
+// to a ticket purchase or game start, for the app to show.
+const RealityCheckHeader = "X-Reality-Check"
+
+type ResponsibleGamingHandler struct {
+	db *gorm.DB
+}
+
+func NewResponsibleGamingHandler(db *gorm.DB) *ResponsibleGamingHandler {
+	return &ResponsibleGamingHandler{
+		db: db,
+	}
+}
+
+// Enforce runs Check for a request, writing a 403 and returning false when
+// the user is on a break or over one of their limits. For play it also
+// records the session and attaches a reality check when one is due.
+func Enforce(ctx *gin.Context, db *gorm.DB, a Attempt) bool {
+	err := Check(db, a)
+	var excludedErr *ExcludedError
+	var limitErr *LimitError
+	switch {
+	case err == nil:
+	case errors.As(err, &excludedErr):
+		utils.Error(ctx, http.StatusForbidden, excludedErr.Error())
+		return false
+	case errors.As(err, &limitErr):
+		utils.Error(ctx, http.StatusForbidden, limitErr.Error())
+		return false
+	default:
+		log.Printf("[ResponsibleGaming] Check failed for userID %s: %v", a.User.ID, err)
+		utils.Error(ctx, http.StatusInternalServerError, "Failed to process request")
+		return false
+	}
+
+	if a.Type == models.GamingDepositLimit {
+		return true
+	}
+	// A reminder is a courtesy; failing to work one out must not stop play
+	reminder, err := RecordPlay(db, a.User)
+	if err != nil {
+		log.Printf("[ResponsibleGaming] Could not record play for userID %s: %v", a.User.ID, err)
+		return true
+	}
+	if reminder != nil {
+		ctx.Header(RealityCheckHeader, reminder.String())
+	}
+	return true
+}
+
+// GetStatusHandler returns the user's limits with what they have used of
+// each, any break in force and their reality-check setting.
+func (h *ResponsibleGamingHandler) GetStatusHandler(ctx *gin.Context) {
+	currentUser := ctx.MustGet("currentUser").(models.User)
+
+	limits, err := Limits(h.db, currentUser.ID)
+	if err != nil {
+		log.Printf("[ResponsibleGaming] Failed to load limits for userID %s: %v", currentUser.ID, err)
+		utils.Error(ctx, http.StatusInternalServerError, "Failed to fetch responsible gaming settings")
+		return
+	}
+	exclusion, err := ActiveExclusion(h.db, currentUser.ID)
+	if err != nil {
+		log.Printf("[ResponsibleGaming] Failed to load exclusion for userID %s: %v", currentUser.ID, err)
+		utils.Error(ctx, http.StatusInternalServerError, "Failed to fetch responsible gaming settings")
+		return
+	}
+	var check models.RealityCheck
+	if err := h.db.Where("user_id = ?", currentUser.ID).Limit(1).Find(&check).Error; err != nil {
+		utils.Error(ctx, http.StatusInternalServerError, "Failed to fetch responsible gaming settings")
+		return
+	}
+	rates, err := fx.LoadRates(h.db)
+	if err != nil {
+		utils.Error(ctx, http.StatusInternalServerError, "Failed to fetch responsible gaming settings")
+		return
+	}
+
+	response := StatusResponse{
+		Limits:       make([]LimitResponse, 0, len(limits)),
+		RealityCheck: RealityCheckResponse{IntervalMinutes: check.IntervalMinutes, SessionStartedAt: check.SessionStartedAt},
+	}
+	now := time.Now()
+	for _, l := range limits {
+		used, err := totalSince(h.db, rates, currentUser.ID, limitCategories[l.Type], periodStart(l.Period, now))
+		if err != nil {
+			log.Printf("[ResponsibleGaming] Failed to total usage for userID %s: %v", currentUser.ID, err)
+			utils.Error(ctx, http.StatusInternalServerError, "Failed to fetch responsible gaming settings")
+			return
+		}
+		response.Limits = append(response.Limits, LimitResponse{
+			Type:          string(l.Type),
+			Period:        string(l.Period),
+			Amount:        l.Amount,
+			Used:          used,
+			PendingAmount: l.PendingAmount,
+			PendingFrom:   l.PendingFrom,
+		})
+	}
+	if exclusion != nil {
+		response.Exclusion = toExclusionResponse(*exclusion)
+	}
+
+	ctx.JSON(http.StatusOK, gin.H{
+		"message": "Responsible gaming settings retrieved successfully",
+		"data":    response,
+	})
+}
+
+func (h *ResponsibleGamingHandler) SetLimitHandler(ctx *gin.Context) {
+	var req SetLimitRequest
+	if err := ctx.ShouldBindJSON(&req); err != nil {
+		utils.Error(ctx, http.StatusBadRequest, utils.ValidationErrorToJSON(err))
+		return
+	}
+	if err := req.Validate(); err != nil {
+		utils.Error(ctx, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	currentUser := ctx.MustGet("currentUser").(models.User)
+	limit, err := SetLimit(h.db, currentUser.ID, models.GamingLimitType(req.Type), models.GamingLimitPeriod(req.Period), *req.Amount)
+	if err != nil {
+		if errors.Is(err, ErrNoLimitToRemove) {
+			utils.Error(ctx, http.StatusNotFound, err.Error())
+			return
+		}
+		log.Printf("[ResponsibleGaming] Failed to set limit for userID %s: %v", currentUser.ID, err)
+		utils.Error(ctx, http.StatusInternalServerError, "Failed to set limit")
+		return
+	}
+
+	message := "Limit updated"
+	if limit.PendingFrom != nil {
+		message = "Limit change will apply on " + limit.PendingFrom.Format(time.RFC1123)
+	}
+	ctx.JSON(http.StatusOK, gin.H{
+		"message": message,
+		"data": LimitResponse{
+			Type:          string(limit.Type),
+			Period:        string(limit.Period),
+			Amount:        limit.Amount,
+			PendingAmount: limit.PendingAmount,
+			PendingFrom:   limit.PendingFrom,
+		},
+	})
+}
+
+func (h *ResponsibleGamingHandler) CoolOffHandler(ctx *gin.Context) {
+	var req CoolOffRequest
+	if err := ctx.ShouldBindJSON(&req); err != nil {
+		utils.Error(ctx, http.StatusBadRequest, utils.ValidationErrorToJSON(err))
+		return
+	}
+	if err := req.Validate(); err != nil {
+		utils.Error(ctx, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	until := time.Now().AddDate(0, 0, req.Days)
+	h.takeBreak(ctx, models.CoolOff, &until, req.Reason)
+}
+
+// SelfExclusionHandler excludes the user for the months asked for, or
+// permanently. It cannot be reversed through the app.
+func (h *ResponsibleGamingHandler) SelfExclusionHandler(ctx *gin.Context) {
+	var req SelfExclusionRequest
+	if err := ctx.ShouldBindJSON(&req); err != nil {
+		utils.Error(ctx, http.StatusBadRequest, utils.ValidationErrorToJSON(err))
+		return
+	}
+	if err := req.Validate(); err != nil {
+		utils.Error(ctx, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	var until *time.Time
+	if req.Months > 0 {
+		t := time.Now().AddDate(0, req.Months, 0)
+		until = &t
+	}
+	h.takeBreak(ctx, models.SelfExclusion, until, req.Reason)
+}
+
+func (h *ResponsibleGamingHandler) takeBreak(ctx *gin.Context, kind models.ExclusionKind, until *time.Time, reason string) {
+	currentUser := ctx.MustGet("currentUser").(models.User)
+
+	exclusion, err := TakeBreak(h.db, currentUser.ID, kind, until, strings.TrimSpace(reason))
+	if err != nil {
+		if errors.Is(err, ErrAlreadyExcluded) {
+			utils.Error(ctx, http.StatusConflict, err.Error())
+			return
+		}
+		log.Printf("[ResponsibleGaming] Failed to start %s for userID %s: %v", kind, currentUser.ID, err)
+		utils.Error(ctx, http.StatusInternalServerError, "Failed to start your break")
+		return
+	}
+
+	ctx.JSON(http.StatusCreated, gin.H{
+		"message": "Your break from play has started",
+		"data":    toExclusionResponse(*exclusion),
+	})
+}
+
+func (h *ResponsibleGamingHandler) SetRealityCheckHandler(ctx *gin.Context) {
+	var req RealityCheckRequest
+	if err := ctx.ShouldBindJSON(&req); err != nil {
+		utils.Error(ctx, http.StatusBadRequest, utils.ValidationErrorToJSON(err))
+		return
+	}
+	if err := req.Validate(); err != nil {
+		utils.Error(ctx, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	currentUser := ctx.MustGet("currentUser").(models.User)
+	check := models.RealityCheck{UserID: currentUser.ID, IntervalMinutes: *req.IntervalMinutes}
+	if err := h.db.Clauses(clause.OnConflict{
+		Columns:   []clause.Column{{Name: "user_id"}},
+		DoUpdates: clause.AssignmentColumns([]string{"interval_minutes", "updated_at"}),
+	}).Create(&check).Error; err != nil {
+		log.Printf("[ResponsibleGaming] Failed to save reality check for userID %s: %v", currentUser.ID, err)
+		utils.Error(ctx, http.StatusInternalServerError, "Failed to save reality check")
+		return
+	}
+
+	ctx.JSON(http.StatusOK, gin.H{
+		"message": "Reality check updated",
+		"data":    RealityCheckResponse{IntervalMinutes: check.IntervalMinutes},
+	})
+}
+
+func toExclusionResponse(e models.GamingExclusion) *ExclusionResponse {
+	return &ExclusionResponse{
+		Kind:     string(e.Kind),
+		StartsAt: e.StartsAt,
+		EndsAt:   e.EndsAt,
+	}
+}
@@ -0,0 +1,19 @@
+package responsiblegaming
+
+import (
+	"github.com/dblaq/buzzycash/internal/middlewares"
+	"github.com/gin-gonic/gin"
+	"gorm.io/gorm"
+)
+
+func ResponsibleGamingRoutes(rg *gin.RouterGroup, db *gorm.DB) {
+	rgHandler := NewResponsibleGamingHandler(db)
+	rgRoutes := rg.Group("/responsible-gaming", middlewares.AuthMiddleware)
+	{
+		rgRoutes.GET("", rgHandler.GetStatusHandler)
+		rgRoutes.PUT("/limits", rgHandler.SetLimitHandler)
+		rgRoutes.POST("/cool-off", rgHandler.CoolOffHandler)
+		rgRoutes.POST("/self-exclusion", rgHandler.SelfExclusionHandler)
+		rgRoutes.PUT("/reality-check", rgHandler.SetRealityCheckHandler)
+	}
+}
@@ -0,0 +1,415 @@
+package responsiblegaming
+
+import (
+	"errors"
+	"fmt"
+	"log"
+	"time"
+
+	"github.com/dblaq/buzzycash/internal/config"
+	"github.com/dblaq/buzzycash/internal/core/fx"
+	"github.com/dblaq/buzzycash/internal/helpers"
+	"github.com/dblaq/buzzycash/internal/models"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+var (
+	// ErrExcluded is wrapped by every ExcludedError.
+	ErrExcluded = errors.New("you are taking a break from play")
+	// ErrLimitExceeded is wrapped by every LimitError.
+	ErrLimitExceeded = errors.New("amount exceeds your own limit")
+
+	ErrAlreadyExcluded = errors.New("you are already on a longer break from play")
+	ErrNoLimitToRemove = errors.New("there is no limit to remove")
+)
+
+// limitCategories are the transactions each kind of limit counts.
+var limitCategories = map[models.GamingLimitType]models.TransactionCategory{
+	models.GamingDepositLimit: models.Deposit,
+	models.GamingSpendLimit:   models.Ticket,
+}
+
+// ExcludedError is returned while a cool-off or self-exclusion runs.
+type ExcludedError struct {
+	Kind   models.ExclusionKind
+	EndsAt *time.Time // nil when permanent
+}
+
+func (e *ExcludedError) Error() string {
+	if e.EndsAt == nil {
+		return "you have permanently excluded yourself from play"
+	}
+	if e.Kind == models.CoolOff {
+		return fmt.Sprintf("you are on a cool-off until %s", e.EndsAt.Format(time.RFC1123))
+	}
+	return fmt.Sprintf("you have excluded yourself from play until %s", e.EndsAt.Format(time.RFC1123))
+}
+
+func (e *ExcludedError) Unwrap() error { return ErrExcluded }
+
+// LimitError reports which of the user's own limits an amount would break.
+type LimitError struct {
+	Type      models.GamingLimitType
+	Period    models.GamingLimitPeriod
+	Max       int64 // naira
+	Remaining int64 // naira
+}
+
+func (e *LimitError) Error() string {
+	what := "deposit"
+	if e.Type == models.GamingSpendLimit {
+		what = "spend"
+	}
+	return fmt.Sprintf("amount exceeds your %s %s limit of %d NGN (%d NGN left)",
+		periodName(e.Period), what, e.Max, e.Remaining)
+}
+
+func (e *LimitError) Unwrap() error { return ErrLimitExceeded }
+
+func periodName(p models.GamingLimitPeriod) string {
+	switch p {
+	case models.GamingWeekly:
+		return "weekly"
+	case models.GamingMonthly:
+		return "monthly"
+	default:
+		return "daily"
+	}
+}
+
+// periodStart is the start of the calendar day, week (from Monday) or month
+// containing now.
+func periodStart(p models.GamingLimitPeriod, now time.Time) time.Time {
+	midnight := time.Date(now.Year(), now.Month(), now.Day(), 0, 0, 0, 0, now.Location())
+	switch p {
+	case models.GamingWeekly:
+		return midnight.AddDate(0, 0, -((int(now.Weekday()) + 6) % 7))
+	case models.GamingMonthly:
+		return time.Date(now.Year(), now.Month(), 1, 0, 0, 0, 0, now.Location())
+	default:
+		return midnight
+	}
+}
+
+// ActiveExclusion returns the break from play in force for a user, the one
+// ending last when several overlap, or nil.
+func ActiveExclusion(db *gorm.DB, userID string) (*models.GamingExclusion, error) {
+	var exclusion models.GamingExclusion
+	err := db.Where("user_id = ? AND starts_at <= ? AND (ends_at IS NULL OR ends_at > ?)", userID, time.Now(), time.Now()).
+		Order("ends_at DESC NULLS FIRST").
+		First(&exclusion).Error
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("load exclusion failed: %w", err)
+	}
+	return &exclusion, nil
+}
+
+// TakeBreak starts a cool-off or self-exclusion. until is nil for a
+// permanent exclusion. A break cannot shorten one already running.
+func TakeBreak(db *gorm.DB, userID string, kind models.ExclusionKind, until *time.Time, reason string) (*models.GamingExclusion, error) {
+	current, err := ActiveExclusion(db, userID)
+	if err != nil {
+		return nil, err
+	}
+	if current != nil && (current.EndsAt == nil || (until != nil && !until.After(*current.EndsAt))) {
+		return nil, ErrAlreadyExcluded
+	}
+
+	exclusion := models.GamingExclusion{
+		UserID:   userID,
+		Kind:     kind,
+		StartsAt: time.Now(),
+		EndsAt:   until,
+		Reason:   reason,
+	}
+	if err := db.Create(&exclusion).Error; err != nil {
+		return nil, fmt.Errorf("record exclusion failed: %w", err)
+	}
+
+	log.Printf("[ResponsibleGaming] %s started for userID=%s until %v", kind, userID, until)
+	return &exclusion, nil
+}
+
+// Limits returns the user's limits, first applying any raised limits whose
+// cooling period has passed.
+func Limits(db *gorm.DB, userID string) ([]models.GamingLimit, error) {
+	var limits []models.GamingLimit
+	if err := db.Where("user_id = ?", userID).Order("type, period").Find(&limits).Error; err != nil {
+		return nil, fmt.Errorf("load limits failed: %w", err)
+	}
+
+	now := time.Now()
+	kept := limits[:0]
+	for _, l := range limits {
+		if !matured(l, now) {
+			kept = append(kept, l)
+			continue
+		}
+		applied, err := applyPending(db, l)
+		if err != nil {
+			return nil, err
+		}
+		if applied != nil {
+			kept = append(kept, *applied)
+		}
+	}
+	return kept, nil
+}
+
+func matured(l models.GamingLimit, now time.Time) bool {
+	return l.PendingAmount != nil && l.PendingFrom != nil && !now.Before(*l.PendingFrom)
+}
+
+// applyPending makes a matured change the limit in force, deleting the
+// limit when the change removes it.
+func applyPending(db *gorm.DB, l models.GamingLimit) (*models.GamingLimit, error) {
+	if *l.PendingAmount == 0 {
+		if err := db.Delete(&l).Error; err != nil {
+			return nil, fmt.Errorf("remove limit failed: %w", err)
+		}
+		return nil, nil
+	}
+	l.Amount, l.PendingAmount, l.PendingFrom = *l.PendingAmount, nil, nil
+	if err := db.Model(&l).Updates(map[string]interface{}{
+		"amount":         l.Amount,
+		"pending_amount": nil,
+		"pending_from":   nil,
+	}).Error; err != nil {
+		return nil, fmt.Errorf("apply limit failed: %w", err)
+	}
+	return &l, nil
+}
+
+// SetLimit sets one of the user's limits in naira; 0 removes it. A tighter
+// limit applies at once. A looser one, or removing the limit, waits out the
+// cooling period and replaces any change already waiting.
+func SetLimit(db *gorm.DB, userID string, limitType models.GamingLimitType, period models.GamingLimitPeriod, amount int64) (*models.GamingLimit, error) {
+	var limit models.GamingLimit
+	err := db.Transaction(func(tx *gorm.DB) error {
+		err := tx.Clauses(clause.Locking{Strength: "UPDATE"}).
+			First(&limit, "user_id = ? AND type = ? AND period = ?", userID, limitType, period).Error
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			if amount == 0 {
+				return ErrNoLimitToRemove
+			}
+			limit = models.GamingLimit{UserID: userID, Type: limitType, Period: period, Amount: amount}
+			return tx.Create(&limit).Error
+		}
+		if err != nil {
+			return err
+		}
+
+		if matured(limit, time.Now()) {
+			applied, err := applyPending(tx, limit)
+			if err != nil {
+				return err
+			}
+			if applied == nil {
+				if amount == 0 {
+					return ErrNoLimitToRemove
+				}
+				limit = models.GamingLimit{UserID: userID, Type: limitType, Period: period, Amount: amount}
+				return tx.Create(&limit).Error
+			}
+			limit = *applied
+		}
+
+		if amount != 0 && amount <= limit.Amount {
+			limit.Amount, limit.PendingAmount, limit.PendingFrom = amount, nil, nil
+		} else {
+			from := time.Now().Add(time.Duration(config.AppConfig.RgLimitIncreaseCoolingHours) * time.Hour)
+			limit.PendingAmount, limit.PendingFrom = &amount, &from
+		}
+		return tx.Model(&limit).Updates(map[string]interface{}{
+			"amount":         limit.Amount,
+			"pending_amount": limit.PendingAmount,
+			"pending_from":   limit.PendingFrom,
+		}).Error
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	log.Printf("[ResponsibleGaming] %s %s limit for userID=%s set to %d", period, limitType, userID, amount)
+	return &limit, nil
+}
+
+// Attempt is a deposit or ticket purchase about to be made. Type is empty
+// for play that costs nothing up front, such as starting a virtual game,
+// which is only checked against breaks.
+type Attempt struct {
+	User     models.User
+	Type     models.GamingLimitType
+	Amount   int64
+	Currency models.ECurrency
+}
+
+// Check stops an attempt during a break or when it would take the user past
+// one of their own limits. Everything is read from the database on every
+// attempt so neither can be shaken off by logging in again. A breach is
+// returned as an *ExcludedError or *LimitError.
+func Check(db *gorm.DB, a Attempt) error {
+	exclusion, err := ActiveExclusion(db, a.User.ID)
+	if err != nil {
+		return err
+	}
+	if exclusion != nil {
+		return &ExcludedError{Kind: exclusion.Kind, EndsAt: exclusion.EndsAt}
+	}
+
+	category, ok := limitCategories[a.Type]
+	if !ok {
+		return nil
+	}
+	limits, err := Limits(db, a.User.ID)
+	if err != nil {
+		return err
+	}
+
+	rates, err := fx.LoadRates(db)
+	if err != nil {
+		return err
+	}
+	naira, _, err := rates.Convert(a.Amount, a.Currency, models.NGN)
+	if err != nil {
+		return err
+	}
+
+	now := time.Now()
+	for _, l := range limits {
+		if l.Type != a.Type {
+			continue
+		}
+		total, err := totalSince(db, rates, a.User.ID, category, periodStart(l.Period, now))
+		if err != nil {
+			return err
+		}
+		if total+naira > l.Amount {
+			remaining := l.Amount - total
+			if remaining < 0 {
+				remaining = 0
+			}
+			return &LimitError{Type: l.Type, Period: l.Period, Max: l.Amount, Remaining: remaining}
+		}
+	}
+	return nil
+}
+
+// totalSince adds up a user's pending and successful transactions of a
+// category since the given time, in naira.
+func totalSince(db *gorm.DB, rates fx.Rates, userID string, category models.TransactionCategory, since time.Time) (int64, error) {
+	var totals []struct {
+		Currency models.ECurrency
+		Total    int64
+	}
+	if err := db.Model(&models.Transaction{}).
+		Select("currency, COALESCE(SUM(amount), 0) AS total").
+		Where("user_id = ? AND category = ? AND payment_status IN ? AND created_at >= ?",
+			userID, category, []models.EPaymentStatus{models.Pending, models.Successful}, since).
+		Group("currency").
+		Scan(&totals).Error; err != nil {
+		return 0, fmt.Errorf("load spend failed: %w", err)
+	}
+
+	var naira int64
+	for _, t := range totals {
+		converted, _, err := rates.Convert(t.Total, t.Currency, models.NGN)
+		if err != nil {
+			return 0, err
+		}
+		naira += converted
+	}
+	return naira, nil
+}
+
+// Reminder is a reality check: how long the user has been playing this
+// session and what they have staked and won, in their wallet currency.
+type Reminder struct {
+	SessionMinutes int              `json:"session_minutes"`
+	Staked         int64            `json:"staked"`
+	Won            int64            `json:"won"`
+	Currency       models.ECurrency `json:"currency"`
+}
+
+func (r *Reminder) String() string {
+	return fmt.Sprintf("You have been playing for %d minutes, staking %d %s and winning %d %s",
+		r.SessionMinutes, r.Staked, r.Currency, r.Won, r.Currency)
+}
+
+// RecordPlay marks play in the user's session, starting a new session after
+// a spell of inactivity, and returns a reminder when one is due.
+func RecordPlay(db *gorm.DB, user models.User) (*Reminder, error) {
+	var check models.RealityCheck
+	var due bool
+	now := time.Now()
+	idle := time.Duration(config.AppConfig.RgSessionIdleMinutes) * time.Minute
+
+	err := db.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Clauses(clause.OnConflict{DoNothing: true}).
+			Create(&models.RealityCheck{UserID: user.ID}).Error; err != nil {
+			return err
+		}
+		if err := tx.Clauses(clause.Locking{Strength: "UPDATE"}).First(&check, "user_id = ?", user.ID).Error; err != nil {
+			return err
+		}
+
+		if check.SessionStartedAt == nil || check.LastActivityAt == nil || now.Sub(*check.LastActivityAt) > idle {
+			check.SessionStartedAt, check.LastReminderAt = &now, nil
+		}
+		check.LastActivityAt = &now
+
+		if check.IntervalMinutes > 0 {
+			last := *check.SessionStartedAt
+			if check.LastReminderAt != nil {
+				last = *check.LastReminderAt
+			}
+			if now.Sub(last) >= time.Duration(check.IntervalMinutes)*time.Minute {
+				due = true
+				check.LastReminderAt = &now
+			}
+		}
+		return tx.Model(&check).Updates(map[string]interface{}{
+			"session_started_at": check.SessionStartedAt,
+			"last_activity_at":   check.LastActivityAt,
+			"last_reminder_at":   check.LastReminderAt,
+		}).Error
+	})
+	if err != nil {
+		return nil, fmt.Errorf("record play failed: %w", err)
+	}
+	if !due {
+		return nil, nil
+	}
+	return sessionSummary(db, user, *check.SessionStartedAt)
+}
+
+// sessionSummary builds a reminder for the session that began at start.
+func sessionSummary(db *gorm.DB, user models.User, start time.Time) (*Reminder, error) {
+	currency := helpers.UserCurrency(user)
+	rates, err := fx.LoadRates(db)
+	if err != nil {
+		return nil, err
+	}
+
+	reminder := &Reminder{
+		SessionMinutes: int(time.Since(start).Minutes()),
+		Currency:       currency,
+	}
+	for category, total := range map[models.TransactionCategory]*int64{
+		models.Ticket:     &reminder.Staked,
+		models.PrizeMoney: &reminder.Won,
+	} {
+		naira, err := totalSince(db, rates, user.ID, category, start)
+		if err != nil {
+			return nil, err
+		}
+		if *total, _, err = rates.Convert(naira, models.NGN, currency); err != nil {
+			return nil, err
+		}
+	}
+	return reminder, nil
+}
@@ -0,0 +1,179 @@
+package responsiblegaming
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/dblaq/buzzycash/internal/config"
+	"github.com/dblaq/buzzycash/internal/models"
+	"github.com/dblaq/buzzycash/internal/testutil"
+	"gorm.io/gorm"
+)
+
+const coolingHours = 24
+
+func useCoolingPeriod(t *testing.T) {
+	t.Helper()
+	saved := config.AppConfig
+	t.Cleanup(func() { config.AppConfig = saved })
+	config.AppConfig.RgLimitIncreaseCoolingHours = coolingHours
+}
+
+// mature moves a limit's pending change into the past, as if the cooling
+// period had run.
+func mature(t *testing.T, db *gorm.DB, limit *models.GamingLimit) {
+	t.Helper()
+	if err := db.Model(limit).Update("pending_from", time.Now().Add(-time.Minute)).Error; err != nil {
+		t.Fatalf("mature limit: %v", err)
+	}
+}
+
+func dailyDeposit(t *testing.T, db *gorm.DB, userID string) *models.GamingLimit {
+	t.Helper()
+	limits, err := Limits(db, userID)
+	if err != nil {
+		t.Fatalf("Limits: %v", err)
+	}
+	for i, l := range limits {
+		if l.Type == models.GamingDepositLimit && l.Period == models.GamingDaily {
+			return &limits[i]
+		}
+	}
+	return nil
+}
+
+func TestMatured(t *testing.T) {
+	now := time.Now()
+	amount := int64(5000)
+	past, future := now.Add(-time.Second), now.Add(time.Hour)
+
+	tests := []struct {
+		name  string
+		limit models.GamingLimit
+		want  bool
+	}{
+		{"nothing pending", models.GamingLimit{Amount: 1000}, false},
+		{"still cooling", models.GamingLimit{Amount: 1000, PendingAmount: &amount, PendingFrom: &future}, false},
+		{"cooling over", models.GamingLimit{Amount: 1000, PendingAmount: &amount, PendingFrom: &past}, true},
+		{"cooling ends now", models.GamingLimit{Amount: 1000, PendingAmount: &amount, PendingFrom: &now}, true},
+	}
+	for _, tt := range tests {
+		if got := matured(tt.limit, now); got != tt.want {
+			t.Errorf("%s: matured = %v, want %v", tt.name, got, tt.want)
+		}
+	}
+}
+
+func TestSetLimitDecreaseAppliesAtOnce(t *testing.T) {
+	db := testutil.DB(t)
+	useCoolingPeriod(t)
+	user := testutil.User(t, db)
+
+	first, err := SetLimit(db, user.ID, models.GamingDepositLimit, models.GamingDaily, 10000)
+	if err != nil {
+		t.Fatalf("first limit: %v", err)
+	}
+	if first.Amount != 10000 || first.PendingAmount != nil {
+		t.Fatalf("a new limit applies at once; got amount %d pending %v", first.Amount, first.PendingAmount)
+	}
+
+	lower, err := SetLimit(db, user.ID, models.GamingDepositLimit, models.GamingDaily, 4000)
+	if err != nil {
+		t.Fatalf("lower limit: %v", err)
+	}
+	if lower.Amount != 4000 || lower.PendingAmount != nil || lower.PendingFrom != nil {
+		t.Fatalf("decrease: got amount %d pending %v from %v, want 4000 with nothing pending", lower.Amount, lower.PendingAmount, lower.PendingFrom)
+	}
+	if got := dailyDeposit(t, db, user.ID); got == nil || got.Amount != 4000 {
+		t.Fatalf("limit in force = %+v, want 4000", got)
+	}
+}
+
+func TestSetLimitIncreaseWaitsOutCooling(t *testing.T) {
+	db := testutil.DB(t)
+	useCoolingPeriod(t)
+	user := testutil.User(t, db)
+
+	if _, err := SetLimit(db, user.ID, models.GamingDepositLimit, models.GamingDaily, 10000); err != nil {
+		t.Fatalf("first limit: %v", err)
+	}
+	before := time.Now()
+	raised, err := SetLimit(db, user.ID, models.GamingDepositLimit, models.GamingDaily, 50000)
+	if err != nil {
+		t.Fatalf("raise limit: %v", err)
+	}
+	if raised.Amount != 10000 {
+		t.Fatalf("increase changed the limit in force to %d; want 10000 until cooling ends", raised.Amount)
+	}
+	if raised.PendingAmount == nil || *raised.PendingAmount != 50000 || raised.PendingFrom == nil {
+		t.Fatalf("increase not held as pending: %v from %v", raised.PendingAmount, raised.PendingFrom)
+	}
+	if wait := raised.PendingFrom.Sub(before); wait < coolingHours*time.Hour-time.Minute || wait > coolingHours*time.Hour+time.Minute {
+		t.Errorf("pending from %s after the request, want %dh", wait, coolingHours)
+	}
+
+	// The old limit still binds during the cooling period
+	err = Check(db, Attempt{User: user, Type: models.GamingDepositLimit, Amount: 20000, Currency: models.NGN})
+	var limitErr *LimitError
+	if !errors.As(err, &limitErr) || limitErr.Max != 10000 {
+		t.Fatalf("Check during cooling = %v, want LimitError against 10000", err)
+	}
+
+	mature(t, db, raised)
+	got := dailyDeposit(t, db, user.ID)
+	if got == nil || got.Amount != 50000 || got.PendingAmount != nil {
+		t.Fatalf("after cooling: limit = %+v, want 50000 with nothing pending", got)
+	}
+	if err := Check(db, Attempt{User: user, Type: models.GamingDepositLimit, Amount: 20000, Currency: models.NGN}); err != nil {
+		t.Fatalf("Check after cooling: %v", err)
+	}
+}
+
+func TestSetLimitDecreaseCancelsPendingIncrease(t *testing.T) {
+	db := testutil.DB(t)
+	useCoolingPeriod(t)
+	user := testutil.User(t, db)
+
+	if _, err := SetLimit(db, user.ID, models.GamingDepositLimit, models.GamingDaily, 10000); err != nil {
+		t.Fatalf("first limit: %v", err)
+	}
+	if _, err := SetLimit(db, user.ID, models.GamingDepositLimit, models.GamingDaily, 50000); err != nil {
+		t.Fatalf("raise limit: %v", err)
+	}
+	lower, err := SetLimit(db, user.ID, models.GamingDepositLimit, models.GamingDaily, 8000)
+	if err != nil {
+		t.Fatalf("lower limit: %v", err)
+	}
+	if lower.Amount != 8000 || lower.PendingAmount != nil {
+		t.Fatalf("decrease after a pending increase: got amount %d pending %v, want 8000 and nothing pending", lower.Amount, lower.PendingAmount)
+	}
+}
+
+func TestSetLimitRemovalWaitsOutCooling(t *testing.T) {
+	db := testutil.DB(t)
+	useCoolingPeriod(t)
+	user := testutil.User(t, db)
+
+	if _, err := SetLimit(db, user.ID, models.GamingDepositLimit, models.GamingDaily, 0); !errors.Is(err, ErrNoLimitToRemove) {
+		t.Fatalf("removing a missing limit: err = %v, want ErrNoLimitToRemove", err)
+	}
+	if _, err := SetLimit(db, user.ID, models.GamingDepositLimit, models.GamingDaily, 10000); err != nil {
+		t.Fatalf("first limit: %v", err)
+	}
+	removed, err := SetLimit(db, user.ID, models.GamingDepositLimit, models.GamingDaily, 0)
+	if err != nil {
+		t.Fatalf("remove limit: %v", err)
+	}
+	if removed.Amount != 10000 || removed.PendingAmount == nil || *removed.PendingAmount != 0 {
+		t.Fatalf("removal not held as pending: amount %d pending %v", removed.Amount, removed.PendingAmount)
+	}
+	if got := dailyDeposit(t, db, user.ID); got == nil {
+		t.Fatal("limit gone before the cooling period ended")
+	}
+
+	mature(t, db, removed)
+	if got := dailyDeposit(t, db, user.ID); got != nil {
+		t.Fatalf("limit still in force after cooling: %+v", got)
+	}
+}
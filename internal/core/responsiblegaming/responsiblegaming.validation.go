@@ -0,0 +1,69 @@
+package responsiblegaming
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/dblaq/buzzycash/internal/config"
+	"github.com/dblaq/buzzycash/internal/models"
+)
+
+// Validation errors
+var (
+	ErrInvalidLimitType = errors.New("type must be DEPOSIT or SPEND")
+	ErrInvalidPeriod    = errors.New("period must be DAILY, WEEKLY or MONTHLY")
+	ErrNegativeLimit    = errors.New("amount cannot be negative")
+	ErrNegativeMonths   = errors.New("months cannot be negative")
+	ErrInvalidInterval  = errors.New("interval_minutes must be 0 or between 10 and 240")
+	ErrReasonTooLong    = errors.New("reason must be at most 500 characters")
+)
+
+func (r *SetLimitRequest) Validate() error {
+	r.Type = strings.ToUpper(strings.TrimSpace(r.Type))
+	r.Period = strings.ToUpper(strings.TrimSpace(r.Period))
+
+	if _, ok := limitCategories[models.GamingLimitType(r.Type)]; !ok {
+		return ErrInvalidLimitType
+	}
+	switch models.GamingLimitPeriod(r.Period) {
+	case models.GamingDaily, models.GamingWeekly, models.GamingMonthly:
+	default:
+		return ErrInvalidPeriod
+	}
+	if *r.Amount < 0 {
+		return ErrNegativeLimit
+	}
+	return nil
+}
+
+func (r *CoolOffRequest) Validate() error {
+	if max := config.AppConfig.RgMaxCoolOffDays; r.Days < 1 || r.Days > max {
+		return fmt.Errorf("days must be between 1 and %d", max)
+	}
+	return validateReason(r.Reason)
+}
+
+func (r *SelfExclusionRequest) Validate() error {
+	if r.Months < 0 {
+		return ErrNegativeMonths
+	}
+	if min := config.AppConfig.RgMinExclusionMonths; r.Months != 0 && r.Months < min {
+		return fmt.Errorf("self-exclusion must last at least %d months; take a cool-off for a shorter break", min)
+	}
+	return validateReason(r.Reason)
+}
+
+func (r *RealityCheckRequest) Validate() error {
+	if m := *r.IntervalMinutes; m != 0 && (m < 10 || m > 240) {
+		return ErrInvalidInterval
+	}
+	return nil
+}
+
+func validateReason(reason string) error {
+	if len(reason) > 500 {
+		return ErrReasonTooLong
+	}
+	return nil
+}
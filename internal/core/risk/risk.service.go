@@ -0,0 +1,182 @@
+package risk
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/dblaq/buzzycash/internal/config"
+	"github.com/dblaq/buzzycash/internal/core/fx"
+	"github.com/dblaq/buzzycash/internal/models"
+	"gorm.io/gorm"
+)
+
+// Points each signal adds to a withdrawal's score.
+const (
+	NewAccountPoints     = 20
+	NoDepositsPoints     = 30
+	HighRatioPoints      = 20
+	NeverPlayedPoints    = 30
+	NewBeneficiaryPoints = 15
+	NameMismatchPoints   = 30
+	NewDevicePoints      = 10
+	NewIPPoints          = 10
+)
+
+// Withdrawal is what is known about a withdrawal before it is paid out.
+type Withdrawal struct {
+	User          models.User
+	Amount        int64
+	Currency      models.ECurrency
+	BankCode      string
+	AccountNumber string
+	AccountName   string
+	IPAddress     string
+	DeviceID      string
+}
+
+// Assessment is a withdrawal's score and the signals behind it.
+type Assessment struct {
+	Score   int
+	Signals map[string]int
+}
+
+// Review reports whether the score is high enough to hold the withdrawal
+// for an admin.
+func (a *Assessment) Review() bool {
+	return a.Score >= config.AppConfig.RiskReviewScore
+}
+
+func (a *Assessment) add(signal string, points int) {
+	a.Signals[signal] = points
+	a.Score += points
+}
+
+// Assess scores a withdrawal against the rules. It only reads, so it runs
+// before any funds move.
+func Assess(db *gorm.DB, w Withdrawal) (*Assessment, error) {
+	a := &Assessment{Signals: map[string]int{}}
+	c := config.AppConfig
+
+	if time.Since(w.User.CreatedAt) < time.Duration(c.RiskNewAccountDays)*24*time.Hour {
+		a.add("new_account", NewAccountPoints)
+	}
+
+	rates, err := fx.LoadRates(db)
+	if err != nil {
+		return nil, err
+	}
+	amount, _, err := rates.Convert(w.Amount, w.Currency, models.NGN)
+	if err != nil {
+		return nil, err
+	}
+	deposited, err := total(db, rates, w.User.ID, models.Deposit, models.Successful)
+	if err != nil {
+		return nil, err
+	}
+	withdrawn, err := total(db, rates, w.User.ID, models.WithdrawRequest, models.Pending, models.Successful)
+	if err != nil {
+		return nil, err
+	}
+	switch {
+	case deposited == 0:
+		a.add("no_deposits", NoDepositsPoints)
+	case float64(withdrawn+amount)/float64(deposited) > c.RiskMaxWithdrawalRatio:
+		a.add("high_withdrawal_ratio", HighRatioPoints)
+	}
+
+	var tickets int64
+	if err := db.Model(&models.Transaction{}).
+		Where("user_id = ? AND category = ? AND payment_status = ?", w.User.ID, models.Ticket, models.Successful).
+		Count(&tickets).Error; err != nil {
+		return nil, fmt.Errorf("count tickets failed: %w", err)
+	}
+	if tickets == 0 {
+		a.add("never_played", NeverPlayedPoints)
+	}
+
+	var paidBefore int64
+	if err := db.Model(&models.Transaction{}).
+		Where("user_id = ? AND category = ? AND payment_status = ?", w.User.ID, models.WithdrawRequest, models.Successful).
+		Where("metadata->>'accountNumber' = ? AND metadata->>'bankCode' = ?", w.AccountNumber, w.BankCode).
+		Count(&paidBefore).Error; err != nil {
+		return nil, fmt.Errorf("count previous payouts failed: %w", err)
+	}
+	if paidBefore == 0 {
+		a.add("new_beneficiary", NewBeneficiaryPoints)
+	}
+
+	if !namesMatch(w.User.FullName, w.AccountName) {
+		a.add("name_mismatch", NameMismatchPoints)
+	}
+
+	var last models.WithdrawalRisk
+	err = db.Where("user_id = ?", w.User.ID).Order("created_at DESC").First(&last).Error
+	switch {
+	case errors.Is(err, gorm.ErrRecordNotFound):
+	case err != nil:
+		return nil, fmt.Errorf("load previous assessment failed: %w", err)
+	default:
+		if last.DeviceID != "" && w.DeviceID != last.DeviceID {
+			a.add("new_device", NewDevicePoints)
+		}
+		if last.IPAddress != "" && w.IPAddress != last.IPAddress {
+			a.add("new_ip", NewIPPoints)
+		}
+	}
+
+	return a, nil
+}
+
+// total adds up a user's transactions of a category in the given statuses,
+// in naira.
+func total(db *gorm.DB, rates fx.Rates, userID string, category models.TransactionCategory, statuses ...models.EPaymentStatus) (int64, error) {
+	var totals []struct {
+		Currency models.ECurrency
+		Total    int64
+	}
+	if err := db.Model(&models.Transaction{}).
+		Select("currency, COALESCE(SUM(amount), 0) AS total").
+		Where("user_id = ? AND category = ? AND payment_status IN ?", userID, category, statuses).
+		Group("currency").
+		Scan(&totals).Error; err != nil {
+		return 0, fmt.Errorf("load %s total failed: %w", strings.ToLower(string(category)), err)
+	}
+
+	var naira int64
+	for _, t := range totals {
+		converted, _, err := rates.Convert(t.Total, t.Currency, models.NGN)
+		if err != nil {
+			return 0, err
+		}
+		naira += converted
+	}
+	return naira, nil
+}
+
+// namesMatch accepts a bank account name sharing at least two names with
+// the profile name, ignoring case and order, or all of them when the
+// profile has fewer.
+func namesMatch(fullName, accountName string) bool {
+	profile := strings.Fields(strings.ToUpper(fullName))
+	if len(profile) == 0 {
+		return false
+	}
+	account := map[string]bool{}
+	for _, part := range strings.Fields(strings.ToUpper(strings.ReplaceAll(accountName, ",", " "))) {
+		account[part] = true
+	}
+
+	shared := 0
+	for _, part := range profile {
+		if account[part] {
+			shared++
+		}
+	}
+	need := 2
+	if len(profile) < need {
+		need = len(profile)
+	}
+	return shared >= need
+}
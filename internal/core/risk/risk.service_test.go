@@ -0,0 +1,156 @@
+package risk
+
+import (
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/dblaq/buzzycash/internal/config"
+	"github.com/dblaq/buzzycash/internal/models"
+	"github.com/dblaq/buzzycash/internal/testutil"
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+const (
+	testAccount = "0123456789"
+	testBank    = "058"
+)
+
+func useRiskRules(t *testing.T) {
+	t.Helper()
+	saved := config.AppConfig
+	t.Cleanup(func() { config.AppConfig = saved })
+	config.AppConfig.RiskNewAccountDays = 7
+	config.AppConfig.RiskMaxWithdrawalRatio = 0.8
+}
+
+// established returns a user old enough not to count as new.
+func established(t *testing.T, db *gorm.DB) models.User {
+	t.Helper()
+	user := testutil.User(t, db)
+	user.FullName = "Ada Obi Eze"
+	user.CreatedAt = time.Now().AddDate(0, -3, 0)
+	if err := db.Model(&user).Updates(map[string]interface{}{"full_name": user.FullName, "created_at": user.CreatedAt}).Error; err != nil {
+		t.Fatalf("age user: %v", err)
+	}
+	return user
+}
+
+func record(t *testing.T, db *gorm.DB, userID string, category models.TransactionCategory, amount int64, meta models.JSONB) {
+	t.Helper()
+	history := models.Transaction{
+		UserID:               userID,
+		Amount:               amount,
+		TransactionReference: "TX-" + uuid.NewString(),
+		Reference:            "REF-" + uuid.NewString(),
+		PaymentStatus:        models.Successful,
+		Category:             category,
+		Currency:             models.NGN,
+		Metadata:             meta,
+	}
+	if err := db.Create(&history).Error; err != nil {
+		t.Fatalf("create %s: %v", category, err)
+	}
+}
+
+func withdrawal(user models.User, amount int64) Withdrawal {
+	return Withdrawal{
+		User:          user,
+		Amount:        amount,
+		Currency:      models.NGN,
+		BankCode:      testBank,
+		AccountNumber: testAccount,
+		AccountName:   "EZE ADA",
+		IPAddress:     "10.0.0.1",
+		DeviceID:      "device-1",
+	}
+}
+
+func TestAssessFirstWithdrawalOfNewAccount(t *testing.T) {
+	useRiskRules(t)
+	db := testutil.DB(t)
+	user := testutil.User(t, db)
+
+	w := withdrawal(user, 5000)
+	w.AccountName = "SOMEONE ELSE"
+	a, err := Assess(db, w)
+	if err != nil {
+		t.Fatalf("Assess: %v", err)
+	}
+
+	want := map[string]int{
+		"new_account":     NewAccountPoints,
+		"no_deposits":     NoDepositsPoints,
+		"never_played":    NeverPlayedPoints,
+		"new_beneficiary": NewBeneficiaryPoints,
+		"name_mismatch":   NameMismatchPoints,
+	}
+	if !reflect.DeepEqual(a.Signals, want) {
+		t.Errorf("signals = %v, want %v", a.Signals, want)
+	}
+	if a.Score != NewAccountPoints+NoDepositsPoints+NeverPlayedPoints+NewBeneficiaryPoints+NameMismatchPoints {
+		t.Errorf("score = %d, want the sum of its signals", a.Score)
+	}
+}
+
+func TestAssessEstablishedUser(t *testing.T) {
+	useRiskRules(t)
+	db := testutil.DB(t)
+	user := established(t, db)
+	record(t, db, user.ID, models.Deposit, 10000, nil)
+	record(t, db, user.ID, models.Ticket, 500, nil)
+	record(t, db, user.ID, models.WithdrawRequest, 2000, models.JSONB{"accountNumber": testAccount, "bankCode": testBank})
+	if err := db.Create(&models.WithdrawalRisk{
+		TransactionID: uuid.NewString(),
+		UserID:        user.ID,
+		Decision:      models.RiskAutoApproved,
+		IPAddress:     "10.0.0.1",
+		DeviceID:      "device-1",
+	}).Error; err != nil {
+		t.Fatalf("create previous assessment: %v", err)
+	}
+
+	tests := []struct {
+		name   string
+		amount int64
+		device string
+		want   map[string]int
+	}{
+		{"familiar withdrawal", 3000, "device-1", map[string]int{}},
+		// (2000 + 7000) / 10000 is over the 0.8 ratio
+		{"withdraws most of what was deposited", 7000, "device-1", map[string]int{"high_withdrawal_ratio": HighRatioPoints}},
+		{"from another device", 3000, "device-2", map[string]int{"new_device": NewDevicePoints}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			w := withdrawal(user, tt.amount)
+			w.DeviceID = tt.device
+			a, err := Assess(db, w)
+			if err != nil {
+				t.Fatalf("Assess: %v", err)
+			}
+			if !reflect.DeepEqual(a.Signals, tt.want) {
+				t.Errorf("signals = %v, want %v", a.Signals, tt.want)
+			}
+		})
+	}
+}
+
+func TestNamesMatch(t *testing.T) {
+	tests := []struct {
+		profile, account string
+		want             bool
+	}{
+		{"Ada Obi Eze", "EZE, ADA", true},
+		{"Ada Obi Eze", "ADA OBI EZE", true},
+		{"Ada Obi Eze", "ADA NWOSU", false},
+		{"Ada", "ADA NWOSU", true},
+		{"", "ADA NWOSU", false},
+	}
+	for _, tt := range tests {
+		if got := namesMatch(tt.profile, tt.account); got != tt.want {
+			t.Errorf("namesMatch(%q, %q) = %v, want %v", tt.profile, tt.account, got, tt.want)
+		}
+	}
+}
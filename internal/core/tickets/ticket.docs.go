@@ -1,7 +1,7 @@
 package tickets
 
 // @Summary Purchase game ticket
-// @Description Buy a ticket for a specific game
+// @Description Buy tickets for a running game. The price is the game's catalogue price in the gaming base currency; the wallet is charged its equivalent in the wallet currency using the FX table
 // @Tags tickets
 // @Accept json
 // @Produce json
@@ -9,6 +9,10 @@ package tickets
 // @Success 201 {object} map[string]interface{} "Ticket purchased successfully"
 // @Failure 400 {object} map[string]interface{} "Invalid request payload"
 // @Failure 401 {object} map[string]interface{} "Unauthorized"
+// @Failure 404 {object} map[string]interface{} "Game not found"
+// @Failure 409 {object} map[string]interface{} "This game is not selling tickets"
+// @Failure 403 {object} map[string]interface{} "AGE_UNVERIFIED or UNDERAGE; on a break from play, or exceeds own or daily ticket spend limit"
+// @Failure 429 {object} map[string]interface{} "Too many purchases; see Retry-After"
 // @Failure 500 {object} map[string]interface{} "Internal server error"
 // @Router /ticket/purchase-ticket [post]
 // @Security BearerAuth
@@ -29,7 +33,7 @@ func _() {}
 
 
 // @Summary Get all games
-// @Description Retrieve a list of all available games from the gaming service
+// @Description Retrieve a list of all available games from the gaming service, with prices in the user's wallet currency where it differs
 // @Tags tickets
 // @Accept json
 // @Produce json
@@ -6,15 +6,4 @@ package tickets
 type BuyTicketRequest struct {
 	GameID     string  `json:"game_id" binding:"required" validate:"required"`
 	Quantity   int     `json:"quantity" binding:"required" validate:"required"`
-	AmountPaid int64 `json:"amount_paid" binding:"required" validate:"required"`
-}
-
-type CreateGameRequest struct {
-	GameName             string  `json:"game_name" binding:"required" validate:"required"`
-	Amount               int64 `json:"amount" binding:"required" validate:"required"`
-	DrawInterval         int     `json:"draw_interval" binding:"required" validate:"required"`
-	WinningPercentage    float64 `json:"winning_percentage" binding:"required" validate:"required"`
-	MaxWinners           int     `json:"max_winners" binding:"required" validate:"required"`
-	Date                 string  `json:"date" binding:"required" validate:"required"`
-	WeightedDistribution bool    `json:"weighted_distribution" validate:"required"`
 }
@@ -1,12 +1,19 @@
 package tickets
 
 import (
+	"errors"
 	"fmt"
 	"net/http"
 
 	"log"
+	"math"
      "strings"
-	// "github.com/dblaq/buzzycash/internal/config"
+	"github.com/dblaq/buzzycash/internal/config"
+	"github.com/dblaq/buzzycash/internal/core/audit"
+	"github.com/dblaq/buzzycash/internal/core/fx"
+	"github.com/dblaq/buzzycash/internal/core/ledger"
+	"github.com/dblaq/buzzycash/internal/core/responsiblegaming"
+	"github.com/dblaq/buzzycash/internal/core/velocity"
 	"github.com/dblaq/buzzycash/internal/helpers"
 	"github.com/dblaq/buzzycash/internal/models"
 	"github.com/dblaq/buzzycash/internal/utils"
@@ -44,14 +51,79 @@ func (h *TicketHandler)BuyGameTicketHandler(ctx *gin.Context) {
 	userID := currentUser.ID
 	username := currentUser.PhoneNumber
 	
-	log.Printf("Attempting to purchase ticket for user: %s, game_id: %s, quantity: %d, amount: %.2f",
-		username, req.GameID, req.Quantity, req.AmountPaid)
+	log.Printf("Attempting to purchase ticket for user: %s, game_id: %s, quantity: %d",
+		username, req.GameID, req.Quantity)
 	
+	// Tickets are priced by the catalogue in the gaming base currency, which
+	// is what the gaming API is paid. The wallet is charged the equivalent in
+	// its own currency.
+	var game models.Game
+	if err := h.db.First(&game, "provider_game_id = ?", req.GameID).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			utils.Error(ctx, http.StatusNotFound, "Game not found")
+			return
+		}
+		log.Printf("Cannot load game %s: %v", req.GameID, err)
+		utils.Error(ctx, http.StatusInternalServerError, "Failed to price ticket")
+		return
+	}
+	if game.Status != models.GameRunning {
+		utils.Error(ctx, http.StatusConflict, "This game is not selling tickets")
+		return
+	}
+
+	currency := helpers.UserCurrency(currentUser)
+	base, ok := models.ParseCurrency(config.AppConfig.GamingBaseCurrency)
+	if !ok {
+		base = currency
+	}
+	price := game.Amount * int64(req.Quantity)
+	amount := price
+	metadata := models.JSONB{
+		"gameId":        req.GameID,
+		"priceAmount":   price,
+		"priceCurrency": base,
+	}
+	if base != currency {
+		converted, rate, err := fx.Convert(h.db, price, base, currency)
+		if err != nil {
+			log.Printf("Cannot convert ticket price from %s to %s: %v", base, currency, err)
+			if errors.Is(err, fx.ErrNoRate) {
+				utils.Error(ctx, http.StatusBadRequest, fmt.Sprintf("Tickets cannot be paid from a %s wallet", currency))
+				return
+			}
+			utils.Error(ctx, http.StatusInternalServerError, "Failed to price ticket")
+			return
+		}
+		amount = converted
+		metadata["fxRate"] = rate
+	}
+	if err := validateAmount(amount, currency); err != nil {
+		utils.Error(ctx, http.StatusBadRequest, err.Error())
+		return
+	}
+	if !responsiblegaming.Enforce(ctx, h.db, responsiblegaming.Attempt{
+		User:     currentUser,
+		Type:     models.GamingSpendLimit,
+		Amount:   amount,
+		Currency: currency,
+	}) {
+		return
+	}
+	if !velocity.Enforce(ctx, h.db, velocity.Attempt{
+		User:     currentUser,
+		Action:   models.VelocityTicket,
+		Amount:   amount,
+		Currency: currency,
+	}) {
+		return
+	}
+
 	transactionTxRef := helpers.GenerateTransactionReference()
 	log.Printf("[transactionTxRef] ✅ Unique transaction ref generated: %s", transactionTxRef)
 	
 	gs := gaming.GMInstance()
-	buyResponse, err := gs.BuyTicket(req.GameID, username, req.Quantity, req.AmountPaid)
+	buyResponse, err := gs.BuyTicket(req.GameID, username, req.Quantity, price)
 	if err != nil {
 		if apiErr, ok := err.(*gaming.APIError); ok {
 			switch {
@@ -74,25 +146,28 @@ func (h *TicketHandler)BuyGameTicketHandler(ctx *gin.Context) {
 	log.Printf("Received response for ticket purchase: %+v", buyResponse)
 	log.Printf("🔍 DEBUG: About to save transaction with reference: %s", transactionTxRef)
 	
+	metadata["ticketIds"] = buyResponse.TicketIDs
 	history := models.Transaction{
-		Amount:               req.AmountPaid,
+		Amount:               amount,
 		UserID:               userID,
 		PaymentStatus:        models.Successful,
-		UnitPrice:            req.AmountPaid / int64(req.Quantity),
+		UnitPrice:            amount / int64(req.Quantity),
 		Quantity:             req.Quantity,
 		PaymentMethod:        models.Wallet,
 		TransactionReference: transactionTxRef,
 		TransactionType:      models.Debit,
 		Category:             models.Ticket,
-		Currency:             "NGN",
-		Metadata: map[string]interface{}{
-			"ticketIds": buyResponse.TicketIDs,
-			"gameId":    req.GameID,
-		},
+		Currency:             currency,
+		Metadata:             metadata,
 	}
 	
-	// Save transaction history
-	if err := h.db.Create(&history).Error; err != nil {
+	// Save transaction history and ledger entry together
+	if err := h.db.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Create(&history).Error; err != nil {
+			return err
+		}
+		return ledger.PostTicketPurchase(tx, history)
+	}); err != nil {
 		log.Printf("Database error while saving transaction: %v", err) // Add logging
 	 log.Printf("Database error details: %v", err)
     log.Printf("Transaction data: %+v", history)
@@ -100,6 +175,12 @@ func (h *TicketHandler)BuyGameTicketHandler(ctx *gin.Context) {
 		return
 	}
 	
+	after := audit.TransactionState(history)
+	after["game_id"] = req.GameID
+	after["quantity"] = req.Quantity
+	after["ticket_ids"] = buyResponse.TicketIDs
+	audit.Log(ctx, h.db, audit.TicketPurchased, audit.Target{Type: audit.TargetTransaction, ID: history.ID}, nil, after)
+
 	// Remove the duplicate error check completely
 	
 	// Send notification (optional)
@@ -113,9 +194,13 @@ func (h *TicketHandler)BuyGameTicketHandler(ctx *gin.Context) {
 	// config.DB.Create(&notification)
 	
 	ctx.JSON(http.StatusOK, gin.H{
-		"status":  "success",
-		"tickets": buyResponse,
-		"message": "Ticket purchased successfully",
+		"status":     "success",
+		"tickets":    buyResponse,
+		"amountPaid": amount,
+		"currency":   currency,
+		"price":         price,
+		"priceCurrency": base,
+		"message":    "Ticket purchased successfully",
 	})
 }
 
@@ -197,7 +282,9 @@ func GetUserGameTicketsHandler(ctx *gin.Context) {
 // 	})
 // }
 
-func GetAllGamesHandler(ctx *gin.Context) {
+// GetAllGamesHandler lists the games, adding each price in the user's
+// wallet currency when it differs from the one games are priced in.
+func (h *TicketHandler) GetAllGamesHandler(ctx *gin.Context) {
 	log.Println("Fetching all games")
 
 	gs := gaming.GMInstance()
@@ -210,6 +297,15 @@ func GetAllGamesHandler(ctx *gin.Context) {
 
 	log.Printf("Successfully retrieved games: %+v", gameResults)
 
+	currentUser := ctx.MustGet("currentUser").(models.User)
+	currency := helpers.UserCurrency(currentUser)
+	base, ok := models.ParseCurrency(config.AppConfig.GamingBaseCurrency)
+	if ok && base != currency {
+		if err := localisePrices(h.db, gameResults, base, currency); err != nil {
+			log.Printf("Could not price games in %s: %v", currency, err)
+		}
+	}
+
 	// Return the successful response
 	ctx.JSON(http.StatusOK, gin.H{
 		"success": true,
@@ -218,48 +314,33 @@ func GetAllGamesHandler(ctx *gin.Context) {
 	})
 }
 
-func CreateGameHandler(ctx *gin.Context) {
-	var req CreateGameRequest
-	if err := ctx.ShouldBindJSON(&req); err != nil {
-		log.Printf("Invalid request payload: %v", err)
-		utils.Error(ctx, http.StatusBadRequest, utils.ValidationErrorToJSON(err))
-		return
-	}
-
-	// if err := utils.Validate.Struct(req); err != nil {
-	// 	log.Printf("Validation error: %v", err)
-	// 	utils.Error(ctx, http.StatusBadRequest, utils.ValidationErrorToJSON(err))
-	// 	return
-	// }
-
-	if req.WinningPercentage < 0 || req.WinningPercentage > 100 {
-		log.Printf("Invalid winning_percentage: %d", req.WinningPercentage)
-		utils.Error(ctx, http.StatusBadRequest, "winning_percentage must be between 0 and 100")
-		return
+// localisePrices adds local_amount and local_currency to every game in the
+// gaming API's loosely typed list response.
+func localisePrices(db *gorm.DB, results map[string]interface{}, base, currency models.ECurrency) error {
+	rates, err := fx.LoadRates(db)
+	if err != nil {
+		return err
 	}
-
-	log.Printf("Creating game with request: %+v", req)
-
-	gs := gaming.GMInstance()
-	gameResponse, err := gs.CreateGames(
-		req.GameName,
-		req.Amount,
-		req.DrawInterval,
-		req.WinningPercentage,
-		req.MaxWinners,
-		req.Date,
-		req.WeightedDistribution,
-	)
+	rate, err := rates.Rate(base, currency)
 	if err != nil {
-		log.Printf("Error creating game: %v", err)
-		utils.Error(ctx, http.StatusInternalServerError, fmt.Sprintf("Failed to create game: %v", err))
-		return
+		return err
 	}
 
-	log.Printf("Game created successfully: %+v", gameResponse)
-
-	ctx.JSON(http.StatusOK, gin.H{
-		"message": "Game created successfully",
-		"data":    gameResponse,
-	})
+	for _, key := range []string{"games", "results", "data"} {
+		list, ok := results[key].([]interface{})
+		if !ok {
+			continue
+		}
+		for _, entry := range list {
+			game, ok := entry.(map[string]interface{})
+			if !ok {
+				continue
+			}
+			if amount, ok := game["amount"].(float64); ok {
+				game["local_amount"] = math.Round(amount * rate)
+				game["local_currency"] = currency
+			}
+		}
+	}
+	return nil
 }
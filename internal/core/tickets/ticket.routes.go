@@ -12,10 +12,9 @@ func TicketRoutes(rg *gin.RouterGroup,db *gorm.DB){
 	ticketHandler := NewTicketHandler(db)
 	ticketRoutes := rg.Group("/ticket")
 	{
-		ticketRoutes.POST("/purchase-ticket",middlewares.AuthMiddleware,ticketHandler.BuyGameTicketHandler)
+		ticketRoutes.POST("/purchase-ticket",middlewares.AuthMiddleware,middlewares.AgeVerificationMiddleware,ticketHandler.BuyGameTicketHandler)
 		ticketRoutes.GET("/get-tickets",middlewares.AuthMiddleware, GetUserGameTicketsHandler)
-		ticketRoutes.GET("/gaming",middlewares.AuthMiddleware, GetAllGamesHandler)
-		ticketRoutes.POST("/create-game",middlewares.AuthMiddleware, CreateGameHandler)
+		ticketRoutes.GET("/gaming",middlewares.AuthMiddleware, ticketHandler.GetAllGamesHandler)
 
 	}
 }
\ No newline at end of file
@@ -4,6 +4,9 @@ package tickets
 
 	import (
 		"errors"
+		"fmt"
+
+		"github.com/dblaq/buzzycash/internal/models"
 )
 
 
@@ -11,25 +14,28 @@ package tickets
 
 // Validation errors
 var (
-	ErrAmountTooShort          = errors.New("amount must be at least 100 naira")
+	ErrAmountTooShort          = errors.New("ticket amount is too low")
 	ErrQuantityTooShort      = errors.New("quantity must be at least 1")
 
 )
 
 
+// minTicketAmount is the smallest purchase allowed in each wallet currency.
+var minTicketAmount = map[models.ECurrency]int64{
+	models.NGN: 100,
+	models.CED: 1,
+}
+
+// Validate checks the request itself; the price comes from the catalogue
+// and is checked against the wallet currency's minimum once converted.
 func (r *BuyTicketRequest) Validate() error {
-	if err := validateAmount(r.AmountPaid); err != nil {
-		return err
-	}
-	if err := validateQuantity(r.Quantity); err != nil {
-		return err
-	}
-	return nil
+	return validateQuantity(r.Quantity)
 }
 
-func validateAmount(amount int64) error {
-	if amount < 100 {
-		return ErrAmountTooShort
+func validateAmount(amount int64, currency models.ECurrency) error {
+	min := minTicketAmount[currency]
+	if amount < min {
+		return fmt.Errorf("%w: minimum is %d %s", ErrAmountTooShort, min, currency)
 	}
 	return nil
 }
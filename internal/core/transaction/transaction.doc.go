@@ -1,7 +1,7 @@
 package transaction
 
 // @Summary Get all transactions
-// @Description Retrieve a list of all transactions, optionally filtered by parameters like status or date
+// @Description Retrieve a list of all transactions, optionally filtered by parameters like status or date. Amounts are reported in the user's wallet currency
 // @Tags transactions
 // @Accept json
 // @Produce json
@@ -17,6 +17,9 @@ type TransactionHistoryResponse struct {
 	 // Metadata             map[string]interface{} `json:"metadata,omitempty"`
 	PaymentMethod        string    `json:"payment_method"`
 	Category             string    `json:"category"`
+	// Set when Amount was converted into the wallet currency
+	OriginalAmount   *float64 `json:"original_amount,omitempty"`
+	OriginalCurrency string   `json:"original_currency,omitempty"`
 }
 
 
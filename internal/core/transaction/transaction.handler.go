@@ -3,6 +3,8 @@ package transaction
 import (
 	"fmt"
 	"github.com/dblaq/buzzycash/internal/config"
+	"github.com/dblaq/buzzycash/internal/core/fx"
+	"github.com/dblaq/buzzycash/internal/helpers"
 	"github.com/dblaq/buzzycash/internal/models"
 	"github.com/dblaq/buzzycash/internal/utils"
 	"github.com/gin-gonic/gin"
@@ -26,7 +28,7 @@ func NewTransactionHandler(db *gorm.DB) *TransactionHandler {
 
 func GetTransactionHistoryHandler(ctx *gin.Context) {
 	currentUser := ctx.MustGet("currentUser").(models.User)
-	log.Printf("INFO: GetTransactionHistoryHandler called for user ID: %d", currentUser.ID)
+	log.Printf("INFO: GetTransactionHistoryHandler called for user ID: %s", currentUser.ID)
 
 	// Pagination: page number from query, default is 1
 	pageStr := ctx.Query("page")
@@ -158,22 +160,11 @@ func GetTransactionHistoryHandler(ctx *gin.Context) {
 	}
 
 	// Map to response
-	var response []TransactionHistoryResponse
-	for _, h := range histories {
-		response = append(response, TransactionHistoryResponse{
-			ID:                   h.ID,
-			Amount:               float64(h.Amount),
-			TransactionReference: h.TransactionReference,
-			Reference:            h.Reference,
-			CustomerEmail:        h.CustomerEmail,
-			PaymentStatus:        string(h.PaymentStatus),
-			PaymentType:          string(h.PaymentType),
-			Currency:             string(h.Currency),
-			PaidAt:               h.PaidAt,
-			TransactionType:      string(h.TransactionType),
-			PaymentMethod:        string(h.PaymentMethod),
-			Category:             string(h.Category),
-		})
+	response, err := toTransactionResponses(histories, helpers.UserCurrency(currentUser))
+	if err != nil {
+		log.Printf("ERROR: User ID: %s, Failed to load exchange rates: %v", currentUser.ID, err)
+		utils.Error(ctx, http.StatusInternalServerError, "Failed to fetch transaction history")
+		return
 	}
 	log.Printf("INFO: GetTransactionHistoryHandler: User ID: %s, Mapped %d transaction history records to response.", currentUser.ID, len(response))
 
@@ -290,22 +281,11 @@ func SearchTransactionHistoryHandler(ctx *gin.Context) {
 	}
 
 	// Map response
-	var response []TransactionHistoryResponse
-	for _, h := range histories {
-		response = append(response, TransactionHistoryResponse{
-			ID:                   h.ID,
-			Amount:               float64(h.Amount),
-			TransactionReference: h.TransactionReference,
-			Reference:            h.Reference,
-			CustomerEmail:        h.CustomerEmail,
-			PaymentStatus:        string(h.PaymentStatus),
-			PaymentType:          string(h.PaymentType),
-			Currency:             string(h.Currency),
-			PaidAt:               h.PaidAt,
-			TransactionType:      string(h.TransactionType),
-			PaymentMethod:        string(h.PaymentMethod),
-			Category:             string(h.Category),
-		})
+	response, err := toTransactionResponses(histories, helpers.UserCurrency(currentUser))
+	if err != nil {
+		log.Printf("ERROR: User ID: %s, Failed to load exchange rates: %v", currentUser.ID, err)
+		utils.Error(ctx, http.StatusInternalServerError, "Failed to fetch transaction history")
+		return
 	}
 
 	hasMore := int64(offset+limit) < totalCount
@@ -331,24 +311,59 @@ func (h *TransactionHandler) GetTransactionByID(ctx *gin.Context) {
 		return
 	}
 
-	log.Printf("INFO: GetTransactionByID: Successfully retrieved transaction ID: %d for user ID: %s", tx.ID, currentUser.ID)
-	response := TransactionHistoryResponse{
-		ID:                   tx.ID,
-		Amount:               float64(tx.Amount),
-		TransactionReference: tx.TransactionReference,
-		Reference:            tx.Reference,
-		CustomerEmail:        tx.CustomerEmail,
-		PaymentStatus:        string(tx.PaymentStatus),
-		PaymentType:          string(tx.PaymentType),
-		Currency:             string(tx.Currency),
-		PaidAt:               tx.PaidAt,
-		TransactionType:      string(tx.TransactionType),
-		PaymentMethod:        string(tx.PaymentMethod),
-		Category:             string(tx.Category),
+	log.Printf("INFO: GetTransactionByID: Successfully retrieved transaction ID: %s for user ID: %s", tx.ID, currentUser.ID)
+	responses, err := toTransactionResponses([]models.Transaction{tx}, helpers.UserCurrency(currentUser))
+	if err != nil {
+		log.Printf("ERROR: GetTransactionByID: Failed to load exchange rates: %v", err)
+		utils.Error(ctx, http.StatusInternalServerError, "Failed to fetch transaction")
+		return
 	}
+	response := responses[0]
 
 	ctx.JSON(http.StatusOK, gin.H{
 		"user_id":     currentUser.ID,
 		"transaction": response,
 	})
 }
+
+// toTransactionResponses reports every amount in the user's wallet
+// currency. Rows in another currency are converted at the current rate and
+// keep their original amount; rows with no rate are left as they are.
+func toTransactionResponses(histories []models.Transaction, currency models.ECurrency) ([]TransactionHistoryResponse, error) {
+	var rates fx.Rates
+	response := make([]TransactionHistoryResponse, 0, len(histories))
+	for _, h := range histories {
+		item := TransactionHistoryResponse{
+			ID:                   h.ID,
+			Amount:               float64(h.Amount),
+			TransactionReference: h.TransactionReference,
+			Reference:            h.Reference,
+			CustomerEmail:        h.CustomerEmail,
+			PaymentStatus:        string(h.PaymentStatus),
+			PaymentType:          string(h.PaymentType),
+			Currency:             string(h.Currency),
+			PaidAt:               h.PaidAt,
+			TransactionType:      string(h.TransactionType),
+			PaymentMethod:        string(h.PaymentMethod),
+			Category:             string(h.Category),
+		}
+
+		if h.Currency != "" && h.Currency != currency {
+			if rates == nil {
+				var err error
+				if rates, err = fx.LoadRates(config.DB); err != nil {
+					return nil, err
+				}
+			}
+			if converted, _, err := rates.Convert(h.Amount, h.Currency, currency); err == nil {
+				original := item.Amount
+				item.OriginalAmount = &original
+				item.OriginalCurrency = item.Currency
+				item.Amount = float64(converted)
+				item.Currency = string(currency)
+			}
+		}
+		response = append(response, item)
+	}
+	return response, nil
+}
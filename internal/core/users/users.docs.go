@@ -0,0 +1,85 @@
+package users
+
+// @Summary Search users
+// @Description Find users whose phone number, email or username contains q, or whose referral code is q. Without q, lists every user, newest first. Requires users:manage
+// @Tags admin-users
+// @Produce json
+// @Param q query string false "Search term, at least 3 characters"
+// @Param page query int false "Page number" default(1)
+// @Success 200 {object} map[string]interface{} "users, page, has_more and total_count"
+// @Failure 400 {object} map[string]interface{} "Search too short"
+// @Failure 403 {object} map[string]interface{} "Missing permission"
+// @Router /admin/users [get]
+// @Security BearerAuth
+func _() {}
+
+
+// @Summary Get user profile
+// @Description Get a user with their recent transactions, tickets, referrals, KYC verifications and admin actions. Requires users:manage
+// @Tags admin-users
+// @Produce json
+// @Param id path string true "User ID"
+// @Success 200 {object} UserProfileResponse "User profile"
+// @Failure 404 {object} map[string]interface{} "User not found"
+// @Router /admin/users/{id} [get]
+// @Security BearerAuth
+func _() {}
+
+
+// @Summary Suspend user
+// @Description Deactivate the account and end all of its sessions. Suspended users are turned away at login and on every authenticated route. Requires users:manage
+// @Tags admin-users
+// @Accept json
+// @Produce json
+// @Param id path string true "User ID"
+// @Param request body AccountActionRequest true "Reason"
+// @Success 200 {object} UserSummary "User suspended"
+// @Failure 404 {object} map[string]interface{} "User not found"
+// @Failure 409 {object} map[string]interface{} "Account is already suspended"
+// @Router /admin/users/{id}/suspend [post]
+// @Security BearerAuth
+func _() {}
+
+
+// @Summary Reactivate user
+// @Description Let a suspended user log in again. Requires users:manage
+// @Tags admin-users
+// @Accept json
+// @Produce json
+// @Param id path string true "User ID"
+// @Param request body AccountActionRequest true "Reason"
+// @Success 200 {object} UserSummary "User reactivated"
+// @Failure 404 {object} map[string]interface{} "User not found"
+// @Failure 409 {object} map[string]interface{} "Account is not suspended"
+// @Router /admin/users/{id}/reactivate [post]
+// @Security BearerAuth
+func _() {}
+
+
+// @Summary Reset verification
+// @Description Clear the user's phone, email and/or KYC verified flags so they have to verify again. Requires users:manage
+// @Tags admin-users
+// @Accept json
+// @Produce json
+// @Param id path string true "User ID"
+// @Param request body ResetVerificationRequest true "Flags to reset"
+// @Success 200 {object} UserSummary "Verification reset"
+// @Failure 400 {object} map[string]interface{} "Nothing to reset"
+// @Failure 404 {object} map[string]interface{} "User not found"
+// @Router /admin/users/{id}/reset-verification [post]
+// @Security BearerAuth
+func _() {}
+
+
+// @Summary Force logout
+// @Description Revoke all of the user's refresh tokens and reject access tokens issued before now. Requires users:manage
+// @Tags admin-users
+// @Accept json
+// @Produce json
+// @Param id path string true "User ID"
+// @Param request body AccountActionRequest true "Reason"
+// @Success 200 {object} UserSummary "Sessions revoked"
+// @Failure 404 {object} map[string]interface{} "User not found"
+// @Router /admin/users/{id}/revoke-sessions [post]
+// @Security BearerAuth
+func _() {}
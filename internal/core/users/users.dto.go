@@ -0,0 +1,97 @@
+package users
+
+import "time"
+
+// AccountActionRequest is the body of a suspend, reactivate or session
+// revocation.
+type AccountActionRequest struct {
+	Reason string `json:"reason" binding:"required"`
+}
+
+// ResetVerificationRequest clears the chosen verification flags so the user
+// has to verify again.
+type ResetVerificationRequest struct {
+	Phone  bool   `json:"phone"`
+	Email  bool   `json:"email"`
+	Kyc    bool   `json:"kyc"`
+	Reason string `json:"reason" binding:"required"`
+}
+
+type UserSummary struct {
+	ID              string    `json:"id"`
+	FullName        string    `json:"full_name"`
+	Username        string    `json:"username"`
+	PhoneNumber     string    `json:"phone_number"`
+	Email           string    `json:"email"`
+	ReferralCode    string    `json:"referral_code"`
+	IsActive        bool      `json:"is_active"`
+	IsVerified      bool      `json:"is_verified"`
+	IsEmailVerified bool      `json:"is_email_verified"`
+	IsKycVerified   bool      `json:"is_kyc_verified"`
+	CreatedAt       time.Time `json:"created_at"`
+	LastLogin       time.Time `json:"last_login"`
+}
+
+type KycSummary struct {
+	Tier          int               `json:"tier"`
+	Verifications []KycVerification `json:"verifications"`
+}
+
+type KycVerification struct {
+	ID        string    `json:"id"`
+	Tier      int       `json:"tier"`
+	IDType    string    `json:"id_type"`
+	Status    string    `json:"status"`
+	Reason    string    `json:"reason,omitempty"`
+	CreatedAt time.Time `json:"created_at"`
+}
+
+type ReferralSummary struct {
+	ReferredBy *UserSummary  `json:"referred_by,omitempty"`
+	Balance    int64         `json:"balance"`
+	Count      int64         `json:"count"`
+	Referred   []UserSummary `json:"referred"` // most recent referrals
+}
+
+type TransactionSummary struct {
+	ID              string    `json:"id"`
+	Reference       string    `json:"reference"`
+	Amount          int64     `json:"amount"`
+	Currency        string    `json:"currency"`
+	Category        string    `json:"category"`
+	TransactionType string    `json:"transaction_type"`
+	PaymentStatus   string    `json:"payment_status"`
+	PaymentMethod   string    `json:"payment_method"`
+	CreatedAt       time.Time `json:"created_at"`
+}
+
+type TicketSummary struct {
+	ID          string    `json:"id"`
+	TotalAmount int64     `json:"total_amount"`
+	UnitPrice   int64     `json:"unit_price"`
+	Quantity    int       `json:"quantity"`
+	Currency    string    `json:"currency"`
+	PurchasedAt time.Time `json:"purchased_at"`
+}
+
+type AccountEventResponse struct {
+	ID        string                 `json:"id"`
+	Action    string                 `json:"action"`
+	Reason    string                 `json:"reason,omitempty"`
+	Details   map[string]interface{} `json:"details,omitempty"`
+	AdminID   *string                `json:"admin_id,omitempty"`
+	CreatedAt time.Time              `json:"created_at"`
+}
+
+// UserProfileResponse is everything support needs about one user.
+type UserProfileResponse struct {
+	User               UserSummary            `json:"user"`
+	DateOfBirth        string                 `json:"date_of_birth,omitempty"`
+	Gender             string                 `json:"gender,omitempty"`
+	CountryOfResidence string                 `json:"country_of_residence,omitempty"`
+	Kyc                KycSummary             `json:"kyc"`
+	Referrals          ReferralSummary        `json:"referrals"`
+	Transactions       []TransactionSummary   `json:"transactions"` // most recent first
+	Tickets            []TicketSummary        `json:"tickets"`      // most recent first
+	Events             []AccountEventResponse `json:"events"`       // admin actions, most recent first
+}
@@ -0,0 +1,248 @@
+package users
+
+import (
+	"errors"
+	"log"
+	"net/http"
+	"strconv"
+
+	"github.com/dblaq/buzzycash/internal/core/audit"
+	"github.com/dblaq/buzzycash/internal/models"
+	"github.com/dblaq/buzzycash/internal/utils"
+	"github.com/gin-gonic/gin"
+	"gorm.io/gorm"
+)
+
+type UsersHandler struct {
+	db *gorm.DB
+}
+
+func NewUsersHandler(db *gorm.DB) *UsersHandler {
+	return &UsersHandler{
+		db: db,
+	}
+}
+
+// SearchUsersHandler finds users by phone number, email, username or
+// referral code.
+func (h *UsersHandler) SearchUsersHandler(ctx *gin.Context) {
+	q, err := validateSearch(ctx.Query("q"))
+	if err != nil {
+		utils.Error(ctx, http.StatusBadRequest, err.Error())
+		return
+	}
+	page := 1
+	if p, err := strconv.Atoi(ctx.Query("page")); err == nil && p > 0 {
+		page = p
+	}
+	limit := 20
+
+	users, total, err := Search(h.db, q, page, limit)
+	if err != nil {
+		log.Printf("[Users] Search for %q failed: %v", q, err)
+		utils.Error(ctx, http.StatusInternalServerError, "Failed to search users")
+		return
+	}
+
+	response := make([]UserSummary, 0, len(users))
+	for _, u := range users {
+		response = append(response, toUserSummary(u))
+	}
+	ctx.JSON(http.StatusOK, gin.H{
+		"users":       response,
+		"page":        page,
+		"has_more":    int64(page*limit) < total,
+		"total_count": total,
+	})
+}
+
+func (h *UsersHandler) GetUserHandler(ctx *gin.Context) {
+	profile, err := GetProfile(h.db, ctx.Param("id"))
+	if err != nil {
+		accountError(ctx, err, "Failed to fetch user")
+		return
+	}
+
+	ctx.JSON(http.StatusOK, gin.H{
+		"message": "User retrieved successfully",
+		"data":    toProfileResponse(*profile),
+	})
+}
+
+func (h *UsersHandler) SuspendUserHandler(ctx *gin.Context) {
+	h.accountAction(ctx, Suspend, audit.AccountSuspended, "User suspended successfully", "Failed to suspend user")
+}
+
+func (h *UsersHandler) ReactivateUserHandler(ctx *gin.Context) {
+	h.accountAction(ctx, Reactivate, audit.AccountReactivated, "User reactivated successfully", "Failed to reactivate user")
+}
+
+func (h *UsersHandler) RevokeSessionsHandler(ctx *gin.Context) {
+	h.accountAction(ctx, RevokeSessions, audit.AccountSessionsRevoked, "User logged out of all sessions", "Failed to revoke sessions")
+}
+
+func (h *UsersHandler) accountAction(ctx *gin.Context, action func(*gorm.DB, string, models.Admin, string) (*models.User, error), auditAction, success, failure string) {
+	var req AccountActionRequest
+	if err := ctx.ShouldBindJSON(&req); err != nil {
+		utils.Error(ctx, http.StatusBadRequest, utils.ValidationErrorToJSON(err))
+		return
+	}
+	if err := req.Validate(); err != nil {
+		utils.Error(ctx, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	var before models.User
+	h.db.First(&before, "id = ?", ctx.Param("id"))
+
+	admin := ctx.MustGet("currentAdmin").(models.Admin)
+	user, err := action(h.db, ctx.Param("id"), admin, req.Reason)
+	if err != nil {
+		accountError(ctx, err, failure)
+		return
+	}
+	auditAccount(ctx, h.db, auditAction, before, *user, req.Reason)
+
+	ctx.JSON(http.StatusOK, gin.H{
+		"message": success,
+		"data":    toUserSummary(*user),
+	})
+}
+
+func (h *UsersHandler) ResetVerificationHandler(ctx *gin.Context) {
+	var req ResetVerificationRequest
+	if err := ctx.ShouldBindJSON(&req); err != nil {
+		utils.Error(ctx, http.StatusBadRequest, utils.ValidationErrorToJSON(err))
+		return
+	}
+	if err := req.Validate(); err != nil {
+		utils.Error(ctx, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	var before models.User
+	h.db.First(&before, "id = ?", ctx.Param("id"))
+
+	admin := ctx.MustGet("currentAdmin").(models.Admin)
+	user, err := ResetVerification(h.db, ctx.Param("id"), admin, req)
+	if err != nil {
+		accountError(ctx, err, "Failed to reset verification")
+		return
+	}
+	auditAccount(ctx, h.db, audit.AccountVerificationReset, before, *user, req.Reason)
+
+	ctx.JSON(http.StatusOK, gin.H{
+		"message": "Verification reset successfully",
+		"data":    toUserSummary(*user),
+	})
+}
+
+func auditAccount(ctx *gin.Context, db *gorm.DB, action string, before, after models.User, reason string) {
+	state := map[string]interface{}{
+		"user":   toUserSummary(after),
+		"reason": reason,
+	}
+	audit.Log(ctx, db, action, audit.Target{Type: audit.TargetUser, ID: after.ID}, toUserSummary(before), state)
+}
+
+func accountError(ctx *gin.Context, err error, fallback string) {
+	switch {
+	case errors.Is(err, ErrUserNotFound):
+		utils.Error(ctx, http.StatusNotFound, err.Error())
+	case errors.Is(err, ErrAlreadySuspended), errors.Is(err, ErrNotSuspended):
+		utils.Error(ctx, http.StatusConflict, err.Error())
+	default:
+		log.Printf("[Users] %s: %v", fallback, err)
+		utils.Error(ctx, http.StatusInternalServerError, fallback)
+	}
+}
+
+func toUserSummary(u models.User) UserSummary {
+	return UserSummary{
+		ID:              u.ID,
+		FullName:        u.FullName,
+		Username:        u.Username,
+		PhoneNumber:     u.PhoneNumber,
+		Email:           u.Email,
+		ReferralCode:    u.ReferralCode,
+		IsActive:        u.IsActive,
+		IsVerified:      u.IsVerified,
+		IsEmailVerified: u.IsEmailVerified,
+		IsKycVerified:   u.IsKycVerified,
+		CreatedAt:       u.CreatedAt,
+		LastLogin:       u.LastLogin,
+	}
+}
+
+func toProfileResponse(p Profile) UserProfileResponse {
+	response := UserProfileResponse{
+		User:               toUserSummary(p.User),
+		DateOfBirth:        p.User.DateOfBirthString(),
+		Gender:             string(p.User.Gender),
+		CountryOfResidence: p.User.CountryOfResidence,
+		Kyc: KycSummary{
+			Tier:          p.KycTier,
+			Verifications: make([]KycVerification, 0, len(p.Verifications)),
+		},
+		Referrals: ReferralSummary{
+			Balance:  p.ReferralBalance,
+			Count:    p.ReferralCount,
+			Referred: make([]UserSummary, 0, len(p.Referred)),
+		},
+		Transactions: make([]TransactionSummary, 0, len(p.Transactions)),
+		Tickets:      make([]TicketSummary, 0, len(p.Tickets)),
+		Events:       make([]AccountEventResponse, 0, len(p.Events)),
+	}
+
+	for _, v := range p.Verifications {
+		response.Kyc.Verifications = append(response.Kyc.Verifications, KycVerification{
+			ID:        v.ID,
+			Tier:      v.Tier,
+			IDType:    string(v.IDType),
+			Status:    string(v.Status),
+			Reason:    v.Reason,
+			CreatedAt: v.CreatedAt,
+		})
+	}
+	if p.ReferredBy != nil {
+		referrer := toUserSummary(*p.ReferredBy)
+		response.Referrals.ReferredBy = &referrer
+	}
+	for _, u := range p.Referred {
+		response.Referrals.Referred = append(response.Referrals.Referred, toUserSummary(u))
+	}
+	for _, t := range p.Transactions {
+		response.Transactions = append(response.Transactions, TransactionSummary{
+			ID:              t.ID,
+			Reference:       t.Reference,
+			Amount:          t.Amount,
+			Currency:        string(t.Currency),
+			Category:        string(t.Category),
+			TransactionType: string(t.TransactionType),
+			PaymentStatus:   string(t.PaymentStatus),
+			PaymentMethod:   string(t.PaymentMethod),
+			CreatedAt:       t.CreatedAt,
+		})
+	}
+	for _, t := range p.Tickets {
+		response.Tickets = append(response.Tickets, TicketSummary{
+			ID:          t.ID,
+			TotalAmount: t.TotalAmount,
+			UnitPrice:   t.UnitPrice,
+			Quantity:    t.Quantity,
+			Currency:    t.Currency,
+			PurchasedAt: t.PurchasedAt,
+		})
+	}
+	for _, e := range p.Events {
+		response.Events = append(response.Events, AccountEventResponse{
+			ID:        e.ID,
+			Action:    string(e.Action),
+			Reason:    e.Reason,
+			Details:   e.Details,
+			AdminID:   e.AdminID,
+			CreatedAt: e.CreatedAt,
+		})
+	}
+	return response
+}
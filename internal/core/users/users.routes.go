@@ -0,0 +1,21 @@
+package users
+
+import (
+	"github.com/dblaq/buzzycash/internal/middlewares"
+	"github.com/dblaq/buzzycash/internal/models"
+	"github.com/gin-gonic/gin"
+	"gorm.io/gorm"
+)
+
+func UsersRoutes(rg *gin.RouterGroup, db *gorm.DB) {
+	usersHandler := NewUsersHandler(db)
+	userRoutes := rg.Group("/admin/users", middlewares.AdminAuthMiddleware, middlewares.RequirePermission(models.PermUsersManage))
+	{
+		userRoutes.GET("", usersHandler.SearchUsersHandler)
+		userRoutes.GET("/:id", usersHandler.GetUserHandler)
+		userRoutes.POST("/:id/suspend", usersHandler.SuspendUserHandler)
+		userRoutes.POST("/:id/reactivate", usersHandler.ReactivateUserHandler)
+		userRoutes.POST("/:id/reset-verification", usersHandler.ResetVerificationHandler)
+		userRoutes.POST("/:id/revoke-sessions", usersHandler.RevokeSessionsHandler)
+	}
+}
@@ -0,0 +1,204 @@
+package users
+
+import (
+	"errors"
+	"fmt"
+	"log"
+	"strings"
+	"time"
+
+	"github.com/dblaq/buzzycash/internal/core/kyc"
+	"github.com/dblaq/buzzycash/internal/models"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+var (
+	ErrUserNotFound     = errors.New("user not found")
+	ErrAlreadySuspended = errors.New("account is already suspended")
+	ErrNotSuspended     = errors.New("account is not suspended")
+)
+
+// profileListLimit caps each list in a user profile.
+const profileListLimit = 20
+
+// Search finds users whose phone number, email or username contains q, or
+// whose referral code is q. An empty q lists every user, newest first.
+func Search(db *gorm.DB, q string, page, limit int) ([]models.User, int64, error) {
+	query := db.Model(&models.User{})
+	if q != "" {
+		like := "%" + strings.ToLower(q) + "%"
+		query = query.Where(
+			"phone_number LIKE ? OR LOWER(email) LIKE ? OR LOWER(username) LIKE ? OR UPPER(referral_code) = ?",
+			like, like, like, strings.ToUpper(q),
+		)
+	}
+
+	var total int64
+	if err := query.Count(&total).Error; err != nil {
+		return nil, 0, err
+	}
+
+	var users []models.User
+	if err := query.Order("created_at DESC").Limit(limit).Offset((page - 1) * limit).Find(&users).Error; err != nil {
+		return nil, 0, err
+	}
+	return users, total, nil
+}
+
+// Profile is a user with their money, play, referral and KYC history.
+type Profile struct {
+	User            models.User
+	KycTier         int
+	Verifications   []models.KycVerification
+	ReferredBy      *models.User
+	ReferralBalance int64
+	ReferralCount   int64
+	Referred        []models.User
+	Transactions    []models.Transaction
+	Tickets         []models.TicketPurchase
+	Events          []models.AccountEvent
+}
+
+func GetProfile(db *gorm.DB, userID string) (*Profile, error) {
+	var p Profile
+	if err := db.First(&p.User, "id = ?", userID).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, ErrUserNotFound
+		}
+		return nil, err
+	}
+
+	tier, err := kyc.CurrentTier(db, userID)
+	if err != nil {
+		return nil, err
+	}
+	p.KycTier = tier
+	if err := db.Where("user_id = ?", userID).Order("created_at DESC").Find(&p.Verifications).Error; err != nil {
+		return nil, fmt.Errorf("load kyc verifications failed: %w", err)
+	}
+
+	if p.User.ReferredByID != nil {
+		var referrer models.User
+		if err := db.First(&referrer, "id = ?", *p.User.ReferredByID).Error; err == nil {
+			p.ReferredBy = &referrer
+		}
+	}
+	var wallet models.ReferralWallet
+	if err := db.First(&wallet, "user_id = ?", userID).Error; err == nil {
+		p.ReferralBalance = wallet.ReferralBalance
+	}
+	if err := db.Model(&models.User{}).Where("referred_by_id = ?", userID).Count(&p.ReferralCount).Error; err != nil {
+		return nil, fmt.Errorf("count referrals failed: %w", err)
+	}
+	if err := db.Where("referred_by_id = ?", userID).Order("created_at DESC").Limit(profileListLimit).Find(&p.Referred).Error; err != nil {
+		return nil, fmt.Errorf("load referrals failed: %w", err)
+	}
+
+	if err := db.Where("user_id = ?", userID).Order("created_at DESC").Limit(profileListLimit).Find(&p.Transactions).Error; err != nil {
+		return nil, fmt.Errorf("load transactions failed: %w", err)
+	}
+	if err := db.Where("user_id = ?", userID).Order("purchased_at DESC").Limit(profileListLimit).Find(&p.Tickets).Error; err != nil {
+		return nil, fmt.Errorf("load tickets failed: %w", err)
+	}
+	if err := db.Where("user_id = ?", userID).Order("created_at DESC").Limit(profileListLimit).Find(&p.Events).Error; err != nil {
+		return nil, fmt.Errorf("load account events failed: %w", err)
+	}
+	return &p, nil
+}
+
+// Suspend deactivates the account and ends all of its sessions.
+func Suspend(db *gorm.DB, userID string, admin models.Admin, reason string) (*models.User, error) {
+	return changeAccount(db, userID, admin, models.AccountSuspended, reason, nil, func(tx *gorm.DB, user *models.User) error {
+		if !user.IsActive {
+			return ErrAlreadySuspended
+		}
+		if err := tx.Model(user).Update("is_active", false).Error; err != nil {
+			return err
+		}
+		return revokeSessions(tx, user.ID)
+	})
+}
+
+// Reactivate lets a suspended user log in again.
+func Reactivate(db *gorm.DB, userID string, admin models.Admin, reason string) (*models.User, error) {
+	return changeAccount(db, userID, admin, models.AccountReactivated, reason, nil, func(tx *gorm.DB, user *models.User) error {
+		if user.IsActive {
+			return ErrNotSuspended
+		}
+		return tx.Model(user).Update("is_active", true).Error
+	})
+}
+
+// ResetVerification clears the chosen verification flags. Resetting the
+// phone flag sends the user through OTP verification at their next login.
+func ResetVerification(db *gorm.DB, userID string, admin models.Admin, req ResetVerificationRequest) (*models.User, error) {
+	updates := map[string]interface{}{}
+	details := models.JSONB{}
+	if req.Phone {
+		updates["is_verified"] = false
+		details["phone"] = true
+	}
+	if req.Email {
+		updates["is_email_verified"] = false
+		details["email"] = true
+	}
+	if req.Kyc {
+		updates["is_kyc_verified"] = false
+		details["kyc"] = true
+	}
+
+	return changeAccount(db, userID, admin, models.AccountVerificationReset, req.Reason, details, func(tx *gorm.DB, user *models.User) error {
+		return tx.Model(user).Updates(updates).Error
+	})
+}
+
+// RevokeSessions logs the user out everywhere: refresh tokens are deleted
+// and access tokens issued before now stop being accepted.
+func RevokeSessions(db *gorm.DB, userID string, admin models.Admin, reason string) (*models.User, error) {
+	return changeAccount(db, userID, admin, models.AccountSessionsRevoked, reason, nil, func(tx *gorm.DB, user *models.User) error {
+		return revokeSessions(tx, user.ID)
+	})
+}
+
+// changeAccount locks the user, applies change and records the action.
+func changeAccount(db *gorm.DB, userID string, admin models.Admin, action models.AccountAction, reason string, details models.JSONB, change func(*gorm.DB, *models.User) error) (*models.User, error) {
+	var user models.User
+	err := db.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Clauses(clause.Locking{Strength: "UPDATE"}).First(&user, "id = ?", userID).Error; err != nil {
+			if errors.Is(err, gorm.ErrRecordNotFound) {
+				return ErrUserNotFound
+			}
+			return err
+		}
+		if err := change(tx, &user); err != nil {
+			return err
+		}
+		return tx.Create(&models.AccountEvent{
+			UserID:  user.ID,
+			Action:  action,
+			Reason:  reason,
+			Details: details,
+			AdminID: &admin.ID,
+		}).Error
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	if err := db.First(&user, "id = ?", userID).Error; err != nil {
+		return nil, err
+	}
+	log.Printf("[Users] Admin %s: %s on userID %s: %s", admin.ID, action, userID, reason)
+	return &user, nil
+}
+
+func revokeSessions(tx *gorm.DB, userID string) error {
+	if err := tx.Where("user_id = ?", userID).Delete(&models.RefreshToken{}).Error; err != nil {
+		return err
+	}
+	return tx.Clauses(clause.OnConflict{
+		Columns:   []clause.Column{{Name: "user_id"}},
+		DoUpdates: clause.AssignmentColumns([]string{"revoked_at", "updated_at"}),
+	}).Create(&models.SessionRevocation{UserID: userID, RevokedAt: time.Now()}).Error
+}
@@ -0,0 +1,49 @@
+package users
+
+import (
+	"errors"
+	"strings"
+)
+
+// Validation errors
+var (
+	ErrReasonMissing  = errors.New("a reason is required")
+	ErrReasonTooLong  = errors.New("reason must be at most 500 characters")
+	ErrNothingToReset = errors.New("choose at least one of phone, email or kyc to reset")
+	ErrSearchTooShort = errors.New("search must be at least 3 characters")
+)
+
+const (
+	maxReasonLength = 500
+	minSearchLength = 3
+)
+
+func (r *AccountActionRequest) Validate() error {
+	return validateReason(&r.Reason)
+}
+
+func (r *ResetVerificationRequest) Validate() error {
+	if !r.Phone && !r.Email && !r.Kyc {
+		return ErrNothingToReset
+	}
+	return validateReason(&r.Reason)
+}
+
+func validateReason(reason *string) error {
+	*reason = strings.TrimSpace(*reason)
+	if *reason == "" {
+		return ErrReasonMissing
+	}
+	if len(*reason) > maxReasonLength {
+		return ErrReasonTooLong
+	}
+	return nil
+}
+
+func validateSearch(q string) (string, error) {
+	q = strings.TrimSpace(q)
+	if q != "" && len(q) < minSearchLength {
+		return "", ErrSearchTooShort
+	}
+	return q, nil
+}
@@ -0,0 +1,40 @@
+package velocity
+
+// @Summary Get user velocity limits
+// @Description Get the deposit, ticket and withdrawal limits in force for a user, with any admin overrides
+// @Tags admin-velocity
+// @Produce json
+// @Param id path string true "User ID"
+// @Success 200 {object} UserLimitsResponse "Limits"
+// @Failure 401 {object} map[string]interface{} "Unauthorized"
+// @Failure 500 {object} map[string]interface{} "Failed to fetch limits"
+// @Router /admin/velocity/users/{id} [get]
+// @Security BearerAuth
+func _() {}
+
+
+// @Summary Override user velocity limit
+// @Description Set a user's count and/or amount limit for one action and window. Omitted fields keep the default; 0 removes the limit
+// @Tags admin-velocity
+// @Accept json
+// @Produce json
+// @Param id path string true "User ID"
+// @Param request body SetOverrideRequest true "Override"
+// @Success 200 {object} OverrideResponse "Override saved"
+// @Failure 400 {object} map[string]interface{} "Invalid override"
+// @Failure 404 {object} map[string]interface{} "User not found"
+// @Router /admin/velocity/users/{id} [put]
+// @Security BearerAuth
+func _() {}
+
+
+// @Summary Delete velocity override
+// @Description Remove an override so the user goes back to the default limits
+// @Tags admin-velocity
+// @Produce json
+// @Param id path string true "Override ID"
+// @Success 200 {object} map[string]interface{} "Override deleted"
+// @Failure 404 {object} map[string]interface{} "Limit override not found"
+// @Router /admin/velocity/overrides/{id} [delete]
+// @Security BearerAuth
+func _() {}
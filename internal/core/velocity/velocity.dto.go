@@ -0,0 +1,30 @@
+package velocity
+
+import "time"
+
+// SetOverrideRequest replaces a user's limits for one action and window.
+// Omitted fields keep the default; 0 lifts the limit.
+type SetOverrideRequest struct {
+	Action    string `json:"action" binding:"required"` // DEPOSIT, TICKET or WITHDRAWAL
+	Window    string `json:"window" binding:"required"` // HOUR or DAY
+	MaxCount  *int64 `json:"max_count"`
+	MaxAmount *int64 `json:"max_amount"` // naira
+	Reason    string `json:"reason" binding:"required"`
+}
+
+type OverrideResponse struct {
+	ID        string    `json:"id"`
+	Action    string    `json:"action"`
+	Window    string    `json:"window"`
+	MaxCount  *int64    `json:"max_count,omitempty"`
+	MaxAmount *int64    `json:"max_amount,omitempty"`
+	Reason    string    `json:"reason"`
+	SetBy     *string   `json:"set_by,omitempty"`
+	UpdatedAt time.Time `json:"updated_at"`
+}
+
+type UserLimitsResponse struct {
+	UserID    string             `json:"user_id"`
+	Rules     []Rule             `json:"rules"` // effective limits, overrides applied
+	Overrides []OverrideResponse `json:"overrides"`
+}
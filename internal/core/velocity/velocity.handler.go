@@ -0,0 +1,182 @@
+package velocity
+
+import (
+	"errors"
+	"log"
+	"math"
+	"net/http"
+	"strconv"
+	"strings"
+
+	"github.com/dblaq/buzzycash/internal/core/audit"
+	"github.com/dblaq/buzzycash/internal/models"
+	"github.com/dblaq/buzzycash/internal/utils"
+	"github.com/gin-gonic/gin"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+type VelocityHandler struct {
+	db *gorm.DB
+}
+
+func NewVelocityHandler(db *gorm.DB) *VelocityHandler {
+	return &VelocityHandler{
+		db: db,
+	}
+}
+
+// Enforce runs Allow for a request. When the attempt is over a limit it
+// writes the error response, 429 with Retry-After for too many attempts or
+// 403 for an amount cap, and returns false.
+func Enforce(ctx *gin.Context, db *gorm.DB, a Attempt) bool {
+	if a.DeviceID == "" {
+		a.DeviceID = strings.TrimSpace(ctx.GetHeader(DeviceHeader))
+	}
+
+	err := Allow(db, a)
+	if err == nil {
+		return true
+	}
+
+	var limitErr *LimitError
+	if errors.As(err, &limitErr) {
+		log.Printf("[Velocity] Blocked %s for userID %s: %v", a.Action, a.User.ID, err)
+		if limitErr.Count {
+			ctx.Header("Retry-After", strconv.Itoa(int(math.Ceil(limitErr.RetryAfter.Seconds()))))
+			utils.Error(ctx, http.StatusTooManyRequests, limitErr.Error())
+			return false
+		}
+		utils.Error(ctx, http.StatusForbidden, limitErr.Error())
+		return false
+	}
+
+	log.Printf("[Velocity] Check failed for userID %s: %v", a.User.ID, err)
+	utils.Error(ctx, http.StatusInternalServerError, "Failed to process request")
+	return false
+}
+
+// GetUserLimitsHandler shows the limits in force for a user and the
+// overrides behind them.
+func (h *VelocityHandler) GetUserLimitsHandler(ctx *gin.Context) {
+	userID := ctx.Param("id")
+
+	response := UserLimitsResponse{UserID: userID}
+	for _, action := range actions {
+		rules, err := UserRules(h.db, userID, action)
+		if err != nil {
+			utils.Error(ctx, http.StatusInternalServerError, "Failed to fetch limits")
+			return
+		}
+		response.Rules = append(response.Rules, rules...)
+	}
+
+	var overrides []models.VelocityOverride
+	if err := h.db.Where("user_id = ?", userID).Order("action, \"window\"").Find(&overrides).Error; err != nil {
+		utils.Error(ctx, http.StatusInternalServerError, "Failed to fetch limits")
+		return
+	}
+	response.Overrides = make([]OverrideResponse, 0, len(overrides))
+	for _, o := range overrides {
+		response.Overrides = append(response.Overrides, toOverrideResponse(o))
+	}
+
+	ctx.JSON(http.StatusOK, gin.H{
+		"message": "Limits retrieved successfully",
+		"data":    response,
+	})
+}
+
+// SetOverrideHandler creates or replaces a user's override for one action
+// and window.
+func (h *VelocityHandler) SetOverrideHandler(ctx *gin.Context) {
+	var req SetOverrideRequest
+	if err := ctx.ShouldBindJSON(&req); err != nil {
+		utils.Error(ctx, http.StatusBadRequest, utils.ValidationErrorToJSON(err))
+		return
+	}
+	if err := req.Validate(); err != nil {
+		utils.Error(ctx, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	userID := ctx.Param("id")
+	var user models.User
+	if err := h.db.Select("id").First(&user, "id = ?", userID).Error; err != nil {
+		utils.Error(ctx, http.StatusNotFound, "User not found")
+		return
+	}
+
+	var before models.VelocityOverride
+	h.db.First(&before, "user_id = ? AND action = ? AND \"window\" = ?", userID, req.Action, req.Window)
+
+	admin := ctx.MustGet("currentAdmin").(models.Admin)
+	override := models.VelocityOverride{
+		UserID:    userID,
+		Action:    models.VelocityAction(req.Action),
+		Window:    models.VelocityWindow(req.Window),
+		MaxCount:  req.MaxCount,
+		MaxAmount: req.MaxAmount,
+		Reason:    strings.TrimSpace(req.Reason),
+		SetBy:     &admin.ID,
+	}
+	if err := h.db.Clauses(clause.OnConflict{
+		Columns:   []clause.Column{{Name: "user_id"}, {Name: "action"}, {Name: "window"}},
+		DoUpdates: clause.AssignmentColumns([]string{"max_count", "max_amount", "reason", "set_by", "updated_at"}),
+	}).Create(&override).Error; err != nil {
+		log.Printf("[Velocity] Failed to save override for userID %s: %v", userID, err)
+		utils.Error(ctx, http.StatusInternalServerError, "Failed to save limit override")
+		return
+	}
+	if err := h.db.First(&override, "user_id = ? AND action = ? AND \"window\" = ?", userID, override.Action, override.Window).Error; err != nil {
+		utils.Error(ctx, http.StatusInternalServerError, "Failed to save limit override")
+		return
+	}
+
+	log.Printf("[Velocity] %s %s override for userID %s set by admin %s: %s", override.Action, override.Window, userID, admin.ID, override.Reason)
+	var previous interface{}
+	if before.ID != "" {
+		previous = toOverrideResponse(before)
+	}
+	audit.Log(ctx, h.db, audit.VelocityOverrideSet, audit.Target{Type: audit.TargetUser, ID: userID}, previous, toOverrideResponse(override))
+	ctx.JSON(http.StatusOK, gin.H{
+		"message": "Limit override saved successfully",
+		"data":    toOverrideResponse(override),
+	})
+}
+
+func (h *VelocityHandler) DeleteOverrideHandler(ctx *gin.Context) {
+	var before models.VelocityOverride
+	h.db.First(&before, "id = ?", ctx.Param("id"))
+
+	result := h.db.Delete(&models.VelocityOverride{}, "id = ?", ctx.Param("id"))
+	if result.Error != nil {
+		utils.Error(ctx, http.StatusInternalServerError, "Failed to delete limit override")
+		return
+	}
+	if result.RowsAffected == 0 {
+		utils.Error(ctx, http.StatusNotFound, "Limit override not found")
+		return
+	}
+
+	if admin, ok := ctx.Get("currentAdmin"); ok {
+		log.Printf("[Velocity] Override %s deleted by admin %s", ctx.Param("id"), admin.(models.Admin).ID)
+	}
+	audit.Log(ctx, h.db, audit.VelocityOverrideDeleted, audit.Target{Type: audit.TargetUser, ID: before.UserID}, toOverrideResponse(before), nil)
+	ctx.JSON(http.StatusOK, gin.H{
+		"message": "Limit override deleted successfully",
+	})
+}
+
+func toOverrideResponse(o models.VelocityOverride) OverrideResponse {
+	return OverrideResponse{
+		ID:        o.ID,
+		Action:    string(o.Action),
+		Window:    string(o.Window),
+		MaxCount:  o.MaxCount,
+		MaxAmount: o.MaxAmount,
+		Reason:    o.Reason,
+		SetBy:     o.SetBy,
+		UpdatedAt: o.UpdatedAt,
+	}
+}
@@ -0,0 +1,18 @@
+package velocity
+
+import (
+	"github.com/dblaq/buzzycash/internal/middlewares"
+	"github.com/dblaq/buzzycash/internal/models"
+	"github.com/gin-gonic/gin"
+	"gorm.io/gorm"
+)
+
+func VelocityRoutes(rg *gin.RouterGroup, db *gorm.DB) {
+	velocityHandler := NewVelocityHandler(db)
+	velocityRoutes := rg.Group("/admin/velocity", middlewares.AdminAuthMiddleware, middlewares.RequirePermission(models.PermUsersManage))
+	{
+		velocityRoutes.GET("/users/:id", velocityHandler.GetUserLimitsHandler)
+		velocityRoutes.PUT("/users/:id", velocityHandler.SetOverrideHandler)
+		velocityRoutes.DELETE("/overrides/:id", velocityHandler.DeleteOverrideHandler)
+	}
+}
@@ -0,0 +1,241 @@
+package velocity
+
+import (
+	"errors"
+	"fmt"
+	"log"
+	"time"
+
+	"github.com/dblaq/buzzycash/internal/config"
+	"github.com/dblaq/buzzycash/internal/core/fx"
+	"github.com/dblaq/buzzycash/internal/models"
+	"gorm.io/gorm"
+)
+
+// DeviceHeader carries the app's install ID; limits are also applied per
+// device so one phone cannot get around them with several accounts.
+const DeviceHeader = "X-Device-ID"
+
+// ErrLimitExceeded is wrapped by every LimitError.
+var ErrLimitExceeded = errors.New("velocity limit exceeded")
+
+var windows = map[models.VelocityWindow]time.Duration{
+	models.VelocityHour: time.Hour,
+	models.VelocityDay:  24 * time.Hour,
+}
+
+// Rule is one limit over a rolling window. Zero means no limit.
+type Rule struct {
+	Action     models.VelocityAction `json:"action"`
+	Window     models.VelocityWindow `json:"window"`
+	MaxCount   int64                 `json:"max_count"`
+	MaxAmount  int64                 `json:"max_amount"` // naira
+	Overridden bool                  `json:"overridden"`
+}
+
+// DefaultRules returns the configured limits for an action.
+func DefaultRules(action models.VelocityAction) []Rule {
+	c := config.AppConfig
+	var hourly, daily Rule
+	switch action {
+	case models.VelocityDeposit:
+		hourly = Rule{MaxCount: c.VelocityDepositHourlyCount, MaxAmount: c.VelocityDepositHourlyAmount}
+		daily = Rule{MaxCount: c.VelocityDepositDailyCount, MaxAmount: c.VelocityDepositDailyAmount}
+	case models.VelocityTicket:
+		hourly = Rule{MaxCount: c.VelocityTicketHourlyCount, MaxAmount: c.VelocityTicketHourlyAmount}
+		daily = Rule{MaxCount: c.VelocityTicketDailyCount, MaxAmount: c.VelocityTicketDailyAmount}
+	case models.VelocityWithdrawal:
+		hourly = Rule{MaxCount: c.VelocityWithdrawalHourlyCount, MaxAmount: c.VelocityWithdrawalHourlyAmount}
+		daily = Rule{MaxCount: c.VelocityWithdrawalDailyCount, MaxAmount: c.VelocityWithdrawalDailyAmount}
+	}
+	hourly.Action, hourly.Window = action, models.VelocityHour
+	daily.Action, daily.Window = action, models.VelocityDay
+	return []Rule{hourly, daily}
+}
+
+// UserRules returns the limits that apply to a user: the defaults with any
+// admin overrides laid over them.
+func UserRules(db *gorm.DB, userID string, action models.VelocityAction) ([]Rule, error) {
+	var overrides []models.VelocityOverride
+	if err := db.Where("user_id = ? AND action = ?", userID, action).Find(&overrides).Error; err != nil {
+		return nil, fmt.Errorf("load velocity overrides failed: %w", err)
+	}
+
+	rules := DefaultRules(action)
+	for i := range rules {
+		for _, o := range overrides {
+			if o.Window != rules[i].Window {
+				continue
+			}
+			if o.MaxCount != nil {
+				rules[i].MaxCount = *o.MaxCount
+			}
+			if o.MaxAmount != nil {
+				rules[i].MaxAmount = *o.MaxAmount
+			}
+			rules[i].Overridden = true
+		}
+	}
+	return rules, nil
+}
+
+// Attempt is a deposit, ticket purchase or withdrawal about to be made.
+type Attempt struct {
+	User     models.User
+	DeviceID string
+	Action   models.VelocityAction
+	Amount   int64
+	Currency models.ECurrency
+}
+
+// LimitError reports the limit an attempt ran into. Too many attempts is a
+// rate problem the user can wait out; too much money is a cap.
+type LimitError struct {
+	Action     models.VelocityAction
+	Window     models.VelocityWindow
+	Device     bool // the limit was reached on the device rather than the account
+	Count      bool // false when the amount cap was reached
+	Max        int64
+	RetryAfter time.Duration
+}
+
+func (e *LimitError) Error() string {
+	period := "hour"
+	if e.Window == models.VelocityDay {
+		period = "24 hours"
+	}
+	scope := "your account"
+	if e.Device {
+		scope = "this device"
+	}
+	if e.Count {
+		return fmt.Sprintf("too many %s attempts from %s in the last %s; try again in %s",
+			actionName(e.Action), scope, period, e.RetryAfter.Round(time.Minute))
+	}
+	return fmt.Sprintf("%s from %s would exceed the limit of %d NGN per %s",
+		actionName(e.Action), scope, e.Max, period)
+}
+
+func (e *LimitError) Unwrap() error { return ErrLimitExceeded }
+
+func actionName(action models.VelocityAction) string {
+	switch action {
+	case models.VelocityDeposit:
+		return "deposit"
+	case models.VelocityTicket:
+		return "ticket purchase"
+	default:
+		return "withdrawal"
+	}
+}
+
+// Allow checks an attempt against the user's limits and the device's, and
+// records it when it is within them. Attempts are counted whether or not
+// they go on to succeed, so hammering an endpoint uses the allowance up.
+// A breach is returned as a *LimitError.
+func Allow(db *gorm.DB, a Attempt) error {
+	naira, _, err := fx.Convert(db, a.Amount, a.Currency, models.NGN)
+	if err != nil {
+		return err
+	}
+	userRules, err := UserRules(db, a.User.ID, a.Action)
+	if err != nil {
+		return err
+	}
+
+	return db.Transaction(func(tx *gorm.DB) error {
+		// Serialise a user's attempts so concurrent requests cannot both
+		// squeeze under the same limit
+		if err := tx.Exec("SELECT pg_advisory_xact_lock(hashtext(?))", "velocity:"+a.User.ID).Error; err != nil {
+			return fmt.Errorf("velocity lock failed: %w", err)
+		}
+
+		now := time.Now()
+		if err := check(tx, "user_id = ?", a.User.ID, a.Action, naira, userRules, now); err != nil {
+			return err
+		}
+		if a.DeviceID != "" {
+			if err := check(tx, "device_id = ?", a.DeviceID, a.Action, naira, DefaultRules(a.Action), now); err != nil {
+				if limitErr, ok := err.(*LimitError); ok {
+					limitErr.Device = true
+				}
+				return err
+			}
+		}
+
+		return tx.Create(&models.VelocityEvent{
+			UserID:   a.User.ID,
+			DeviceID: a.DeviceID,
+			Action:   a.Action,
+			Amount:   naira,
+		}).Error
+	})
+}
+
+func check(tx *gorm.DB, scope string, key interface{}, action models.VelocityAction, amount int64, rules []Rule, now time.Time) error {
+	for _, rule := range rules {
+		if rule.MaxCount <= 0 && rule.MaxAmount <= 0 {
+			continue
+		}
+		since := now.Add(-windows[rule.Window])
+
+		var stats windowStats
+		if err := tx.Model(&models.VelocityEvent{}).
+			Select("COUNT(*) AS count, COALESCE(SUM(amount), 0) AS total, MIN(created_at) AS oldest").
+			Where(scope+" AND action = ? AND created_at >= ?", key, action, since).
+			Scan(&stats).Error; err != nil {
+			return fmt.Errorf("load velocity window failed: %w", err)
+		}
+
+		if limitErr := breach(action, rule, stats, amount, now); limitErr != nil {
+			return limitErr
+		}
+	}
+	return nil
+}
+
+// windowStats summarises the attempts already inside a rule's window.
+type windowStats struct {
+	Count  int64
+	Total  int64
+	Oldest *time.Time
+}
+
+// breach reports the limit one more attempt of amount would break, or nil.
+// A count breach says when the oldest attempt leaves the window, and never
+// less than a minute.
+func breach(action models.VelocityAction, rule Rule, stats windowStats, amount int64, now time.Time) *LimitError {
+	if rule.MaxCount > 0 && stats.Count+1 > rule.MaxCount {
+		retry := time.Minute
+		if stats.Oldest != nil {
+			if wait := stats.Oldest.Add(windows[rule.Window]).Sub(now); wait > retry {
+				retry = wait
+			}
+		}
+		return &LimitError{Action: action, Window: rule.Window, Count: true, Max: rule.MaxCount, RetryAfter: retry}
+	}
+	if rule.MaxAmount > 0 && stats.Total+amount > rule.MaxAmount {
+		return &LimitError{Action: action, Window: rule.Window, Max: rule.MaxAmount}
+	}
+	return nil
+}
+
+// StartPruner deletes events that no window can reach any more, hourly.
+func StartPruner(db *gorm.DB) {
+	go func() {
+		ticker := time.NewTicker(time.Hour)
+		defer ticker.Stop()
+
+		for range ticker.C {
+			cutoff := time.Now().Add(-25 * time.Hour)
+			result := db.Where("created_at < ?", cutoff).Delete(&models.VelocityEvent{})
+			if result.Error != nil {
+				log.Printf("[Velocity] Could not prune events: %v", result.Error)
+				continue
+			}
+			if result.RowsAffected > 0 {
+				log.Printf("[Velocity] Pruned %d events", result.RowsAffected)
+			}
+		}
+	}()
+}
@@ -0,0 +1,212 @@
+package velocity
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/dblaq/buzzycash/internal/config"
+	"github.com/dblaq/buzzycash/internal/models"
+	"github.com/dblaq/buzzycash/internal/testutil"
+	"gorm.io/gorm"
+)
+
+func TestBreach(t *testing.T) {
+	now := time.Date(2025, 1, 1, 12, 0, 0, 0, time.UTC)
+	oldest := now.Add(-20 * time.Minute)
+	hourly := Rule{Window: models.VelocityHour, MaxCount: 3, MaxAmount: 10000}
+
+	tests := []struct {
+		name      string
+		rule      Rule
+		stats     windowStats
+		amount    int64
+		wantCount bool
+		wantErr   bool
+		wantRetry time.Duration
+	}{
+		{name: "empty window", rule: hourly, amount: 500},
+		{name: "last attempt allowed", rule: hourly, stats: windowStats{Count: 2, Total: 1000, Oldest: &oldest}, amount: 500},
+		{
+			name: "count reached", rule: hourly, stats: windowStats{Count: 3, Total: 1000, Oldest: &oldest}, amount: 500,
+			wantErr: true, wantCount: true, wantRetry: 40 * time.Minute,
+		},
+		{name: "amount exactly at cap", rule: hourly, stats: windowStats{Count: 1, Total: 9000, Oldest: &oldest}, amount: 1000},
+		{name: "amount over cap", rule: hourly, stats: windowStats{Count: 1, Total: 9000, Oldest: &oldest}, amount: 1001, wantErr: true},
+		{name: "single attempt over cap", rule: hourly, amount: 10001, wantErr: true},
+		{
+			name: "retry is at least a minute", rule: hourly,
+			stats:   windowStats{Count: 3, Oldest: ptr(now.Add(-time.Hour + 10*time.Second))},
+			wantErr: true, wantCount: true, wantRetry: time.Minute,
+		},
+		{
+			name: "daily window", rule: Rule{Window: models.VelocityDay, MaxCount: 1},
+			stats:   windowStats{Count: 1, Oldest: ptr(now.Add(-20 * time.Hour))},
+			wantErr: true, wantCount: true, wantRetry: 4 * time.Hour,
+		},
+		{name: "count lifted", rule: Rule{Window: models.VelocityHour, MaxAmount: 10000}, stats: windowStats{Count: 500, Total: 100}, amount: 100},
+		{name: "amount lifted", rule: Rule{Window: models.VelocityHour, MaxCount: 3}, stats: windowStats{Count: 1, Total: 1 << 40}, amount: 1 << 40},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := breach(models.VelocityDeposit, tt.rule, tt.stats, tt.amount, now)
+			if !tt.wantErr {
+				if got != nil {
+					t.Fatalf("breach = %v, want nil", got)
+				}
+				return
+			}
+			if got == nil {
+				t.Fatal("breach = nil, want a LimitError")
+			}
+			if got.Count != tt.wantCount || got.Window != tt.rule.Window {
+				t.Errorf("breach = %+v, want Count=%v Window=%s", got, tt.wantCount, tt.rule.Window)
+			}
+			if tt.wantCount && got.RetryAfter != tt.wantRetry {
+				t.Errorf("RetryAfter = %s, want %s", got.RetryAfter, tt.wantRetry)
+			}
+		})
+	}
+}
+
+func ptr(t time.Time) *time.Time { return &t }
+
+func setDepositDefaults(t *testing.T, hourlyCount, hourlyAmount, dailyCount, dailyAmount int64) {
+	t.Helper()
+	saved := config.AppConfig
+	t.Cleanup(func() { config.AppConfig = saved })
+	config.AppConfig.VelocityDepositHourlyCount = hourlyCount
+	config.AppConfig.VelocityDepositHourlyAmount = hourlyAmount
+	config.AppConfig.VelocityDepositDailyCount = dailyCount
+	config.AppConfig.VelocityDepositDailyAmount = dailyAmount
+}
+
+func event(t *testing.T, db *gorm.DB, userID string, amount int64, at time.Time) {
+	t.Helper()
+	if err := db.Create(&models.VelocityEvent{
+		UserID:    userID,
+		Action:    models.VelocityDeposit,
+		Amount:    amount,
+		CreatedAt: at,
+	}).Error; err != nil {
+		t.Fatalf("create velocity event: %v", err)
+	}
+}
+
+func TestCheckCountsOnlyTheWindow(t *testing.T) {
+	db := testutil.DB(t)
+	user := testutil.User(t, db)
+	now := time.Now()
+	rules := []Rule{
+		{Action: models.VelocityDeposit, Window: models.VelocityHour, MaxCount: 3},
+		{Action: models.VelocityDeposit, Window: models.VelocityDay, MaxAmount: 5000},
+	}
+
+	event(t, db, user.ID, 1000, now.Add(-10*time.Minute))
+	event(t, db, user.ID, 1000, now.Add(-30*time.Minute))
+	// Outside the hour but inside the day
+	event(t, db, user.ID, 2000, now.Add(-3*time.Hour))
+	// Outside both windows
+	event(t, db, user.ID, 9000, now.Add(-25*time.Hour))
+
+	if err := check(db, "user_id = ?", user.ID, models.VelocityDeposit, 1000, rules, now); err != nil {
+		t.Fatalf("third attempt in the hour: %v", err)
+	}
+
+	var limitErr *LimitError
+	err := check(db, "user_id = ?", user.ID, models.VelocityDeposit, 1001, rules, now)
+	if !errors.As(err, &limitErr) || limitErr.Count || limitErr.Window != models.VelocityDay {
+		t.Fatalf("over the daily amount: err = %v, want daily amount LimitError", err)
+	}
+
+	event(t, db, user.ID, 100, now.Add(-time.Minute))
+	err = check(db, "user_id = ?", user.ID, models.VelocityDeposit, 100, rules, now)
+	if !errors.As(err, &limitErr) || !limitErr.Count || limitErr.Window != models.VelocityHour {
+		t.Fatalf("fourth attempt in the hour: err = %v, want hourly count LimitError", err)
+	}
+	// The oldest event in the hour leaves it in 30 minutes
+	if limitErr.RetryAfter < 29*time.Minute || limitErr.RetryAfter > 30*time.Minute {
+		t.Errorf("RetryAfter = %s, want about 30m", limitErr.RetryAfter)
+	}
+}
+
+func TestUserRulesOverrides(t *testing.T) {
+	db := testutil.DB(t)
+	setDepositDefaults(t, 5, 100000, 20, 500000)
+	user := testutil.User(t, db)
+	other := testutil.User(t, db)
+
+	maxCount, lifted := int64(50), int64(0)
+	for _, o := range []models.VelocityOverride{
+		{UserID: user.ID, Action: models.VelocityDeposit, Window: models.VelocityHour, MaxCount: &maxCount},
+		{UserID: user.ID, Action: models.VelocityDeposit, Window: models.VelocityDay, MaxAmount: &lifted},
+		{UserID: user.ID, Action: models.VelocityWithdrawal, Window: models.VelocityHour, MaxCount: &lifted},
+	} {
+		if err := db.Create(&o).Error; err != nil {
+			t.Fatalf("create override: %v", err)
+		}
+	}
+
+	rules, err := UserRules(db, user.ID, models.VelocityDeposit)
+	if err != nil {
+		t.Fatalf("UserRules: %v", err)
+	}
+	want := []Rule{
+		{Action: models.VelocityDeposit, Window: models.VelocityHour, MaxCount: 50, MaxAmount: 100000, Overridden: true},
+		{Action: models.VelocityDeposit, Window: models.VelocityDay, MaxCount: 20, MaxAmount: 0, Overridden: true},
+	}
+	if len(rules) != len(want) {
+		t.Fatalf("UserRules returned %d rules, want %d", len(rules), len(want))
+	}
+	for i := range want {
+		if rules[i] != want[i] {
+			t.Errorf("rule %d = %+v, want %+v", i, rules[i], want[i])
+		}
+	}
+
+	defaults, err := UserRules(db, other.ID, models.VelocityDeposit)
+	if err != nil {
+		t.Fatalf("UserRules: %v", err)
+	}
+	for i, rule := range DefaultRules(models.VelocityDeposit) {
+		if defaults[i] != rule {
+			t.Errorf("user without overrides: rule %d = %+v, want default %+v", i, defaults[i], rule)
+		}
+	}
+}
+
+func TestAllowAppliesOverrideAndDeviceLimit(t *testing.T) {
+	db := testutil.DB(t)
+	setDepositDefaults(t, 1, 0, 0, 0)
+	user := testutil.User(t, db)
+	first := testutil.User(t, db)
+	second := testutil.User(t, db)
+
+	raised := int64(3)
+	if err := db.Create(&models.VelocityOverride{
+		UserID: user.ID, Action: models.VelocityDeposit, Window: models.VelocityHour, MaxCount: &raised,
+	}).Error; err != nil {
+		t.Fatalf("create override: %v", err)
+	}
+
+	attempt := Attempt{User: user, Action: models.VelocityDeposit, Amount: 1000, Currency: models.NGN}
+	for i := 0; i < 3; i++ {
+		if err := Allow(db, attempt); err != nil {
+			t.Fatalf("attempt %d within the override: %v", i+1, err)
+		}
+	}
+	if err := Allow(db, attempt); !errors.Is(err, ErrLimitExceeded) {
+		t.Fatalf("attempt over the override: err = %v, want ErrLimitExceeded", err)
+	}
+
+	// Devices keep the default limit whatever the account's override
+	device := Attempt{User: first, DeviceID: "device-1", Action: models.VelocityDeposit, Amount: 1000, Currency: models.NGN}
+	if err := Allow(db, device); err != nil {
+		t.Fatalf("first attempt on device: %v", err)
+	}
+	device.User = second
+	var limitErr *LimitError
+	if err := Allow(db, device); !errors.As(err, &limitErr) || !limitErr.Device {
+		t.Fatalf("second account on the device: err = %v, want device LimitError", err)
+	}
+}
@@ -0,0 +1,45 @@
+package velocity
+
+import (
+	"errors"
+	"strings"
+
+	"github.com/dblaq/buzzycash/internal/models"
+)
+
+// Validation errors
+var (
+	ErrInvalidAction   = errors.New("action must be DEPOSIT, TICKET or WITHDRAWAL")
+	ErrInvalidWindow   = errors.New("window must be HOUR or DAY")
+	ErrNothingToChange = errors.New("set max_count, max_amount or both")
+	ErrNegativeLimit   = errors.New("limits cannot be negative")
+	ErrReasonMissing   = errors.New("a reason is required")
+)
+
+var actions = []models.VelocityAction{models.VelocityDeposit, models.VelocityTicket, models.VelocityWithdrawal}
+
+func (r *SetOverrideRequest) Validate() error {
+	r.Action = strings.ToUpper(strings.TrimSpace(r.Action))
+	r.Window = strings.ToUpper(strings.TrimSpace(r.Window))
+
+	valid := false
+	for _, a := range actions {
+		valid = valid || models.VelocityAction(r.Action) == a
+	}
+	if !valid {
+		return ErrInvalidAction
+	}
+	if _, ok := windows[models.VelocityWindow(r.Window)]; !ok {
+		return ErrInvalidWindow
+	}
+	if r.MaxCount == nil && r.MaxAmount == nil {
+		return ErrNothingToChange
+	}
+	if (r.MaxCount != nil && *r.MaxCount < 0) || (r.MaxAmount != nil && *r.MaxAmount < 0) {
+		return ErrNegativeLimit
+	}
+	if strings.TrimSpace(r.Reason) == "" {
+		return ErrReasonMissing
+	}
+	return nil
+}
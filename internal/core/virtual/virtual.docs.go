@@ -10,6 +10,7 @@ package virtual
 // @Success 201 {object} map[string]interface{} "Game started successfully"
 // @Failure 400 {object} map[string]interface{} "Invalid request payload"
 // @Failure 401 {object} map[string]interface{} "Unauthorized"
+// @Failure 403 {object} map[string]interface{} "AGE_UNVERIFIED or UNDERAGE; on a cool-off or self-excluded"
 // @Failure 500 {object} map[string]interface{} "Failed to start game"
 // @Router /virtual/start-game [post]
 // @Security BearerAuth
@@ -3,6 +3,8 @@ package virtual
 import (
 	"net/http"
      "log"
+	"github.com/dblaq/buzzycash/internal/config"
+	"github.com/dblaq/buzzycash/internal/core/responsiblegaming"
 	"github.com/dblaq/buzzycash/internal/models"
 	"github.com/dblaq/buzzycash/internal/utils"
 	"github.com/dblaq/buzzycash/external/gaming"
@@ -44,6 +46,10 @@ func StartVirtualGameHandler(ctx *gin.Context) {
 	username := currentUser.PhoneNumber
 	log.Printf("Validated request data and extracted username: %s\n", username)
 
+	if !responsiblegaming.Enforce(ctx, config.DB, responsiblegaming.Attempt{User: currentUser}) {
+		return
+	}
+
 	gs := gaming.GMInstance()
 	gameData, err := gs.StartVirtualGame(req.GameType, username)
 	if err != nil {
@@ -8,7 +8,7 @@ import (
 func VirtualRoutes(rg *gin.RouterGroup) {
 	virtualRoutes := rg.Group("/virtual")
 	{
-		virtualRoutes.POST("/start-game", middlewares.AuthMiddleware,StartVirtualGameHandler)
+		virtualRoutes.POST("/start-game", middlewares.AuthMiddleware,middlewares.AgeVerificationMiddleware,StartVirtualGameHandler)
 		virtualRoutes.GET("/get-games", middlewares.AuthMiddleware,GetVirtualGamesHandler)
 	}
 }
\ No newline at end of file
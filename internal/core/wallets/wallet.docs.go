@@ -3,7 +3,7 @@ package wallets
 
 
 // @Summary Credit wallet
-// @Description Generate a payment link to credit the user's wallet
+// @Description Generate a payment link to credit the user's wallet in their local currency. Mobile money (hubtel) sends an approval prompt to the phone instead of returning a link
 // @Tags wallet
 // @Accept json
 // @Produce json
@@ -11,6 +11,8 @@ package wallets
 // @Success 201 {object} map[string]interface{} "Payment link generated successfully"
 // @Failure 400 {object} map[string]interface{} "Invalid request payload"
 // @Failure 401 {object} map[string]interface{} "Unauthorized"
+// @Failure 403 {object} map[string]interface{} "AGE_UNVERIFIED or UNDERAGE; on a break from play, or exceeds KYC tier, own or deposit limit"
+// @Failure 429 {object} map[string]interface{} "Too many deposits; see Retry-After"
 // @Failure 500 {object} map[string]interface{} "Failed to generate payment link"
 // @Router /wallet/fund-wallet [post]
 // @Security BearerAuth
@@ -28,3 +30,78 @@ func _() {}
 // @Router /wallet/get-wallet [get]
 // @Security BearerAuth
 func _() {}
+
+
+// @Summary Get wallet balances
+// @Description Retrieve the authenticated user's ledger balance in each supported currency (NGN, CED)
+// @Tags wallet
+// @Accept json
+// @Produce json
+// @Success 200 {object} map[string]interface{} "Wallet balances per currency"
+// @Failure 401 {object} map[string]interface{} "Unauthorized"
+// @Failure 500 {object} map[string]interface{} "Failed to fetch wallet balances"
+// @Router /wallet/balances [get]
+// @Security BearerAuth
+func _() {}
+
+
+// @Summary Get funding account
+// @Description Get the authenticated user's dedicated bank account. Any transfer into it is credited to their wallet. The account is opened on first request, once the user's age is verified
+// @Tags wallet
+// @Accept json
+// @Produce json
+// @Success 200 {object} VirtualAccountResponse "Funding account"
+// @Failure 400 {object} map[string]interface{} "Not available in the user's currency"
+// @Failure 401 {object} map[string]interface{} "Unauthorized"
+// @Failure 403 {object} map[string]interface{} "AGE_UNVERIFIED or UNDERAGE"
+// @Failure 502 {object} map[string]interface{} "Failed to fetch funding account"
+// @Router /wallet/virtual-account [get]
+// @Security BearerAuth
+func _() {}
+
+
+// @Summary List saved cards
+// @Description List the cards the authenticated user saved during checkout
+// @Tags wallet
+// @Accept json
+// @Produce json
+// @Success 200 {object} map[string]interface{} "Saved cards"
+// @Failure 401 {object} map[string]interface{} "Unauthorized"
+// @Failure 500 {object} map[string]interface{} "Failed to fetch saved cards"
+// @Router /wallet/cards [get]
+// @Security BearerAuth
+func _() {}
+
+
+// @Summary Delete saved card
+// @Description Remove a saved card
+// @Tags wallet
+// @Accept json
+// @Produce json
+// @Param id path string true "Saved card ID"
+// @Success 200 {object} map[string]interface{} "Saved card deleted"
+// @Failure 401 {object} map[string]interface{} "Unauthorized"
+// @Failure 404 {object} map[string]interface{} "Saved card not found"
+// @Failure 500 {object} map[string]interface{} "Failed to delete saved card"
+// @Router /wallet/cards/{id} [delete]
+// @Security BearerAuth
+func _() {}
+
+
+// @Summary Top up with saved card
+// @Description Charge a saved card without the hosted checkout. The deposit is recorded as pending and settles through the payment webhook
+// @Tags wallet
+// @Accept json
+// @Produce json
+// @Param id path string true "Saved card ID"
+// @Param request body ChargeSavedCardRequest true "Top-up amount"
+// @Success 200 {object} map[string]interface{} "Card charge submitted"
+// @Failure 400 {object} map[string]interface{} "Invalid request payload or expired card"
+// @Failure 401 {object} map[string]interface{} "Unauthorized"
+// @Failure 403 {object} map[string]interface{} "AGE_UNVERIFIED or UNDERAGE; on a break from play, or exceeds KYC tier, own or deposit limit"
+// @Failure 404 {object} map[string]interface{} "Saved card not found"
+// @Failure 429 {object} map[string]interface{} "Too many deposits; see Retry-After"
+// @Failure 502 {object} map[string]interface{} "Card could not be charged"
+// @Router /wallet/cards/{id}/charge [post]
+// @Security BearerAuth
+func _() {}
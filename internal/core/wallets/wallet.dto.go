@@ -1,11 +1,49 @@
 package wallets
 
+import "time"
+
 
 
 
 
 type CreditWalletRequest struct {
 	Amount        int64  `json:"amount" validate:"required,gt=0"`
-	PaymentMethod string `json:"payment_method" validate:"required,oneof=flutterwave nomba"`
+	PaymentMethod string `json:"payment_method" validate:"required"` // flutterwave, nomba, paystack or hubtel
+	// Mobile money only: the network (mtn, vodafone, airteltigo) and the
+	// wallet number to charge. Both default from the user's phone number.
+	Network      string `json:"network,omitempty"`
+	MobileNumber string `json:"mobile_number,omitempty"`
 }
 
+
+type WalletBalance struct {
+	Currency string `json:"currency"`
+	Balance  int64  `json:"balance"`
+	Primary  bool   `json:"primary"` // the currency the user deposits, plays and withdraws in
+}
+
+type ChargeSavedCardRequest struct {
+	Amount int64 `json:"amount" binding:"required" validate:"required,gt=0"`
+}
+
+type SavedCardResponse struct {
+	ID          string     `json:"id"`
+	Provider    string     `json:"provider"`
+	MaskedPan   string     `json:"masked_pan"`
+	Brand       string     `json:"brand"`
+	ExpiryMonth string     `json:"expiry_month"`
+	ExpiryYear  string     `json:"expiry_year"`
+	LastUsedAt  *time.Time `json:"last_used_at,omitempty"`
+	CreatedAt   time.Time  `json:"created_at"`
+}
+
+// VirtualAccountResponse is the account the user can transfer to at any
+// time to fund their wallet.
+type VirtualAccountResponse struct {
+	AccountNumber string    `json:"account_number"`
+	AccountName   string    `json:"account_name"`
+	BankName      string    `json:"bank_name"`
+	Provider      string    `json:"provider"`
+	Currency      string    `json:"currency"`
+	CreatedAt     time.Time `json:"created_at"`
+}
@@ -1,14 +1,21 @@
 package wallets
 
 import (
+	"errors"
 	"log"
 	"net/http"
 	"github.com/gin-gonic/gin"
 	"strings"
+	"time"
      "gorm.io/gorm"
 	"github.com/dblaq/buzzycash/internal/config"
 	"github.com/dblaq/buzzycash/external/gaming"
 	"github.com/dblaq/buzzycash/external/gateway"
+	"github.com/dblaq/buzzycash/internal/core/audit"
+	"github.com/dblaq/buzzycash/internal/core/kyc"
+	"github.com/dblaq/buzzycash/internal/core/ledger"
+	"github.com/dblaq/buzzycash/internal/core/responsiblegaming"
+	"github.com/dblaq/buzzycash/internal/core/velocity"
 	"github.com/dblaq/buzzycash/internal/helpers"
 	"github.com/dblaq/buzzycash/internal/models"
 	"github.com/dblaq/buzzycash/internal/utils"
@@ -51,14 +58,52 @@ func (h *WalletHandler)GetUserBalanceHandler(ctx *gin.Context) {
 		return
 	}
 
+	currency := helpers.UserCurrency(user)
+	ledgerBalance, err := ledger.UserBalanceAt(h.db, userID, currency, time.Now())
+	if err != nil {
+		log.Printf("[GetUserBalance] Failed to compute ledger balance for userID %s: %v\n", userID, err)
+		utils.Error(ctx, http.StatusInternalServerError, "Failed to fetch user wallet")
+		return
+	}
+
 	ctx.JSON(http.StatusOK,
 		gin.H{
-			"message": "User wallet retrieved successfully",
-			"result":  result,
+			"message":       "User wallet retrieved successfully",
+			"result":        result,
+			"ledgerBalance": ledgerBalance,
+			"currency":      currency,
 		},
 	)
 }
 
+// GetWalletBalancesHandler lists the user's ledger balance in every
+// supported currency, flagging the one they transact in.
+func (h *WalletHandler) GetWalletBalancesHandler(ctx *gin.Context) {
+	currentUser := ctx.MustGet("currentUser").(models.User)
+	primary := helpers.UserCurrency(currentUser)
+	now := time.Now()
+
+	balances := make([]WalletBalance, 0, len(models.SupportedCurrencies))
+	for _, currency := range models.SupportedCurrencies {
+		balance, err := ledger.UserBalanceAt(h.db, currentUser.ID, currency, now)
+		if err != nil {
+			log.Printf("[GetWalletBalances] Failed to compute %s balance for userID %s: %v\n", currency, currentUser.ID, err)
+			utils.Error(ctx, http.StatusInternalServerError, "Failed to fetch wallet balances")
+			return
+		}
+		balances = append(balances, WalletBalance{
+			Currency: string(currency),
+			Balance:  balance,
+			Primary:  currency == primary,
+		})
+	}
+
+	ctx.JSON(http.StatusOK, gin.H{
+		"message": "Wallet balances retrieved successfully",
+		"data":    balances,
+	})
+}
+
 
 
 func FundWalletHandler(ctx *gin.Context) {
@@ -87,63 +132,55 @@ func FundWalletHandler(ctx *gin.Context) {
 	reference := helpers.GenerateFWRef()
 	log.Printf("[FundWallet] Generated payment gateway reference (for Flutterwave/Nomba): %s\n", reference)
 
-	var checkoutLink, orderRef string
-	var err error
-
-	switch strings.ToLower(req.PaymentMethod) {
-	case "flutterwave":
-		fwReq := gateway.FWPaymentRequest{
-			Reference:   reference,
-			Amount:      req.Amount,
-			Currency:    "NGN",
-			RedirectURL: "Buzzycash://Home",
-			Customer: gateway.FWCustomer{
-				Email:    email,
-				FullName: fullName,
-			},
-		}
-		ps := gateway.FWInstance()
-		checkoutLink, err = ps.CreateCheckout(fwReq)
-		orderRef = reference 
-	case "nomba":
-	nbReq := gateway.NBPaymentRequest{
-		Order: gateway.NBOrder{
-			CallbackURL:   "Buzzycash://Home",
-			CustomerEmail: email,
-			Amount:        req.Amount,
-			Currency:      "NGN",
-			CustomerID:    currentUser.ID,
-		},
-		TokenizeCard: true,
-}
+	if !checkDepositLimit(ctx, config.DB, currentUser, req.Amount) {
+		return
+	}
+	if !responsiblegaming.Enforce(ctx, config.DB, responsiblegaming.Attempt{
+		User:     currentUser,
+		Type:     models.GamingDepositLimit,
+		Amount:   req.Amount,
+		Currency: helpers.UserCurrency(currentUser),
+	}) {
+		return
+	}
+	if !velocity.Enforce(ctx, config.DB, velocity.Attempt{
+		User:     currentUser,
+		Action:   models.VelocityDeposit,
+		Amount:   req.Amount,
+		Currency: helpers.UserCurrency(currentUser),
+	}) {
+		return
+	}
 
-		ps := gateway.NBInstance()
-		checkoutLink, orderRef, err = ps.CreateNBCheckout(nbReq)
-	default:
+	provider, err := gateway.GetProvider(req.PaymentMethod)
+	if err != nil {
 		log.Printf("[FundWallet] Invalid payment method requested: %s for userID: %s\n", req.PaymentMethod, currentUser.ID)
 		utils.Error(ctx, http.StatusBadRequest, "Invalid payment method")
 		return
 	}
 
-	if err != nil {
-		log.Printf("Payment provider error (%s): %v", req.PaymentMethod, err)
-		utils.Error(ctx, http.StatusInternalServerError, "Failed to generate payment")
-		return
+	currency := helpers.UserCurrency(currentUser)
+	phoneNumber := currentUser.PhoneNumber
+	if req.MobileNumber != "" {
+		phoneNumber = req.MobileNumber
 	}
 
-	// Save transaction history
+	paymentMethod := models.EPaymentMethod(strings.ToUpper(provider.Name()))
+
+	// Record the deposit before the provider hears of it, so its webhook
+	// always finds the transaction it settles
 	history := models.Transaction{
 		Amount:              req.Amount,
 		CustomerEmail:       email,
 		UserID:              currentUser.ID,
 		PaymentStatus:       models.Pending,
-		PaymentMethod:       models.EPaymentMethod(req.PaymentMethod),
+		PaymentMethod:       paymentMethod,
 		TransactionReference: transactionRef,
-		Reference:            orderRef,
+		Reference:            reference,
 		TransactionType:     models.Credit,
 		Category:            models.Deposit,
 		PaymentType:         models.Topup,
-		Currency:            "NGN",
+		Currency:            currency,
 	}
 	if err := config.DB.Create(&history).Error; err != nil {
 		log.Printf("DB history creation failed: %+v\n", err)
@@ -151,23 +188,319 @@ func FundWalletHandler(ctx *gin.Context) {
 		return
 	}
 
+	checkout, err := provider.CreateCheckout(gateway.CheckoutRequest{
+		Reference:   reference,
+		Amount:      req.Amount,
+		Currency:    currency.ISOCode(),
+		Email:       email,
+		FullName:    fullName,
+		PhoneNumber: phoneNumber,
+		Channel:     req.Network,
+		CustomerID:  currentUser.ID,
+		CallbackURL: "Buzzycash://Home",
+	})
+	if err != nil {
+		failDeposit(config.DB, &history, err)
+	}
+	if errors.Is(err, gateway.ErrUnsupportedCurrency) {
+		utils.Error(ctx, http.StatusBadRequest, "Payment method is not available in your currency")
+		return
+	}
+	if err != nil {
+		log.Printf("Payment provider error (%s): %v", req.PaymentMethod, err)
+		utils.Error(ctx, http.StatusInternalServerError, "Failed to generate payment")
+		return
+	}
+	checkoutLink, orderRef := checkout.CheckoutLink, checkout.Reference
+
+	// Some providers name the order themselves. Nothing can be paid until
+	// the user has the checkout link, so no webhook can arrive before this.
+	if orderRef != "" && orderRef != history.Reference {
+		if err := config.DB.Model(&history).Update("reference", orderRef).Error; err != nil {
+			log.Printf("[FundWallet] Failed to record provider reference %s for %s: %v", orderRef, history.ID, err)
+			utils.Error(ctx, http.StatusInternalServerError, "Failed to record transaction")
+			return
+		}
+		history.Reference = orderRef
+	}
+	audit.Log(ctx, config.DB, audit.DepositInitiated, audit.Target{Type: audit.TargetTransaction, ID: history.ID}, nil, audit.TransactionState(history))
+
+	// Mobile money has no payment page; the user approves a prompt on their phone
+	message := "Generated payment link successfully"
+	if checkoutLink == "" {
+		message = "Payment request sent; approve the prompt on your phone to complete it"
+	}
+
 	// Respond
 	ctx.JSON(http.StatusOK, gin.H{
-		"message":              "Generated payment link successfully",
+		"message":              message,
 		"checkoutLink":         checkoutLink,
 		"amountPaid":           req.Amount,
 		"customerEmail":        email,
 		"userID":               currentUser.ID,
 		"paymentStatus":        models.Pending,
-		"paymentMethod":        req.PaymentMethod,
+		"paymentMethod":        paymentMethod,
 		 "paymentType":          models.Topup,
 		"transactionReference": transactionRef,
 		"reference":            orderRef,
 		"transactionType":      models.Credit,
 		"category":             models.Deposit,
-		"currency":             "NGN",
+		"currency":             currency,
 	})
 }
 
+// GetVirtualAccountHandler returns the user's dedicated funding account,
+// opening it on first request. Accounts are only opened here, behind the
+// age check, so no one can be funded before they are allowed to play.
+func (h *WalletHandler) GetVirtualAccountHandler(ctx *gin.Context) {
+	currentUser := ctx.MustGet("currentUser").(models.User)
 
+	va, err := ProvisionVirtualAccount(h.db, currentUser)
+	if errors.Is(err, gateway.ErrUnsupportedCurrency) || errors.Is(err, gateway.ErrNotSupported) {
+		utils.Error(ctx, http.StatusBadRequest, "Bank transfer funding is not available in your currency")
+		return
+	}
+	if err != nil {
+		log.Printf("[VirtualAccount] Failed to provision account for userID %s: %v\n", currentUser.ID, err)
+		utils.Error(ctx, http.StatusBadGateway, "Failed to fetch funding account")
+		return
+	}
 
+	ctx.JSON(http.StatusOK, gin.H{
+		"message": "Funding account retrieved successfully",
+		"data": VirtualAccountResponse{
+			AccountNumber: va.AccountNumber,
+			AccountName:   va.AccountName,
+			BankName:      va.BankName,
+			Provider:      string(va.Provider),
+			Currency:      string(va.Currency),
+			CreatedAt:     va.CreatedAt,
+		},
+	})
+}
+
+// ListSavedCardsHandler lists the cards the user saved during checkout.
+func (h *WalletHandler) ListSavedCardsHandler(ctx *gin.Context) {
+	currentUser := ctx.MustGet("currentUser").(models.User)
+
+	var cards []models.SavedCard
+	if err := h.db.Where("user_id = ?", currentUser.ID).Order("created_at desc").Find(&cards).Error; err != nil {
+		log.Printf("[SavedCards] Failed to fetch cards for userID %s: %v\n", currentUser.ID, err)
+		utils.Error(ctx, http.StatusInternalServerError, "Failed to fetch saved cards")
+		return
+	}
+
+	response := make([]SavedCardResponse, 0, len(cards))
+	for _, c := range cards {
+		response = append(response, SavedCardResponse{
+			ID:          c.ID,
+			Provider:    string(c.Provider),
+			MaskedPan:   c.MaskedPan,
+			Brand:       c.Brand,
+			ExpiryMonth: c.ExpiryMonth,
+			ExpiryYear:  c.ExpiryYear,
+			LastUsedAt:  c.LastUsedAt,
+			CreatedAt:   c.CreatedAt,
+		})
+	}
+
+	ctx.JSON(http.StatusOK, gin.H{
+		"message": "Saved cards retrieved successfully",
+		"data":    response,
+	})
+}
+
+func (h *WalletHandler) DeleteSavedCardHandler(ctx *gin.Context) {
+	currentUser := ctx.MustGet("currentUser").(models.User)
+
+	result := h.db.Where("id = ? AND user_id = ?", ctx.Param("id"), currentUser.ID).Delete(&models.SavedCard{})
+	if result.Error != nil {
+		log.Printf("[SavedCards] Failed to delete card %s for userID %s: %v\n", ctx.Param("id"), currentUser.ID, result.Error)
+		utils.Error(ctx, http.StatusInternalServerError, "Failed to delete saved card")
+		return
+	}
+	if result.RowsAffected == 0 {
+		utils.Error(ctx, http.StatusNotFound, "Saved card not found")
+		return
+	}
+
+	ctx.JSON(http.StatusOK, gin.H{
+		"message": "Saved card deleted successfully",
+	})
+}
+
+// ChargeSavedCardHandler tops up the wallet from a saved card without a
+// checkout page. The deposit is recorded PENDING and settles through the
+// gateway's webhook like any other.
+func (h *WalletHandler) ChargeSavedCardHandler(ctx *gin.Context) {
+	var req ChargeSavedCardRequest
+	if err := ctx.ShouldBindJSON(&req); err != nil {
+		utils.Error(ctx, http.StatusBadRequest, utils.ValidationErrorToJSON(err))
+		return
+	}
+	if err := req.Validate(); err != nil {
+		utils.Error(ctx, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	currentUser := ctx.MustGet("currentUser").(models.User)
+
+	var card models.SavedCard
+	if err := h.db.First(&card, "id = ? AND user_id = ?", ctx.Param("id"), currentUser.ID).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			utils.Error(ctx, http.StatusNotFound, "Saved card not found")
+			return
+		}
+		utils.Error(ctx, http.StatusInternalServerError, "Failed to fetch saved card")
+		return
+	}
+	if err := validateCardExpiry(card, time.Now()); err != nil {
+		utils.Error(ctx, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	if !checkDepositLimit(ctx, h.db, currentUser, req.Amount) {
+		return
+	}
+	if !responsiblegaming.Enforce(ctx, h.db, responsiblegaming.Attempt{
+		User:     currentUser,
+		Type:     models.GamingDepositLimit,
+		Amount:   req.Amount,
+		Currency: helpers.UserCurrency(currentUser),
+	}) {
+		return
+	}
+	if !velocity.Enforce(ctx, h.db, velocity.Attempt{
+		User:     currentUser,
+		Action:   models.VelocityDeposit,
+		Amount:   req.Amount,
+		Currency: helpers.UserCurrency(currentUser),
+	}) {
+		return
+	}
+
+	provider, err := gateway.GetProvider(string(card.Provider))
+	if err != nil {
+		utils.Error(ctx, http.StatusBadRequest, "Invalid payment method")
+		return
+	}
+
+	currency := helpers.UserCurrency(currentUser)
+	transactionRef := helpers.GenerateTransactionReference()
+	reference := helpers.GenerateFWRef()
+	log.Printf("[ChargeSavedCard] Charging card %s for userID: %s, ref: %s\n", card.ID, currentUser.ID, reference)
+
+	// The charge can settle by webhook before the provider even answers,
+	// so the deposit is recorded first
+	now := time.Now()
+	history := models.Transaction{
+		Amount:               req.Amount,
+		CustomerEmail:        currentUser.Email,
+		UserID:               currentUser.ID,
+		PaymentStatus:        models.Pending,
+		PaymentMethod:        card.Provider,
+		TransactionReference: transactionRef,
+		Reference:            reference,
+		TransactionType:      models.Credit,
+		Category:             models.Deposit,
+		PaymentType:          models.Topup,
+		Currency:             currency,
+		Metadata: models.JSONB{
+			"savedCardId": card.ID,
+		},
+	}
+	if err := h.db.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Create(&history).Error; err != nil {
+			return err
+		}
+		return tx.Model(&card).Update("last_used_at", &now).Error
+	}); err != nil {
+		log.Printf("[ChargeSavedCard] DB history creation failed: %+v\n", err)
+		utils.Error(ctx, http.StatusInternalServerError, "Failed to record transaction")
+		return
+	}
+
+	checkout, err := provider.ChargeSavedCard(gateway.CheckoutRequest{
+		Reference:   reference,
+		Amount:      req.Amount,
+		Currency:    currency.ISOCode(),
+		Email:       currentUser.Email,
+		FullName:    currentUser.FullName,
+		PhoneNumber: currentUser.PhoneNumber,
+		CustomerID:  currentUser.ID,
+		CallbackURL: "Buzzycash://Home",
+	}, card.Token)
+	status, message := http.StatusOK, "Card charge submitted successfully"
+	switch {
+	case errors.Is(err, gateway.ErrChargeDeclined), errors.Is(err, gateway.ErrNotSupported):
+		log.Printf("[ChargeSavedCard] %s declined card %s: %v", provider.Name(), card.ID, err)
+		failDeposit(h.db, &history, err)
+		utils.Error(ctx, http.StatusBadGateway, "Card could not be charged")
+		return
+	case err != nil:
+		// The charge may still have gone through. The deposit stays
+		// PENDING for its webhook or the requery job to settle.
+		log.Printf("[ChargeSavedCard] Outcome of charging card %s with %s unknown, ref=%s: %v", card.ID, provider.Name(), reference, err)
+		checkout = &gateway.CheckoutResult{Reference: reference}
+		status, message = http.StatusAccepted, "Card charge is being confirmed"
+	}
+	audit.Log(ctx, h.db, audit.DepositInitiated, audit.Target{Type: audit.TargetTransaction, ID: history.ID}, nil, audit.TransactionState(history))
+
+	ctx.JSON(status, gin.H{
+		"message":              message,
+		"amountPaid":           req.Amount,
+		"customerEmail":        currentUser.Email,
+		"userID":               currentUser.ID,
+		"paymentStatus":        models.Pending,
+		"paymentMethod":        card.Provider,
+		"paymentType":          models.Topup,
+		"transactionReference": transactionRef,
+		"reference":            checkout.Reference,
+		"transactionType":      models.Credit,
+		"category":             models.Deposit,
+		"currency":             currency,
+	})
+}
+
+// failDeposit marks a deposit the provider definitely turned down as
+// FAILED. Only a PENDING deposit is touched, in case its webhook already
+// settled it.
+func failDeposit(db *gorm.DB, history *models.Transaction, cause error) {
+	meta := models.JSONB{}
+	for k, v := range history.Metadata {
+		meta[k] = v
+	}
+	meta["failureReason"] = cause.Error()
+
+	result := db.Model(&models.Transaction{}).
+		Where("id = ? AND payment_status = ?", history.ID, models.Pending).
+		Updates(map[string]interface{}{
+			"payment_status": models.Failed,
+			"metadata":       meta,
+		})
+	if result.Error != nil {
+		log.Printf("[Wallet] ERROR: could not mark deposit %s failed: %v", history.ID, result.Error)
+		return
+	}
+	if result.RowsAffected > 0 {
+		history.PaymentStatus = models.Failed
+		history.Metadata = meta
+	}
+}
+
+// checkDepositLimit enforces the user's KYC tier on a top-up, writing the
+// error response and returning false when it may not go ahead.
+func checkDepositLimit(ctx *gin.Context, db *gorm.DB, user models.User, amount int64) bool {
+	err := kyc.CheckLimit(db, user, models.Deposit, amount)
+	if err == nil {
+		return true
+	}
+	var limitErr *kyc.LimitError
+	if errors.As(err, &limitErr) {
+		utils.Error(ctx, http.StatusForbidden, limitErr.Error())
+		return false
+	}
+	log.Printf("[FundWallet] KYC limit check failed for userID %s: %v\n", user.ID, err)
+	utils.Error(ctx, http.StatusInternalServerError, "Failed to generate payment")
+	return false
+}
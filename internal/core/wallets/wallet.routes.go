@@ -12,7 +12,12 @@ func WalletRoutes(rg *gin.RouterGroup, db *gorm.DB) {
 	walletHandler := NewWalletHandler(db)
 	walletRoutes := rg.Group("/wallet")
 	{
-		walletRoutes.POST("/fund-wallet", middlewares.AuthMiddleware,FundWalletHandler)
+		walletRoutes.POST("/fund-wallet", middlewares.AuthMiddleware,middlewares.AgeVerificationMiddleware,FundWalletHandler)
 		walletRoutes.GET("/get-wallet", middlewares.AuthMiddleware,walletHandler.GetUserBalanceHandler)
+		walletRoutes.GET("/balances", middlewares.AuthMiddleware, walletHandler.GetWalletBalancesHandler)
+		walletRoutes.GET("/virtual-account", middlewares.AuthMiddleware, middlewares.AgeVerificationMiddleware, walletHandler.GetVirtualAccountHandler)
+		walletRoutes.GET("/cards", middlewares.AuthMiddleware, walletHandler.ListSavedCardsHandler)
+		walletRoutes.DELETE("/cards/:id", middlewares.AuthMiddleware, walletHandler.DeleteSavedCardHandler)
+		walletRoutes.POST("/cards/:id/charge", middlewares.AuthMiddleware, middlewares.AgeVerificationMiddleware, walletHandler.ChargeSavedCardHandler)
 	}
 }
\ No newline at end of file
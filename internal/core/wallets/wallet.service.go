@@ -0,0 +1,71 @@
+package wallets
+
+import (
+	"errors"
+	"fmt"
+	"log"
+	"strings"
+
+	"github.com/dblaq/buzzycash/external/gateway"
+	"github.com/dblaq/buzzycash/internal/config"
+	"github.com/dblaq/buzzycash/internal/helpers"
+	"github.com/dblaq/buzzycash/internal/models"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+// ProvisionVirtualAccount returns the user's dedicated funding account,
+// opening one with the configured gateway the first time. The account is
+// opened under a reference derived from the user, so asking again after a
+// failure does not leave a second account behind at the gateway.
+func ProvisionVirtualAccount(db *gorm.DB, user models.User) (*models.VirtualAccount, error) {
+	var existing models.VirtualAccount
+	err := db.Where("user_id = ?", user.ID).First(&existing).Error
+	if err == nil {
+		return &existing, nil
+	}
+	if !errors.Is(err, gorm.ErrRecordNotFound) {
+		return nil, fmt.Errorf("load virtual account failed: %w", err)
+	}
+
+	provider, err := gateway.GetProvider(config.AppConfig.VirtualAccountProvider)
+	if err != nil {
+		return nil, err
+	}
+
+	currency := helpers.UserCurrency(user)
+	accountName := user.FullName
+	if accountName == "" {
+		accountName = user.Username
+	}
+	opened, err := provider.CreateVirtualAccount(gateway.VirtualAccountRequest{
+		Reference:   gateway.VirtualAccountRefPrefix + user.ID,
+		AccountName: accountName,
+		Email:       user.Email,
+		PhoneNumber: user.PhoneNumber,
+		Currency:    currency.ISOCode(),
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	va := models.VirtualAccount{
+		UserID:        user.ID,
+		Provider:      models.EPaymentMethod(strings.ToUpper(provider.Name())),
+		AccountNumber: opened.AccountNumber,
+		AccountName:   opened.AccountName,
+		BankName:      opened.BankName,
+		Reference:     opened.Reference,
+		Currency:      currency,
+	}
+	// A concurrent request may have stored the account first; keep theirs
+	if err := db.Clauses(clause.OnConflict{DoNothing: true}).Create(&va).Error; err != nil {
+		return nil, fmt.Errorf("save virtual account failed: %w", err)
+	}
+	if err := db.Where("user_id = ?", user.ID).First(&va).Error; err != nil {
+		return nil, fmt.Errorf("load virtual account failed: %w", err)
+	}
+
+	log.Printf("[VirtualAccount] Opened %s account %s for userID: %s\n", va.Provider, va.AccountNumber, user.ID)
+	return &va, nil
+}
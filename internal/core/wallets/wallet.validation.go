@@ -4,6 +4,11 @@ package wallets
 
 	import (
 		"errors"
+		"strconv"
+		"time"
+
+		"github.com/dblaq/buzzycash/external/gateway"
+		"github.com/dblaq/buzzycash/internal/models"
 )
 
 
@@ -12,6 +17,8 @@ package wallets
 // Validation errors
 var (
 	ErrAmountTooShort          = errors.New("topup amount must be at least 100 naira")
+	ErrUnsupportedPaymentMethod = errors.New("unsupported payment method")
+	ErrCardExpired             = errors.New("saved card has expired")
 
 )
 
@@ -20,6 +27,29 @@ func (r *CreditWalletRequest) Validate() error {
 	if err := validateAmount(r.Amount); err != nil {
 		return err
 	}
+	if _, err := gateway.GetProvider(r.PaymentMethod); err != nil {
+		return ErrUnsupportedPaymentMethod
+	}
+	return nil
+}
+
+func (r *ChargeSavedCardRequest) Validate() error {
+	return validateAmount(r.Amount)
+}
+
+// validateCardExpiry rejects a card whose expiry month has passed.
+func validateCardExpiry(card models.SavedCard, now time.Time) error {
+	month, errM := strconv.Atoi(card.ExpiryMonth)
+	year, errY := strconv.Atoi(card.ExpiryYear)
+	if errM != nil || errY != nil {
+		return nil // let the gateway decide
+	}
+	if year < 100 {
+		year += 2000
+	}
+	if year < now.Year() || (year == now.Year() && month < int(now.Month())) {
+		return ErrCardExpired
+	}
 	return nil
 }
 
@@ -0,0 +1,123 @@
+package withdrawal
+
+import (
+	"errors"
+	"fmt"
+	"log"
+	"strings"
+	"time"
+
+	"github.com/dblaq/buzzycash/external/gateway"
+	"github.com/dblaq/buzzycash/internal/helpers"
+	"github.com/dblaq/buzzycash/internal/models"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+var (
+	ErrBeneficiaryNotFound = errors.New("beneficiary not found")
+	ErrBeneficiaryExists   = errors.New("beneficiary already saved")
+	ErrAccountLookupFailed = errors.New("account could not be verified with the bank")
+	// ErrAccountNameChanged means the bank now reports a different holder
+	// for a saved account, so paying it out could send money to a stranger.
+	ErrAccountNameChanged = errors.New("account name no longer matches the saved beneficiary")
+)
+
+// AddBeneficiary resolves the account with the payout gateway and saves it
+// under the name the bank returned.
+func (s *WithdrawalService) AddBeneficiary(user models.User, req CreateBeneficiaryRequest) (*models.Beneficiary, error) {
+	provider, err := payoutProvider(req.PaymentMethod, helpers.UserCurrency(user))
+	if err != nil {
+		return nil, err
+	}
+
+	details, err := provider.ResolveAccount(req.AccountNumber, req.BankCode)
+	if err != nil {
+		log.Printf("[Beneficiary] %s lookup failed for userID=%s: %v", provider.Name(), user.ID, err)
+		return nil, ErrAccountLookupFailed
+	}
+
+	beneficiary := models.Beneficiary{
+		UserID:        user.ID,
+		Provider:      models.EPaymentMethod(strings.ToUpper(provider.Name())),
+		BankCode:      req.BankCode,
+		BankName:      bankName(provider, req.BankCode),
+		AccountNumber: req.AccountNumber,
+		AccountName:   details.AccountName,
+		Nickname:      strings.TrimSpace(req.Nickname),
+		VerifiedAt:    time.Now(),
+	}
+	result := s.db.Clauses(clause.OnConflict{DoNothing: true}).Create(&beneficiary)
+	if result.Error != nil {
+		return nil, fmt.Errorf("save beneficiary failed: %w", result.Error)
+	}
+	if result.RowsAffected == 0 {
+		return nil, ErrBeneficiaryExists
+	}
+	return &beneficiary, nil
+}
+
+// VerifyBeneficiary loads one of the user's beneficiaries and re-resolves
+// the account before it is paid out to. The account holder's name must
+// still match what was saved.
+func (s *WithdrawalService) VerifyBeneficiary(user models.User, id string) (*models.Beneficiary, error) {
+	var beneficiary models.Beneficiary
+	if err := s.db.First(&beneficiary, "id = ? AND user_id = ?", id, user.ID).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, ErrBeneficiaryNotFound
+		}
+		return nil, fmt.Errorf("load beneficiary failed: %w", err)
+	}
+
+	provider, err := gateway.GetProvider(string(beneficiary.Provider))
+	if err != nil {
+		return nil, err
+	}
+	details, err := provider.ResolveAccount(beneficiary.AccountNumber, beneficiary.BankCode)
+	if err != nil {
+		log.Printf("[Beneficiary] %s re-verification failed for id=%s: %v", provider.Name(), beneficiary.ID, err)
+		return nil, ErrAccountLookupFailed
+	}
+	if normaliseAccountName(details.AccountName) != normaliseAccountName(beneficiary.AccountName) {
+		log.Printf("[Beneficiary] Name changed for id=%s: saved %q, bank reports %q", beneficiary.ID, beneficiary.AccountName, details.AccountName)
+		return nil, ErrAccountNameChanged
+	}
+
+	beneficiary.VerifiedAt = time.Now()
+	if err := s.db.Model(&beneficiary).Update("verified_at", beneficiary.VerifiedAt).Error; err != nil {
+		log.Printf("[Beneficiary] WARNING: could not record verification for id=%s: %v", beneficiary.ID, err)
+	}
+	return &beneficiary, nil
+}
+
+// bankName looks the bank up in the provider's (cached) bank list. A name
+// is only a label, so failing to find one is not an error.
+func bankName(provider gateway.PaymentProvider, code string) string {
+	banks, err := provider.ListBanks()
+	if err != nil {
+		return ""
+	}
+	for _, b := range banks {
+		if b.Code == code {
+			return b.Name
+		}
+	}
+	return ""
+}
+
+// normaliseAccountName ignores case, spacing and punctuation differences
+// between lookups of the same account.
+func normaliseAccountName(name string) string {
+	var b strings.Builder
+	for _, word := range strings.Fields(strings.ToUpper(name)) {
+		word = strings.Trim(word, ".,-")
+		if word == "" {
+			continue
+		}
+		if b.Len() > 0 {
+			b.WriteByte(' ')
+		}
+		b.WriteString(word)
+	}
+	return b.String()
+}
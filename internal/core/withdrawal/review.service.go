@@ -0,0 +1,208 @@
+package withdrawal
+
+import (
+	"errors"
+	"fmt"
+	"log"
+	"time"
+
+	"github.com/dblaq/buzzycash/external/gateway"
+	"github.com/dblaq/buzzycash/internal/models"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+var (
+	ErrReviewNotFound    = errors.New("withdrawal review not found")
+	ErrNotInReview       = errors.New("withdrawal is not awaiting review")
+	ErrNotAwaitingSubmit = errors.New("withdrawal is not an approved payout awaiting submission")
+	ErrSubmitInProgress  = errors.New("withdrawal was approved moments ago and may still be submitting")
+	// ErrPayoutUnverified means the provider could not confirm the payout
+	// was never sent, so resubmitting it could pay twice.
+	ErrPayoutUnverified = errors.New("could not confirm with the provider that the payout was not already sent")
+)
+
+// submitRetryAfter is how long an approval is left to finish submitting
+// before it may be retried.
+const submitRetryAfter = 5 * time.Minute
+
+// ListReviews returns withdrawal assessments with the given decision,
+// oldest first, so the queue is worked in order.
+func (s *WithdrawalService) ListReviews(decision models.RiskDecision) ([]models.WithdrawalRisk, error) {
+	var reviews []models.WithdrawalRisk
+	if err := s.db.Preload("Transaction").Preload("User").
+		Where("decision = ?", decision).
+		Order("created_at ASC").
+		Find(&reviews).Error; err != nil {
+		return nil, err
+	}
+	return reviews, nil
+}
+
+// ApproveReview releases a held withdrawal to the payout provider. If the
+// provider turns it down the withdrawal is reversed as usual. The approval
+// commits first; if the payout is never submitted, RetrySubmit sends it.
+func (s *WithdrawalService) ApproveReview(id string, admin models.Admin, note string) (*models.WithdrawalRisk, error) {
+	review, err := s.decide(id, admin, models.RiskApproved, note)
+	if err != nil {
+		return nil, err
+	}
+	if err := s.submitApproved(s.db, review); err != nil {
+		return nil, err
+	}
+	return review, nil
+}
+
+// RetrySubmit sends an approved withdrawal whose payout never reached the
+// provider, such as one whose process stopped right after the approval.
+// The provider is asked first, so a payout that did go out is only
+// recorded as submitted, and one it cannot vouch for is left alone.
+func (s *WithdrawalService) RetrySubmit(id string, admin models.Admin) (*models.WithdrawalRisk, error) {
+	var review models.WithdrawalRisk
+	var submitErr error
+	err := s.db.Transaction(func(tx *gorm.DB) error {
+		// Held for the whole attempt so two retries cannot both submit
+		if err := tx.Clauses(clause.Locking{Strength: "UPDATE"}).First(&review, "id = ?", id).Error; err != nil {
+			if errors.Is(err, gorm.ErrRecordNotFound) {
+				return ErrReviewNotFound
+			}
+			return err
+		}
+		if !awaitingSubmit(review) {
+			return ErrNotAwaitingSubmit
+		}
+		if err := tx.First(&review.Transaction, "id = ?", review.TransactionID).Error; err != nil {
+			return err
+		}
+		if review.Transaction.PaymentStatus != models.Pending {
+			return ErrNotAwaitingSubmit
+		}
+		if review.ReviewedAt != nil && time.Since(*review.ReviewedAt) < submitRetryAfter {
+			return ErrSubmitInProgress
+		}
+
+		history := review.Transaction
+		if provider, err := payoutProvider(string(history.PaymentMethod), history.Currency); err == nil {
+			_, err := provider.VerifyPayout(history.Reference, history.CreatedAt)
+			switch {
+			case err == nil:
+				log.Printf("[Withdrawal] ref=%s already reached %s; recording it as submitted", history.Reference, provider.Name())
+				recordSubmit(tx, &review, nil)
+				return nil
+			case !errors.Is(err, gateway.ErrTransactionNotFound):
+				return fmt.Errorf("%w: %v", ErrPayoutUnverified, err)
+			}
+		}
+		submitErr = s.submitApproved(tx, &review)
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	if submitErr != nil {
+		return nil, submitErr
+	}
+
+	log.Printf("[Withdrawal] Payout for ref=%s resubmitted by admin %s", review.Transaction.Reference, admin.ID)
+	return &review, nil
+}
+
+// submitApproved sends an approved withdrawal to its payout provider and
+// records the outcome on the review through db.
+func (s *WithdrawalService) submitApproved(db *gorm.DB, review *models.WithdrawalRisk) error {
+	history := review.Transaction
+	provider, err := payoutProvider(string(history.PaymentMethod), history.Currency)
+	if err != nil {
+		if revErr := s.Fail(history.Reference, err.Error()); revErr != nil {
+			log.Printf("[Withdrawal] ERROR: reversal failed for ref=%s: %v", history.Reference, revErr)
+		}
+		err = fmt.Errorf("%w: %v", ErrPayoutSubmitFailed, err)
+	} else {
+		err = s.submitPayout(provider, history)
+	}
+	recordSubmit(db, review, err)
+	return err
+}
+
+// recordSubmit stamps a successful submission, or keeps why it failed so
+// the review queue shows it.
+func recordSubmit(db *gorm.DB, review *models.WithdrawalRisk, submitErr error) {
+	updates := map[string]interface{}{"submit_error": ""}
+	if submitErr != nil {
+		updates["submit_error"] = submitErr.Error()
+		review.SubmitError = submitErr.Error()
+	} else {
+		now := time.Now()
+		updates["payout_submitted_at"] = now
+		review.PayoutSubmittedAt = &now
+		review.SubmitError = ""
+	}
+	if err := db.Model(&models.WithdrawalRisk{}).Where("id = ?", review.ID).Updates(updates).Error; err != nil {
+		log.Printf("[Withdrawal] WARNING: could not record payout submission for review %s: %v", review.ID, err)
+	}
+}
+
+// awaitingSubmit reports whether a review was approved but its payout never
+// reached the provider.
+func awaitingSubmit(review models.WithdrawalRisk) bool {
+	return review.Decision == models.RiskApproved && review.PayoutSubmittedAt == nil
+}
+
+// RejectReview turns down a held withdrawal and returns the funds to the
+// user's wallet. One held because its debit was unconfirmed is cancelled
+// instead, since the admin found nothing was taken.
+func (s *WithdrawalService) RejectReview(id string, admin models.Admin, reason string) (*models.WithdrawalRisk, error) {
+	review, err := s.decide(id, admin, models.RiskRejected, reason)
+	if err != nil {
+		return nil, err
+	}
+	settle := s.Fail
+	if debitUnconfirmed(review.Transaction) {
+		settle = s.cancel
+	}
+	if err := settle(review.Transaction.Reference, "rejected in review: "+reason); err != nil {
+		return nil, fmt.Errorf("reverse rejected withdrawal failed: %w", err)
+	}
+	review.Transaction.PaymentStatus = models.Failed
+	return review, nil
+}
+
+// decide records an admin's decision on a withdrawal awaiting review.
+func (s *WithdrawalService) decide(id string, admin models.Admin, decision models.RiskDecision, note string) (*models.WithdrawalRisk, error) {
+	var review models.WithdrawalRisk
+	err := s.db.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Clauses(clause.Locking{Strength: "UPDATE"}).First(&review, "id = ?", id).Error; err != nil {
+			if errors.Is(err, gorm.ErrRecordNotFound) {
+				return ErrReviewNotFound
+			}
+			return err
+		}
+		if review.Decision != models.RiskPendingReview {
+			return ErrNotInReview
+		}
+		if err := tx.First(&review.Transaction, "id = ?", review.TransactionID).Error; err != nil {
+			return err
+		}
+		if review.Transaction.PaymentStatus != models.Pending {
+			return ErrNotInReview
+		}
+
+		now := time.Now()
+		review.Decision = decision
+		review.ReviewNote = note
+		review.ReviewedBy = &admin.ID
+		review.ReviewedAt = &now
+		return tx.Model(&review).Updates(map[string]interface{}{
+			"decision":    decision,
+			"review_note": note,
+			"reviewed_by": admin.ID,
+			"reviewed_at": now,
+		}).Error
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	log.Printf("[Withdrawal] Review %s for ref=%s %s by admin %s", review.ID, review.Transaction.Reference, decision, admin.ID)
+	return &review, nil
+}
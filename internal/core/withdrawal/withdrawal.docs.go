@@ -7,6 +7,7 @@ package withdrawal
 // @tags withdrawal
 // @accept json
 // @produce json
+// @Param payment_method query string false "Payout gateway (nomba, paystack, hubtel); defaults to the one configured for the user's currency"
 // @success 200 {object} map[string]interface{} "List of banks"
 // @failure 500 {object} map[string]interface{} "Internal server error"
 // @Router /withdrawal/list-banks [get]
@@ -29,14 +30,133 @@ func _() {}
 
 
 // @summary Initiate Withdrawal
-// @description Initiate a withdrawal request
+// @description Initiate a withdrawal request, either to a saved beneficiary (beneficiary_id) or to the account given inline. A beneficiary's account name is re-verified with the bank first
 // @tags withdrawal
 // @accept json
 // @produce json
 // @Param request body InitiateWithdrawalRequest true "Withdrawal Request"
 // @success 200 {object} map[string]interface{} "Withdrawal initiated successfully"
+// @success 202 {object} map[string]interface{} "Withdrawal held for risk review; funds are debited until an admin decides"
 // @failure 400 {object} map[string]interface{} "Bad request"
+// @failure 403 {object} map[string]interface{} "AGE_UNVERIFIED or UNDERAGE, or exceeds KYC tier or withdrawal limit"
+// @failure 404 {object} map[string]interface{} "Beneficiary not found"
+// @failure 409 {object} map[string]interface{} "Beneficiary account name changed"
+// @failure 429 {object} map[string]interface{} "Too many withdrawals; see Retry-After"
 // @failure 500 {object} map[string]interface{} "Internal server error"
 // @Router /withdrawal/initiate-withdrawal [post]
 // @security BearerAuth
-func _() {}
\ No newline at end of file
+func _() {}
+
+
+// @summary List Beneficiaries
+// @description List the payout accounts the user has saved
+// @tags withdrawal
+// @accept json
+// @produce json
+// @success 200 {array} BeneficiaryResponse "Saved beneficiaries"
+// @failure 500 {object} map[string]interface{} "Internal server error"
+// @Router /withdrawal/beneficiaries [get]
+// @security BearerAuth
+func _() {}
+
+
+// @summary Add Beneficiary
+// @description Save a payout account. The account name is resolved with the bank, not taken from the request
+// @tags withdrawal
+// @accept json
+// @produce json
+// @Param request body CreateBeneficiaryRequest true "Beneficiary"
+// @success 201 {object} BeneficiaryResponse "Beneficiary saved"
+// @failure 400 {object} map[string]interface{} "Bad request"
+// @failure 409 {object} map[string]interface{} "Beneficiary already saved"
+// @failure 502 {object} map[string]interface{} "Account could not be verified"
+// @Router /withdrawal/beneficiaries [post]
+// @security BearerAuth
+func _() {}
+
+
+// @summary Update Beneficiary
+// @description Change a beneficiary's nickname
+// @tags withdrawal
+// @accept json
+// @produce json
+// @Param id path string true "Beneficiary ID"
+// @Param request body UpdateBeneficiaryRequest true "Nickname"
+// @success 200 {object} BeneficiaryResponse "Beneficiary updated"
+// @failure 400 {object} map[string]interface{} "Bad request"
+// @failure 404 {object} map[string]interface{} "Beneficiary not found"
+// @Router /withdrawal/beneficiaries/{id} [patch]
+// @security BearerAuth
+func _() {}
+
+
+// @summary Delete Beneficiary
+// @description Remove a saved payout account
+// @tags withdrawal
+// @accept json
+// @produce json
+// @Param id path string true "Beneficiary ID"
+// @success 200 {object} map[string]interface{} "Beneficiary deleted"
+// @failure 404 {object} map[string]interface{} "Beneficiary not found"
+// @Router /withdrawal/beneficiaries/{id} [delete]
+// @security BearerAuth
+func _() {}
+
+
+// @summary List withdrawal reviews
+// @description List withdrawals the risk engine held for review, oldest first, with their score and the signals behind it, or those with another decision
+// @tags admin-withdrawals
+// @produce json
+// @Param decision query string false "PENDING_REVIEW (default), APPROVED, REJECTED or AUTO_APPROVED"
+// @success 200 {array} ReviewResponse "Withdrawal reviews"
+// @failure 400 {object} map[string]interface{} "Invalid decision"
+// @failure 401 {object} map[string]interface{} "Unauthorized"
+// @Router /admin/withdrawals/reviews [get]
+// @security BearerAuth
+func _() {}
+
+
+// @summary Approve withdrawal
+// @description Release a held withdrawal to the payout provider
+// @tags admin-withdrawals
+// @accept json
+// @produce json
+// @Param id path string true "Review ID"
+// @Param request body ReviewDecisionRequest false "Optional note"
+// @success 200 {object} ReviewResponse "Withdrawal approved"
+// @failure 404 {object} map[string]interface{} "Withdrawal review not found"
+// @failure 409 {object} map[string]interface{} "Not awaiting review"
+// @failure 502 {object} map[string]interface{} "Account could not be verified, or payout rejected by the provider and refunded"
+// @Router /admin/withdrawals/reviews/{id}/approve [post]
+// @security BearerAuth
+func _() {}
+
+// @summary Retry approved payout
+// @description Send an approved withdrawal whose payout never reached the provider (awaiting_submit in the review list). The provider is checked first so a payout that did go out is not sent twice
+// @tags admin-withdrawals
+// @accept json
+// @produce json
+// @Param id path string true "Review ID"
+// @success 200 {object} ReviewResponse "Withdrawal sent for payout"
+// @failure 404 {object} map[string]interface{} "Withdrawal review not found"
+// @failure 409 {object} map[string]interface{} "Not awaiting submission, or approved moments ago"
+// @failure 502 {object} map[string]interface{} "Provider could not confirm the payout was not sent, or rejected it and the withdrawal was refunded"
+// @Router /admin/withdrawals/reviews/{id}/retry-submit [post]
+// @security BearerAuth
+func _() {}
+
+
+// @summary Reject withdrawal
+// @description Reject a held withdrawal and refund the funds to the user's wallet
+// @tags admin-withdrawals
+// @accept json
+// @produce json
+// @Param id path string true "Review ID"
+// @Param request body ReviewDecisionRequest true "Reason"
+// @success 200 {object} ReviewResponse "Withdrawal rejected"
+// @failure 400 {object} map[string]interface{} "Reason missing"
+// @failure 404 {object} map[string]interface{} "Withdrawal review not found"
+// @failure 409 {object} map[string]interface{} "Not awaiting review"
+// @Router /admin/withdrawals/reviews/{id}/reject [post]
+// @security BearerAuth
+func _() {}
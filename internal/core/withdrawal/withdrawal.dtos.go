@@ -1,18 +1,83 @@
 package withdrawal
 
+import "time"
+
 
 
 
 type RetrieveAccountDetailsRequest struct {
 	BankCode    string `json:"bank_code" validate:"required"`
 	AccountNumber string `json:"account_number" binding:"required" validate:"required,len=10,numeric"`
+	PaymentMethod string `json:"payment_method,omitempty"` // payout gateway; defaults to the configured one
 }
 
 
+// InitiateWithdrawalRequest pays out either to a saved beneficiary or to the
+// account given inline; the account fields are only needed without one.
 type InitiateWithdrawalRequest struct {
     Amount        int64  `json:"amount" binding:"required" validate:"required"`
-    AccountName   string `json:"account_name" binding:"required" validate:"required"`
-    BankCode      string `json:"bank_code" binding:"required" validate:"required"`
-    AccountNumber string `json:"account_number" binding:"required" validate:"required,len=10,numeric"`
+    BeneficiaryID string `json:"beneficiary_id,omitempty"`
+    AccountName   string `json:"account_name" validate:"required_without=BeneficiaryID"` // not trusted: the payout uses the name the provider resolves
+    BankCode      string `json:"bank_code" validate:"required_without=BeneficiaryID"` // bank code, or the mobile money network for hubtel
+    AccountNumber string `json:"account_number" validate:"required_without=BeneficiaryID,len=10,numeric"`
     Currency      string `json:"currency" binding:"required" validate:"required,len=3"`
-}
\ No newline at end of file
+    PaymentMethod string `json:"payment_method,omitempty"` // payout gateway; defaults to the configured one
+}
+
+
+type CreateBeneficiaryRequest struct {
+	BankCode      string `json:"bank_code" binding:"required" validate:"required"` // bank code, or the mobile money network for hubtel
+	AccountNumber string `json:"account_number" binding:"required" validate:"required,len=10,numeric"`
+	Nickname      string `json:"nickname,omitempty" validate:"max=100"`
+	PaymentMethod string `json:"payment_method,omitempty"` // payout gateway; defaults to the configured one
+}
+
+type UpdateBeneficiaryRequest struct {
+	Nickname string `json:"nickname" validate:"max=100"`
+}
+
+type BeneficiaryResponse struct {
+	ID            string    `json:"id"`
+	Provider      string    `json:"provider"`
+	BankCode      string    `json:"bank_code"`
+	BankName      string    `json:"bank_name,omitempty"`
+	AccountNumber string    `json:"account_number"`
+	AccountName   string    `json:"account_name"`
+	Nickname      string    `json:"nickname,omitempty"`
+	VerifiedAt    time.Time `json:"verified_at"`
+	CreatedAt     time.Time `json:"created_at"`
+}
+
+// ReviewDecisionRequest carries the admin's note on a review; it is
+// required when rejecting and shown as the reason.
+type ReviewDecisionRequest struct {
+	Note string `json:"note" validate:"max=500"`
+}
+
+type ReviewResponse struct {
+	ID            string         `json:"id"`
+	TransactionID string         `json:"transaction_id"`
+	Reference     string         `json:"reference"`
+	Status        string         `json:"status"`
+	UserID        string         `json:"user_id"`
+	FullName      string         `json:"full_name,omitempty"`
+	PhoneNumber   string         `json:"phone_number,omitempty"`
+	Amount        int64          `json:"amount"`
+	Currency      string         `json:"currency"`
+	BankCode      string         `json:"bank_code"`
+	AccountNumber string         `json:"account_number"`
+	AccountName   string         `json:"account_name"`
+	Score         int            `json:"score"`
+	Signals       map[string]int `json:"signals"`
+	Decision      string         `json:"decision"`
+	IPAddress     string         `json:"ip_address,omitempty"`
+	DeviceID      string         `json:"device_id,omitempty"`
+	ReviewNote    string         `json:"review_note,omitempty"`
+	CreatedAt     time.Time      `json:"created_at"`
+	ReviewedAt    *time.Time     `json:"reviewed_at,omitempty"`
+
+	// An approval whose payout never reached the provider can be retried
+	AwaitingSubmit    bool       `json:"awaiting_submit,omitempty"`
+	PayoutSubmittedAt *time.Time `json:"payout_submitted_at,omitempty"`
+	SubmitError       string     `json:"submit_error,omitempty"`
+}
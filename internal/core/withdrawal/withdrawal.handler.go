@@ -1,34 +1,34 @@
 package withdrawal
 
 import (
+	"errors"
+	"io"
 	"log"
 	"net/http"
+	"strings"
 
 	// "github.com/dblaq/buzzycash/internal/config"
+	"github.com/dblaq/buzzycash/external/gateway"
+	"github.com/dblaq/buzzycash/internal/core/audit"
+	"github.com/dblaq/buzzycash/internal/core/kyc"
+	"github.com/dblaq/buzzycash/internal/core/velocity"
 	"github.com/dblaq/buzzycash/internal/helpers"
 	"github.com/dblaq/buzzycash/internal/models"
 	"github.com/dblaq/buzzycash/internal/utils"
-	"github.com/dblaq/buzzycash/external/gateway"
 	"github.com/gin-gonic/gin"
 	"gorm.io/gorm"
 )
 
-// nomba routes payout
-// do kyc above 250k
-
-const (
-WITHDRAWAL_LIMIT = 250000
-
-)
-
 type WithdawHandler struct {
-	db *gorm.DB
+	db          *gorm.DB
+	withdrawals *WithdrawalService
 }
 
 
 func NewWithdrawHandler(db *gorm.DB) *WithdawHandler {
 	return &WithdawHandler{
-		db: db,
+		db:          db,
+		withdrawals: NewWithdrawalService(db),
 	}
 }
 
@@ -41,10 +41,14 @@ func (h *WithdawHandler)ListBanksHandler(ctx *gin.Context) {
 		return
 	}
 
-	ps := gateway.NBInstance()
-	banks, err := ps.ListNBBanks()
+	provider, err := payoutProvider(ctx.Query("payment_method"), helpers.UserCurrency(user))
 	if err != nil {
-		log.Printf("Flutterwave error: %v", err)
+		utils.Error(ctx, http.StatusBadRequest, "Invalid payment method")
+		return
+	}
+	banks, err := provider.ListBanks()
+	if err != nil {
+		log.Printf("%s error: %v", provider.Name(), err)
 		utils.Error(ctx, http.StatusInternalServerError, "Failed to fetch banks")
 		return
 	}
@@ -76,15 +80,14 @@ func (h *WithdawHandler)RetrieveAccountDetailsHandler(ctx *gin.Context) {
 		return
 	}
 
-	nbReq := gateway.NBRetrieveAccountDetails{
-		AccountNumber: req.AccountNumber,
-		BankCode:      req.BankCode,
+	provider, err := payoutProvider(req.PaymentMethod, helpers.UserCurrency(user))
+	if err != nil {
+		utils.Error(ctx, http.StatusBadRequest, "Invalid payment method")
+		return
 	}
-
-	ps := gateway.NBInstance()
-	accountDetails, err := ps.FetchAccountDetails(nbReq)
+	accountDetails, err := provider.ResolveAccount(req.AccountNumber, req.BankCode)
 	if err != nil {
-		log.Printf("Flutterwave error: %v", err)
+		log.Printf("%s error: %v", provider.Name(), err)
 		utils.Error(ctx, http.StatusInternalServerError, "Failed to fetch account details")
 		return
 	}
@@ -128,75 +131,372 @@ func (h *WithdawHandler)InitiateWithdrawalHandler(ctx *gin.Context) {
 		return
 	}
 	
-	if req.Amount >= WITHDRAWAL_LIMIT{
-		utils.Error(ctx, http.StatusForbidden, "Please complete kyc")
-		 return
+	if err := kyc.CheckLimit(h.db, user, models.WithdrawRequest, req.Amount); err != nil {
+		var limitErr *kyc.LimitError
+		if errors.As(err, &limitErr) {
+			utils.Error(ctx, http.StatusForbidden, limitErr.Error())
+			return
+		}
+		log.Printf("KYC limit check failed for userID %s: %v", userID, err)
+		utils.Error(ctx, http.StatusInternalServerError, "Failed to process withdrawal")
+		return
 	}
-	
-	// if !currentUser.IsKycVerified{
-	// 	utils.Error(ctx, http.StatusForbidden, "Please complete kyc")
-	// 	return	
-	// }
-
-	transactionRef := helpers.GenerateTransactionReference()
-	log.Printf("[transactionRef] Generated transaction reference: %s\n", transactionRef)
-
-	reference := helpers.GenerateFWRef()
-	log.Printf("[reference] Generated  reference: %s\n", reference)
-
-	nbReq := gateway.NBWithdrawalRequest{
-		MerchantTxRef: reference,
-		Amount:        req.Amount,
-		BankCode:      req.BankCode,
-		AccountNumber: req.AccountNumber,
-		AccountName:   req.AccountName,
-		Narration:     "Buzzycash withdrawal",
-		SenderName:    "BuzzyCash",
-	}
-	ps := gateway.NBInstance()
-	_, err := ps.InitiateWithdrawal(nbReq)
-	if err != nil {
-		log.Printf("Nomba Withdrawal error: %v", err)
-		utils.Error(ctx, http.StatusInternalServerError, "Failed to generate payment")
+	if !velocity.Enforce(ctx, h.db, velocity.Attempt{
+		User:     user,
+		Action:   models.VelocityWithdrawal,
+		Amount:   req.Amount,
+		Currency: helpers.UserCurrency(user),
+	}) {
 		return
 	}
 
-	// Record pending transaction
-	history := models.Transaction{
-		Amount:               req.Amount,
-		CustomerEmail:        email,
-		UserID:               currentUser.ID,
-		PaymentStatus:        models.Pending,
-		PaymentMethod:        models.Nomba,
-		TransactionReference: transactionRef,
-		Reference:            reference,
-		TransactionType:      models.Withdrawal,
-		Category:             models.WithdrawRequest,
-		PaymentType:         models.Payout,
-		Currency:             "NGN",
+	if req.BeneficiaryID != "" {
+		beneficiary, err := h.withdrawals.VerifyBeneficiary(user, req.BeneficiaryID)
+		if err != nil {
+			log.Printf("Beneficiary %s rejected for userID %s: %v", req.BeneficiaryID, userID, err)
+			beneficiaryError(ctx, err)
+			return
+		}
+		req.BankCode = beneficiary.BankCode
+		req.AccountNumber = beneficiary.AccountNumber
+		req.AccountName = beneficiary.AccountName
+		req.PaymentMethod = string(beneficiary.Provider)
 	}
-	log.Printf("DEBUG TransactionHistory UserID: '%s'", history.UserID)
-	if err := h.db.Create(&history).Error; err != nil {
-		log.Printf("DB history creation failed: %+v\n", err)
-		utils.Error(ctx, http.StatusInternalServerError, "Failed to record transaction")
+
+	history, assessed, err := h.withdrawals.Initiate(user, req, Client{
+		IPAddress: ctx.ClientIP(),
+		DeviceID:  ctx.GetHeader(velocity.DeviceHeader),
+	})
+	if err != nil {
+		log.Printf("Withdrawal error for userID %s: %v", userID, err)
+		switch {
+		case errors.Is(err, ErrWalletDebitFailed):
+			utils.Error(ctx, http.StatusPaymentRequired, "Insufficient wallet balance")
+		case errors.Is(err, ErrWalletDebitUnconfirmed):
+			utils.Error(ctx, http.StatusGatewayTimeout, "Withdrawal is being confirmed; check its status shortly")
+		case errors.Is(err, ErrPayoutSubmitFailed):
+			utils.Error(ctx, http.StatusBadGateway, "Withdrawal could not be processed; your wallet has been refunded")
+		case errors.Is(err, ErrAccountLookupFailed):
+			utils.Error(ctx, http.StatusBadGateway, "Could not verify the account with the bank")
+		default:
+			utils.Error(ctx, http.StatusInternalServerError, "Failed to process withdrawal")
+		}
 		return
 	}
 
-	ctx.JSON(http.StatusOK, gin.H{
-		"message":              "Generated payment link successfully",
+	audit.Log(ctx, h.db, audit.WithdrawalInitiated, audit.Target{Type: audit.TargetTransaction, ID: history.ID}, nil, gin.H{
+		"transaction": audit.TransactionState(*history),
+		"risk_score":  assessed.Score,
+		"decision":    assessed.Decision,
+	})
+
+	status, message := http.StatusOK, "Withdrawal initiated successfully"
+	if assessed.Decision == models.RiskPendingReview {
+		status, message = http.StatusAccepted, "Withdrawal is being reviewed and will be paid out once approved"
+	}
+	ctx.JSON(status, gin.H{
+		"message":              message,
 		"amountPaid":           req.Amount,
 		"customerEmail":        email,
 		"userID":               userID,
-		"paymentStatus":        models.Pending,
-		"paymentMethod":        models.Nomba,
-		"transactionReference": transactionRef,
-		"reference":            reference,
+		"paymentStatus":        history.PaymentStatus,
+		"paymentMethod":        history.PaymentMethod,
+		"transactionReference": history.TransactionReference,
+		"reference":            history.Reference,
 		"transactionType":      models.Withdrawal,
 		"category":             models.WithdrawRequest,
 		"paymentType":          models.Payout,
-		"currency":             "NGN",
+		"currency":             history.Currency,
+		"reviewStatus":         assessed.Decision,
+	})
+}
+
+// ListReviewsHandler lists withdrawals held for review, or those with the
+// decision in ?decision= when given.
+func (h *WithdawHandler) ListReviewsHandler(ctx *gin.Context) {
+	decision := models.RiskDecision(strings.ToUpper(ctx.DefaultQuery("decision", string(models.RiskPendingReview))))
+	switch decision {
+	case models.RiskPendingReview, models.RiskApproved, models.RiskRejected, models.RiskAutoApproved:
+	default:
+		utils.Error(ctx, http.StatusBadRequest, "Invalid decision")
+		return
+	}
+
+	reviews, err := h.withdrawals.ListReviews(decision)
+	if err != nil {
+		log.Printf("[Withdrawal] Failed to load review queue: %v", err)
+		utils.Error(ctx, http.StatusInternalServerError, "Failed to fetch withdrawal reviews")
+		return
+	}
+
+	response := make([]ReviewResponse, 0, len(reviews))
+	for _, r := range reviews {
+		response = append(response, toReviewResponse(r))
+	}
+
+	ctx.JSON(http.StatusOK, gin.H{
+		"message": "Withdrawal reviews retrieved successfully",
+		"data":    response,
+	})
+}
+
+func (h *WithdawHandler) ApproveReviewHandler(ctx *gin.Context) {
+	var req ReviewDecisionRequest
+	if err := ctx.ShouldBindJSON(&req); err != nil && !errors.Is(err, io.EOF) {
+		utils.Error(ctx, http.StatusBadRequest, utils.ValidationErrorToJSON(err))
+		return
+	}
+	if err := req.Validate(); err != nil {
+		utils.Error(ctx, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	admin := ctx.MustGet("currentAdmin").(models.Admin)
+	review, err := h.withdrawals.ApproveReview(ctx.Param("id"), admin, req.Note)
+	if err != nil {
+		reviewError(ctx, err)
+		return
+	}
+	audit.Log(ctx, h.db, audit.WithdrawalApproved, audit.Target{Type: audit.TargetTransaction, ID: review.TransactionID},
+		gin.H{"decision": models.RiskPendingReview}, gin.H{"decision": review.Decision, "note": review.ReviewNote})
+
+	ctx.JSON(http.StatusOK, gin.H{
+		"message": "Withdrawal approved and sent for payout",
+		"data":    toReviewResponse(*review),
 	})
 }
 
+// RetrySubmitHandler resubmits an approved withdrawal whose payout never
+// reached the provider.
+func (h *WithdawHandler) RetrySubmitHandler(ctx *gin.Context) {
+	admin := ctx.MustGet("currentAdmin").(models.Admin)
+	review, err := h.withdrawals.RetrySubmit(ctx.Param("id"), admin)
+	if err != nil {
+		reviewError(ctx, err)
+		return
+	}
+	audit.Log(ctx, h.db, audit.WithdrawalSubmitRetried, audit.Target{Type: audit.TargetTransaction, ID: review.TransactionID},
+		gin.H{"payoutSubmittedAt": nil}, gin.H{"payoutSubmittedAt": review.PayoutSubmittedAt})
 
+	ctx.JSON(http.StatusOK, gin.H{
+		"message": "Withdrawal sent for payout",
+		"data":    toReviewResponse(*review),
+	})
+}
+
+func (h *WithdawHandler) RejectReviewHandler(ctx *gin.Context) {
+	var req ReviewDecisionRequest
+	if err := ctx.ShouldBindJSON(&req); err != nil {
+		utils.Error(ctx, http.StatusBadRequest, utils.ValidationErrorToJSON(err))
+		return
+	}
+	if err := req.Validate(); err != nil {
+		utils.Error(ctx, http.StatusBadRequest, err.Error())
+		return
+	}
+	if req.Note == "" {
+		utils.Error(ctx, http.StatusBadRequest, ErrRejectReasonMissing.Error())
+		return
+	}
 
+	admin := ctx.MustGet("currentAdmin").(models.Admin)
+	review, err := h.withdrawals.RejectReview(ctx.Param("id"), admin, req.Note)
+	if err != nil {
+		reviewError(ctx, err)
+		return
+	}
+	audit.Log(ctx, h.db, audit.WithdrawalRejected, audit.Target{Type: audit.TargetTransaction, ID: review.TransactionID},
+		gin.H{"decision": models.RiskPendingReview}, gin.H{"decision": review.Decision, "note": review.ReviewNote})
+
+	ctx.JSON(http.StatusOK, gin.H{
+		"message": "Withdrawal rejected and refunded",
+		"data":    toReviewResponse(*review),
+	})
+}
+
+func (h *WithdawHandler) ListBeneficiariesHandler(ctx *gin.Context) {
+	currentUser := ctx.MustGet("currentUser").(models.User)
+
+	var beneficiaries []models.Beneficiary
+	if err := h.db.Where("user_id = ?", currentUser.ID).Order("created_at desc").Find(&beneficiaries).Error; err != nil {
+		log.Printf("[Beneficiary] Failed to fetch beneficiaries for userID %s: %v", currentUser.ID, err)
+		utils.Error(ctx, http.StatusInternalServerError, "Failed to fetch beneficiaries")
+		return
+	}
+
+	response := make([]BeneficiaryResponse, 0, len(beneficiaries))
+	for _, b := range beneficiaries {
+		response = append(response, toBeneficiaryResponse(b))
+	}
+
+	ctx.JSON(http.StatusOK, gin.H{
+		"message": "Beneficiaries retrieved successfully",
+		"data":    response,
+	})
+}
+
+// CreateBeneficiaryHandler saves a payout account after resolving its name
+// with the bank.
+func (h *WithdawHandler) CreateBeneficiaryHandler(ctx *gin.Context) {
+	var req CreateBeneficiaryRequest
+	if err := ctx.ShouldBindJSON(&req); err != nil {
+		utils.Error(ctx, http.StatusBadRequest, utils.ValidationErrorToJSON(err))
+		return
+	}
+	if err := req.Validate(); err != nil {
+		utils.Error(ctx, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	currentUser := ctx.MustGet("currentUser").(models.User)
+	beneficiary, err := h.withdrawals.AddBeneficiary(currentUser, req)
+	if err != nil {
+		log.Printf("[Beneficiary] Failed to add beneficiary for userID %s: %v", currentUser.ID, err)
+		beneficiaryError(ctx, err)
+		return
+	}
+
+	ctx.JSON(http.StatusCreated, gin.H{
+		"message": "Beneficiary saved successfully",
+		"data":    toBeneficiaryResponse(*beneficiary),
+	})
+}
+
+// UpdateBeneficiaryHandler renames a beneficiary. The account itself cannot
+// change; save a new beneficiary instead.
+func (h *WithdawHandler) UpdateBeneficiaryHandler(ctx *gin.Context) {
+	var req UpdateBeneficiaryRequest
+	if err := ctx.ShouldBindJSON(&req); err != nil {
+		utils.Error(ctx, http.StatusBadRequest, utils.ValidationErrorToJSON(err))
+		return
+	}
+	if err := req.Validate(); err != nil {
+		utils.Error(ctx, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	currentUser := ctx.MustGet("currentUser").(models.User)
+	var beneficiary models.Beneficiary
+	if err := h.db.First(&beneficiary, "id = ? AND user_id = ?", ctx.Param("id"), currentUser.ID).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			utils.Error(ctx, http.StatusNotFound, "Beneficiary not found")
+			return
+		}
+		utils.Error(ctx, http.StatusInternalServerError, "Failed to fetch beneficiary")
+		return
+	}
+
+	if err := h.db.Model(&beneficiary).Update("nickname", strings.TrimSpace(req.Nickname)).Error; err != nil {
+		log.Printf("[Beneficiary] Failed to update beneficiary %s: %v", beneficiary.ID, err)
+		utils.Error(ctx, http.StatusInternalServerError, "Failed to update beneficiary")
+		return
+	}
+
+	ctx.JSON(http.StatusOK, gin.H{
+		"message": "Beneficiary updated successfully",
+		"data":    toBeneficiaryResponse(beneficiary),
+	})
+}
+
+func (h *WithdawHandler) DeleteBeneficiaryHandler(ctx *gin.Context) {
+	currentUser := ctx.MustGet("currentUser").(models.User)
+
+	result := h.db.Where("id = ? AND user_id = ?", ctx.Param("id"), currentUser.ID).Delete(&models.Beneficiary{})
+	if result.Error != nil {
+		log.Printf("[Beneficiary] Failed to delete beneficiary %s for userID %s: %v", ctx.Param("id"), currentUser.ID, result.Error)
+		utils.Error(ctx, http.StatusInternalServerError, "Failed to delete beneficiary")
+		return
+	}
+	if result.RowsAffected == 0 {
+		utils.Error(ctx, http.StatusNotFound, "Beneficiary not found")
+		return
+	}
+
+	ctx.JSON(http.StatusOK, gin.H{
+		"message": "Beneficiary deleted successfully",
+	})
+}
+
+func beneficiaryError(ctx *gin.Context, err error) {
+	switch {
+	case errors.Is(err, ErrBeneficiaryNotFound):
+		utils.Error(ctx, http.StatusNotFound, "Beneficiary not found")
+	case errors.Is(err, ErrBeneficiaryExists):
+		utils.Error(ctx, http.StatusConflict, "Beneficiary already saved")
+	case errors.Is(err, ErrAccountNameChanged):
+		utils.Error(ctx, http.StatusConflict, "The bank reports a different name for this account; please save it again")
+	case errors.Is(err, ErrAccountLookupFailed):
+		utils.Error(ctx, http.StatusBadGateway, "Could not verify the account with the bank")
+	case errors.Is(err, gateway.ErrUnknownProvider):
+		utils.Error(ctx, http.StatusBadRequest, "Invalid payment method")
+	default:
+		utils.Error(ctx, http.StatusInternalServerError, "Failed to process beneficiary")
+	}
+}
+
+func reviewError(ctx *gin.Context, err error) {
+	switch {
+	case errors.Is(err, ErrReviewNotFound):
+		utils.Error(ctx, http.StatusNotFound, "Withdrawal review not found")
+	case errors.Is(err, ErrNotInReview), errors.Is(err, ErrNotAwaitingSubmit), errors.Is(err, ErrSubmitInProgress):
+		utils.Error(ctx, http.StatusConflict, err.Error())
+	case errors.Is(err, ErrPayoutUnverified):
+		utils.Error(ctx, http.StatusBadGateway, "Could not confirm the payout was not already sent; check with the provider before retrying")
+	case errors.Is(err, ErrPayoutSubmitFailed):
+		utils.Error(ctx, http.StatusBadGateway, "Payout could not be submitted; the withdrawal has been refunded")
+	default:
+		log.Printf("[Withdrawal] Review failed: %v", err)
+		utils.Error(ctx, http.StatusInternalServerError, "Failed to review withdrawal")
+	}
+}
+
+func toReviewResponse(r models.WithdrawalRisk) ReviewResponse {
+	signals := map[string]int{}
+	for name, points := range r.Signals {
+		if n, ok := points.(float64); ok {
+			signals[name] = int(n)
+		} else if n, ok := points.(int); ok {
+			signals[name] = n
+		}
+	}
+	return ReviewResponse{
+		ID:            r.ID,
+		TransactionID: r.TransactionID,
+		Reference:     r.Transaction.Reference,
+		Status:        string(r.Transaction.PaymentStatus),
+		UserID:        r.UserID,
+		FullName:      r.User.FullName,
+		PhoneNumber:   r.User.PhoneNumber,
+		Amount:        r.Transaction.Amount,
+		Currency:      string(r.Transaction.Currency),
+		BankCode:      metaString(r.Transaction.Metadata, "bankCode"),
+		AccountNumber: metaString(r.Transaction.Metadata, "accountNumber"),
+		AccountName:   metaString(r.Transaction.Metadata, "accountName"),
+		Score:         r.Score,
+		Signals:       signals,
+		Decision:      string(r.Decision),
+		IPAddress:     r.IPAddress,
+		DeviceID:      r.DeviceID,
+		ReviewNote:    r.ReviewNote,
+		CreatedAt:     r.CreatedAt,
+		ReviewedAt:    r.ReviewedAt,
+
+		AwaitingSubmit:    awaitingSubmit(r) && r.Transaction.PaymentStatus == models.Pending,
+		PayoutSubmittedAt: r.PayoutSubmittedAt,
+		SubmitError:       r.SubmitError,
+	}
+}
+
+func toBeneficiaryResponse(b models.Beneficiary) BeneficiaryResponse {
+	return BeneficiaryResponse{
+		ID:            b.ID,
+		Provider:      string(b.Provider),
+		BankCode:      b.BankCode,
+		BankName:      b.BankName,
+		AccountNumber: b.AccountNumber,
+		AccountName:   b.AccountName,
+		Nickname:      b.Nickname,
+		VerifiedAt:    b.VerifiedAt,
+		CreatedAt:     b.CreatedAt,
+	}
+}
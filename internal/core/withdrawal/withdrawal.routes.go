@@ -7,6 +7,7 @@ import (
 	"github.com/gin-gonic/gin"
 	"gorm.io/gorm"
 	"github.com/dblaq/buzzycash/internal/middlewares"
+	"github.com/dblaq/buzzycash/internal/models"
 )
 
 func WithdrawalRoutes(rg *gin.RouterGroup, db *gorm.DB) {
@@ -15,6 +16,18 @@ func WithdrawalRoutes(rg *gin.RouterGroup, db *gorm.DB) {
 	{
 		withdrawalRoutes.GET("/list-banks", middlewares.AuthMiddleware,withdrawHandler.ListBanksHandler)
 		withdrawalRoutes.POST("/account-details", middlewares.AuthMiddleware,withdrawHandler.RetrieveAccountDetailsHandler)
-		withdrawalRoutes.POST("/initiate-withdrawal", middlewares.AuthMiddleware,withdrawHandler.InitiateWithdrawalHandler)
+		withdrawalRoutes.POST("/initiate-withdrawal", middlewares.AuthMiddleware,middlewares.AgeVerificationMiddleware,withdrawHandler.InitiateWithdrawalHandler)
+		withdrawalRoutes.GET("/beneficiaries", middlewares.AuthMiddleware, withdrawHandler.ListBeneficiariesHandler)
+		withdrawalRoutes.POST("/beneficiaries", middlewares.AuthMiddleware, withdrawHandler.CreateBeneficiaryHandler)
+		withdrawalRoutes.PATCH("/beneficiaries/:id", middlewares.AuthMiddleware, withdrawHandler.UpdateBeneficiaryHandler)
+		withdrawalRoutes.DELETE("/beneficiaries/:id", middlewares.AuthMiddleware, withdrawHandler.DeleteBeneficiaryHandler)
+	}
+
+	reviewRoutes := rg.Group("/admin/withdrawals/reviews", middlewares.AdminAuthMiddleware, middlewares.RequirePermission(models.PermWithdrawalsReview))
+	{
+		reviewRoutes.GET("", withdrawHandler.ListReviewsHandler)
+		reviewRoutes.POST("/:id/approve", withdrawHandler.ApproveReviewHandler)
+		reviewRoutes.POST("/:id/retry-submit", withdrawHandler.RetrySubmitHandler)
+		reviewRoutes.POST("/:id/reject", withdrawHandler.RejectReviewHandler)
 	}
 }
\ No newline at end of file
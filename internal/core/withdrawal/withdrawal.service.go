@@ -0,0 +1,442 @@
+package withdrawal
+
+import (
+	"errors"
+	"fmt"
+	"log"
+	"strings"
+	"time"
+
+	"github.com/dblaq/buzzycash/external/gaming"
+	"github.com/dblaq/buzzycash/external/gateway"
+	"github.com/dblaq/buzzycash/internal/config"
+	"github.com/dblaq/buzzycash/internal/core/audit"
+	"github.com/dblaq/buzzycash/internal/core/ledger"
+	"github.com/dblaq/buzzycash/internal/core/outbox"
+	"github.com/dblaq/buzzycash/internal/core/risk"
+	"github.com/dblaq/buzzycash/internal/helpers"
+	"github.com/dblaq/buzzycash/internal/models"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+var (
+	ErrWalletDebitFailed  = errors.New("unable to debit wallet for withdrawal")
+	ErrPayoutSubmitFailed = errors.New("payout provider rejected the withdrawal")
+	// ErrWalletDebitUnconfirmed means the gaming API's answer to the debit
+	// was lost. The withdrawal stays PENDING until it is resolved.
+	ErrWalletDebitUnconfirmed = errors.New("wallet debit for withdrawal is unconfirmed")
+)
+
+// Withdrawals move PENDING -> SUCCESSFUL when the payout settles, or
+// PENDING -> FAILED when it does not. A settled payout the bank later
+// returns moves SUCCESSFUL -> REVERSED. FAILED and REVERSED withdrawals are
+// paired with a WITHDRAW_REVERSED credit that returns the funds, except one
+// failed because the wallet refused the debit, which never held any.
+var withdrawalTransitions = map[models.EPaymentStatus][]models.EPaymentStatus{
+	models.Pending:    {models.Successful, models.Failed},
+	models.Successful: {models.Reversed},
+}
+
+func canTransition(from, to models.EPaymentStatus) bool {
+	for _, next := range withdrawalTransitions[from] {
+		if next == to {
+			return true
+		}
+	}
+	return false
+}
+
+type WithdrawalService struct {
+	db *gorm.DB
+}
+
+func NewWithdrawalService(db *gorm.DB) *WithdrawalService {
+	return &WithdrawalService{
+		db: db,
+	}
+}
+
+// payoutProvider resolves the gateway for a payout, falling back to the
+// configured default for the currency when the user did not pick one.
+func payoutProvider(method string, currency models.ECurrency) (gateway.PaymentProvider, error) {
+	if method == "" {
+		method = config.AppConfig.PayoutProvider
+		if currency == models.CED {
+			method = config.AppConfig.GhanaPayoutProvider
+		}
+	}
+	return gateway.GetProvider(method)
+}
+
+// Client identifies where a withdrawal request came from, for risk scoring.
+type Client struct {
+	IPAddress string
+	DeviceID  string
+}
+
+// Initiate resolves the account with the payout provider, scores the
+// withdrawal, records it PENDING, debits the gaming wallet and submits the
+// payout. The account holder's name always comes from the provider, never
+// from the request. A withdrawal that
+// scores high enough for review is held with its funds debited until an
+// admin approves or rejects it. A refused debit cancels the withdrawal; any
+// failure after the debit reverses it.
+func (s *WithdrawalService) Initiate(user models.User, req InitiateWithdrawalRequest, client Client) (*models.Transaction, *models.WithdrawalRisk, error) {
+	currency := helpers.UserCurrency(user)
+	provider, err := payoutProvider(req.PaymentMethod, currency)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	details, err := provider.ResolveAccount(req.AccountNumber, req.BankCode)
+	if err != nil || strings.TrimSpace(details.AccountName) == "" {
+		log.Printf("[Withdrawal] %s could not resolve account for userID=%s: %v", provider.Name(), user.ID, err)
+		return nil, nil, ErrAccountLookupFailed
+	}
+	accountName := details.AccountName
+
+	assessment, err := risk.Assess(s.db, risk.Withdrawal{
+		User:          user,
+		Amount:        req.Amount,
+		Currency:      currency,
+		BankCode:      req.BankCode,
+		AccountNumber: req.AccountNumber,
+		AccountName:   accountName,
+		IPAddress:     client.IPAddress,
+		DeviceID:      client.DeviceID,
+	})
+	if err != nil {
+		return nil, nil, fmt.Errorf("risk assessment failed: %w", err)
+	}
+
+	history := models.Transaction{
+		Amount:               req.Amount,
+		CustomerEmail:        user.Email,
+		UserID:               user.ID,
+		PaymentStatus:        models.Pending,
+		PaymentMethod:        models.EPaymentMethod(strings.ToUpper(provider.Name())),
+		TransactionReference: helpers.GenerateTransactionReference(),
+		Reference:            helpers.GenerateFWRef(),
+		TransactionType:      models.Withdrawal,
+		Category:             models.WithdrawRequest,
+		PaymentType:          models.Payout,
+		Currency:             currency,
+		Metadata: models.JSONB{
+			"bankCode":      req.BankCode,
+			"accountNumber": req.AccountNumber,
+			"accountName":   accountName,
+		},
+	}
+	assessed := models.WithdrawalRisk{
+		UserID:    user.ID,
+		Score:     assessment.Score,
+		Signals:   signalsJSON(assessment.Signals),
+		Decision:  models.RiskAutoApproved,
+		IPAddress: client.IPAddress,
+		DeviceID:  client.DeviceID,
+	}
+	if assessment.Review() {
+		assessed.Decision = models.RiskPendingReview
+	}
+
+	// 1) Record the withdrawal, its ledger posting and the risk decision
+	// together, so none exists without the others
+	log.Printf("[Withdrawal] Recording pending withdrawal ref=%s for userID=%s", history.Reference, user.ID)
+	if err := s.db.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Create(&history).Error; err != nil {
+			return fmt.Errorf("record withdrawal failed: %w", err)
+		}
+		if err := ledger.PostWithdrawal(tx, history); err != nil {
+			return fmt.Errorf("ledger posting failed: %w", err)
+		}
+		assessed.TransactionID = history.ID
+		return tx.Create(&assessed).Error
+	}); err != nil {
+		return nil, nil, err
+	}
+
+	// 2) Hold the funds by debiting the gaming wallet
+	gs := gaming.GMInstance()
+	if _, err := gs.DebitUserWalletIdempotent(user.PhoneNumber, float64(req.Amount), withdrawalDebitKey(history)); err != nil {
+		if !errors.Is(err, gaming.ErrRejected) {
+			// The debit may have landed; the withdrawal stays PENDING
+			// until the stale withdrawal sweep resolves it
+			log.Printf("[Withdrawal] Outcome of wallet debit for ref=%s unknown: %v", history.Reference, err)
+			return nil, nil, ErrWalletDebitUnconfirmed
+		}
+		log.Printf("[Withdrawal] Wallet debit refused for ref=%s: %v", history.Reference, err)
+		if cancelErr := s.cancel(history.Reference, "wallet debit failed"); cancelErr != nil {
+			log.Printf("[Withdrawal] WARNING: could not cancel ref=%s: %v", history.Reference, cancelErr)
+		}
+		return nil, nil, ErrWalletDebitFailed
+	}
+	history.Metadata = withWalletDebited(history.Metadata)
+	if err := s.db.Model(&history).Update("metadata", history.Metadata).Error; err != nil {
+		// The stale withdrawal sweep sends it to review rather than guess
+		log.Printf("[Withdrawal] WARNING: could not record wallet debit for ref=%s: %v", history.Reference, err)
+	}
+
+	if assessed.Decision == models.RiskPendingReview {
+		log.Printf("[Withdrawal] Holding ref=%s for review (score %d: %v)", history.Reference, assessment.Score, assessment.Signals)
+		return &history, &assessed, nil
+	}
+
+	// 3) Submit the payout
+	if err := s.submitPayout(provider, history); err != nil {
+		return nil, nil, err
+	}
+	return &history, &assessed, nil
+}
+
+// submitPayout sends a debited withdrawal to the payout provider, reversing
+// it if the provider turns it down.
+func (s *WithdrawalService) submitPayout(provider gateway.PaymentProvider, history models.Transaction) error {
+	payout := gateway.PayoutRequest{
+		Reference:     history.Reference,
+		Amount:        history.Amount,
+		Currency:      history.Currency.ISOCode(),
+		BankCode:      metaString(history.Metadata, "bankCode"),
+		AccountNumber: metaString(history.Metadata, "accountNumber"),
+		AccountName:   metaString(history.Metadata, "accountName"),
+		Narration:     "Buzzycash withdrawal",
+	}
+	if err := provider.InitiatePayout(payout); err != nil {
+		log.Printf("[Withdrawal] %s rejected ref=%s: %v", provider.Name(), history.Reference, err)
+		if revErr := s.Fail(history.Reference, err.Error()); revErr != nil {
+			log.Printf("[Withdrawal] ERROR: reversal failed for ref=%s: %v", history.Reference, revErr)
+		}
+		return fmt.Errorf("%w: %v", ErrPayoutSubmitFailed, err)
+	}
+
+	log.Printf("[Withdrawal] Submitted ref=%s to %s; awaiting settlement", history.Reference, provider.Name())
+	return nil
+}
+
+// Fail moves a PENDING withdrawal to FAILED, records a WITHDRAW_REVERSED
+// transaction and queues a credit of the funds back to the gaming wallet. Calling it on a
+// withdrawal that has already left PENDING is a no-op.
+func (s *WithdrawalService) Fail(reference, reason string) error {
+	return s.reverse(reference, models.Failed, reason)
+}
+
+// Refund handles a payout the bank returned after it was reported
+// successful, moving it to REVERSED and crediting the funds back.
+func (s *WithdrawalService) Refund(reference, reason string) error {
+	return s.reverse(reference, models.Reversed, reason)
+}
+
+func (s *WithdrawalService) reverse(reference string, target models.EPaymentStatus, reason string) error {
+	var history, reversal models.Transaction
+
+	if err := s.db.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Preload("User").
+			Clauses(clause.Locking{Strength: "UPDATE"}).
+			Where("reference = ? AND category = ?", reference, models.WithdrawRequest).
+			First(&history).Error; err != nil {
+			if errors.Is(err, gorm.ErrRecordNotFound) {
+				log.Printf("[Withdrawal] No withdrawal for reference=%s", reference)
+				return nil
+			}
+			return fmt.Errorf("load withdrawal failed: %w", err)
+		}
+
+		if !canTransition(history.PaymentStatus, target) {
+			log.Printf("[Withdrawal] ref=%s is %s; cannot move to %s", reference, history.PaymentStatus, target)
+			history = models.Transaction{}
+			return nil
+		}
+
+		if err := tx.Model(&history).Updates(map[string]interface{}{
+			"payment_status": target,
+			"metadata":       withReason(history.Metadata, reason),
+		}).Error; err != nil {
+			return fmt.Errorf("update withdrawal failed: %w", err)
+		}
+
+		reversal = models.Transaction{
+			Amount:               history.Amount,
+			CustomerEmail:        history.CustomerEmail,
+			UserID:               history.UserID,
+			PaymentStatus:        models.Successful,
+			PaymentMethod:        models.Wallet,
+			TransactionReference: helpers.GenerateTransactionReference("REV"),
+			Reference:            "REV-" + history.Reference,
+			TransactionType:      models.Credit,
+			Category:             models.WithdrawReversed,
+			PaymentType:          models.Payout,
+			Currency:             history.Currency,
+			PaidAt:               time.Now(),
+			Metadata: models.JSONB{
+				"originalTransactionId": history.ID,
+				"reason":                reason,
+			},
+		}
+		if err := tx.Create(&reversal).Error; err != nil {
+			return fmt.Errorf("record reversal failed: %w", err)
+		}
+
+		if err := ledger.PostWithdrawalReversal(tx, history); err != nil {
+			return fmt.Errorf("ledger reversal failed: %w", err)
+		}
+
+		before := audit.TransactionState(history)
+		after := audit.TransactionState(history)
+		after["payment_status"] = target
+		after["reason"] = reason
+		after["reversal_id"] = reversal.ID
+		if _, err := audit.Record(tx, audit.Entry{
+			Actor:  audit.System,
+			Action: audit.WithdrawalReversed,
+			Target: audit.Target{Type: audit.TargetTransaction, ID: history.ID},
+			Before: before,
+			After:  after,
+		}); err != nil {
+			return err
+		}
+
+		// The refund reaches the gaming wallet through the outbox once this
+		// commits, so no HTTP call runs while the row and audit chain are locked
+		return outbox.EnqueueCredit(tx, outbox.ReversalCreditKey(history.Reference), reversal, history.User.PhoneNumber, float64(history.Amount))
+	}); err != nil {
+		return err
+	}
+
+	if history.ID == "" {
+		return nil
+	}
+
+	notif := models.Notification{
+		UserID:   history.UserID,
+		Type:     models.Transactions,
+		Title:    "Withdrawal Reversed",
+		Subtitle: "Your withdrawal could not be completed and the funds have been returned to your wallet.",
+		Amount:   history.Amount,
+		Currency: string(history.Currency),
+		Status:   "reversed",
+	}
+	if err := s.db.Create(&notif).Error; err != nil {
+		log.Printf("[Withdrawal] WARNING: could not create reversal notification for ref=%s: %v", reference, err)
+	}
+
+	log.Printf("[Withdrawal] ref=%s moved to %s (%s) and was reversed", reference, target, reason)
+	return nil
+}
+
+// Abandon winds up a PENDING withdrawal the payout provider never received.
+// One whose wallet debit is on record is failed and its funds returned.
+// Otherwise the process stopped around the debit and nobody knows whether
+// it landed; the gaming API has no lookup to ask, so the withdrawal goes to
+// the review queue for an admin to check the wallet. Approving it pays out;
+// rejecting it cancels it without returning funds that were never taken.
+func (s *WithdrawalService) Abandon(history models.Transaction, reason string) error {
+	if walletDebited(history) {
+		return s.Fail(history.Reference, reason)
+	}
+	log.Printf("[Withdrawal] WARNING: wallet debit for ref=%s unconfirmed; sending to review", history.Reference)
+	meta := models.JSONB{}
+	for k, v := range history.Metadata {
+		meta[k] = v
+	}
+	meta[debitUnconfirmedKey] = true
+	return s.db.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Model(&history).Update("metadata", meta).Error; err != nil {
+			return err
+		}
+		return tx.Model(&models.WithdrawalRisk{}).
+			Where("transaction_id = ?", history.ID).
+			Updates(map[string]interface{}{
+				"decision":    models.RiskPendingReview,
+				"review_note": "wallet debit unconfirmed: approve only if the gaming wallet was debited. " + reason,
+			}).Error
+	})
+}
+
+// debitUnconfirmedKey marks a withdrawal sent to review because nobody
+// knows whether its wallet debit landed.
+const debitUnconfirmedKey = "debitUnconfirmed"
+
+func debitUnconfirmed(history models.Transaction) bool {
+	unconfirmed, _ := history.Metadata[debitUnconfirmedKey].(bool)
+	return unconfirmed
+}
+
+// walletDebited reports whether the debit holding a withdrawal's funds is
+// known to have landed.
+func walletDebited(history models.Transaction) bool {
+	debited, _ := history.Metadata["walletDebited"].(bool)
+	return debited
+}
+
+func withWalletDebited(meta models.JSONB) models.JSONB {
+	out := models.JSONB{}
+	for k, v := range meta {
+		out[k] = v
+	}
+	out["walletDebited"] = true
+	return out
+}
+
+// withdrawalDebitKey is the idempotency key for the wallet debit that
+// holds a withdrawal's funds.
+func withdrawalDebitKey(history models.Transaction) string {
+	return "withdrawal:" + history.ID
+}
+
+// cancel fails a PENDING withdrawal whose wallet debit was refused. Nothing
+// left the wallet, so only the ledger posting is undone.
+func (s *WithdrawalService) cancel(reference, reason string) error {
+	return s.db.Transaction(func(tx *gorm.DB) error {
+		var history models.Transaction
+		if err := tx.Clauses(clause.Locking{Strength: "UPDATE"}).
+			Where("reference = ? AND category = ? AND payment_status = ?", reference, models.WithdrawRequest, models.Pending).
+			First(&history).Error; err != nil {
+			if errors.Is(err, gorm.ErrRecordNotFound) {
+				return nil
+			}
+			return fmt.Errorf("load withdrawal failed: %w", err)
+		}
+		if err := tx.Model(&history).Updates(map[string]interface{}{
+			"payment_status": models.Failed,
+			"metadata":       withReason(history.Metadata, reason),
+		}).Error; err != nil {
+			return fmt.Errorf("update withdrawal failed: %w", err)
+		}
+		if err := ledger.PostWithdrawalReversal(tx, history); err != nil {
+			return fmt.Errorf("ledger reversal failed: %w", err)
+		}
+		after := audit.TransactionState(history)
+		after["payment_status"] = models.Failed
+		after["reason"] = reason
+		_, err := audit.Record(tx, audit.Entry{
+			Actor:  audit.System,
+			Action: audit.WithdrawalCancelled,
+			Target: audit.Target{Type: audit.TargetTransaction, ID: history.ID},
+			Before: audit.TransactionState(history),
+			After:  after,
+		})
+		return err
+	})
+}
+
+func withReason(meta models.JSONB, reason string) models.JSONB {
+	out := models.JSONB{}
+	for k, v := range meta {
+		out[k] = v
+	}
+	out["failureReason"] = reason
+	return out
+}
+
+func metaString(meta models.JSONB, key string) string {
+	value, _ := meta[key].(string)
+	return value
+}
+
+func signalsJSON(signals map[string]int) models.JSONB {
+	out := models.JSONB{}
+	for name, points := range signals {
+		out[name] = points
+	}
+	return out
+}
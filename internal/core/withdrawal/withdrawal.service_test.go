@@ -0,0 +1,167 @@
+package withdrawal
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/dblaq/buzzycash/internal/core/ledger"
+	"github.com/dblaq/buzzycash/internal/models"
+	"github.com/dblaq/buzzycash/internal/testutil"
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+// pendingWithdrawal records a withdrawal the way Initiate does before the
+// wallet is debited.
+func pendingWithdrawal(t *testing.T, db *gorm.DB, user models.User) models.Transaction {
+	t.Helper()
+	history := models.Transaction{
+		Amount:               2000,
+		UserID:               user.ID,
+		PaymentStatus:        models.Pending,
+		PaymentMethod:        models.Nomba,
+		TransactionReference: "TX-" + uuid.NewString(),
+		Reference:            "REF-" + uuid.NewString(),
+		TransactionType:      models.Withdrawal,
+		Category:             models.WithdrawRequest,
+		PaymentType:          models.Payout,
+		Currency:             models.NGN,
+	}
+	if err := db.Create(&history).Error; err != nil {
+		t.Fatalf("create withdrawal: %v", err)
+	}
+	if err := ledger.PostWithdrawal(db, history); err != nil {
+		t.Fatalf("post withdrawal: %v", err)
+	}
+	return history
+}
+
+func TestCancelUndoesLedgerWithoutRefund(t *testing.T) {
+	db := testutil.DB(t)
+	user := testutil.User(t, db)
+	history := pendingWithdrawal(t, db, user)
+	service := NewWithdrawalService(db)
+
+	for i := 0; i < 2; i++ {
+		if err := service.cancel(history.Reference, "wallet debit failed"); err != nil {
+			t.Fatalf("cancel #%d: %v", i+1, err)
+		}
+	}
+
+	var got models.Transaction
+	db.First(&got, "id = ?", history.ID)
+	if got.PaymentStatus != models.Failed {
+		t.Errorf("status = %s, want %s", got.PaymentStatus, models.Failed)
+	}
+	var reversals int64
+	db.Model(&models.JournalEntry{}).Where("reference = ?", "WITHDRAWAL_REVERSAL:"+history.ID).Count(&reversals)
+	if reversals != 1 {
+		t.Errorf("posted %d ledger reversals, want 1", reversals)
+	}
+	var credits int64
+	db.Model(&models.WalletCreditOutbox{}).Where("user_id = ?", user.ID).Count(&credits)
+	if credits != 0 {
+		t.Errorf("queued %d wallet credits for funds never debited, want none", credits)
+	}
+}
+
+func assess(t *testing.T, db *gorm.DB, history models.Transaction, decision models.RiskDecision) models.WithdrawalRisk {
+	t.Helper()
+	review := models.WithdrawalRisk{TransactionID: history.ID, UserID: history.UserID, Decision: decision}
+	if err := db.Create(&review).Error; err != nil {
+		t.Fatalf("create risk row: %v", err)
+	}
+	return review
+}
+
+func TestAbandonRefundsDebitedWithdrawal(t *testing.T) {
+	db := testutil.DB(t)
+	user := testutil.User(t, db)
+	history := pendingWithdrawal(t, db, user)
+	history.Metadata = withWalletDebited(history.Metadata)
+	db.Model(&history).Update("metadata", history.Metadata)
+	assess(t, db, history, models.RiskAutoApproved)
+
+	if err := NewWithdrawalService(db).Abandon(history, "requery: payout never submitted"); err != nil {
+		t.Fatalf("Abandon: %v", err)
+	}
+
+	var got models.Transaction
+	db.First(&got, "id = ?", history.ID)
+	if got.PaymentStatus != models.Failed {
+		t.Errorf("status = %s, want %s", got.PaymentStatus, models.Failed)
+	}
+	var credits int64
+	db.Model(&models.WalletCreditOutbox{}).Where("user_id = ?", user.ID).Count(&credits)
+	if credits != 1 {
+		t.Errorf("queued %d refunds, want 1", credits)
+	}
+}
+
+func TestAbandonSendsUnconfirmedDebitToReview(t *testing.T) {
+	db := testutil.DB(t)
+	user := testutil.User(t, db)
+	history := pendingWithdrawal(t, db, user)
+	review := assess(t, db, history, models.RiskAutoApproved)
+	service := NewWithdrawalService(db)
+
+	if err := service.Abandon(history, "requery: payout never submitted"); err != nil {
+		t.Fatalf("Abandon: %v", err)
+	}
+	db.First(&review, "id = ?", review.ID)
+	if review.Decision != models.RiskPendingReview {
+		t.Fatalf("decision = %s, want %s", review.Decision, models.RiskPendingReview)
+	}
+
+	// The admin finds no debit in the wallet and rejects it
+	if _, err := service.RejectReview(review.ID, models.Admin{ID: uuid.NewString()}, "no debit in wallet"); err != nil {
+		t.Fatalf("RejectReview: %v", err)
+	}
+	var got models.Transaction
+	db.First(&got, "id = ?", history.ID)
+	if got.PaymentStatus != models.Failed {
+		t.Errorf("status = %s, want %s", got.PaymentStatus, models.Failed)
+	}
+	var credits int64
+	db.Model(&models.WalletCreditOutbox{}).Where("user_id = ?", user.ID).Count(&credits)
+	if credits != 0 {
+		t.Errorf("queued %d refunds for a debit that never landed, want none", credits)
+	}
+}
+
+func TestRetrySubmitOnlyForStaleUnsubmittedApprovals(t *testing.T) {
+	db := testutil.DB(t)
+	user := testutil.User(t, db)
+	service := NewWithdrawalService(db)
+	admin := models.Admin{ID: uuid.NewString()}
+
+	approved := func(reviewedAgo time.Duration, submitted bool) models.WithdrawalRisk {
+		review := assess(t, db, pendingWithdrawal(t, db, user), models.RiskApproved)
+		updates := map[string]interface{}{"reviewed_at": time.Now().Add(-reviewedAgo)}
+		if submitted {
+			updates["payout_submitted_at"] = time.Now()
+		}
+		if err := db.Model(&review).Updates(updates).Error; err != nil {
+			t.Fatalf("approve review: %v", err)
+		}
+		return review
+	}
+
+	tests := []struct {
+		name   string
+		review models.WithdrawalRisk
+		want   error
+	}{
+		{"still submitting", approved(time.Minute, false), ErrSubmitInProgress},
+		{"already submitted", approved(time.Hour, true), ErrNotAwaitingSubmit},
+		{"not approved", assess(t, db, pendingWithdrawal(t, db, user), models.RiskPendingReview), ErrNotAwaitingSubmit},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if _, err := service.RetrySubmit(tt.review.ID, admin); !errors.Is(err, tt.want) {
+				t.Errorf("RetrySubmit = %v, want %v", err, tt.want)
+			}
+		})
+	}
+}
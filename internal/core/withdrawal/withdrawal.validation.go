@@ -6,23 +6,39 @@ import (
 	"errors"
 	"regexp"
 	"strings"
+
+	"github.com/dblaq/buzzycash/external/gateway"
 )
 
 // Validation errors
 var (
 	ErrAmountTooShort          = errors.New("amount must be at least 100 naira")
 	ErrAccountNumberLength      = errors.New("account number must be exactly 10 digits")
+	ErrUnsupportedPaymentMethod = errors.New("unsupported payment method")
+	ErrAccountDetailsRequired   = errors.New("account_name and bank_code are required without a beneficiary_id")
+	ErrNicknameTooLong          = errors.New("nickname must be at most 100 characters")
+	ErrReviewNoteTooLong        = errors.New("note must be at most 500 characters")
+	ErrRejectReasonMissing      = errors.New("a note giving the reason is required to reject a withdrawal")
 
 )
 
 
 func (r *InitiateWithdrawalRequest) Validate() error {
-	if err := validateAccountNumber(r.AccountNumber); err != nil {
-		return err
+	// A beneficiary supplies the account, checked when it was saved
+	if r.BeneficiaryID == "" {
+		if err := validateAccountNumber(r.AccountNumber); err != nil {
+			return err
+		}
+		if strings.TrimSpace(r.AccountName) == "" || strings.TrimSpace(r.BankCode) == "" {
+			return ErrAccountDetailsRequired
+		}
 	}
 	if err := validateAmount(r.Amount); err != nil {
 		return err
 	}
+	if err := validatePaymentMethod(r.PaymentMethod); err != nil {
+		return err
+	}
 	return nil
 }
 
@@ -30,6 +46,41 @@ func (r *RetrieveAccountDetailsRequest) Validate() error {
 	if err := validateAccountNumber(r.AccountNumber); err != nil {
 		return err
 	}
+	if err := validatePaymentMethod(r.PaymentMethod); err != nil {
+		return err
+	}
+	return nil
+}
+
+func (r *CreateBeneficiaryRequest) Validate() error {
+	if err := validateAccountNumber(r.AccountNumber); err != nil {
+		return err
+	}
+	if err := validateNickname(r.Nickname); err != nil {
+		return err
+	}
+	if err := validatePaymentMethod(r.PaymentMethod); err != nil {
+		return err
+	}
+	return nil
+}
+
+func (r *UpdateBeneficiaryRequest) Validate() error {
+	return validateNickname(r.Nickname)
+}
+
+func (r *ReviewDecisionRequest) Validate() error {
+	r.Note = strings.TrimSpace(r.Note)
+	if len([]rune(r.Note)) > 500 {
+		return ErrReviewNoteTooLong
+	}
+	return nil
+}
+
+func validateNickname(nickname string) error {
+	if len([]rune(strings.TrimSpace(nickname))) > 100 {
+		return ErrNicknameTooLong
+	}
 	return nil
 }
 
@@ -47,6 +98,18 @@ func validateAccountNumber(accountNumber string) error {
 	}
 	return nil
 }
+// validatePaymentMethod checks an explicitly chosen payout gateway exists;
+// an empty one is filled in from the user's currency later.
+func validatePaymentMethod(method string) error {
+	if method == "" {
+		return nil
+	}
+	if _, err := gateway.GetProvider(method); err != nil {
+		return ErrUnsupportedPaymentMethod
+	}
+	return nil
+}
+
 func validateAmount(amount int64) error {
 	if amount < 100 {
 		return ErrAmountTooShort
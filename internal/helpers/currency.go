@@ -0,0 +1,32 @@
+package helpers
+
+import (
+	"strings"
+
+	"github.com/dblaq/buzzycash/internal/models"
+)
+
+// UserCountry is the ISO 3166 alpha-2 code of the country a user plays
+// from, taken from their country of residence and falling back to their
+// phone number's country code.
+func UserCountry(user models.User) string {
+	switch strings.ToLower(strings.TrimSpace(user.CountryOfResidence)) {
+	case "ghana", "gh", "gha":
+		return "GH"
+	case "nigeria", "ng", "nga":
+		return "NG"
+	}
+	if strings.HasPrefix(strings.TrimPrefix(user.PhoneNumber, "+"), "233") {
+		return "GH"
+	}
+	return "NG"
+}
+
+// UserCurrency is the currency a user deposits and withdraws in, that of
+// their country.
+func UserCurrency(user models.User) models.ECurrency {
+	if UserCountry(user) == "GH" {
+		return models.CED
+	}
+	return models.NGN
+}
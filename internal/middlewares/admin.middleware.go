@@ -0,0 +1,78 @@
+package middlewares
+
+import (
+	"fmt"
+	"net/http"
+	"strings"
+
+	"github.com/dblaq/buzzycash/internal/config"
+	"github.com/dblaq/buzzycash/internal/models"
+	"github.com/dblaq/buzzycash/internal/utils"
+	"github.com/gin-gonic/gin"
+	"github.com/golang-jwt/jwt/v5"
+)
+
+// AdminAuthMiddleware accepts only access tokens issued for the admin
+// audience, so a player's token can never reach an admin route.
+func AdminAuthMiddleware(ctx *gin.Context) {
+	authHeader := ctx.GetHeader("Authorization")
+	tokenString := strings.TrimPrefix(authHeader, "Bearer ")
+	if authHeader == "" || tokenString == authHeader {
+		abortWithError(ctx, "Invalid or missing authorization token")
+		return
+	}
+
+	token, err := jwt.Parse(tokenString, func(token *jwt.Token) (interface{}, error) {
+		if _, ok := token.Method.(*jwt.SigningMethodHMAC); !ok {
+			return nil, fmt.Errorf("unexpected signing method")
+		}
+		return []byte(config.AppConfig.JwtAccessSecret), nil
+	}, jwt.WithAudience(utils.AdminAudience), jwt.WithExpirationRequired())
+
+	if err != nil || !token.Valid {
+		abortWithError(ctx, "Invalid or expired token")
+		return
+	}
+
+	var blacklisted models.BlacklistedToken
+	if err := config.DB.First(&blacklisted, "token = ?", tokenString).Error; err == nil {
+		abortWithError(ctx, "Token blacklisted")
+		return
+	}
+
+	claims, ok := token.Claims.(jwt.MapClaims)
+	if !ok {
+		abortWithError(ctx, "Invalid token claims")
+		return
+	}
+
+	adminID, ok := claims["admin_id"].(string)
+	if !ok || adminID == "" {
+		abortWithError(ctx, "Invalid admin ID")
+		return
+	}
+
+	var admin models.Admin
+	if err := config.DB.Preload("Role.Permissions").First(&admin, "id = ?", adminID).Error; err != nil {
+		abortWithError(ctx, "Admin not found")
+		return
+	}
+
+	ctx.Set("currentAdmin", admin)
+	ctx.Next()
+}
+
+// RequirePermission lets the request through only when the current admin's
+// role grants at least one of perms. It must run after AdminAuthMiddleware.
+func RequirePermission(perms ...models.Permission) gin.HandlerFunc {
+	return func(ctx *gin.Context) {
+		admin := ctx.MustGet("currentAdmin").(models.Admin)
+		for _, perm := range perms {
+			if admin.Role.Has(perm) {
+				ctx.Next()
+				return
+			}
+		}
+		ctx.AbortWithStatusJSON(http.StatusForbidden, gin.H{"error": "You do not have permission to perform this action"})
+	}
+}
@@ -0,0 +1,56 @@
+package middlewares
+
+import (
+	"fmt"
+	"net/http"
+	"time"
+
+	"github.com/dblaq/buzzycash/internal/config"
+	"github.com/dblaq/buzzycash/internal/helpers"
+	"github.com/dblaq/buzzycash/internal/models"
+	"github.com/dblaq/buzzycash/internal/utils"
+	"github.com/gin-gonic/gin"
+)
+
+// Error codes the app uses to send the user to the right screen.
+const (
+	AgeUnverifiedCode = "AGE_UNVERIFIED"
+	UnderageCode      = "UNDERAGE"
+)
+
+// defaultMinimumAge applies to countries missing from MinimumAges.
+const defaultMinimumAge = 18
+
+// AgeVerificationMiddleware lets a user through to gaming and payment
+// routes only once they have given a date of birth showing they are of age
+// in their country. It must run after AuthMiddleware.
+func AgeVerificationMiddleware(ctx *gin.Context) {
+	user := ctx.MustGet("currentUser").(models.User)
+
+	if user.DateOfBirth == nil {
+		utils.ErrorWithCode(ctx, http.StatusForbidden, AgeUnverifiedCode, "Please add your date of birth to your profile to continue")
+		ctx.Abort()
+		return
+	}
+
+	minimum, ok := config.AppConfig.MinimumAges[helpers.UserCountry(user)]
+	if !ok {
+		minimum = defaultMinimumAge
+	}
+	if age(*user.DateOfBirth, time.Now()) < minimum {
+		utils.ErrorWithCode(ctx, http.StatusForbidden, UnderageCode, fmt.Sprintf("You must be at least %d to play", minimum))
+		ctx.Abort()
+		return
+	}
+
+	ctx.Next()
+}
+
+// age is the number of whole years from dob to now.
+func age(dob, now time.Time) int {
+	years := now.Year() - dob.Year()
+	if now.Month() < dob.Month() || (now.Month() == dob.Month() && now.Day() < dob.Day()) {
+		years--
+	}
+	return years
+}
@@ -4,6 +4,7 @@ import (
 	"fmt"
 	"github.com/dblaq/buzzycash/internal/config"
 	"github.com/dblaq/buzzycash/internal/models"
+	"github.com/dblaq/buzzycash/internal/utils"
 	"net/http"
 	"strings"
 	"time"
@@ -11,6 +12,9 @@ import (
 	"github.com/golang-jwt/jwt/v5"
 )
 
+// AccountSuspendedCode tells the app the user's account has been suspended.
+const AccountSuspendedCode = "ACCOUNT_SUSPENDED"
+
 func AuthMiddleware(ctx *gin.Context) {
 	// Extract token
 	authHeader := ctx.GetHeader("Authorization")
@@ -53,6 +57,16 @@ func AuthMiddleware(ctx *gin.Context) {
 		return
 	}
 
+	// Admin tokens share the signing secret; keep them off player routes
+	if aud, err := claims.GetAudience(); err == nil {
+		for _, a := range aud {
+			if a == utils.AdminAudience || a == utils.AdminRefreshAudience {
+				abortWithError(ctx, "Invalid token audience")
+				return
+			}
+		}
+	}
+
 	// Get user
 	userID, ok := claims["user_id"].(string)
 	if !ok || userID == "" {
@@ -66,6 +80,24 @@ func AuthMiddleware(ctx *gin.Context) {
 		return
 	}
 
+	if !user.IsActive {
+		utils.ErrorWithCode(ctx, http.StatusForbidden, AccountSuspendedCode, "Your account has been suspended, please contact support")
+		ctx.Abort()
+		return
+	}
+
+	// Tokens issued before an admin revoked the user's sessions. iat only
+	// has second precision, so a token from the revocation's own second is
+	// treated as issued before it
+	var revocation models.SessionRevocation
+	if err := config.DB.First(&revocation, "user_id = ?", userID).Error; err == nil {
+		issuedAt, err := claims.GetIssuedAt()
+		if err != nil || issuedAt == nil || !issuedAt.Time.After(revocation.RevokedAt.Truncate(time.Second)) {
+			abortWithError(ctx, "Session revoked, please log in again")
+			return
+		}
+	}
+
 	ctx.Set("currentUser", user)
 	ctx.Next()
 }
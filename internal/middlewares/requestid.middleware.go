@@ -0,0 +1,21 @@
+package middlewares
+
+import (
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+)
+
+const RequestIDHeader = "X-Request-ID"
+
+// RequestIDMiddleware tags every request with an ID, taken from the
+// X-Request-ID header when the caller sends one, and echoes it back so
+// client reports can be matched to server logs and audit entries.
+func RequestIDMiddleware(ctx *gin.Context) {
+	id := ctx.GetHeader(RequestIDHeader)
+	if id == "" || len(id) > 100 {
+		id = uuid.NewString()
+	}
+	ctx.Set("requestID", id)
+	ctx.Header(RequestIDHeader, id)
+	ctx.Next()
+}
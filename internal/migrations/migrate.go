@@ -3,10 +3,15 @@ package migrations
 import (
 	"gorm.io/gorm"
 	"log"
-	// "github.com/dblaq/buzzycash/internal/models"
+	"time"
+	"github.com/dblaq/buzzycash/internal/models"
 )
 
 func AutoMigrate(db *gorm.DB) {
+	migrateDateOfBirth(db)
+	migrateTransactions(db)
+	migrateWithdrawalRisks(db)
+
 	err := db.AutoMigrate(
 		// &models.User{},
 		// &models.ReferralWallet{},
@@ -16,15 +21,140 @@ func AutoMigrate(db *gorm.DB) {
 		// &models.RefreshToken{},
 		// &models.Transaction{},
 		// &models.UserOtpSecurity{},
-		// &models.Role{},
+		&models.Role{},
 		// &models.RefreshToken{},
-		// &models.Admin{},
+		&models.Admin{},
 		// &models.BlacklistedToken{},
+		&models.LedgerAccount{},
+		&models.JournalEntry{},
+		&models.Posting{},
+		&models.WebhookRejection{},
+		&models.WebhookEvent{},
+		&models.WalletCreditOutbox{},
+		&models.ExchangeRate{},
+		&models.SavedCard{},
+		&models.VirtualAccount{},
+		&models.Beneficiary{},
+		&models.KycVerification{},
+		&models.VelocityEvent{},
+		&models.VelocityOverride{},
+		&models.GamingLimit{},
+		&models.GamingExclusion{},
+		&models.RealityCheck{},
+		&models.WithdrawalRisk{},
+		&models.RolePermission{},
+		&models.AdminRefreshToken{},
+		&models.Game{},
+		&models.GameEvent{},
+		&models.AccountEvent{},
+		&models.SessionRevocation{},
+		&models.AuditEvent{},
 	)
 
 	if err != nil {
 		log.Fatalf("Database migration failed: %v", err)
 	}
 
+	protectAuditEvents(db)
+
 	log.Println("✅ Database migration completed.")
-}
\ No newline at end of file
+}
+
+// protectAuditEvents makes audit_events append-only: any UPDATE or DELETE
+// raises an error, whoever runs it.
+func protectAuditEvents(db *gorm.DB) {
+	statements := []string{
+		`CREATE OR REPLACE FUNCTION audit_events_append_only() RETURNS trigger AS $$
+		BEGIN
+			RAISE EXCEPTION 'audit_events is append-only';
+		END;
+		$$ LANGUAGE plpgsql`,
+		`DROP TRIGGER IF EXISTS audit_events_append_only ON audit_events`,
+		`CREATE TRIGGER audit_events_append_only BEFORE UPDATE OR DELETE ON audit_events
+		FOR EACH ROW EXECUTE FUNCTION audit_events_append_only()`,
+	}
+	for _, stmt := range statements {
+		if err := db.Exec(stmt).Error; err != nil {
+			log.Fatalf("Database migration failed: %v", err)
+		}
+	}
+}
+
+// migrateTransactions adds the columns later features need to the
+// transactions table, which predates AutoMigrate.
+func migrateTransactions(db *gorm.DB) {
+	m := db.Migrator()
+	if !m.HasTable(&models.Transaction{}) {
+		return
+	}
+	if !m.HasColumn(&models.Transaction{}, "LastCheckedAt") {
+		if err := m.AddColumn(&models.Transaction{}, "LastCheckedAt"); err != nil {
+			log.Fatalf("Database migration failed: %v", err)
+		}
+	}
+	if !m.HasIndex(&models.Transaction{}, "LastCheckedAt") {
+		if err := m.CreateIndex(&models.Transaction{}, "LastCheckedAt"); err != nil {
+			log.Fatalf("Database migration failed: %v", err)
+		}
+	}
+	// Fails if a gateway reference was already recorded twice in a
+	// category; those rows must be merged by hand first.
+	if !m.HasIndex(&models.Transaction{}, "idx_transactions_reference_category") {
+		if err := m.CreateIndex(&models.Transaction{}, "idx_transactions_reference_category"); err != nil {
+			log.Fatalf("Database migration failed: %v", err)
+		}
+	}
+}
+
+// migrateWithdrawalRisks adds payout_submitted_at and marks approvals made
+// before it existed as submitted, since approving submitted the payout in
+// the same request.
+func migrateWithdrawalRisks(db *gorm.DB) {
+	m := db.Migrator()
+	if !m.HasTable(&models.WithdrawalRisk{}) || m.HasColumn(&models.WithdrawalRisk{}, "PayoutSubmittedAt") {
+		return
+	}
+	if err := m.AddColumn(&models.WithdrawalRisk{}, "PayoutSubmittedAt"); err != nil {
+		log.Fatalf("Database migration failed: %v", err)
+	}
+	if err := db.Model(&models.WithdrawalRisk{}).
+		Where("decision = ?", models.RiskApproved).
+		Update("payout_submitted_at", gorm.Expr("reviewed_at")).Error; err != nil {
+		log.Fatalf("Database migration failed: %v", err)
+	}
+}
+
+// migrateDateOfBirth turns users.date_of_birth from free text into a date.
+// Values that are not YYYY-MM-DD dates are cleared, so those users are
+// asked for their date of birth again.
+func migrateDateOfBirth(db *gorm.DB) {
+	var dataType string
+	if err := db.Raw(`SELECT data_type FROM information_schema.columns
+		WHERE table_name = 'users' AND column_name = 'date_of_birth'`).Scan(&dataType).Error; err != nil {
+		log.Fatalf("Database migration failed: %v", err)
+	}
+	if dataType == "" || dataType == "date" {
+		return
+	}
+
+	var rows []struct {
+		ID          string
+		DateOfBirth string
+	}
+	if err := db.Raw("SELECT id, date_of_birth FROM users WHERE date_of_birth IS NOT NULL").Scan(&rows).Error; err != nil {
+		log.Fatalf("Database migration failed: %v", err)
+	}
+	for _, row := range rows {
+		if _, err := time.Parse("2006-01-02", row.DateOfBirth); err == nil {
+			continue
+		}
+		if err := db.Exec("UPDATE users SET date_of_birth = NULL WHERE id = ?", row.ID).Error; err != nil {
+			log.Fatalf("Database migration failed: %v", err)
+		}
+	}
+
+	if err := db.Exec("ALTER TABLE users ALTER COLUMN date_of_birth TYPE date USING date_of_birth::date").Error; err != nil {
+		log.Fatalf("Database migration failed: %v", err)
+	}
+	log.Println("✅ Converted users.date_of_birth to date.")
+}
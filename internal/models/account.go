@@ -0,0 +1,38 @@
+package models
+
+import (
+	"time"
+)
+
+type AccountAction string
+
+const (
+	AccountSuspended         AccountAction = "SUSPEND"
+	AccountReactivated       AccountAction = "REACTIVATE"
+	AccountVerificationReset AccountAction = "RESET_VERIFICATION"
+	AccountSessionsRevoked   AccountAction = "REVOKE_SESSIONS"
+)
+
+// AccountEvent records an admin action on a player's account.
+type AccountEvent struct {
+	ID        string        `gorm:"type:uuid;default:gen_random_uuid();primaryKey"`
+	UserID    string        `gorm:"type:uuid;not null;index"`
+	Action    AccountAction `gorm:"size:30;not null"`
+	Reason    string        `gorm:"size:500"`
+	Details   JSONB         `gorm:"type:jsonb"`
+	AdminID   *string       `gorm:"type:uuid"`
+	CreatedAt time.Time
+
+	User User `gorm:"constraint:OnDelete:CASCADE;"`
+}
+
+// SessionRevocation invalidates every token issued to a user before
+// RevokedAt, so a forced logout also ends sessions whose access tokens have
+// not expired yet.
+type SessionRevocation struct {
+	UserID    string `gorm:"type:uuid;primaryKey"`
+	RevokedAt time.Time
+	UpdatedAt time.Time
+
+	User User `gorm:"constraint:OnDelete:CASCADE;"`
+}
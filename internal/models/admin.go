@@ -15,4 +15,17 @@ type Admin struct {
 	RoleID         *string   `gorm:"type:uuid"`
 	
 	Role *Role `gorm:"constraint:OnDelete:SET NULL;"`
-}
\ No newline at end of file
+}
+
+// AdminRefreshToken is an admin's current refresh token; refreshing
+// replaces it, so each token can be used once.
+type AdminRefreshToken struct {
+	ID        string    `gorm:"type:uuid;default:gen_random_uuid();primaryKey"`
+	AdminID   string    `gorm:"type:uuid;uniqueIndex"`
+	Token     string    `gorm:"type:text"`
+	ExpireAt  time.Time
+	CreatedAt time.Time
+	UpdatedAt time.Time
+
+	Admin Admin `gorm:"constraint:OnDelete:CASCADE;"`
+}
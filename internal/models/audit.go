@@ -0,0 +1,36 @@
+package models
+
+import (
+	"time"
+)
+
+type AuditActorType string
+
+const (
+	AuditActorUser    AuditActorType = "USER"
+	AuditActorAdmin   AuditActorType = "ADMIN"
+	AuditActorSystem  AuditActorType = "SYSTEM"
+	AuditActorWebhook AuditActorType = "WEBHOOK"
+)
+
+// AuditEvent is one entry in the append-only audit log. Entries form a hash
+// chain: Hash covers the entry's fields and PrevHash, the hash of the entry
+// before it, so editing or removing an entry breaks every hash after it.
+// The table rejects UPDATE and DELETE at the database level.
+type AuditEvent struct {
+	ID         string         `gorm:"type:uuid;default:gen_random_uuid();primaryKey"`
+	Sequence   int64          `gorm:"not null;uniqueIndex"`
+	ActorType  AuditActorType `gorm:"size:20;not null;index:idx_audit_actor"`
+	ActorID    string         `gorm:"size:255;index:idx_audit_actor"` // provider name for webhooks
+	Action     string         `gorm:"size:100;not null;index"`
+	TargetType string         `gorm:"size:50;index:idx_audit_target"`
+	TargetID   string         `gorm:"size:255;index:idx_audit_target"`
+	Before     JSONB          `gorm:"type:jsonb"`
+	After      JSONB          `gorm:"type:jsonb"`
+	IPAddress  string         `gorm:"size:64"`
+	UserAgent  string         `gorm:"size:500"`
+	RequestID  string         `gorm:"size:100;index"`
+	PrevHash   string         `gorm:"size:64"`
+	Hash       string         `gorm:"size:64;not null"`
+	CreatedAt  time.Time      `gorm:"not null;index"`
+}
@@ -0,0 +1,24 @@
+package models
+
+import (
+	"time"
+)
+
+// Beneficiary is a payout account a user saved so they can withdraw to it
+// without re-entering its details. AccountName is the name the bank
+// returned, not what the user typed.
+type Beneficiary struct {
+	ID            string         `gorm:"type:uuid;default:gen_random_uuid();primaryKey"`
+	UserID        string         `gorm:"type:uuid;not null;uniqueIndex:idx_beneficiary_account"`
+	Provider      EPaymentMethod `gorm:"size:50;not null;uniqueIndex:idx_beneficiary_account"` // payout gateway the account was resolved with
+	BankCode      string         `gorm:"size:50;not null;uniqueIndex:idx_beneficiary_account"`
+	BankName      string         `gorm:"size:255"`
+	AccountNumber string         `gorm:"size:20;not null;uniqueIndex:idx_beneficiary_account"`
+	AccountName   string         `gorm:"size:255;not null"`
+	Nickname      string         `gorm:"size:100"`
+	VerifiedAt    time.Time
+	CreatedAt     time.Time
+	UpdatedAt     time.Time
+
+	User User `gorm:"constraint:OnDelete:CASCADE;"`
+}
@@ -0,0 +1,18 @@
+package models
+
+import (
+	"time"
+)
+
+// ExchangeRate converts BaseCurrency into QuoteCurrency: one unit of base is
+// worth Rate units of quote. Rates are maintained by admins and only used
+// when money crosses currencies, which is rare.
+type ExchangeRate struct {
+	ID            string    `gorm:"type:uuid;default:gen_random_uuid();primaryKey"`
+	BaseCurrency  ECurrency `gorm:"size:10;not null;uniqueIndex:idx_exchange_rate_pair"`
+	QuoteCurrency ECurrency `gorm:"size:10;not null;uniqueIndex:idx_exchange_rate_pair"`
+	Rate          float64   `gorm:"not null"`
+	UpdatedBy     *string   `gorm:"type:uuid"`
+	CreatedAt     time.Time
+	UpdatedAt     time.Time
+}
@@ -0,0 +1,53 @@
+package models
+
+import (
+	"time"
+)
+
+type GameState string
+
+const (
+	GameCreated GameState = "CREATED"
+	GameRunning GameState = "RUNNING"
+	GameStopped GameState = "STOPPED"
+)
+
+type GameAction string
+
+const (
+	GameActionCreate GameAction = "CREATE"
+	GameActionStart  GameAction = "START"
+	GameActionStop   GameAction = "STOP"
+)
+
+// Game is our copy of a game in the gaming provider's catalogue.
+// ProviderGameID is the ID the provider knows it by.
+type Game struct {
+	ID                   string    `gorm:"type:uuid;default:gen_random_uuid();primaryKey"`
+	ProviderGameID       string    `gorm:"size:255;not null;uniqueIndex"`
+	Name                 string    `gorm:"size:255;not null"`
+	Amount               int64     `gorm:"not null"`
+	DrawInterval         int       `gorm:"not null"` // minutes
+	WinningPercentage    float64   `gorm:"not null"`
+	MaxWinners           int       `gorm:"not null"`
+	StartDate            time.Time `gorm:"type:date;not null"`
+	WeightedDistribution bool      `gorm:"default:false"`
+	Status               GameState `gorm:"size:20;not null;index"`
+	CreatedBy            *string   `gorm:"type:uuid"`
+	CreatedAt            time.Time
+	UpdatedAt            time.Time
+
+	Events []GameEvent `gorm:"foreignKey:GameID"`
+}
+
+// GameEvent records an admin creating, starting or stopping a game.
+type GameEvent struct {
+	ID        string     `gorm:"type:uuid;default:gen_random_uuid();primaryKey"`
+	GameID    string     `gorm:"type:uuid;not null;index"`
+	Action    GameAction `gorm:"size:20;not null"`
+	AdminID   *string    `gorm:"type:uuid"`
+	Reason    string     `gorm:"size:500"`
+	CreatedAt time.Time
+
+	Game Game `gorm:"constraint:OnDelete:CASCADE;"`
+}
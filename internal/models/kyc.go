@@ -0,0 +1,53 @@
+package models
+
+import (
+	"time"
+)
+
+// KYC tiers. Every verified user starts at KycTierPhone; each tier above it
+// needs an approved KycVerification and raises the user's limits.
+const (
+	KycTierPhone = 0
+	KycTierBVN   = 1
+	KycTierNIN   = 2
+	MaxKycTier   = KycTierNIN
+)
+
+type KycIDType string
+
+const (
+	KycBVN KycIDType = "BVN"
+	KycNIN KycIDType = "NIN"
+)
+
+type KycStatus string
+
+const (
+	KycApproved     KycStatus = "APPROVED"
+	KycRejected     KycStatus = "REJECTED"
+	KycManualReview KycStatus = "MANUAL_REVIEW" // waiting in the admin review queue
+)
+
+// KycVerification is one attempt to reach a tier. A user's tier is the
+// highest tier among their approved verifications. Only the last four
+// digits of the ID are kept.
+type KycVerification struct {
+	ID                string    `gorm:"type:uuid;default:gen_random_uuid();primaryKey"`
+	UserID            string    `gorm:"type:uuid;not null;index"`
+	Tier              int       `gorm:"not null"`
+	IDType            KycIDType `gorm:"size:10;not null"`
+	MaskedIDNumber    string    `gorm:"size:20"`
+	SelfiePath        string    `gorm:"size:255"`
+	Provider          string    `gorm:"size:50"`
+	ProviderReference string    `gorm:"size:255"`
+	RegisteredName    string    `gorm:"size:255"` // name the registry holds for the ID
+	MatchScore        float64
+	Status            KycStatus `gorm:"size:20;not null;index"`
+	Reason            string    `gorm:"size:500"`
+	ReviewedBy        *string   `gorm:"type:uuid"`
+	ReviewedAt        *time.Time
+	CreatedAt         time.Time
+	UpdatedAt         time.Time
+
+	User User `gorm:"constraint:OnDelete:CASCADE;"`
+}
@@ -0,0 +1,66 @@
+package models
+
+import (
+	"errors"
+	"time"
+
+	"gorm.io/gorm"
+)
+
+type LedgerAccountType string
+type PostingDirection string
+
+const (
+	AssetAccount     LedgerAccountType = "ASSET"
+	LiabilityAccount LedgerAccountType = "LIABILITY"
+	RevenueAccount   LedgerAccountType = "REVENUE"
+	ExpenseAccount   LedgerAccountType = "EXPENSE"
+)
+
+const (
+	DebitPosting  PostingDirection = "DEBIT"
+	CreditPosting PostingDirection = "CREDIT"
+)
+
+var ErrImmutableLedger = errors.New("ledger records are immutable")
+
+type LedgerAccount struct {
+	ID        string            `gorm:"type:uuid;default:gen_random_uuid();primaryKey"`
+	Code      string            `gorm:"size:255;uniqueIndex;not null"`
+	Name      string            `gorm:"size:255"`
+	Type      LedgerAccountType `gorm:"size:20;not null"`
+	UserID    *string           `gorm:"type:uuid;index"`
+	Currency  ECurrency         `gorm:"size:10;default:NGN"`
+	CreatedAt time.Time         `gorm:"default:current_timestamp"`
+}
+
+type JournalEntry struct {
+	ID            string    `gorm:"type:uuid;default:gen_random_uuid();primaryKey"`
+	TransactionID *string   `gorm:"type:uuid;index"`
+	Reference     string    `gorm:"size:255;uniqueIndex;not null"`
+	Description   string    `gorm:"size:500"`
+	PostedAt      time.Time `gorm:"index"`
+	CreatedAt     time.Time `gorm:"default:current_timestamp"`
+
+	Postings []Posting `gorm:"foreignKey:JournalEntryID"`
+}
+
+type Posting struct {
+	ID             string           `gorm:"type:uuid;default:gen_random_uuid();primaryKey"`
+	JournalEntryID string           `gorm:"type:uuid;index;not null"`
+	AccountID      string           `gorm:"type:uuid;index;not null"`
+	Direction      PostingDirection `gorm:"size:10;not null"`
+	Amount         int64            `gorm:"not null"`
+	Currency       ECurrency        `gorm:"size:10;default:NGN"`
+	PostedAt       time.Time        `gorm:"index"`
+	CreatedAt      time.Time        `gorm:"default:current_timestamp"`
+
+	Account LedgerAccount `gorm:"foreignKey:AccountID"`
+}
+
+// Journal entries and postings are append-only; corrections are made with
+// a new reversing entry, never by editing history.
+func (j *JournalEntry) BeforeUpdate(tx *gorm.DB) error { return ErrImmutableLedger }
+func (j *JournalEntry) BeforeDelete(tx *gorm.DB) error { return ErrImmutableLedger }
+func (p *Posting) BeforeUpdate(tx *gorm.DB) error      { return ErrImmutableLedger }
+func (p *Posting) BeforeDelete(tx *gorm.DB) error      { return ErrImmutableLedger }
@@ -0,0 +1,51 @@
+package models
+
+import (
+	"time"
+)
+
+type OutboxStatus string
+
+const (
+	OutboxPending      OutboxStatus = "PENDING"
+	OutboxProcessing   OutboxStatus = "PROCESSING"
+	OutboxDispatched   OutboxStatus = "DISPATCHED"
+	OutboxFailed       OutboxStatus = "FAILED"
+	OutboxDeadLettered OutboxStatus = "DEAD_LETTERED"
+	OutboxCancelled    OutboxStatus = "CANCELLED"
+	// OutboxNeedsReview is a credit whose dispatch was interrupted or ended
+	// in an ambiguous error. The provider cannot tell us whether it landed,
+	// so it is not retried until an admin resolves it.
+	OutboxNeedsReview OutboxStatus = "NEEDS_REVIEW"
+)
+
+// OutboxDirection says whether an outbox row credits or debits the wallet.
+type OutboxDirection string
+
+const (
+	OutboxCredit OutboxDirection = "CREDIT"
+	OutboxDebit  OutboxDirection = "DEBIT"
+)
+
+// WalletCreditOutbox is a gaming wallet credit written in the same DB
+// transaction as the deposit it pays out. The dispatcher performs the credit
+// afterwards, sending IdempotencyKey with each attempt. Only attempts that
+// certainly did not reach the wallet are retried.
+// Debits that take back a reversed deposit travel the same way.
+type WalletCreditOutbox struct {
+	ID               string          `gorm:"type:uuid;default:gen_random_uuid();primaryKey"`
+	IdempotencyKey   string          `gorm:"size:255;uniqueIndex;not null"`
+	Direction        OutboxDirection `gorm:"size:10;not null;default:CREDIT"`
+	TransactionID    string          `gorm:"type:uuid;index"`
+	UserID           string          `gorm:"type:uuid;index"`
+	Username         string          `gorm:"size:255;not null"`
+	Amount           float64         `gorm:"not null"`
+	Status           OutboxStatus    `gorm:"size:20;index;default:PENDING"`
+	Attempts         int             `gorm:"default:0"`
+	NextAttemptAt    time.Time       `gorm:"index"`
+	LastError        string          `gorm:"type:text"`
+	ProviderResponse string          `gorm:"type:text"`
+	DispatchedAt     *time.Time
+	CreatedAt        time.Time `gorm:"default:current_timestamp"`
+	UpdatedAt        time.Time
+}
@@ -0,0 +1,73 @@
+package models
+
+import (
+	"time"
+)
+
+type GamingLimitType string
+
+const (
+	GamingDepositLimit GamingLimitType = "DEPOSIT"
+	GamingSpendLimit   GamingLimitType = "SPEND" // ticket purchases
+)
+
+type GamingLimitPeriod string
+
+const (
+	GamingDaily   GamingLimitPeriod = "DAILY"
+	GamingWeekly  GamingLimitPeriod = "WEEKLY"
+	GamingMonthly GamingLimitPeriod = "MONTHLY"
+)
+
+// GamingLimit is a limit a user has set on themselves, in naira. Lowering
+// it applies at once; raising or removing it is held in PendingAmount until
+// PendingFrom so it cannot be undone in the heat of the moment. A
+// PendingAmount of 0 removes the limit.
+type GamingLimit struct {
+	ID            string            `gorm:"type:uuid;default:gen_random_uuid();primaryKey"`
+	UserID        string            `gorm:"type:uuid;not null;uniqueIndex:idx_gaming_limit"`
+	Type          GamingLimitType   `gorm:"size:20;not null;uniqueIndex:idx_gaming_limit"`
+	Period        GamingLimitPeriod `gorm:"size:20;not null;uniqueIndex:idx_gaming_limit"`
+	Amount        int64             `gorm:"not null"`
+	PendingAmount *int64
+	PendingFrom   *time.Time
+	CreatedAt     time.Time
+	UpdatedAt     time.Time
+
+	User User `gorm:"constraint:OnDelete:CASCADE;"`
+}
+
+type ExclusionKind string
+
+const (
+	CoolOff       ExclusionKind = "COOL_OFF"
+	SelfExclusion ExclusionKind = "SELF_EXCLUSION"
+)
+
+// GamingExclusion is a break from play the user asked for. It cannot be
+// ended early; EndsAt is nil for a permanent self-exclusion.
+type GamingExclusion struct {
+	ID        string        `gorm:"type:uuid;default:gen_random_uuid();primaryKey"`
+	UserID    string        `gorm:"type:uuid;not null;index"`
+	Kind      ExclusionKind `gorm:"size:20;not null"`
+	StartsAt  time.Time     `gorm:"not null"`
+	EndsAt    *time.Time    `gorm:"index"`
+	Reason    string        `gorm:"size:500"`
+	CreatedAt time.Time
+
+	User User `gorm:"constraint:OnDelete:CASCADE;"`
+}
+
+// RealityCheck holds a user's reminder interval and their current play
+// session. A session starts with the first play after a spell of
+// inactivity.
+type RealityCheck struct {
+	UserID           string `gorm:"type:uuid;primaryKey"`
+	IntervalMinutes  int    `gorm:"not null;default:0"` // 0 turns reminders off
+	SessionStartedAt *time.Time
+	LastActivityAt   *time.Time
+	LastReminderAt   *time.Time
+	UpdatedAt        time.Time
+
+	User User `gorm:"constraint:OnDelete:CASCADE;"`
+}
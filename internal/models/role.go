@@ -1,13 +1,74 @@
 package models
 
 import (
+	"time"
+)
+
+// Permission names one thing an admin role may do.
+type Permission string
 
+const (
+	PermAll               Permission = "*" // every permission; held by the super-admin role
+	PermAdminsManage      Permission = "admins:manage"
+	PermGamesManage       Permission = "games:manage"
+	PermFinanceRead       Permission = "finance:read"
+	PermFxManage          Permission = "fx:manage"
+	PermWebhooksManage    Permission = "webhooks:manage"
+	PermOutboxManage      Permission = "outbox:manage"
+	PermKycReview         Permission = "kyc:review"
+	PermWithdrawalsReview Permission = "withdrawals:review"
+	PermPayoutsApprove    Permission = "payouts:approve"
+	PermUsersManage       Permission = "users:manage"
+	PermAuditRead         Permission = "audit:read"
 )
 
+// Permissions lists every grantable permission.
+var Permissions = []Permission{
+	PermAll,
+	PermAdminsManage,
+	PermGamesManage,
+	PermFinanceRead,
+	PermFxManage,
+	PermWebhooksManage,
+	PermOutboxManage,
+	PermKycReview,
+	PermWithdrawalsReview,
+	PermPayoutsApprove,
+	PermUsersManage,
+	PermAuditRead,
+}
+
+// SuperAdminRole is the role created for the bootstrap super admin.
+const SuperAdminRole = "super_admin"
+
 type Role struct {
 	ID          string `gorm:"type:uuid;default:gen_random_uuid();primaryKey"`
-	Name        string    `gorm:"size:255;uniqueIndex"`
-	Description string    `gorm:"size:255"`
-	
-	Admins []Admin `gorm:"foreignKey:RoleID"`
-}
\ No newline at end of file
+	Name        string `gorm:"size:255;uniqueIndex"`
+	Description string `gorm:"size:255"`
+
+	Admins      []Admin          `gorm:"foreignKey:RoleID"`
+	Permissions []RolePermission `gorm:"foreignKey:RoleID"`
+}
+
+// Has reports whether the role grants a permission. Permissions must be
+// preloaded.
+func (r *Role) Has(p Permission) bool {
+	if r == nil {
+		return false
+	}
+	for _, granted := range r.Permissions {
+		if granted.Permission == PermAll || granted.Permission == p {
+			return true
+		}
+	}
+	return false
+}
+
+type RolePermission struct {
+	ID         string     `gorm:"type:uuid;default:gen_random_uuid();primaryKey"`
+	RoleID     string     `gorm:"type:uuid;not null;uniqueIndex:idx_role_permission"`
+	Permission Permission `gorm:"size:50;not null;uniqueIndex:idx_role_permission"`
+	CreatedAt  time.Time
+
+	Role Role `gorm:"constraint:OnDelete:CASCADE;"`
+}
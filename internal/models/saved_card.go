@@ -0,0 +1,23 @@
+package models
+
+import (
+	"time"
+)
+
+// SavedCard is a card tokenized by a payment gateway during checkout. The
+// token is only ever sent back to the gateway that issued it.
+type SavedCard struct {
+	ID          string         `gorm:"type:uuid;default:gen_random_uuid();primaryKey"`
+	UserID      string         `gorm:"type:uuid;not null;uniqueIndex:idx_saved_card_token"`
+	Provider    EPaymentMethod `gorm:"size:50;not null;uniqueIndex:idx_saved_card_token"`
+	Token       string         `gorm:"size:255;not null;uniqueIndex:idx_saved_card_token"`
+	MaskedPan   string         `gorm:"size:32"`
+	Brand       string         `gorm:"size:50"`
+	ExpiryMonth string         `gorm:"size:2"`
+	ExpiryYear  string         `gorm:"size:4"`
+	LastUsedAt  *time.Time
+	CreatedAt   time.Time
+	UpdatedAt   time.Time
+
+	User User `gorm:"constraint:OnDelete:CASCADE;"`
+}
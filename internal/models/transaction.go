@@ -5,6 +5,7 @@ import (
 	"database/sql/driver"
 	"encoding/json"
 	"fmt"
+	"strings"
 )
 
 type EPaymentStatus string
@@ -34,6 +35,30 @@ const (
 	CED ECurrency = "CED"
 )
 
+// SupportedCurrencies are the currencies users hold wallets in.
+var SupportedCurrencies = []ECurrency{NGN, CED}
+
+// ParseCurrency reads a currency code case-insensitively, accepting the ISO
+// code GHS for cedis.
+func ParseCurrency(code string) (ECurrency, bool) {
+	switch strings.ToUpper(strings.TrimSpace(code)) {
+	case "NGN":
+		return NGN, true
+	case "CED", "GHS":
+		return CED, true
+	}
+	return "", false
+}
+
+// ISOCode is the ISO 4217 code payment gateways expect; Ghana cedis are
+// stored as CED but settled as GHS.
+func (c ECurrency) ISOCode() string {
+	if c == CED {
+		return "GHS"
+	}
+	return string(c)
+}
+
 const (
 	Credit ETransactionType = "CREDIT"
 	Debit  ETransactionType = "DEBIT"
@@ -44,6 +69,8 @@ const (
 	Nomba  EPaymentMethod = "NOMBA"
 	Wallet EPaymentMethod = "WALLET"
 	Flutterwave EPaymentMethod = "FLUTTERWAVE"
+	Paystack EPaymentMethod = "PAYSTACK"
+	Hubtel   EPaymentMethod = "HUBTEL"
 )
 
 const (
@@ -62,7 +89,9 @@ type Transaction struct {
 	
 	Amount               int64 
 	TransactionReference string  `gorm:"size:255;uniqueIndex"`
-	Reference             string  `gorm:"size:255"`
+	// Reference is the gateway's reference; each is recorded at most once
+	// per category.
+	Reference             string  `gorm:"size:255;uniqueIndex:idx_transactions_reference_category,where:reference <> ''"`
 	Metadata             JSONB
 	CustomerEmail        string `gorm:"size:255"`
 	PaymentStatus        EPaymentStatus
@@ -77,7 +106,10 @@ type Transaction struct {
 	CreatedAt            time.Time `gorm:"default:current_timestamp"`
 	UpdatedAt            time.Time
 	PaymentMethod        EPaymentMethod
-	Category             TransactionCategory
+	Category             TransactionCategory `gorm:"uniqueIndex:idx_transactions_reference_category"`
+	// LastCheckedAt is when a requery job last asked the gateway about a
+	// PENDING deposit or withdrawal.
+	LastCheckedAt        *time.Time `gorm:"index"`
 	
 	User            User               `gorm:"constraint:OnDelete:CASCADE;"`
 	// TicketPurchase  []TicketPurchase  `gorm:"foreignKey:TransactionHistoryID"`
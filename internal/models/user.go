@@ -18,7 +18,7 @@ type User struct {
 	PhoneNumber        string     `gorm:"size:255;uniqueIndex"`
 	Email              string     `gorm:"size:255;uniqueIndex"`
 	Username           string     `gorm:"size:255;uniqueIndex"`
-	DateOfBirth        string     `gorm:"size:255"`
+	DateOfBirth        *time.Time `gorm:"type:date"`
 	Password           string     `gorm:"size:255"`
 	ProfilePicture     string     `gorm:"size:255"`
 	IsProfileCreated   bool       `gorm:"default:false"`
@@ -42,4 +42,14 @@ type User struct {
 	TicketPurchases    []TicketPurchase      `gorm:"foreignKey:UserID"`
 	GameHistories      []GameHistory         `gorm:"foreignKey:UserID"`
 	OtpSecurity        *UserOtpSecurity      `gorm:"foreignKey:UserID"`
+	VirtualAccount     *VirtualAccount       `gorm:"foreignKey:UserID"`
+}
+
+// DateOfBirthString formats the date of birth as YYYY-MM-DD, or "" when it
+// has not been given.
+func (u User) DateOfBirthString() string {
+	if u.DateOfBirth == nil {
+		return ""
+	}
+	return u.DateOfBirth.Format("2006-01-02")
 }
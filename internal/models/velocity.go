@@ -0,0 +1,48 @@
+package models
+
+import (
+	"time"
+)
+
+type VelocityAction string
+
+const (
+	VelocityDeposit    VelocityAction = "DEPOSIT"
+	VelocityTicket     VelocityAction = "TICKET"
+	VelocityWithdrawal VelocityAction = "WITHDRAWAL"
+)
+
+type VelocityWindow string
+
+const (
+	VelocityHour VelocityWindow = "HOUR"
+	VelocityDay  VelocityWindow = "DAY"
+)
+
+// VelocityEvent is one attempt counted towards velocity limits. Amount is
+// in naira so attempts in different currencies add up.
+type VelocityEvent struct {
+	ID        string         `gorm:"type:uuid;default:gen_random_uuid();primaryKey"`
+	UserID    string         `gorm:"type:uuid;not null;index:idx_velocity_user"`
+	DeviceID  string         `gorm:"size:255;index:idx_velocity_device"`
+	Action    VelocityAction `gorm:"size:20;not null;index:idx_velocity_user;index:idx_velocity_device"`
+	Amount    int64
+	CreatedAt time.Time `gorm:"index:idx_velocity_user;index:idx_velocity_device"`
+}
+
+// VelocityOverride replaces one of the default limits for a user. A nil
+// field keeps the default; zero lifts the limit.
+type VelocityOverride struct {
+	ID        string         `gorm:"type:uuid;default:gen_random_uuid();primaryKey"`
+	UserID    string         `gorm:"type:uuid;not null;uniqueIndex:idx_velocity_override"`
+	Action    VelocityAction `gorm:"size:20;not null;uniqueIndex:idx_velocity_override"`
+	Window    VelocityWindow `gorm:"size:10;not null;uniqueIndex:idx_velocity_override"`
+	MaxCount  *int64
+	MaxAmount *int64
+	Reason    string  `gorm:"size:500"`
+	SetBy     *string `gorm:"type:uuid"`
+	CreatedAt time.Time
+	UpdatedAt time.Time
+
+	User User `gorm:"constraint:OnDelete:CASCADE;"`
+}
@@ -0,0 +1,22 @@
+package models
+
+import (
+	"time"
+)
+
+// VirtualAccount is a dedicated bank account number a gateway issued to a
+// user. Any transfer into it funds that user's wallet.
+type VirtualAccount struct {
+	ID            string         `gorm:"type:uuid;default:gen_random_uuid();primaryKey"`
+	UserID        string         `gorm:"type:uuid;not null;uniqueIndex"`
+	Provider      EPaymentMethod `gorm:"size:50;not null"`
+	AccountNumber string         `gorm:"size:20;not null;uniqueIndex"`
+	AccountName   string         `gorm:"size:255"`
+	BankName      string         `gorm:"size:255"`
+	Reference     string         `gorm:"size:255;not null;uniqueIndex"` // our reference for the account at the gateway
+	Currency      ECurrency      `gorm:"default:NGN"`
+	CreatedAt     time.Time
+	UpdatedAt     time.Time
+
+	User User `gorm:"constraint:OnDelete:CASCADE;"`
+}
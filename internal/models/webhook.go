@@ -0,0 +1,53 @@
+package models
+
+import (
+	"time"
+)
+
+type WebhookProvider string
+
+const (
+	NombaProvider       WebhookProvider = "NOMBA"
+	FlutterwaveProvider WebhookProvider = "FLUTTERWAVE"
+	PaystackProvider    WebhookProvider = "PAYSTACK"
+	HubtelProvider      WebhookProvider = "HUBTEL"
+)
+
+// WebhookRejection records a delivery we refused, for later auditing.
+type WebhookRejection struct {
+	ID        string          `gorm:"type:uuid;default:gen_random_uuid();primaryKey"`
+	Provider  WebhookProvider `gorm:"size:50;index"`
+	Reason    string          `gorm:"size:255"`
+	Signature string          `gorm:"size:512"`
+	Timestamp string          `gorm:"size:100"`
+	RemoteIP  string          `gorm:"size:100"`
+	Body      string          `gorm:"type:text"`
+	CreatedAt time.Time       `gorm:"default:current_timestamp"`
+}
+
+type WebhookEventStatus string
+
+const (
+	WebhookReceived     WebhookEventStatus = "RECEIVED"
+	WebhookProcessing   WebhookEventStatus = "PROCESSING"
+	WebhookProcessed    WebhookEventStatus = "PROCESSED"
+	WebhookFailed       WebhookEventStatus = "FAILED"
+	WebhookDeadLettered WebhookEventStatus = "DEAD_LETTERED"
+)
+
+// WebhookEvent is the inbox row for a verified delivery. The provider's
+// event ID is unique per provider so redeliveries are stored only once.
+type WebhookEvent struct {
+	ID            string             `gorm:"type:uuid;default:gen_random_uuid();primaryKey"`
+	Provider      WebhookProvider    `gorm:"size:50;not null;uniqueIndex:idx_webhook_events_provider_event"`
+	EventID       string             `gorm:"size:255;not null;uniqueIndex:idx_webhook_events_provider_event"`
+	EventType     string             `gorm:"size:100"`
+	Payload       string             `gorm:"type:text;not null"`
+	Status        WebhookEventStatus `gorm:"size:20;index;default:RECEIVED"`
+	Attempts      int                `gorm:"default:0"`
+	NextAttemptAt time.Time          `gorm:"index"`
+	LastError     string             `gorm:"type:text"`
+	ProcessedAt   *time.Time
+	CreatedAt     time.Time `gorm:"default:current_timestamp"`
+	UpdatedAt     time.Time
+}
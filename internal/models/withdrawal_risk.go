@@ -0,0 +1,42 @@
+package models
+
+import (
+	"time"
+)
+
+type RiskDecision string
+
+const (
+	RiskAutoApproved  RiskDecision = "AUTO_APPROVED"
+	RiskPendingReview RiskDecision = "PENDING_REVIEW" // waiting in the admin review queue
+	RiskApproved      RiskDecision = "APPROVED"
+	RiskRejected      RiskDecision = "REJECTED"
+)
+
+// WithdrawalRisk is the risk assessment of one withdrawal. Signals maps
+// each rule that fired to the points it added. A withdrawal held for
+// review keeps its funds debited and its transaction PENDING until an admin
+// decides.
+type WithdrawalRisk struct {
+	ID            string       `gorm:"type:uuid;default:gen_random_uuid();primaryKey"`
+	TransactionID string       `gorm:"type:uuid;not null;uniqueIndex"`
+	UserID        string       `gorm:"type:uuid;not null;index"`
+	Score         int          `gorm:"not null"`
+	Signals       JSONB        `gorm:"type:jsonb"`
+	Decision      RiskDecision `gorm:"size:20;not null;index"`
+	IPAddress     string       `gorm:"size:64"`
+	DeviceID      string       `gorm:"size:255"`
+	ReviewNote    string       `gorm:"size:500"`
+	ReviewedBy    *string      `gorm:"type:uuid"`
+	ReviewedAt    *time.Time
+	// PayoutSubmittedAt is when an approved withdrawal reached the payout
+	// provider. An approval without it was never paid out and can be
+	// resubmitted; SubmitError says why the last attempt failed.
+	PayoutSubmittedAt *time.Time
+	SubmitError       string `gorm:"type:text"`
+	CreatedAt         time.Time
+	UpdatedAt         time.Time
+
+	Transaction Transaction `gorm:"constraint:OnDelete:CASCADE;"`
+	User        User        `gorm:"constraint:OnDelete:CASCADE;"`
+}
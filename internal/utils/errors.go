@@ -13,12 +13,17 @@ import (
 type AppError struct {
 	StatusCode int         `json:"-"`
 	Message    interface{} `json:"message"`
+	Code       string      `json:"code,omitempty"`
 }
 
 func (e *AppError) ToJSON() map[string]interface{} {
-	return map[string]interface{}{
+	body := map[string]interface{}{
 		"message": e.Message,
 	}
+	if e.Code != "" {
+		body["code"] = e.Code
+	}
+	return body
 }
 
 func (e *AppError) Write(ctx *gin.Context) {
@@ -46,7 +51,14 @@ func Error(ctx *gin.Context, statusCode int, message interface{}) {
 		message = sanitize(message)
 	}
 	
-	(&AppError{statusCode, message}).Write(ctx)
+	(&AppError{StatusCode: statusCode, Message: message}).Write(ctx)
+}
+
+// ErrorWithCode writes an error with a machine-readable code alongside the
+// message, for errors the app handles specially.
+func ErrorWithCode(ctx *gin.Context, statusCode int, code string, message string) {
+	log.Printf("Error [%d] %s: %v", statusCode, code, message)
+	(&AppError{StatusCode: statusCode, Message: message, Code: code}).Write(ctx)
 }
 
 // Sanitize database errors
@@ -16,10 +16,20 @@ var (
 	RefreshTokenTTL = time.Hour * 24 * 7
 )
 
+// JWT audiences. Player and admin tokens are signed with the same secrets,
+// so the audience is what keeps one from being accepted as the other.
+const (
+	UserAudience         = "user"
+	AdminAudience        = "admin"
+	AdminRefreshAudience = "admin-refresh"
+)
+
 
 func GenerateAccessToken(userID string) (string, error) {
 	claims := jwt.MapClaims{
 		"user_id": userID,
+		"aud":     UserAudience,
+		"iat":     time.Now().Unix(),
 		"exp":     time.Now().Add(AccessTokenTTL).Unix(),
 	}
 
@@ -31,6 +41,7 @@ func GenerateAccessToken(userID string) (string, error) {
 func GenerateRefreshToken(userID string) (string, error) {
 	claims := jwt.MapClaims{
 		"user_id": userID,
+		"iat":     time.Now().Unix(),
 		"exp":     time.Now().Add(RefreshTokenTTL).Unix(),
 	}
 
@@ -66,6 +77,70 @@ func VerifyJWTRefreshToken(tokenStr string) (string, error) {
 }
 
 
+// GenerateAdminAccessToken issues an admin access token, valid for
+// AdminAccessTokenExpiresDays (one day when unset).
+func GenerateAdminAccessToken(adminID string) (string, time.Time, error) {
+	days := config.AppConfig.AdminAccessTokenExpiresDays
+	if days <= 0 {
+		days = 1
+	}
+	expireAt := time.Now().Add(time.Duration(days) * 24 * time.Hour)
+	claims := jwt.MapClaims{
+		"admin_id": adminID,
+		"aud":      AdminAudience,
+		"exp":      expireAt.Unix(),
+	}
+
+	token := jwt.NewWithClaims(jwt.SigningMethodHS256, claims)
+	signed, err := token.SignedString([]byte(config.AppConfig.JwtAccessSecret))
+	return signed, expireAt, err
+}
+
+// GenerateAdminRefreshToken issues an admin refresh token, valid for
+// AdminRefreshTokenExpiresDays (seven days when unset).
+func GenerateAdminRefreshToken(adminID string) (string, time.Time, error) {
+	days := config.AppConfig.AdminRefreshTokenExpiresDays
+	if days <= 0 {
+		days = 7
+	}
+	expireAt := time.Now().Add(time.Duration(days) * 24 * time.Hour)
+	claims := jwt.MapClaims{
+		"admin_id": adminID,
+		"aud":      AdminRefreshAudience,
+		"exp":      expireAt.Unix(),
+		"iat":      time.Now().UnixNano(), // keeps tokens issued in the same second distinct
+	}
+
+	token := jwt.NewWithClaims(jwt.SigningMethodHS256, claims)
+	signed, err := token.SignedString([]byte(config.AppConfig.JwtRefreshSecret))
+	return signed, expireAt, err
+}
+
+// VerifyAdminRefreshToken checks an admin refresh token and returns the
+// admin ID it was issued to.
+func VerifyAdminRefreshToken(tokenStr string) (string, error) {
+	token, err := jwt.Parse(tokenStr, func(token *jwt.Token) (interface{}, error) {
+		if _, ok := token.Method.(*jwt.SigningMethodHMAC); !ok {
+			return nil, fmt.Errorf("unexpected signing method")
+		}
+		return []byte(config.AppConfig.JwtRefreshSecret), nil
+	}, jwt.WithAudience(AdminRefreshAudience), jwt.WithExpirationRequired())
+	if err != nil || !token.Valid {
+		return "", fmt.Errorf("invalid or expired refresh token")
+	}
+
+	claims, ok := token.Claims.(jwt.MapClaims)
+	if !ok {
+		return "", fmt.Errorf("invalid token claims")
+	}
+	adminID, ok := claims["admin_id"].(string)
+	if !ok || adminID == "" {
+		return "", fmt.Errorf("admin_id not found in token")
+	}
+	return adminID, nil
+}
+
+
 func DecodeToken(tokenStr string) (map[string]interface{}, error) {
     token, _, err := new(jwt.Parser).ParseUnverified(tokenStr, jwt.MapClaims{})
     if err != nil {
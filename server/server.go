@@ -13,6 +13,7 @@ import (
 func NewServer() *gin.Engine {
 	r := gin.Default()
 
+	r.Use(middlewares.RequestIDMiddleware)
 	r.Use(gin.Logger())
 	r.Use(gin.Recovery())
 	r.Use(middlewares.RecoveryAndErrorMiddleware())